@@ -0,0 +1,38 @@
+// Команда пересчитывает рейтинги с нуля по всем подтверждённым рейтинговым матчам.
+// Запускается после смены формулы или параметров RATING_*: go run ./cmd/recalculate-ratings
+package main
+
+import (
+	"context"
+	"log"
+	"sport-assistance/internal/repositories"
+	"sport-assistance/internal/services"
+	"sport-assistance/pkg/configs"
+	"sport-assistance/pkg/databases"
+	"sport-assistance/pkg/logger"
+)
+
+func main() {
+	cfg, err := configs.GetConfigs()
+	if err != nil {
+		log.Fatalf("error loading configs: %s", err)
+	}
+
+	newLogger := logger.New(cfg.Logger)
+
+	conn, err := databases.ConnectDB(cfg)
+	if err != nil {
+		log.Fatalf("error connecting to database: %s", err)
+	}
+	defer conn.Close()
+
+	// пересчёту нужны только база и настройки рейтинга
+	service := services.NewService(repositories.NewRepository(conn, newLogger), newLogger, cfg, nil, nil, nil)
+
+	replayed, err := service.RecalculateRatings(context.Background())
+	if err != nil {
+		log.Fatalf("error recalculating ratings: %s", err)
+	}
+
+	newLogger.Info("Ratings recalculated", "results", replayed)
+}
@@ -15,14 +15,18 @@ import (
 	"sport-assistance/pkg/configs"
 	"sport-assistance/pkg/databases"
 	"sport-assistance/pkg/logger"
+	"sport-assistance/pkg/notifier"
 	"sport-assistance/pkg/server"
+	"sport-assistance/pkg/storage"
 	"syscall"
 	"time"
 )
 
 type App struct {
-	server *server.Server
-	logger *slog.Logger
+	server  *server.Server
+	logger  *slog.Logger
+	cfg     *configs.Config
+	service *services.Service
 }
 
 func NewApplication() *App {
@@ -41,20 +45,34 @@ func NewApplication() *App {
 
 	newRepository := repositories.NewRepository(conn, newLogger)
 	newRedisClient := databases.ConnectRedis(cfg)
-	newService := services.NewService(newRepository, newLogger, cfg, newRedisClient)
+
+	newStorage, err := storage.New(cfg.StorageConfig)
+	if err != nil {
+		log.Fatalf("error initializing blob storage: %s", err)
+	}
+
+	newNotifier := notifier.NewLogNotifier(newLogger)
+	newService := services.NewService(newRepository, newLogger, cfg, newRedisClient, newStorage, newNotifier)
 	newMiddleware := middlewares.NewMiddleware(newRepository, cfg.SecurityConfig, newLogger, newRedisClient)
 	newHandler := handlers.NewHandler(newService, newLogger, newMiddleware, cfg)
 	newServer := server.NewServer(newHandler.InitHandler(), cfg)
 
 	return &App{
-		server: newServer,
-		logger: newLogger,
+		server:  newServer,
+		logger:  newLogger,
+		cfg:     cfg,
+		service: newService,
 	}
 }
 
 func (a *App) Run() {
 	stopChan := make(chan os.Signal, 1)
 	signal.Notify(stopChan, syscall.SIGINT, syscall.SIGTERM)
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	a.startWorkers(workersCtx)
+
 	go func() {
 		a.logger.Info("Starting application....")
 		if err := a.server.Run(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -64,11 +82,12 @@ func (a *App) Run() {
 
 	<-stopChan
 	a.logger.Info("Shutting down server gracefully...")
+	stopWorkers()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := a.server.Shutdown(ctx); err != nil {
-		a.logger.Error("Server forced to shutdown: %v", err)
+		a.logger.Error("Server forced to shutdown: ", "err", err)
 	}
 
 	a.logger.Info("Server exited properly")
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+// runPeriodic вызывает job с заданным интервалом, пока не отменён ctx.
+// Ошибка одного прогона только логируется: следующий прогон попробует снова.
+func (a *App) runPeriodic(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	if interval <= 0 {
+		a.logger.Warn("Background job is disabled: non-positive interval", "job", name)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil {
+			a.logger.Error("Background job failed", "job", name, "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startWorkers запускает фоновые задачи приложения
+func (a *App) startWorkers(ctx context.Context) {
+	go a.runPeriodic(ctx, "account purge", a.cfg.AccountConfig.PurgeInterval, func(ctx context.Context) error {
+		purged, err := a.service.PurgeDeletedAccounts(ctx)
+		if purged > 0 {
+			a.logger.Info("Deleted accounts anonymized", "count", purged)
+		}
+		return err
+	})
+
+	go a.runPeriodic(ctx, "subscription expiry", a.cfg.SubscriptionConfig.ExpiryInterval, func(ctx context.Context) error {
+		expired, err := a.service.ExpireSubscriptions(ctx)
+		if expired > 0 {
+			a.logger.Info("Expired subscriptions downgraded to guest", "count", expired)
+		}
+		return err
+	})
+
+	go a.runPeriodic(ctx, "rating decay", a.cfg.RatingConfig.DecayInterval, func(ctx context.Context) error {
+		decayed, err := a.service.DecayRatings(ctx)
+		if decayed > 0 {
+			a.logger.Info("Inactive player ratings decayed", "count", decayed)
+		}
+		return err
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) RequestAccountDeletion(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.RequestAccountDeletion(ctx, userID)
+	if err != nil {
+		h.logger.Error("Request account deletion failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+func (h *Handler) CancelAccountDeletion(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.CancelAccountDeletion(ctx, userID); err != nil {
+		h.logger.Error("Cancel account deletion failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) ExportUserData(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	archive, err := h.service.ExportUserData(ctx, userID)
+	if err != nil {
+		h.logger.Error("Export user data failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("user-%d-%s.zip", userID, time.Now().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/zip", archive)
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListActivities(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req requests.ActivitiesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind activities request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	activities, err := h.service.ListActivities(ctx, req)
+	if err != nil {
+		h.logger.Error("List activities failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.ActivitiesResponse{Activities: activities})
+}
+
+func (h *Handler) CreateActivityRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.CreateActivityRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind create activity request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.service.CreateActivityRequest(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Create activity request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+func (h *Handler) ListMyActivityRequests(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.ActivityRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind activity requests request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	activityRequests, err := h.service.ListMyActivityRequests(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("List activity requests failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.ActivityRequestsResponse{Requests: activityRequests})
+}
+
+func (h *Handler) CancelActivityRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	request, err := h.service.CancelActivityRequest(ctx, userID, requestID)
+	if err != nil {
+		h.logger.Error("Cancel activity request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) ListActivityRequestQueue(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req requests.ActivityRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind activity request queue request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	activityRequests, err := h.service.ListActivityRequestQueue(ctx, req)
+	if err != nil {
+		h.logger.Error("List activity request queue failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.ActivityRequestsResponse{Requests: activityRequests})
+}
+
+func (h *Handler) ConfirmActivityRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.ReviewActivityRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind confirm activity request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.service.ConfirmActivityRequest(ctx, userID, requestID, req)
+	if err != nil {
+		h.logger.Error("Confirm activity request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) RejectActivityRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.ReviewActivityRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind reject activity request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.service.RejectActivityRequest(ctx, userID, requestID, req)
+	if err != nil {
+		h.logger.Error("Reject activity request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) GetSchedule(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.ScheduleRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind schedule request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Get schedule failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
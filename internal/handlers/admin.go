@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/pkg/myerrors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListAdminUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.AdminUsersRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind admin users request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if req.Format == "csv" {
+		h.exportAdminUsersCSV(c, req)
+		return
+	}
+
+	resp, err := h.service.ListAdminUsers(ctx, req)
+	if err != nil {
+		h.logger.Error("List admin users failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) AdminBlockUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	adminID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.service.AdminBlockUser(ctx, adminID, userID)
+	if err != nil {
+		h.logger.Error("Block user account failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) AdminUnblockUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.service.AdminUnblockUser(ctx, userID)
+	if err != nil {
+		h.logger.Error("Unblock user account failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) exportAdminUsersCSV(c *gin.Context, req requests.AdminUsersRequest) {
+	w := &csvResponseWriter{
+		c:        c,
+		filename: fmt.Sprintf("users-%s.csv", time.Now().Format("20060102-150405")),
+	}
+
+	if err := h.service.ExportAdminUsersCSV(c.Request.Context(), req, w); err != nil {
+		h.logger.Error("Export admin users failed: ", "err", err)
+		// Если строки уже ушли клиенту, статус поменять нельзя — обрываем ответ
+		if !w.started {
+			h.handleError(c, err)
+		}
+		return
+	}
+}
+
+// csvResponseWriter выставляет заголовки CSV только при первой записи,
+// чтобы ошибки валидации можно было вернуть обычным JSON
+type csvResponseWriter struct {
+	c        *gin.Context
+	filename string
+	started  bool
+}
+
+func (w *csvResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", "text/csv; charset=utf-8")
+		w.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.c.Status(http.StatusOK)
+	}
+
+	n, err := w.c.Writer.Write(p)
+	w.c.Writer.Flush()
+	return n, err
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) MarkAttendance(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.MarkAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind mark attendance request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	mark, err := h.service.MarkAttendance(ctx, userID, matchID, req, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Mark attendance failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mark)
+}
+
+func (h *Handler) ReportNoShow(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.ReportNoShowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind report no-show request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	report, err := h.service.ReportNoShow(ctx, userID, matchID, req)
+	if err != nil {
+		h.logger.Error("Report no-show failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *Handler) ListMatchAttendance(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	marks, err := h.service.ListMatchAttendance(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("List match attendance failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.MatchAttendanceResponse{Marks: marks})
+}
+
+func (h *Handler) ClearAttendance(c *gin.Context) {
+	ctx := c.Request.Context()
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := h.idParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.ClearAttendance(ctx, matchID, userID); err != nil {
+		h.logger.Error("Clear attendance failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) GetUserReliability(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	reliability, err := h.service.GetUserReliability(ctx, userID)
+	if err != nil {
+		h.logger.Error("Get user reliability failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reliability)
+}
@@ -12,7 +12,7 @@ import (
 func (h *Handler) handleError(c *gin.Context, err error) {
 	var appErr myerrors.AppError
 	if errors.As(err, &appErr) {
-		c.JSON(http.StatusBadRequest, appErr.ToResponse())
+		c.JSON(appErrorStatus(appErr.Code), appErr.ToResponse())
 		return
 	}
 	c.JSON(http.StatusInternalServerError, myerrors.Response{
@@ -21,6 +21,25 @@ func (h *Handler) handleError(c *gin.Context, err error) {
 	})
 }
 
+// appErrorStatus сопоставляет код AppError с HTTP-статусом.
+// Коды без явного статуса исторически отдаются как 400.
+func appErrorStatus(code myerrors.ErrorCode) int {
+	switch code {
+	case myerrors.ErrCodeUnauthorized:
+		return http.StatusUnauthorized
+	case myerrors.ErrCodeForbidden:
+		return http.StatusForbidden
+	case myerrors.ErrCodeNotFound:
+		return http.StatusNotFound
+	case myerrors.ErrCodeConflict:
+		return http.StatusConflict
+	case myerrors.ErrCodeTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 func (h *Handler) Register(c *gin.Context) {
 	ctx := c.Request.Context()
 	var req requests.CreateUserRequest
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) RequestContactChange(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.ContactChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind contact change request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response, err := h.service.RequestContactChange(ctx, userID, models.ContactType(req.Type), req.Value)
+	if err != nil {
+		h.logger.Error("Request contact change failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) ConfirmContactChange(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.ConfirmContactChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind confirm contact change request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	jwts, err := h.service.ConfirmContactChange(ctx, userID, models.ContactType(req.Type), req.OTP)
+	if err != nil {
+		h.logger.Error("Confirm contact change failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jwts)
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListMyFriends(c *gin.Context) {
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	h.listFriends(c, userID, userID)
+}
+
+func (h *Handler) ListUserFriends(c *gin.Context) {
+	viewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	h.listFriends(c, viewerID, userID)
+}
+
+func (h *Handler) listFriends(c *gin.Context, viewerID, userID uint64) {
+	ctx := c.Request.Context()
+
+	var req requests.FriendsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind friends request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	friends, err := h.service.ListFriends(ctx, viewerID, userID, req)
+	if err != nil {
+		h.logger.Error("List friends failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, friends)
+}
+
+func (h *Handler) RemoveFriend(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	friendID, ok := h.idParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveFriend(ctx, userID, friendID); err != nil {
+		h.logger.Error("Remove friend failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) SendFriendRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.SendFriendRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind send friend request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	request, err := h.service.SendFriendRequest(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Send friend request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+func (h *Handler) ListFriendRequests(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.FriendRequestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind friend requests request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	list, err := h.service.ListFriendRequests(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("List friend requests failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.FriendRequestsResponse{Requests: list})
+}
+
+func (h *Handler) AcceptFriendRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	request, err := h.service.AcceptFriendRequest(ctx, userID, requestID)
+	if err != nil {
+		h.logger.Error("Accept friend request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) DeclineFriendRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	request, err := h.service.DeclineFriendRequest(ctx, userID, requestID)
+	if err != nil {
+		h.logger.Error("Decline friend request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) CancelFriendRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	requestID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	request, err := h.service.CancelFriendRequest(ctx, userID, requestID)
+	if err != nil {
+		h.logger.Error("Cancel friend request failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
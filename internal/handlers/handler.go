@@ -2,10 +2,16 @@ package handlers
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"net/http"
 	"sport-assistance/internal/handlers/requests"
 	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
 	"sport-assistance/pkg/configs"
+	"sport-assistance/pkg/myerrors"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,7 +27,142 @@ type IService interface {
 
 	//OTP
 	SendOTP(ctx context.Context, identifier string) (responses.SendOTPResponse, error)
-	ConfirmOTP(ctx context.Context, identifier, otp string) (responses.ConfirmOTPResponse, error)
+	ConfirmOTP(ctx context.Context, identifier, otp, inviteToken string) (responses.ConfirmOTPResponse, error)
+
+	// Towns
+	SearchTowns(ctx context.Context, query string, limit int) ([]models.Town, error)
+
+	// Photo
+	UploadUserPhoto(ctx context.Context, userID uint64, file io.Reader) (responses.UserPhotoResponse, error)
+	GetUserPhoto(ctx context.Context, userID uint64) (responses.UserPhotoResponse, error)
+	DeleteUserPhoto(ctx context.Context, userID uint64) error
+	OpenFile(ctx context.Context, key string, expires int64, signature string) (io.ReadCloser, string, error)
+
+	// Contacts
+	RequestContactChange(ctx context.Context, userID uint64, contactType models.ContactType, value string) (responses.SendOTPResponse, error)
+	ConfirmContactChange(ctx context.Context, userID uint64, contactType models.ContactType, otp string) (responses.JWTResponse, error)
+
+	// Player profile
+	GetPublicProfile(ctx context.Context, viewerID, userID uint64) (dto.PublicProfileDto, error)
+	GetProfileVisibility(ctx context.Context, userID uint64) (models.ProfileVisibility, error)
+	UpdateProfileVisibility(ctx context.Context, userID uint64, req requests.UpdateProfileVisibilityRequest) (models.ProfileVisibility, error)
+	UpdateCourtPosition(ctx context.Context, userID uint64, position *models.CourtPosition) error
+	ListPartners(ctx context.Context, viewerID, userID uint64) ([]models.PairStats, error)
+	ListOpponents(ctx context.Context, viewerID, userID uint64) ([]models.PairStats, error)
+	GetHeadToHead(ctx context.Context, viewerID, userID, opponentID uint64) (responses.HeadToHeadResponse, error)
+
+	// Users
+	GetMe(ctx context.Context, userID uint64) (dto.SelfUserDto, error)
+	GetUser(ctx context.Context, viewerID, userID uint64, permissions []string) (any, error)
+	SuggestPlayers(ctx context.Context, userID uint64, req requests.PlayerSuggestionsRequest) ([]models.PlayerSuggestion, error)
+
+	// Account
+	RequestAccountDeletion(ctx context.Context, userID uint64) (responses.AccountDeletionResponse, error)
+	CancelAccountDeletion(ctx context.Context, userID uint64) error
+	ExportUserData(ctx context.Context, userID uint64) ([]byte, error)
+
+	// Subscriptions
+	GetSubscriptions(ctx context.Context) ([]models.Subscription, error)
+	GetUpgradeStatus(ctx context.Context, userID uint64) (responses.UpgradeStatusResponse, error)
+	UpdateExtendedProfile(ctx context.Context, userID uint64, req requests.ExtendedProfileRequest) (dto.SelfUserDto, error)
+	PurchaseSubscription(ctx context.Context, userID uint64, req requests.PurchaseSubscriptionRequest) (responses.SubscriptionActivatedResponse, error)
+	AdminActivateSubscription(ctx context.Context, userID uint64, req requests.AdminActivateSubscriptionRequest) (models.UserSubscription, error)
+
+	// Matches
+	CreateMatch(ctx context.Context, organizerID uint64, req requests.CreateMatchRequest, permissions []string) (models.MatchDetails, error)
+	GetMatch(ctx context.Context, viewerID, matchID uint64, permissions []string) (models.MatchDetails, error)
+	ListMyMatches(ctx context.Context, userID uint64, req requests.MyMatchesRequest) (responses.MatchesResponse, error)
+	LeaveMatch(ctx context.Context, userID, matchID uint64) error
+	CancelMatch(ctx context.Context, userID, matchID uint64, permissions []string) (models.MatchDetails, error)
+	DiscoverPublicMatches(ctx context.Context, userID uint64, req requests.PublicMatchesRequest) (responses.PublicMatchesResponse, error)
+	JoinMatch(ctx context.Context, userID, matchID uint64) (models.MatchDetails, error)
+
+	// Match invitations
+	InviteToMatch(ctx context.Context, userID, matchID uint64, req requests.InviteToMatchRequest, permissions []string) ([]models.MatchInvitation, error)
+	ListMatchInvitations(ctx context.Context, userID, matchID uint64, permissions []string) ([]models.MatchInvitation, error)
+	ListMyInvitations(ctx context.Context, userID uint64) ([]models.MatchInvitation, error)
+	AcceptInvitation(ctx context.Context, userID, invitationID uint64) (models.MatchDetails, error)
+	DeclineInvitation(ctx context.Context, userID, invitationID uint64) error
+	RevokeInvitation(ctx context.Context, userID, invitationID uint64, permissions []string) error
+	CreateInviteLink(ctx context.Context, userID, matchID uint64, req requests.CreateInviteLinkRequest, permissions []string) (models.MatchInviteLink, error)
+	ListInviteLinks(ctx context.Context, userID, matchID uint64, permissions []string) ([]models.MatchInviteLink, error)
+	RevokeInviteLink(ctx context.Context, userID, linkID uint64, permissions []string) error
+	RedeemInviteLink(ctx context.Context, userID uint64, token string) (models.MatchInvitation, error)
+
+	// Match lineup
+	GetMatchLineup(ctx context.Context, viewerID, matchID uint64, permissions []string) (responses.MatchLineupResponse, error)
+	SetMatchLineup(ctx context.Context, userID, matchID uint64, req requests.SetMatchLineupRequest, permissions []string) (responses.MatchLineupResponse, error)
+	AutoBalanceLineup(ctx context.Context, userID, matchID uint64, permissions []string) (responses.MatchLineupResponse, error)
+
+	// Match results
+	SubmitMatchResult(ctx context.Context, userID, matchID uint64, req requests.SubmitMatchResultRequest) (models.MatchResult, error)
+	GetMatchResult(ctx context.Context, viewerID, matchID uint64, permissions []string) (models.MatchResult, error)
+	ConfirmMatchResult(ctx context.Context, userID, resultID uint64) (models.MatchResult, error)
+	DisputeMatchResult(ctx context.Context, userID, resultID uint64, req requests.DisputeMatchResultRequest) (models.MatchResult, error)
+	ListResultDisputes(ctx context.Context) ([]models.MatchResult, error)
+	ResolveResultDispute(ctx context.Context, userID, resultID uint64, req requests.ResolveMatchResultRequest) (models.MatchResult, error)
+	ListRatingFlags(ctx context.Context, req requests.RatingFlagsRequest) ([]models.RatingFlag, error)
+	ResolveRatingFlag(ctx context.Context, userID, flagID uint64, req requests.ResolveRatingFlagRequest) (models.RatingFlag, error)
+
+	// Match attendance
+	MarkAttendance(ctx context.Context, reporterID, matchID uint64, req requests.MarkAttendanceRequest, permissions []string) (models.AttendanceMark, error)
+	ReportNoShow(ctx context.Context, reporterID, matchID uint64, req requests.ReportNoShowRequest) (models.NoShowReport, error)
+	ListMatchAttendance(ctx context.Context, viewerID, matchID uint64, permissions []string) ([]models.AttendanceMark, error)
+	ClearAttendance(ctx context.Context, matchID, userID uint64) error
+	GetUserReliability(ctx context.Context, userID uint64) (models.Reliability, error)
+
+	// Ratings
+	GetMyRatings(ctx context.Context, userID uint64, req requests.RatingHistoryRequest) (responses.MyRatingsResponse, error)
+	GetLeaderboard(ctx context.Context, userID uint64, req requests.LeaderboardRequest) (responses.LeaderboardResponse, error)
+	GetNearbyPlayers(ctx context.Context, userID uint64, req requests.NearbyPlayersRequest) (responses.LeaderboardResponse, error)
+	StartRatingSeason(ctx context.Context, userID uint64, req requests.StartRatingSeasonRequest) (models.RatingSeason, error)
+	ListRatingSeasons(ctx context.Context, req requests.RatingSeasonsRequest) ([]models.RatingSeason, error)
+	GetSeasonStandings(ctx context.Context, viewerID, seasonID uint64) (responses.SeasonStandingsResponse, error)
+
+	// Friends
+	SendFriendRequest(ctx context.Context, userID uint64, req requests.SendFriendRequestRequest) (models.FriendRequest, error)
+	ListFriendRequests(ctx context.Context, userID uint64, req requests.FriendRequestsRequest) ([]models.FriendRequest, error)
+	AcceptFriendRequest(ctx context.Context, userID, requestID uint64) (models.FriendRequest, error)
+	DeclineFriendRequest(ctx context.Context, userID, requestID uint64) (models.FriendRequest, error)
+	CancelFriendRequest(ctx context.Context, userID, requestID uint64) (models.FriendRequest, error)
+	RemoveFriend(ctx context.Context, userID, friendID uint64) error
+	ListFriends(ctx context.Context, viewerID, userID uint64, req requests.FriendsRequest) (responses.FriendsResponse, error)
+
+	// Activities and schedule
+	ListActivities(ctx context.Context, req requests.ActivitiesRequest) ([]models.Activity, error)
+	CreateActivityRequest(ctx context.Context, userID uint64, req requests.CreateActivityRequestRequest) (models.ActivityRequest, error)
+	ListMyActivityRequests(ctx context.Context, userID uint64, req requests.ActivityRequestsRequest) ([]models.ActivityRequest, error)
+	CancelActivityRequest(ctx context.Context, userID, requestID uint64) (models.ActivityRequest, error)
+	ListActivityRequestQueue(ctx context.Context, req requests.ActivityRequestsRequest) ([]models.ActivityRequest, error)
+	ConfirmActivityRequest(ctx context.Context, reviewerID, requestID uint64, req requests.ReviewActivityRequestRequest) (models.ActivityRequest, error)
+	RejectActivityRequest(ctx context.Context, reviewerID, requestID uint64, req requests.ReviewActivityRequestRequest) (models.ActivityRequest, error)
+	GetSchedule(ctx context.Context, userID uint64, req requests.ScheduleRequest) (responses.ScheduleResponse, error)
+
+	// Blocks and reports
+	BlockUser(ctx context.Context, userID uint64, req requests.BlockUserRequest) error
+	UnblockUser(ctx context.Context, userID, blockedID uint64) error
+	ListBlockedUsers(ctx context.Context, userID uint64) ([]models.BlockedUser, error)
+	CreateReport(ctx context.Context, userID uint64, req requests.CreateReportRequest) (models.Report, error)
+	ListReports(ctx context.Context, req requests.ReportsRequest) ([]models.Report, error)
+	ResolveReport(ctx context.Context, userID, reportID uint64, req requests.ResolveReportRequest) (models.Report, error)
+
+	// Tournaments
+	CreateTournament(ctx context.Context, organizerID uint64, req requests.CreateTournamentRequest) (models.TournamentDetails, error)
+	ListTournaments(ctx context.Context, req requests.TournamentsRequest) (responses.TournamentsResponse, error)
+	GetTournament(ctx context.Context, tournamentID uint64) (models.TournamentDetails, error)
+	AddTournamentEntry(ctx context.Context, tournamentID uint64, req requests.AddTournamentEntryRequest) (models.TournamentDetails, error)
+	RemoveTournamentEntry(ctx context.Context, tournamentID, entryID uint64) error
+	StartTournament(ctx context.Context, tournamentID uint64) (models.TournamentDetails, error)
+	CompleteLadder(ctx context.Context, tournamentID uint64) (models.TournamentDetails, error)
+	ListTournamentFixtures(ctx context.Context, tournamentID uint64) (responses.TournamentFixturesResponse, error)
+	GetTournamentStandings(ctx context.Context, tournamentID uint64) (responses.TournamentStandingsResponse, error)
+	ChallengeLadder(ctx context.Context, userID, tournamentID uint64, req requests.LadderChallengeRequest) (models.TournamentFixture, error)
+
+	// Admin
+	ListAdminUsers(ctx context.Context, req requests.AdminUsersRequest) (responses.AdminUsersResponse, error)
+	ExportAdminUsersCSV(ctx context.Context, req requests.AdminUsersRequest, w io.Writer) error
+	AdminBlockUser(ctx context.Context, adminID, userID uint64) (dto.AdminUserDto, error)
+	AdminUnblockUser(ctx context.Context, userID uint64) (dto.AdminUserDto, error)
 }
 type IMiddleware interface {
 	AuthMiddleware() gin.HandlerFunc
@@ -68,6 +209,16 @@ func (h *Handler) InitHandler() *gin.Engine {
 		public.POST("/otp/confirm", h.ConfirmOTP)
 	}
 
+	towns := router.Group("/api/v1/towns")
+	{
+		towns.GET("/search", h.SearchTowns)
+	}
+
+	files := router.Group("/api/v1/files")
+	{
+		files.GET("/*key", h.ServeFile)
+	}
+
 	private := router.Group("/api/v1")
 	private.Use(h.middlewares.AuthMiddleware())
 	private.Use(h.middlewares.CORSMiddleware())
@@ -75,19 +226,192 @@ func (h *Handler) InitHandler() *gin.Engine {
 	profile := private.Group("/profile")
 	profile.Use(h.middlewares.RequirePermissions("profile.view.own"))
 	{
-		profile.GET("/me", func(c *gin.Context) {})
+		profile.GET("/me", h.GetMe)
+		profile.GET("/photo", h.GetPhoto)
+		profile.POST("/photo", h.middlewares.RequirePermissions("profile.edit.own"), h.UploadPhoto)
+		profile.DELETE("/photo", h.middlewares.RequirePermissions("profile.edit.own"), h.DeletePhoto)
+		profile.POST("/contacts/change", h.middlewares.RequirePermissions("profile.edit.own"), h.RequestContactChange)
+		profile.POST("/contacts/confirm", h.middlewares.RequirePermissions("profile.edit.own"), h.ConfirmContactChange)
+		profile.GET("/visibility", h.GetProfileVisibility)
+		profile.PUT("/visibility", h.middlewares.RequirePermissions("profile.edit.own"), h.UpdateProfileVisibility)
+		profile.PUT("/court-position", h.middlewares.RequirePermissions("profile.edit.own"), h.UpdateCourtPosition)
+		profile.GET("/upgrade", h.GetUpgradeStatus)
+		profile.PUT("/extended", h.middlewares.RequirePermissions("profile.edit.own"), h.UpdateExtendedProfile)
+		profile.POST("/subscription", h.middlewares.RequirePermissions("subscription.purchase"), h.PurchaseSubscription)
+		profile.GET("/export", h.ExportUserData)
+		profile.POST("/deletion", h.RequestAccountDeletion)
+		profile.DELETE("/deletion", h.CancelAccountDeletion)
 	}
 
+	private.GET("/subscriptions", h.middlewares.RequirePermissions("subscription.view"), h.GetSubscriptions)
+
+	rating := private.Group("/rating")
+	rating.Use(h.middlewares.RequirePermissions("rating.view"))
+	{
+		rating.GET("/me", h.GetMyRatings)
+		rating.GET("/leaderboard", h.GetLeaderboard)
+		rating.GET("/leaderboard/nearby", h.GetNearbyPlayers)
+		rating.GET("/seasons", h.ListRatingSeasons)
+		rating.POST("/seasons", h.middlewares.RequirePermissions("match.manage.any"), h.StartRatingSeason)
+		rating.GET("/seasons/:id/standings", h.GetSeasonStandings)
+	}
+
+	users := private.Group("/users")
+	{
+		users.GET("/suggestions", h.middlewares.RequirePermissions("match.invite.users"), h.SuggestPlayers)
+		users.GET("/:id", h.GetUser)
+		users.GET("/:id/profile", h.GetPublicProfile)
+		users.GET("/:id/partners", h.ListPartners)
+		users.GET("/:id/opponents", h.ListOpponents)
+		users.GET("/:id/head-to-head/:opponent_id", h.GetHeadToHead)
+		users.GET("/:id/reliability", h.GetUserReliability)
+		users.GET("/:id/friends", h.middlewares.RequirePermissions("friends.view"), h.ListUserFriends)
+	}
+
+	// Гость может дружить только с партнёрами по сыгранным матчам — это проверяет сервис
+	friends := private.Group("/friends")
+	friends.Use(h.middlewares.RequirePermissions("friends.view"))
+	{
+		friends.GET("", h.ListMyFriends)
+		friends.DELETE("/:user_id", h.RemoveFriend)
+		friends.GET("/requests", h.ListFriendRequests)
+		friends.POST("/requests", h.middlewares.RequirePermissions("friends.add"), h.SendFriendRequest)
+		friends.POST("/requests/:id/accept", h.middlewares.RequirePermissions("friends.add"), h.AcceptFriendRequest)
+		friends.POST("/requests/:id/decline", h.DeclineFriendRequest)
+		friends.POST("/requests/:id/cancel", h.CancelFriendRequest)
+	}
+
+	// Блокировка скрывает профили и исключает пару из приглашений, подбора и публичных матчей
+	blocks := private.Group("/blocks")
+	{
+		blocks.GET("", h.ListBlockedUsers)
+		blocks.POST("", h.BlockUser)
+		blocks.DELETE("/:user_id", h.UnblockUser)
+	}
+
+	private.POST("/reports", h.CreateReport)
+
+	moderation := private.Group("/moderation")
+	moderation.Use(h.middlewares.RequirePermissions("moderation.manage"))
+	{
+		moderation.GET("/reports", h.ListReports)
+		moderation.POST("/reports/:id/resolve", h.ResolveReport)
+	}
+
+	// Заявка попадает в расписание только после подтверждения ассистентом (право booking.manage)
+	activities := private.Group("/activities")
+	{
+		activities.GET("", h.middlewares.RequirePermissions("activity.create"), h.ListActivities)
+		activities.GET("/requests", h.middlewares.RequirePermissions("activity.create"), h.ListMyActivityRequests)
+		activities.POST("/requests", h.middlewares.RequirePermissions("activity.create"), h.CreateActivityRequest)
+		activities.POST("/requests/:id/cancel", h.middlewares.RequirePermissions("activity.create"), h.CancelActivityRequest)
+		activities.GET("/queue", h.middlewares.RequirePermissions("booking.manage"), h.ListActivityRequestQueue)
+		activities.POST("/requests/:id/confirm", h.middlewares.RequirePermissions("booking.manage"), h.ConfirmActivityRequest)
+		activities.POST("/requests/:id/reject", h.middlewares.RequirePermissions("booking.manage"), h.RejectActivityRequest)
+	}
+
+	private.GET("/schedule", h.middlewares.RequirePermissions("schedule.view"), h.GetSchedule)
+
+	admin := private.Group("/admin")
+	admin.Use(h.middlewares.RequirePermissions("admin.users.manage"))
+	{
+		admin.GET("/users", h.ListAdminUsers)
+		admin.PUT("/users/:id/subscription", h.middlewares.RequirePermissions("change.user.subscription"), h.AdminActivateSubscription)
+		admin.POST("/users/:id/block", h.middlewares.RequirePermissions("profile.block"), h.AdminBlockUser)
+		admin.POST("/users/:id/unblock", h.middlewares.RequirePermissions("profile.unblock"), h.AdminUnblockUser)
+	}
+
+	// Участие и права организатора проверяет сервис; маршрутам нужно только право на создание
 	match := private.Group("/match")
-	match.Use(
-		h.middlewares.RequirePermissions(
-			"match.confirm.participation",
-			"match.create",
-			"match.enter.result",
-			"match.invite.users"),
-	)
 	{
+		match.POST("", h.middlewares.RequirePermissions("match.create"), h.CreateMatch)
+		match.GET("", h.ListMyMatches)
+		match.GET("/public", h.DiscoverPublicMatches)
+		match.GET("/:id", h.GetMatch)
+		match.POST("/:id/join", h.middlewares.RequirePermissions("match.confirm.participation"), h.JoinMatch)
+		match.POST("/:id/leave", h.LeaveMatch)
+		match.POST("/:id/cancel", h.CancelMatch)
+		match.GET("/:id/invitations", h.ListMatchInvitations)
+		match.POST("/:id/invitations", h.middlewares.RequirePermissions("match.invite.users"), h.InviteToMatch)
+		match.GET("/invitations", h.ListMyInvitations)
+		match.POST("/invitations/:id/accept", h.middlewares.RequirePermissions("match.confirm.participation"), h.AcceptInvitation)
+		match.POST("/invitations/:id/decline", h.DeclineInvitation)
+		match.POST("/invitations/:id/revoke", h.RevokeInvitation)
+		match.GET("/:id/links", h.ListInviteLinks)
+		match.POST("/:id/links", h.middlewares.RequirePermissions("match.invite.users"), h.CreateInviteLink)
+		match.POST("/links/redeem", h.middlewares.RequirePermissions("match.confirm.participation"), h.RedeemInviteLink)
+		match.POST("/links/:id/revoke", h.RevokeInviteLink)
+		match.GET("/:id/teams", h.GetMatchLineup)
+		match.PUT("/:id/teams", h.SetMatchLineup)
+		match.POST("/:id/teams/auto-balance", h.AutoBalanceLineup)
+		match.GET("/:id/result", h.GetMatchResult)
+		match.POST("/:id/result", h.middlewares.RequirePermissions("match.enter.result"), h.SubmitMatchResult)
+		match.POST("/results/:id/confirm", h.ConfirmMatchResult)
+		match.POST("/results/:id/dispute", h.DisputeMatchResult)
+		match.GET("/results/disputes", h.middlewares.RequirePermissions("match.manage.any"), h.ListResultDisputes)
+		match.POST("/results/:id/resolve", h.middlewares.RequirePermissions("match.manage.any"), h.ResolveResultDispute)
+		match.GET("/results/flags", h.middlewares.RequirePermissions("match.manage.any"), h.ListRatingFlags)
+		match.POST("/results/flags/:id/resolve", h.middlewares.RequirePermissions("match.manage.any"), h.ResolveRatingFlag)
+		match.GET("/:id/attendance", h.ListMatchAttendance)
+		match.POST("/:id/attendance", h.MarkAttendance)
+		match.POST("/:id/attendance/reports", h.ReportNoShow)
+		match.DELETE("/:id/attendance/:user_id", h.middlewares.RequirePermissions("match.manage.any"), h.ClearAttendance)
+	}
+
+	// Турниры ведут ассистенты; встречи турнира — обычные матчи раздела /match
+	tournaments := private.Group("/tournaments")
+	{
+		tournaments.POST("", h.middlewares.RequirePermissions("match.manage.any"), h.CreateTournament)
+		tournaments.GET("", h.ListTournaments)
+		tournaments.GET("/:id", h.GetTournament)
+		tournaments.POST("/:id/entries", h.middlewares.RequirePermissions("match.manage.any"), h.AddTournamentEntry)
+		tournaments.DELETE("/:id/entries/:entry_id", h.middlewares.RequirePermissions("match.manage.any"), h.RemoveTournamentEntry)
+		tournaments.POST("/:id/start", h.middlewares.RequirePermissions("match.manage.any"), h.StartTournament)
+		tournaments.POST("/:id/complete", h.middlewares.RequirePermissions("match.manage.any"), h.CompleteLadder)
+		tournaments.GET("/:id/fixtures", h.ListTournamentFixtures)
+		tournaments.GET("/:id/standings", h.GetTournamentStandings)
+		tournaments.POST("/:id/challenges", h.middlewares.RequirePermissions("match.confirm.participation"), h.ChallengeLadder)
 	}
 
 	return router
 }
+
+// currentUserID достаёт id пользователя, положенный AuthMiddleware.
+// Если его нет, сразу отвечает 401 и возвращает false.
+func (h *Handler) currentUserID(c *gin.Context) (uint64, bool) {
+	raw, exists := c.Get("user_id")
+	userID, ok := raw.(uint64)
+	if !exists || !ok || userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"error":   "user is not authenticated",
+		})
+		c.Abort()
+		return 0, false
+	}
+
+	return userID, true
+}
+
+// currentPermissions возвращает права из access-токена. Если их нет, права пустые:
+// проекция пользователя в этом случае сужается до публичной.
+func (h *Handler) currentPermissions(c *gin.Context) []string {
+	raw, _ := c.Get("permissions")
+	permissions, _ := raw.([]string)
+	return permissions
+}
+
+// idParam разбирает числовой параметр пути. При ошибке сразу отвечает 400 и возвращает false.
+func (h *Handler) idParam(c *gin.Context, name string) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid path parameter " + name,
+			Error:   "must be a positive integer",
+		})
+		c.Abort()
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateMatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.CreateMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind create match request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	match, err := h.service.CreateMatch(ctx, userID, req, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Create match failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, match)
+}
+
+func (h *Handler) ListMyMatches(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.MyMatchesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind my matches request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	matches, err := h.service.ListMyMatches(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("List my matches failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+func (h *Handler) GetMatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	match, err := h.service.GetMatch(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Get match failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
+
+func (h *Handler) LeaveMatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.LeaveMatch(ctx, userID, matchID); err != nil {
+		h.logger.Error("Leave match failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) CancelMatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	match, err := h.service.CancelMatch(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Cancel match failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
+
+func (h *Handler) DiscoverPublicMatches(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.PublicMatchesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind public matches request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	matches, err := h.service.DiscoverPublicMatches(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Discover public matches failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+func (h *Handler) JoinMatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	match, err := h.service.JoinMatch(ctx, userID, matchID)
+	if err != nil {
+		h.logger.Error("Join match failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
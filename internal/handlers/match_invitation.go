@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) InviteToMatch(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.InviteToMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind invite to match request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	invitations, err := h.service.InviteToMatch(ctx, userID, matchID, req, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Invite to match failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.MatchInvitationsResponse{Invitations: invitations})
+}
+
+func (h *Handler) ListMatchInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	invitations, err := h.service.ListMatchInvitations(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("List match invitations failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.MatchInvitationsResponse{Invitations: invitations})
+}
+
+func (h *Handler) ListMyInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	invitations, err := h.service.ListMyInvitations(ctx, userID)
+	if err != nil {
+		h.logger.Error("List my invitations failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.MatchInvitationsResponse{Invitations: invitations})
+}
+
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	match, err := h.service.AcceptInvitation(ctx, userID, invitationID)
+	if err != nil {
+		h.logger.Error("Accept invitation failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
+
+func (h *Handler) DeclineInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeclineInvitation(ctx, userID, invitationID); err != nil {
+		h.logger.Error("Decline invitation failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) RevokeInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeInvitation(ctx, userID, invitationID, h.currentPermissions(c)); err != nil {
+		h.logger.Error("Revoke invitation failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) CreateInviteLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.CreateInviteLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind create invite link request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	link, err := h.service.CreateInviteLink(ctx, userID, matchID, req, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Create invite link failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, link)
+}
+
+func (h *Handler) ListInviteLinks(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	links, err := h.service.ListInviteLinks(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("List invite links failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.MatchInviteLinksResponse{Links: links})
+}
+
+func (h *Handler) RevokeInviteLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	linkID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeInviteLink(ctx, userID, linkID, h.currentPermissions(c)); err != nil {
+		h.logger.Error("Revoke invite link failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) RedeemInviteLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.RedeemInviteLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind redeem invite link request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	invitation, err := h.service.RedeemInviteLink(ctx, userID, req.Token)
+	if err != nil {
+		h.logger.Error("Redeem invite link failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
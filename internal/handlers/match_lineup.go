@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetMatchLineup(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	lineup, err := h.service.GetMatchLineup(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Get match lineup failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lineup)
+}
+
+func (h *Handler) SetMatchLineup(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.SetMatchLineupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind match lineup request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	lineup, err := h.service.SetMatchLineup(ctx, userID, matchID, req, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Set match lineup failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lineup)
+}
+
+func (h *Handler) AutoBalanceLineup(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	lineup, err := h.service.AutoBalanceLineup(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Auto-balance lineup failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lineup)
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) SubmitMatchResult(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.SubmitMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind submit match result request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.SubmitMatchResult(ctx, userID, matchID, req)
+	if err != nil {
+		h.logger.Error("Submit match result failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *Handler) GetMatchResult(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	matchID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.GetMatchResult(ctx, userID, matchID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Get match result failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) ConfirmMatchResult(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	resultID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ConfirmMatchResult(ctx, userID, resultID)
+	if err != nil {
+		h.logger.Error("Confirm match result failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) DisputeMatchResult(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	resultID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.DisputeMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind dispute match result request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.DisputeMatchResult(ctx, userID, resultID, req)
+	if err != nil {
+		h.logger.Error("Dispute match result failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) ListResultDisputes(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	results, err := h.service.ListResultDisputes(ctx)
+	if err != nil {
+		h.logger.Error("List result disputes failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.MatchResultsResponse{Results: results})
+}
+
+func (h *Handler) ResolveResultDispute(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	resultID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.ResolveMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind resolve match result request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.ResolveResultDispute(ctx, userID, resultID, req)
+	if err != nil {
+		h.logger.Error("Resolve result dispute failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *Handler) ListRatingFlags(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req requests.RatingFlagsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind rating flags request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	flags, err := h.service.ListRatingFlags(ctx, req)
+	if err != nil {
+		h.logger.Error("List rating flags failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.RatingFlagsResponse{Flags: flags})
+}
+
+func (h *Handler) ResolveRatingFlag(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	flagID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.ResolveRatingFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind resolve rating flag request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	flag, err := h.service.ResolveRatingFlag(ctx, userID, flagID, req)
+	if err != nil {
+		h.logger.Error("Resolve rating flag failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListBlockedUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	users, err := h.service.ListBlockedUsers(ctx, userID)
+	if err != nil {
+		h.logger.Error("List blocked users failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.BlockedUsersResponse{Users: users})
+}
+
+func (h *Handler) BlockUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.BlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind block user request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.BlockUser(ctx, userID, req); err != nil {
+		h.logger.Error("Block user failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) UnblockUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	blockedID, ok := h.idParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnblockUser(ctx, userID, blockedID); err != nil {
+		h.logger.Error("Unblock user failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) CreateReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind create report request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	report, err := h.service.CreateReport(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Create report failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func (h *Handler) ListReports(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req requests.ReportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind reports request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	reports, err := h.service.ListReports(ctx, req)
+	if err != nil {
+		h.logger.Error("List reports failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.ReportsResponse{Reports: reports})
+}
+
+func (h *Handler) ResolveReport(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	reportID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind resolve report request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	report, err := h.service.ResolveReport(ctx, userID, reportID, req)
+	if err != nil {
+		h.logger.Error("Resolve report failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -44,7 +44,7 @@ func (h *Handler) ConfirmOTP(c *gin.Context) {
 		return
 	}
 
-	response, err := h.service.ConfirmOTP(ctx, req.Identifier, req.OTP)
+	response, err := h.service.ConfirmOTP(ctx, req.Identifier, req.OTP, req.InviteToken)
 	if err != nil {
 		h.logger.Error("Confirm otp failed: ", "err", err)
 		h.handleError(c, err)
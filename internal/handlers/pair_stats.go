@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) ListPartners(c *gin.Context) {
+	ctx := c.Request.Context()
+	viewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	partners, err := h.service.ListPartners(ctx, viewerID, userID)
+	if err != nil {
+		h.logger.Error("List partners failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.PairStatsResponse{Players: partners})
+}
+
+func (h *Handler) ListOpponents(c *gin.Context) {
+	ctx := c.Request.Context()
+	viewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	opponents, err := h.service.ListOpponents(ctx, viewerID, userID)
+	if err != nil {
+		h.logger.Error("List opponents failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.PairStatsResponse{Players: opponents})
+}
+
+func (h *Handler) GetHeadToHead(c *gin.Context) {
+	ctx := c.Request.Context()
+	viewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+	opponentID, ok := h.idParam(c, "opponent_id")
+	if !ok {
+		return
+	}
+
+	h2h, err := h.service.GetHeadToHead(ctx, viewerID, userID, opponentID)
+	if err != nil {
+		h.logger.Error("Get head-to-head failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h2h)
+}
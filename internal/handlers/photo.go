@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead — запас на заголовки multipart сверх лимита размера фото
+const multipartOverhead = 1 << 20
+
+func (h *Handler) UploadPhoto(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.cfg.StorageConfig.MaxPhotoSize+multipartOverhead)
+	fileHeader, err := c.FormFile("photo")
+	if err != nil {
+		h.logger.Error("Read photo form file error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Multipart field \"photo\" is required and must not exceed the size limit",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.logger.Error("Open photo form file error: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	response, err := h.service.UploadUserPhoto(ctx, userID, file)
+	if err != nil {
+		h.logger.Error("Upload photo failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) GetPhoto(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.service.GetUserPhoto(ctx, userID)
+	if err != nil {
+		h.logger.Error("Get photo failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) DeletePhoto(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteUserPhoto(ctx, userID); err != nil {
+		h.logger.Error("Delete photo failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) ServeFile(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.SignedFileRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind signed file request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	file, contentType, err := h.service.OpenFile(ctx, key, req.Expires, req.Signature)
+	if err != nil {
+		h.logger.Error("Serve file failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+	defer file.Close()
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Status(http.StatusOK)
+	c.Header("Content-Type", contentType)
+	if _, err = io.Copy(c.Writer, file); err != nil {
+		h.logger.Error("Write file to response failed: ", "err", err)
+	}
+}
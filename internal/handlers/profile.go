@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetPublicProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	viewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	profile, err := h.service.GetPublicProfile(ctx, viewerID, userID)
+	if err != nil {
+		h.logger.Error("Get public profile failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *Handler) GetProfileVisibility(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	visibility, err := h.service.GetProfileVisibility(ctx, userID)
+	if err != nil {
+		h.logger.Error("Get profile visibility failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, visibility)
+}
+
+func (h *Handler) UpdateProfileVisibility(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.UpdateProfileVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind profile visibility request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	visibility, err := h.service.UpdateProfileVisibility(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Update profile visibility failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, visibility)
+}
+
+func (h *Handler) UpdateCourtPosition(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.UpdateCourtPositionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind court position request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	var position *models.CourtPosition
+	if req.CourtPosition != nil {
+		value := models.CourtPosition(*req.CourtPosition)
+		position = &value
+	}
+
+	if err := h.service.UpdateCourtPosition(ctx, userID, position); err != nil {
+		h.logger.Error("Update court position failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
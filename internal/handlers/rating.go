@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetMyRatings(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.RatingHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind rating history request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	ratings, err := h.service.GetMyRatings(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Get my ratings failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ratings)
+}
+
+func (h *Handler) GetLeaderboard(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.LeaderboardRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind leaderboard request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	leaderboard, err := h.service.GetLeaderboard(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Get leaderboard failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+func (h *Handler) GetNearbyPlayers(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.NearbyPlayersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind nearby players request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	leaderboard, err := h.service.GetNearbyPlayers(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Get nearby players failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
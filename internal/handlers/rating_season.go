@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) StartRatingSeason(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.StartRatingSeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind start rating season request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	season, err := h.service.StartRatingSeason(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Start rating season failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, season)
+}
+
+func (h *Handler) ListRatingSeasons(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req requests.RatingSeasonsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind rating seasons request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	seasons, err := h.service.ListRatingSeasons(ctx, req)
+	if err != nil {
+		h.logger.Error("List rating seasons failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.RatingSeasonsResponse{Seasons: seasons})
+}
+
+func (h *Handler) GetSeasonStandings(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	seasonID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	standings, err := h.service.GetSeasonStandings(ctx, userID, seasonID)
+	if err != nil {
+		h.logger.Error("Get season standings failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
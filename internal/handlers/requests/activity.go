@@ -0,0 +1,31 @@
+package requests
+
+import "time"
+
+type ActivitiesRequest struct {
+	SportID *int `form:"sport_id"`
+}
+
+type CreateActivityRequestRequest struct {
+	ActivityID      int        `json:"activity_id"`
+	SportID         int        `json:"sport_id"`
+	SportObjectID   *int       `json:"sport_object_id"`
+	CourtID         *int       `json:"court_id"`         // объект подставляется по корту
+	StartsAt        time.Time  `json:"starts_at"`        // RFC 3339
+	EndsAt          *time.Time `json:"ends_at"`          // nil — starts_at + duration_minutes
+	DurationMinutes int        `json:"duration_minutes"` // 0 — 60 минут; игнорируется, если задан ends_at
+	Comment         *string    `json:"comment"`
+}
+
+type ActivityRequestsRequest struct {
+	Status string `form:"status"` // pending | confirmed | rejected | cancelled; у очереди ассистента по умолчанию pending
+}
+
+type ReviewActivityRequestRequest struct {
+	Comment *string `json:"comment"`
+}
+
+type ScheduleRequest struct {
+	From string `form:"from"` // YYYY-MM-DD, включительно; по умолчанию сегодня
+	To   string `form:"to"`   // YYYY-MM-DD, включительно; по умолчанию неделя от from
+}
@@ -0,0 +1,24 @@
+package requests
+
+// AdminUsersRequest — фильтры справочника пользователей. Списочные параметры передаются
+// повтором ключа: ?role_id=2&role_id=3.
+type AdminUsersRequest struct {
+	SubscriptionIDs       []int  `form:"subscription_id"`
+	HasSubscription       *bool  `form:"has_subscription"`
+	RoleIDs               []int  `form:"role_id"`
+	TownIDs               []int  `form:"town_id"`
+	SportActivityLevelIDs []int  `form:"sport_activity_level_id"`
+	SportTargetIDs        []int  `form:"sport_target_id"`
+	SportIDs              []int  `form:"sport_id"`
+	IsHaveInjury          *bool  `form:"is_have_injury"`
+	IsPhoneVerified       *bool  `form:"is_phone_verified"`
+	IsEmailVerified       *bool  `form:"is_email_verified"`
+	IsBlocked             *bool  `form:"is_blocked"`
+	CreatedFrom           string `form:"created_from"` // YYYY-MM-DD, включительно
+	CreatedTo             string `form:"created_to"`   // YYYY-MM-DD, включительно
+	Query                 string `form:"q"`
+	Sort                  string `form:"sort"` // поле, с «-» — по убыванию: -created_at
+	Page                  int    `form:"page"`
+	PageSize              int    `form:"page_size"`
+	Format                string `form:"format"` // json (по умолчанию) | csv
+}
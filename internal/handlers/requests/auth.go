@@ -20,6 +20,7 @@ type SendOTPRequest struct {
 }
 
 type ConfirmOTPRequest struct {
-	Identifier string `json:"identifier"`
-	OTP        string `json:"otp"`
+	Identifier  string `json:"identifier"`
+	OTP         string `json:"otp"`
+	InviteToken string `json:"invite_token,omitempty"` // токен ссылки-приглашения в матч, если приложение открыли по ней
 }
@@ -0,0 +1,14 @@
+package requests
+
+type SendFriendRequestRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+type FriendRequestsRequest struct {
+	Direction string `form:"direction"` // incoming (по умолчанию) | outgoing
+}
+
+type FriendsRequest struct {
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
@@ -0,0 +1,130 @@
+package requests
+
+import (
+	"sport-assistance/internal/models"
+	"time"
+)
+
+type CreateMatchRequest struct {
+	MatchTypeID     int        `json:"match_type_id"`
+	StartsAt        time.Time  `json:"starts_at"`        // RFC 3339
+	EndsAt          *time.Time `json:"ends_at"`          // nil — starts_at + duration_minutes
+	DurationMinutes int        `json:"duration_minutes"` // 0 — 90 минут; игнорируется, если задан ends_at
+	RequiredPlayers int        `json:"required_players"` // 0 — двое; матч становится active, когда столько игроков подтвердили участие
+	InviteUserIDs   []uint64   `json:"invite_user_ids"`
+
+	Visibility string  `json:"visibility"` // private (по умолчанию) | public
+	SportID    *int    `json:"sport_id"`   // обязателен для публичного матча
+	TownID     *int    `json:"town_id"`    // обязателен для публичного матча
+	Venue      *string `json:"venue"`
+	MinLevelID *int    `json:"min_level_id"`
+	MaxLevelID *int    `json:"max_level_id"`
+	Capacity   *int    `json:"capacity"` // nil — без ограничения; не меньше required_players
+
+	MinReliability *int `json:"min_reliability"` // 0–100; nil у рейтингового публичного матча — порог из настроек
+
+	SportObjectID *int `json:"sport_object_id"`
+	CourtID       *int `json:"court_id"` // объект подставляется по корту
+}
+
+type InviteToMatchRequest struct {
+	UserIDs []uint64 `json:"user_ids"`
+}
+
+type MyMatchesRequest struct {
+	Scope      string  `form:"scope"` // upcoming (по умолчанию) | past
+	PartnerID  *uint64 `form:"partner_id"`
+	OpponentID *uint64 `form:"opponent_id"`
+	Page       int     `form:"page"`
+	PageSize   int     `form:"page_size"`
+}
+
+type PublicMatchesRequest struct {
+	SportID      *int   `form:"sport_id"`
+	TownID       *int   `form:"town_id"`
+	From         string `form:"from"` // YYYY-MM-DD, включительно
+	To           string `form:"to"`   // YYYY-MM-DD, включительно
+	LevelID      *int   `form:"level_id"`
+	MinFreeSpots *int   `form:"min_free_spots"` // nil — хотя бы одно место
+	Cursor       string `form:"cursor"`
+	Limit        int    `form:"limit"`
+}
+
+type CreateInviteLinkRequest struct {
+	MaxUses        *int `json:"max_uses"`         // nil — без ограничения
+	ExpiresInHours int  `json:"expires_in_hours"` // 0 — MATCH_INVITE_LINK_TTL
+}
+
+type RedeemInviteLinkRequest struct {
+	Token string `json:"token"`
+}
+
+type SubmitMatchResultRequest struct {
+	WinnerTeam int               `json:"winner_team"` // 1 | 2, должен совпасть со счётом
+	Team1      []uint64          `json:"team1"`
+	Team2      []uint64          `json:"team2"`
+	Sets       []models.SetScore `json:"sets"`
+	BestOf     int               `json:"best_of"`  // 3 (по умолчанию) | 5 — только теннис
+	SportID    *int              `json:"sport_id"` // если у матча не указан вид спорта
+}
+
+type DisputeMatchResultRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ResolveMatchResultRequest struct {
+	Decision string  `json:"decision"` // confirm | reject
+	Comment  *string `json:"comment"`
+}
+
+type RatingFlagsRequest struct {
+	Status string `form:"status"` // open (по умолчанию) | dismissed | voided
+}
+
+type ResolveRatingFlagRequest struct {
+	Decision string  `json:"decision"` // dismiss | void — исключить результат из рейтинга и пересчитать рейтинги
+	Comment  *string `json:"comment"`
+}
+
+type RatingHistoryRequest struct {
+	SportID *int `form:"sport_id"`
+	Limit   int  `form:"limit"`
+}
+
+type LeaderboardRequest struct {
+	SportID  int    `form:"sport_id"`
+	TownID   *int   `form:"town_id"`
+	Scope    string `form:"scope"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+type NearbyPlayersRequest struct {
+	SportID int    `form:"sport_id"`
+	TownID  *int   `form:"town_id"`
+	Scope   string `form:"scope"`
+	Radius  int    `form:"radius"`
+}
+
+type StartRatingSeasonRequest struct {
+	SportID     int      `json:"sport_id"`
+	Name        string   `json:"name"`
+	ResetFactor *float64 `json:"reset_factor"` // 0–1; nil — RATING_SEASON_RESET_FACTOR
+}
+
+type RatingSeasonsRequest struct {
+	SportID *int `form:"sport_id"`
+}
+
+type SetMatchLineupRequest struct {
+	Players []models.LineupAssignment `json:"players"`
+}
+
+type MarkAttendanceRequest struct {
+	UserID uint64 `json:"user_id"`
+	Status string `json:"status"` // no_show; поздняя отмена записывается только при выходе из матча
+}
+
+type ReportNoShowRequest struct {
+	UserID uint64 `json:"user_id"`
+}
@@ -0,0 +1,22 @@
+package requests
+
+type BlockUserRequest struct {
+	UserID uint64 `json:"user_id"`
+}
+
+type CreateReportRequest struct {
+	TargetType string  `json:"target_type"` // user | message | match
+	TargetID   uint64  `json:"target_id"`
+	Reason     string  `json:"reason"` // spam | harassment | inappropriate_content | cheating | fake_profile | other
+	Comment    *string `json:"comment"`
+}
+
+type ReportsRequest struct {
+	Status     string `form:"status"` // open (по умолчанию) | resolved | dismissed
+	TargetType string `form:"target_type"`
+}
+
+type ResolveReportRequest struct {
+	Decision string  `json:"decision"` // resolve — нарушение подтверждено | dismiss — жалоба отклонена
+	Comment  *string `json:"comment"`
+}
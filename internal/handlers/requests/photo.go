@@ -0,0 +1,6 @@
+package requests
+
+type SignedFileRequest struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
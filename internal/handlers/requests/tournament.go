@@ -0,0 +1,36 @@
+package requests
+
+import "time"
+
+type CreateTournamentRequest struct {
+	Name                 string     `json:"name"`
+	Format               string     `json:"format"` // single_elimination, round_robin, swiss или ladder
+	SportID              int        `json:"sport_id"`
+	TownID               *int       `json:"town_id"`
+	MatchTypeID          int        `json:"match_type_id"`
+	TeamSize             int        `json:"team_size"`              // 0 — одиночный разряд
+	SwissRounds          *int       `json:"swiss_rounds"`           // обязателен для swiss
+	ChallengeRange       *int       `json:"challenge_range"`        // для ladder; nil — 3 позиции
+	StartsAt             *time.Time `json:"starts_at"`              // начало первого круга, RFC 3339; лестнице не нужно
+	RoundIntervalMinutes int        `json:"round_interval_minutes"` // 0 — сутки между кругами
+	MatchDurationMinutes int        `json:"match_duration_minutes"` // 0 — 90 минут
+}
+
+type TournamentsRequest struct {
+	Status   string `form:"status"`
+	SportID  *int   `form:"sport_id"`
+	TownID   *int   `form:"town_id"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"page_size"`
+}
+
+type AddTournamentEntryRequest struct {
+	PlayerIDs []uint64 `json:"player_ids"` // team_size игроков
+}
+
+type LadderChallengeRequest struct {
+	DefenderEntryID uint64     `json:"defender_entry_id"`
+	StartsAt        time.Time  `json:"starts_at"`        // RFC 3339
+	EndsAt          *time.Time `json:"ends_at"`          // nil — starts_at + duration_minutes
+	DurationMinutes int        `json:"duration_minutes"` // 0 — длительность матчей турнира
+}
@@ -0,0 +1,6 @@
+package requests
+
+type SearchTownsRequest struct {
+	Query string `form:"q"`
+	Limit int    `form:"limit"`
+}
@@ -21,3 +21,57 @@ type CreateUserRequest struct {
 	InjuryDescription *string `json:"injury_description,omitempty"`
 	Photo             *string `json:"photo,omitempty"`
 }
+
+type ContactChangeRequest struct {
+	Type  string `json:"type"` // phone | email
+	Value string `json:"value"`
+}
+
+type ConfirmContactChangeRequest struct {
+	Type string `json:"type"` // phone | email
+	OTP  string `json:"otp"`
+}
+
+type UpdateProfileVisibilityRequest struct {
+	ShowRating           *bool `json:"show_rating"`
+	ShowMatchesPlayed    *bool `json:"show_matches_played"`
+	ShowFriends          *bool `json:"show_friends"`
+	ShowPartners         *bool `json:"show_partners"`
+	ShowTown             *bool `json:"show_town"`
+	ShowTrainingDistrict *bool `json:"show_training_district"`
+	ShowCourtPosition    *bool `json:"show_court_position"`
+	ShowInSuggestions    *bool `json:"show_in_suggestions"`
+}
+
+type UpdateCourtPositionRequest struct {
+	CourtPosition *string `json:"court_position"` // left | right | both | null
+}
+
+// ExtendedProfileRequest — анкета клиента. Заполнять можно по частям: не переданные поля
+// не меняются; рост, вес, уровень, цель и город проверяются при оформлении подписки.
+type ExtendedProfileRequest struct {
+	HeightCm                 *int    `json:"height_cm"`
+	WeightKg                 *int    `json:"weight_kg"`
+	SportActivityLevelID     *int    `json:"sport_activity_level_id"`
+	SportTargetID            *int    `json:"sport_target_id"`
+	LocationPreferenceTypeID *int    `json:"location_preference_type_id"`
+	TownID                   *int    `json:"town_id"`
+	IsHaveInjury             *bool   `json:"is_have_injury"`
+	InjuryDescription        *string `json:"injury_description"`
+}
+
+type PurchaseSubscriptionRequest struct {
+	SubscriptionID int    `json:"subscription_id"`
+	RefreshToken   string `json:"refresh_token" binding:"required"` // текущий refresh-токен, отзывается при выдаче новой пары
+}
+
+type AdminActivateSubscriptionRequest struct {
+	SubscriptionID int `json:"subscription_id"`
+	Days           int `json:"days"` // 0 — срок по умолчанию
+}
+
+type PlayerSuggestionsRequest struct {
+	SportID        *int `form:"sport_id"`
+	MinReliability *int `form:"min_reliability"`
+	Limit          int  `form:"limit"`
+}
@@ -0,0 +1,8 @@
+package responses
+
+import "time"
+
+type AccountDeletionResponse struct {
+	DeletionRequestedAt time.Time `json:"deletion_requested_at"`
+	AnonymizeAfter      time.Time `json:"anonymize_after"` // до этого момента удаление можно отменить
+}
@@ -0,0 +1,17 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type ActivitiesResponse struct {
+	Activities []models.Activity `json:"activities"`
+}
+
+type ActivityRequestsResponse struct {
+	Requests []models.ActivityRequest `json:"requests"`
+}
+
+type ScheduleResponse struct {
+	From   string                 `json:"from"`
+	To     string                 `json:"to"`
+	Events []models.ScheduleEvent `json:"events"`
+}
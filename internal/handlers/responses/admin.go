@@ -0,0 +1,10 @@
+package responses
+
+import "sport-assistance/internal/services/dto"
+
+type AdminUsersResponse struct {
+	Users    []dto.AdminUserDto `json:"users"`
+	Total    int                `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
@@ -0,0 +1,13 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type FriendsResponse struct {
+	Friends  []models.Friend `json:"friends"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
+
+type FriendRequestsResponse struct {
+	Requests []models.FriendRequest `json:"requests"`
+}
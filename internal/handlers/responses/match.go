@@ -0,0 +1,84 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type CreateMatchResponse struct {
+	ID uint64 `json:"id"`
+}
+
+type MatchesResponse struct {
+	Matches  []models.Match `json:"matches"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"page_size"`
+}
+
+type PublicMatchesResponse struct {
+	Matches    []models.Match `json:"matches"`
+	NextCursor string         `json:"next_cursor,omitempty"` // пусто — страниц больше нет
+}
+
+type MatchInvitationsResponse struct {
+	Invitations []models.MatchInvitation `json:"invitations"`
+}
+
+type MatchInviteLinksResponse struct {
+	Links []models.MatchInviteLink `json:"links"`
+}
+
+type MatchResultsResponse struct {
+	Results []models.MatchResult `json:"results"`
+}
+
+type MyRatingsResponse struct {
+	Ratings     []models.PlayerRating     `json:"ratings"`
+	History     []models.RatingChange     `json:"history"`
+	Adjustments []models.RatingAdjustment `json:"adjustments"` // сбросы сезонов и снижения за неактивность
+}
+
+// LeaderboardResponse — в me место пользователя, даже если оно за пределами страницы;
+// null, если он ещё не играл рейтинговых матчей в этом виде спорта
+type LeaderboardResponse struct {
+	SportID  int                       `json:"sport_id"`
+	Scope    models.LeaderboardScope   `json:"scope"`
+	TownID   *int                      `json:"town_id"`
+	Entries  []models.LeaderboardEntry `json:"entries"`
+	Me       *models.LeaderboardEntry  `json:"me"`
+	Total    int                       `json:"total"`
+	Page     int                       `json:"page,omitempty"`
+	PageSize int                       `json:"page_size,omitempty"`
+}
+
+// HeadToHeadResponse — against: матчи друг против друга, together: матчи в одной команде;
+// null, если таких не было
+type HeadToHeadResponse struct {
+	UserID     uint64              `json:"user_id"`
+	OpponentID uint64              `json:"opponent_id"`
+	Against    *models.PairStats   `json:"against"`
+	Together   *models.PairStats   `json:"together"`
+	Matches    []models.PairResult `json:"matches"`
+}
+
+// MatchLineupResponse — состав команд; teamN_rating — средний рейтинг команды в виде спорта матча
+type MatchLineupResponse struct {
+	MatchID     uint64                `json:"match_id"`
+	Players     []models.LineupPlayer `json:"players"`
+	Team1Rating *float64              `json:"team1_rating"`
+	Team2Rating *float64              `json:"team2_rating"`
+}
+
+type MatchAttendanceResponse struct {
+	Marks []models.AttendanceMark `json:"marks"`
+}
+
+type RatingFlagsResponse struct {
+	Flags []models.RatingFlag `json:"flags"`
+}
+
+type RatingSeasonsResponse struct {
+	Seasons []models.RatingSeason `json:"seasons"`
+}
+
+type SeasonStandingsResponse struct {
+	Season    models.RatingSeason     `json:"season"`
+	Standings []models.SeasonStanding `json:"standings"`
+}
@@ -0,0 +1,11 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type BlockedUsersResponse struct {
+	Users []models.BlockedUser `json:"users"`
+}
+
+type ReportsResponse struct {
+	Reports []models.Report `json:"reports"`
+}
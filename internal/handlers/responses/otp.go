@@ -11,4 +11,9 @@ type ConfirmOTPResponse struct {
 	Message      string `json:"message"`
 	AccessToken  string `json:"access_token,omitempty"`
 	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// InvitationID — приглашение в матч, выданное по invite_token зарегистрированному пользователю.
+	// InvitePending — пользователь не зарегистрирован, приглашение будет выдано после регистрации.
+	InvitationID  *uint64 `json:"invitation_id,omitempty"`
+	InvitePending bool    `json:"invite_pending,omitempty"`
 }
@@ -0,0 +1,7 @@
+package responses
+
+type UserPhotoResponse struct {
+	PhotoURL     *string `json:"photo_url"`
+	ThumbnailURL *string `json:"thumbnail_url"`
+	ExpiresIn    int64   `json:"expires_in"` // срок жизни ссылок в секундах
+}
@@ -0,0 +1,7 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type PairStatsResponse struct {
+	Players []models.PairStats `json:"players"`
+}
@@ -0,0 +1,21 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type SubscriptionsResponse struct {
+	Subscriptions []models.Subscription `json:"subscriptions"`
+}
+
+// UpgradeStatusResponse — чего не хватает гостю для перехода в клиенты
+type UpgradeStatusResponse struct {
+	ProfileComplete bool                     `json:"profile_complete"`
+	MissingFields   []string                 `json:"missing_fields"`
+	Subscription    *models.UserSubscription `json:"subscription"`
+}
+
+// SubscriptionActivatedResponse — оформленная подписка и токены с обновлёнными правами
+type SubscriptionActivatedResponse struct {
+	Subscription models.UserSubscription `json:"subscription"`
+	AccessToken  string                  `json:"access_token"`
+	RefreshToken string                  `json:"refresh_token"`
+}
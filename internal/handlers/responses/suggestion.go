@@ -0,0 +1,7 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type PlayerSuggestionsResponse struct {
+	Suggestions []models.PlayerSuggestion `json:"suggestions"`
+}
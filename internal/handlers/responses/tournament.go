@@ -0,0 +1,20 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type TournamentsResponse struct {
+	Tournaments []models.Tournament `json:"tournaments"`
+	Page        int                 `json:"page"`
+	PageSize    int                 `json:"page_size"`
+}
+
+type TournamentFixturesResponse struct {
+	Fixtures []models.TournamentFixture `json:"fixtures"`
+}
+
+type TournamentStandingsResponse struct {
+	TournamentID uint64                      `json:"tournament_id"`
+	Format       models.TournamentFormat     `json:"format"`
+	Status       models.TournamentStatus     `json:"status"`
+	Standings    []models.TournamentStanding `json:"standings"`
+}
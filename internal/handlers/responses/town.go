@@ -0,0 +1,7 @@
+package responses
+
+import "sport-assistance/internal/models"
+
+type TownsResponse struct {
+	Towns []models.Town `json:"towns"`
+}
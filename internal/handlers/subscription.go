@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetSubscriptions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	subscriptions, err := h.service.GetSubscriptions(ctx)
+	if err != nil {
+		h.logger.Error("Get subscriptions failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.SubscriptionsResponse{Subscriptions: subscriptions})
+}
+
+func (h *Handler) GetUpgradeStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.GetUpgradeStatus(ctx, userID)
+	if err != nil {
+		h.logger.Error("Get upgrade status failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *Handler) UpdateExtendedProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.ExtendedProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind extended profile request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	user, err := h.service.UpdateExtendedProfile(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Update extended profile failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) PurchaseSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.PurchaseSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind purchase subscription request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.service.PurchaseSubscription(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Purchase subscription failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) AdminActivateSubscription(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.AdminActivateSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind activate subscription request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	subscription, err := h.service.AdminActivateSubscription(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Activate subscription failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
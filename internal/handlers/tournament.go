@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateTournament(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.CreateTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind create tournament request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	tournament, err := h.service.CreateTournament(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Create tournament failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tournament)
+}
+
+func (h *Handler) ListTournaments(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req requests.TournamentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind tournaments request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	tournaments, err := h.service.ListTournaments(ctx, req)
+	if err != nil {
+		h.logger.Error("List tournaments failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tournaments)
+}
+
+func (h *Handler) GetTournament(c *gin.Context) {
+	ctx := c.Request.Context()
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	tournament, err := h.service.GetTournament(ctx, tournamentID)
+	if err != nil {
+		h.logger.Error("Get tournament failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+func (h *Handler) AddTournamentEntry(c *gin.Context) {
+	ctx := c.Request.Context()
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.AddTournamentEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind tournament entry request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	tournament, err := h.service.AddTournamentEntry(ctx, tournamentID, req)
+	if err != nil {
+		h.logger.Error("Add tournament entry failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tournament)
+}
+
+func (h *Handler) RemoveTournamentEntry(c *gin.Context) {
+	ctx := c.Request.Context()
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+	entryID, ok := h.idParam(c, "entry_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveTournamentEntry(ctx, tournamentID, entryID); err != nil {
+		h.logger.Error("Remove tournament entry failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) StartTournament(c *gin.Context) {
+	ctx := c.Request.Context()
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	tournament, err := h.service.StartTournament(ctx, tournamentID)
+	if err != nil {
+		h.logger.Error("Start tournament failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+func (h *Handler) CompleteLadder(c *gin.Context) {
+	ctx := c.Request.Context()
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	tournament, err := h.service.CompleteLadder(ctx, tournamentID)
+	if err != nil {
+		h.logger.Error("Complete ladder failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+func (h *Handler) ListTournamentFixtures(c *gin.Context) {
+	ctx := c.Request.Context()
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	fixtures, err := h.service.ListTournamentFixtures(ctx, tournamentID)
+	if err != nil {
+		h.logger.Error("List tournament fixtures failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fixtures)
+}
+
+func (h *Handler) GetTournamentStandings(c *gin.Context) {
+	ctx := c.Request.Context()
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	standings, err := h.service.GetTournamentStandings(ctx, tournamentID)
+	if err != nil {
+		h.logger.Error("Get tournament standings failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
+
+func (h *Handler) ChallengeLadder(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+	tournamentID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req requests.LadderChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Bind ladder challenge request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	fixture, err := h.service.ChallengeLadder(ctx, userID, tournamentID, req)
+	if err != nil {
+		h.logger.Error("Ladder challenge failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, fixture)
+}
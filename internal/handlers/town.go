@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) SearchTowns(c *gin.Context) {
+	ctx := c.Request.Context()
+	var req requests.SearchTownsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind search towns request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	towns, err := h.service.SearchTowns(ctx, req.Query, req.Limit)
+	if err != nil {
+		h.logger.Error("Search towns failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.TownsResponse{Towns: towns})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetMe(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.GetMe(ctx, userID)
+	if err != nil {
+		h.logger.Error("Get me failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) GetUser(c *gin.Context) {
+	ctx := c.Request.Context()
+	viewerID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := h.idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.service.GetUser(ctx, viewerID, userID, h.currentPermissions(c))
+	if err != nil {
+		h.logger.Error("Get user failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) SuggestPlayers(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID, ok := h.currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req requests.PlayerSuggestionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Error("Bind player suggestions request error: ", "err", err)
+		c.JSON(http.StatusBadRequest, myerrors.Response{
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	suggestions, err := h.service.SuggestPlayers(ctx, userID, req)
+	if err != nil {
+		h.logger.Error("Suggest players failed: ", "err", err)
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.PlayerSuggestionsResponse{Suggestions: suggestions})
+}
@@ -52,7 +52,7 @@ func (m *Middleware) AuthMiddleware() gin.HandlerFunc {
 		})
 
 		if err != nil {
-			m.logger.Error("jwt parse error: %v", err)
+			m.logger.Error("jwt parse error: ", "err", err)
 			c.JSON(http.StatusUnauthorized, AuthResponse{Success: false, Error: myerrors.ParseTokenErrorMessage})
 			c.Abort()
 			return
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// ChatMessage — сообщение пользователя для выгрузки его данных
+type ChatMessage struct {
+	ID        uint64    `json:"id"`
+	ChatID    uint64    `json:"chat_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
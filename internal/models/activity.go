@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Activity — вид активности из каталога: тренировка, аренда корта и т.п.
+// SportID == nil — подходит для любого вида спорта.
+type Activity struct {
+	ID        int    `json:"id"`
+	ServiceID *int   `json:"service_id"`
+	SportID   *int   `json:"sport_id"`
+	Name      string `json:"name"`
+}
+
+type ActivityRequestStatus string
+
+const (
+	ActivityRequestPending   ActivityRequestStatus = "pending"
+	ActivityRequestConfirmed ActivityRequestStatus = "confirmed"
+	ActivityRequestRejected  ActivityRequestStatus = "rejected"
+	ActivityRequestCancelled ActivityRequestStatus = "cancelled"
+)
+
+// ActivityRequest — заявка на активность. После подтверждения ассистентом
+// CalendarID указывает на запись в календаре пользователя.
+type ActivityRequest struct {
+	ID            uint64                `json:"id"`
+	UserID        uint64                `json:"user_id"`
+	ActivityID    int                   `json:"activity_id"`
+	Activity      string                `json:"activity"`
+	SportID       int                   `json:"sport_id"`
+	SportObjectID int                   `json:"sport_object_id"`
+	CourtID       *int                  `json:"court_id"`
+	StartsAt      time.Time             `json:"starts_at"`
+	EndsAt        time.Time             `json:"ends_at"`
+	Comment       *string               `json:"comment"`
+	Status        ActivityRequestStatus `json:"status"`
+	ReviewedBy    *uint64               `json:"reviewed_by"`
+	ReviewComment *string               `json:"review_comment"`
+	ReviewedAt    *time.Time            `json:"reviewed_at"`
+	CalendarID    *uint64               `json:"calendar_id"`
+	CreatedAt     time.Time             `json:"created_at"`
+}
+
+// ActivityRequestFilter — выборка заявок: свои (UserID) или очередь ассистента
+type ActivityRequestFilter struct {
+	UserID *uint64
+	Status *ActivityRequestStatus
+}
+
+// ScheduleEvent — событие личного расписания: открытый матч или подтверждённая активность
+type ScheduleEvent struct {
+	Kind          ScheduleEventKind `json:"kind"`
+	ID            uint64            `json:"id"` // id матча или записи activity_calendars
+	Title         string            `json:"title"`
+	SportID       *int              `json:"sport_id"`
+	SportObjectID *int              `json:"sport_object_id"`
+	CourtID       *int              `json:"court_id"`
+	StartsAt      time.Time         `json:"starts_at"`
+	EndsAt        time.Time         `json:"ends_at"`
+}
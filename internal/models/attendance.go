@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+type AttendanceStatus string
+
+const (
+	AttendanceNoShow           AttendanceStatus = "no_show"
+	AttendanceLateCancellation AttendanceStatus = "late_cancellation" // вышел из матча незадолго до начала
+)
+
+// AttendanceMark — отметка о неявке или поздней отмене. ReportedBy == nil — записана автоматически.
+type AttendanceMark struct {
+	MatchID    uint64           `json:"match_id"`
+	UserID     uint64           `json:"user_id"`
+	Status     AttendanceStatus `json:"status"`
+	ReportedBy *uint64          `json:"reported_by"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// NoShowReport — жалобы участников на неявку игрока. Mark появляется, когда жалоб набралось Required.
+type NoShowReport struct {
+	MatchID  uint64          `json:"match_id"`
+	UserID   uint64          `json:"user_id"`
+	Reports  int             `json:"reports"`
+	Required int             `json:"required"`
+	Mark     *AttendanceMark `json:"mark"`
+}
+
+// Reliability — надёжность игрока: сколько раз он брал на себя участие в матче
+// (сыгранные матчи и поздние выходы) и сколько раз подвёл. Score — от 0 до 100.
+type Reliability struct {
+	UserID            uint64 `json:"user_id"`
+	Score             int    `json:"score"`
+	Commitments       int    `json:"commitments"`
+	NoShows           int    `json:"no_shows"`
+	LateCancellations int    `json:"late_cancellations"`
+}
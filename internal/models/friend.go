@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type FriendRequestStatus string
+
+const (
+	FriendRequestPending   FriendRequestStatus = "pending"
+	FriendRequestAccepted  FriendRequestStatus = "accepted"
+	FriendRequestDeclined  FriendRequestStatus = "declined"
+	FriendRequestCancelled FriendRequestStatus = "cancelled"
+)
+
+// FriendUser — краткая карточка другого пользователя в списках друзей и заявок.
+// MutualFriends — общие друзья с тем, кто смотрит список.
+type FriendUser struct {
+	UserID        uint64  `json:"user_id"`
+	Name          string  `json:"name"`
+	Surname       string  `json:"surname"`
+	PhotoURL      *string `json:"photo_url"`
+	MutualFriends int     `json:"mutual_friends"`
+}
+
+// FriendRequest — заявка в друзья; User — другая сторона заявки относительно смотрящего
+type FriendRequest struct {
+	ID          uint64              `json:"id"`
+	SenderID    uint64              `json:"sender_id"`
+	ReceiverID  uint64              `json:"receiver_id"`
+	Status      FriendRequestStatus `json:"status"`
+	CreatedAt   time.Time           `json:"created_at"`
+	RespondedAt *time.Time          `json:"responded_at"`
+	User        *FriendUser         `json:"user,omitempty"`
+}
+
+// Friend — друг с рейтингами по видам спорта; рейтинги скрыты, если друг выключил show_rating
+type Friend struct {
+	FriendUser
+	TownID       *int           `json:"town_id"`
+	Ratings      []PlayerRating `json:"ratings"`
+	FriendsSince *time.Time     `json:"friends_since"`
+}
@@ -2,8 +2,103 @@ package models
 
 import "time"
 
+type MatchStatus string
+
+const (
+	MatchStatusScheduled MatchStatus = "scheduled" // ждёт подтверждения нужного числа игроков
+	MatchStatusActive    MatchStatus = "active"    // игроков достаточно
+	MatchStatusCancelled MatchStatus = "cancelled"
+	MatchStatusCompleted MatchStatus = "completed"
+)
+
+type MatchVisibility string
+
+const (
+	MatchVisibilityPrivate MatchVisibility = "private" // только по приглашениям
+	MatchVisibilityPublic  MatchVisibility = "public"  // виден в разделе «Публичные», вступить может любой
+)
+
+// Названия типов матчей из справочника match_types
+const (
+	MatchTypeFriendly = "friendly"
+	MatchTypeRanked   = "ranked"
+)
+
+// IsOpen — матч ещё не начался по статусу: в него можно вступить, выйти или отменить его
+func (s MatchStatus) IsOpen() bool {
+	return s == MatchStatusScheduled || s == MatchStatusActive
+}
+
 type Match struct {
-	ID          uint64    `json:"id"`
-	MatchTypeID int       `json:"match_type_id"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID                uint64          `json:"id"`
+	MatchTypeID       int             `json:"match_type_id"`
+	MatchType         string          `json:"match_type"`
+	OrganizerID       *uint64         `json:"organizer_id"`
+	Status            MatchStatus     `json:"status"`
+	Visibility        MatchVisibility `json:"visibility"`
+	SportID           *int            `json:"sport_id"`
+	TownID            *int            `json:"town_id"`
+	Venue             *string         `json:"venue"`
+	MinLevelID        *int            `json:"min_level_id"`
+	MaxLevelID        *int            `json:"max_level_id"`
+	RequiredPlayers   int             `json:"required_players"`
+	Capacity          *int            `json:"capacity"` // nil — без ограничения
+	ParticipantsCount int             `json:"participants_count"`
+	FreeSpots         *int            `json:"free_spots"`      // nil — без ограничения
+	MinReliability    *int            `json:"min_reliability"` // порог надёжности для вступления в публичный матч
+	StartsAt          *time.Time      `json:"starts_at"`
+	EndsAt            *time.Time      `json:"ends_at"`
+	DurationMinutes   *int            `json:"duration_minutes"` // считается из starts_at и ends_at
+	SportObjectID     *int            `json:"sport_object_id"`
+	CourtID           *int            `json:"court_id"`
+	CancelledAt       *time.Time      `json:"cancelled_at"`
+	CreatedAt         time.Time       `json:"created_at"`
+}
+
+// MatchDetails — матч вместе с участниками
+type MatchDetails struct {
+	Match
+	ParticipantIDs []uint64 `json:"participant_ids"`
+}
+
+// MatchListFilter — выборка «моих матчей»: предстоящие или прошедшие
+type MatchListFilter struct {
+	Upcoming   bool
+	Now        time.Time
+	PartnerID  *uint64 // только подтверждённые матчи, где этот игрок был в одной команде с пользователем
+	OpponentID *uint64 // только подтверждённые матчи против этого игрока
+	Limit      int
+	Offset     int
+}
+
+type ScheduleEventKind string
+
+const (
+	ScheduleEventMatch    ScheduleEventKind = "match"
+	ScheduleEventActivity ScheduleEventKind = "activity" // подтверждённая запись из activity_calendars
+)
+
+// ScheduleConflict — событие пользователя, которое пересекается с новым матчем
+type ScheduleConflict struct {
+	Kind     ScheduleEventKind `json:"kind"`
+	ID       uint64            `json:"id"` // id матча или записи activity_calendars
+	Title    string            `json:"title"`
+	StartsAt time.Time         `json:"starts_at"`
+	EndsAt   time.Time         `json:"ends_at"`
+}
+
+// PublicMatchFilter — поиск публичных матчей. Курсор — (StartsAt, ID) последнего матча предыдущей страницы.
+type PublicMatchFilter struct {
+	SportID      *int
+	TownID       *int
+	From         *time.Time
+	To           *time.Time
+	LevelID      *int // матч подходит, если уровень попадает в его диапазон
+	MinFreeSpots int
+	ViewerID     uint64 // скрываются матчи с участниками, состоящими со зрителем в блокировке
+	Now          time.Time
+
+	AfterStartsAt *time.Time
+	AfterID       uint64
+	Limit         int
 }
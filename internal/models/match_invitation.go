@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+	InvitationStatusExpired  InvitationStatus = "expired"
+)
+
+// MatchInvitation — приглашение игрока в матч. Просроченное приглашение читается
+// со статусом expired, даже если в таблице оно ещё pending.
+type MatchInvitation struct {
+	ID          uint64           `json:"id"`
+	MatchID     uint64           `json:"match_id"`
+	UserID      uint64           `json:"user_id"`
+	InviterID   *uint64          `json:"inviter_id"`
+	LinkID      *uint64          `json:"link_id"`
+	Status      InvitationStatus `json:"status"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	RespondedAt *time.Time       `json:"responded_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// MatchInviteLink — ссылка-приглашение в матч. Token и URL собирает сервис:
+// в базе хранится только запись, подпись считается по секрету.
+type MatchInviteLink struct {
+	ID        uint64     `json:"id"`
+	MatchID   uint64     `json:"match_id"`
+	InviterID *uint64    `json:"inviter_id"`
+	MaxUses   *int       `json:"max_uses"`
+	UsesCount int        `json:"uses_count"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	Token     string     `json:"token,omitempty"`
+	URL       string     `json:"url,omitempty"`
+}
+
+// IsUsable — по ссылке ещё можно получить приглашение
+func (l MatchInviteLink) IsUsable(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt) && (l.MaxUses == nil || l.UsesCount < *l.MaxUses)
+}
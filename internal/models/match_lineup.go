@@ -0,0 +1,32 @@
+package models
+
+// MatchSide — сторона корта игрока в паре
+type MatchSide string
+
+const (
+	MatchSideLeft  MatchSide = "left"
+	MatchSideRight MatchSide = "right"
+)
+
+func (s MatchSide) IsValid() bool {
+	return s == MatchSideLeft || s == MatchSideRight
+}
+
+// LineupPlayer — участник матча с командой и стороной. Рейтинг (в виде спорта матча) и предпочтение
+// стороны нужны для автобаланса и наружу не отдаются.
+type LineupPlayer struct {
+	UserID        uint64         `json:"user_id"`
+	Name          string         `json:"name"`
+	Surname       string         `json:"surname"`
+	Team          *int           `json:"team"`
+	Side          *MatchSide     `json:"side"`
+	Rating        *float64       `json:"-"`
+	CourtPosition *CourtPosition `json:"-"`
+}
+
+// LineupAssignment — куда поставить участника; участники без назначения остаются нераспределёнными
+type LineupAssignment struct {
+	UserID uint64     `json:"user_id"`
+	Team   int        `json:"team"`
+	Side   *MatchSide `json:"side"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+type ResultStatus string
+
+const (
+	ResultStatusPending   ResultStatus = "pending"   // ждёт подтверждения соперника
+	ResultStatusConfirmed ResultStatus = "confirmed" // подтверждён соперником или ассистентом
+	ResultStatusDisputed  ResultStatus = "disputed"  // оспорен, ждёт решения ассистента
+	ResultStatusRejected  ResultStatus = "rejected"  // отклонён ассистентом; результат можно ввести заново
+)
+
+// Названия видов спорта из справочника sports, для которых считается счёт
+const (
+	SportTennis = "теннис"
+	SportPadel  = "падел"
+)
+
+// SetScore — счёт сета. Для решающего тай-брейка (MatchTiebreak) в Team1/Team2 записаны очки.
+type SetScore struct {
+	Team1         int  `json:"team1"`
+	Team2         int  `json:"team2"`
+	Team1Tiebreak *int `json:"team1_tiebreak,omitempty"` // только для сета 7:6
+	Team2Tiebreak *int `json:"team2_tiebreak,omitempty"`
+	MatchTiebreak bool `json:"match_tiebreak,omitempty"`
+}
+
+type MatchResult struct {
+	ID                uint64       `json:"id"`
+	MatchID           uint64       `json:"match_id"`
+	SubmittedBy       *uint64      `json:"submitted_by"`
+	WinnerTeam        int          `json:"winner_team"`
+	Team1             []uint64     `json:"team1"`
+	Team2             []uint64     `json:"team2"`
+	Sets              []SetScore   `json:"sets"`
+	Status            ResultStatus `json:"status"`
+	ConfirmedBy       *uint64      `json:"confirmed_by"`
+	ConfirmedAt       *time.Time   `json:"confirmed_at"`
+	DisputedBy        *uint64      `json:"disputed_by"`
+	DisputeReason     *string      `json:"dispute_reason"`
+	DisputedAt        *time.Time   `json:"disputed_at"`
+	ResolvedBy        *uint64      `json:"resolved_by"`
+	ResolutionComment *string      `json:"resolution_comment"`
+	ResolvedAt        *time.Time   `json:"resolved_at"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
+
+// TeamOf возвращает команду игрока (1 или 2); 0 — игрок не в составе
+func (r MatchResult) TeamOf(userID uint64) int {
+	for _, id := range r.Team1 {
+		if id == userID {
+			return 1
+		}
+	}
+	for _, id := range r.Team2 {
+		if id == userID {
+			return 2
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// BlockedUser — запись чёрного списка пользователя
+type BlockedUser struct {
+	UserID    uint64    `json:"user_id"`
+	Name      string    `json:"name"`
+	Surname   string    `json:"surname"`
+	PhotoURL  *string   `json:"photo_url"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+type ReportTargetType string
+
+const (
+	ReportTargetUser    ReportTargetType = "user"
+	ReportTargetMessage ReportTargetType = "message"
+	ReportTargetMatch   ReportTargetType = "match"
+)
+
+type ReportReason string
+
+const (
+	ReportReasonSpam                 ReportReason = "spam"
+	ReportReasonHarassment           ReportReason = "harassment"
+	ReportReasonInappropriateContent ReportReason = "inappropriate_content"
+	ReportReasonCheating             ReportReason = "cheating"
+	ReportReasonFakeProfile          ReportReason = "fake_profile"
+	ReportReasonOther                ReportReason = "other"
+)
+
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "open"
+	ReportResolved  ReportStatus = "resolved"
+	ReportDismissed ReportStatus = "dismissed"
+)
+
+// Report — жалоба в очереди модерации. ReportedUserID — на кого жалоба:
+// сам пользователь, автор сообщения или организатор матча.
+type Report struct {
+	ID             uint64           `json:"id"`
+	ReporterID     *uint64          `json:"reporter_id"`
+	TargetType     ReportTargetType `json:"target_type"`
+	TargetID       uint64           `json:"target_id"`
+	ReportedUserID *uint64          `json:"reported_user_id"`
+	Reason         ReportReason     `json:"reason"`
+	Comment        *string          `json:"comment"`
+	Status         ReportStatus     `json:"status"`
+	ReviewedBy     *uint64          `json:"reviewed_by"`
+	Resolution     *string          `json:"resolution"`
+	ReviewedAt     *time.Time       `json:"reviewed_at"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
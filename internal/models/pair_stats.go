@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// PairResult — подтверждённый матч пользователя с другим игроком: напарником (одна команда)
+// или соперником. Won — выиграла ли команда пользователя.
+type PairResult struct {
+	MatchID        uint64    `json:"match_id"`
+	ResultID       uint64    `json:"result_id"`
+	OtherUserID    uint64    `json:"-"`
+	Name           string    `json:"-"`
+	Surname        string    `json:"-"`
+	PhotoThumbnail *string   `json:"-"`
+	SameTeam       bool      `json:"same_team"`
+	Won            bool      `json:"won"`
+	PlayedAt       time.Time `json:"played_at"`
+}
+
+// PairStats — итог встреч с одним напарником или соперником.
+// Streak — текущая серия: положительная — победы подряд, отрицательная — поражения.
+type PairStats struct {
+	UserID       uint64    `json:"user_id"`
+	Name         string    `json:"name"`
+	Surname      string    `json:"surname"`
+	PhotoURL     *string   `json:"photo_url"`
+	Matches      int       `json:"matches"`
+	Wins         int       `json:"wins"`
+	Losses       int       `json:"losses"`
+	Streak       int       `json:"streak"`
+	LastPlayedAt time.Time `json:"last_played_at"`
+}
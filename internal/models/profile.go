@@ -0,0 +1,65 @@
+package models
+
+// CourtPosition — предпочитаемая сторона корта в паре
+type CourtPosition string
+
+const (
+	CourtPositionLeft  CourtPosition = "left"
+	CourtPositionRight CourtPosition = "right"
+	CourtPositionBoth  CourtPosition = "both"
+)
+
+func (p CourtPosition) IsValid() bool {
+	switch p {
+	case CourtPositionLeft, CourtPositionRight, CourtPositionBoth:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProfileVisibility — какие поля карточки игрока видны другим пользователям
+type ProfileVisibility struct {
+	ShowRating           bool `json:"show_rating"`
+	ShowMatchesPlayed    bool `json:"show_matches_played"`
+	ShowFriends          bool `json:"show_friends"`
+	ShowPartners         bool `json:"show_partners"`
+	ShowTown             bool `json:"show_town"`
+	ShowTrainingDistrict bool `json:"show_training_district"`
+	ShowCourtPosition    bool `json:"show_court_position"`
+	ShowInSuggestions    bool `json:"show_in_suggestions"` // попадать в подбор партнёров у других игроков
+}
+
+// DefaultProfileVisibility — по умолчанию карточка открыта полностью
+func DefaultProfileVisibility() ProfileVisibility {
+	return ProfileVisibility{
+		ShowRating:           true,
+		ShowMatchesPlayed:    true,
+		ShowFriends:          true,
+		ShowPartners:         true,
+		ShowTown:             true,
+		ShowTrainingDistrict: true,
+		ShowCourtPosition:    true,
+		ShowInSuggestions:    true,
+	}
+}
+
+// PlayerStats — вычисляемая статистика для карточки игрока
+type PlayerStats struct {
+	Rating        *float64 `json:"rating"`
+	MatchesPlayed int      `json:"matches_played"`
+	FriendsCount  int      `json:"friends_count"`
+	PartnersCount int      `json:"partners_count"`
+}
+
+// PlayerCard — данные пользователя, нужные для публичной карточки
+type PlayerCard struct {
+	ID                uint64
+	Name              string
+	Surname           string
+	PhotoThumbnail    *string
+	TownID            *int
+	TownName          *string
+	CourtPosition     *CourtPosition
+	TrainingDistricts []string
+}
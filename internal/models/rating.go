@@ -0,0 +1,137 @@
+package models
+
+import "time"
+
+// PlayerRating — рейтинг игрока в одном виде спорта
+type PlayerRating struct {
+	UserID        uint64    `json:"user_id"`
+	SportID       int       `json:"sport_id"`
+	Sport         string    `json:"sport"`
+	Rating        float64   `json:"rating"`
+	MatchesPlayed int       `json:"matches_played"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// RatingChange — изменение рейтинга игрока за один рейтинговый матч
+type RatingChange struct {
+	MatchID      uint64    `json:"match_id"`
+	ResultID     uint64    `json:"result_id"`
+	SportID      int       `json:"sport_id"`
+	RatingBefore float64   `json:"rating_before"`
+	RatingAfter  float64   `json:"rating_after"`
+	Delta        float64   `json:"delta"`
+	Weight       float64   `json:"weight"` // меньше 1 — повторная встреча тех же составов
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LeaderboardScope — чей рейтинг попадает в таблицу
+type LeaderboardScope string
+
+const (
+	LeaderboardScopeAll     LeaderboardScope = "all"
+	LeaderboardScopeFriends LeaderboardScope = "friends" // друзья и сам пользователь
+)
+
+// LeaderboardEntry — строка таблицы рейтинга. При равном рейтинге место общее,
+// следующее место идёт с пропуском (1, 2, 2, 4).
+type LeaderboardEntry struct {
+	Rank          int     `json:"rank"`
+	UserID        uint64  `json:"user_id"`
+	Name          string  `json:"name"`
+	Surname       string  `json:"surname"`
+	PhotoURL      *string `json:"photo_url"`
+	TownID        *int    `json:"town_id"`
+	Rating        float64 `json:"rating"`
+	MatchesPlayed int     `json:"matches_played"`
+}
+
+// LeaderboardFilter — выборка таблицы рейтинга. Если Around задан, вместо страницы
+// берутся Radius игроков выше и ниже зрителя (или места, которое занял бы рейтинг Around).
+type LeaderboardFilter struct {
+	SportID  int
+	ViewerID uint64
+	TownID   *int
+	Scope    LeaderboardScope
+	Limit    int
+	Offset   int
+	Around   *float64
+	Radius   int
+}
+
+// Leaderboard — выбранные строки, место зрителя и число игроков в таблице
+type Leaderboard struct {
+	Entries []LeaderboardEntry
+	Me      *LeaderboardEntry
+	Total   int
+}
+
+// RatingSeason — сезон рейтинга вида спорта. В начале сезона рейтинги мягко сбрасываются
+// к начальному на долю ResetFactor; EndedAt == nil — сезон идёт.
+type RatingSeason struct {
+	ID          uint64     `json:"id"`
+	SportID     int        `json:"sport_id"`
+	Name        string     `json:"name"`
+	ResetFactor float64    `json:"reset_factor"`
+	StartedBy   *uint64    `json:"started_by"`
+	StartedAt   time.Time  `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at"`
+}
+
+// SeasonStanding — строка таблицы сезона; MatchesPlayed — рейтинговые матчи в этом сезоне
+type SeasonStanding struct {
+	Rank          int     `json:"rank"`
+	UserID        uint64  `json:"user_id"`
+	Name          string  `json:"name"`
+	Surname       string  `json:"surname"`
+	PhotoURL      *string `json:"photo_url"`
+	Rating        float64 `json:"rating"`
+	MatchesPlayed int     `json:"matches_played"`
+}
+
+type RatingAdjustmentKind string
+
+const (
+	RatingAdjustmentSeasonReset RatingAdjustmentKind = "season_reset"
+	RatingAdjustmentDecay       RatingAdjustmentKind = "decay"
+)
+
+// RatingAdjustment — изменение рейтинга не по матчу
+type RatingAdjustment struct {
+	SportID      int                  `json:"sport_id"`
+	Kind         RatingAdjustmentKind `json:"kind"`
+	SeasonID     *uint64              `json:"season_id"`
+	RatingBefore float64              `json:"rating_before"`
+	RatingAfter  float64              `json:"rating_after"`
+	Delta        float64              `json:"delta"`
+	CreatedAt    time.Time            `json:"created_at"`
+}
+
+type RatingFlagReason string
+
+const (
+	// одни и те же составы часто играют друг с другом, и побеждает всегда одна сторона
+	RatingFlagRepeatedMatchup RatingFlagReason = "repeated_matchup"
+)
+
+type RatingFlagStatus string
+
+const (
+	RatingFlagOpen      RatingFlagStatus = "open"
+	RatingFlagDismissed RatingFlagStatus = "dismissed"
+	RatingFlagVoided    RatingFlagStatus = "voided" // результат исключён из рейтинга
+)
+
+// RatingFlag — подозрительный рейтинговый результат в очереди ассистента
+type RatingFlag struct {
+	ID            uint64           `json:"id"`
+	ResultID      uint64           `json:"result_id"`
+	MatchID       uint64           `json:"match_id"`
+	SportID       int              `json:"sport_id"`
+	Reason        RatingFlagReason `json:"reason"`
+	Details       string           `json:"details"`
+	Status        RatingFlagStatus `json:"status"`
+	ReviewedBy    *uint64          `json:"reviewed_by"`
+	ReviewComment *string          `json:"review_comment"`
+	ReviewedAt    *time.Time       `json:"reviewed_at"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
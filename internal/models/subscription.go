@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive   SubscriptionStatus = "active"
+	SubscriptionStatusExpired  SubscriptionStatus = "expired"
+	SubscriptionStatusReplaced SubscriptionStatus = "replaced" // заменена новой (смена тарифа)
+)
+
+// Subscription — тариф (Sport Basic / Pro / Elite)
+type Subscription struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserSubscription — период действия тарифа у пользователя
+type UserSubscription struct {
+	ID               uint64             `json:"id"`
+	UserID           uint64             `json:"user_id"`
+	SubscriptionID   int                `json:"subscription_id"`
+	SubscriptionName string             `json:"subscription_name"`
+	Status           SubscriptionStatus `json:"status"`
+	StartsAt         time.Time          `json:"starts_at"`
+	EndsAt           time.Time          `json:"ends_at"`
+}
+
+// ExtendedProfile — анкета клиента, которую гость дозаполняет перед оформлением подписки.
+// Поля со значением nil при сохранении остаются прежними.
+type ExtendedProfile struct {
+	HeightCm                 *int
+	WeightKg                 *int
+	SportActivityLevelID     *int
+	SportTargetID            *int
+	LocationPreferenceTypeID *int
+	TownID                   *int
+	IsHaveInjury             *bool
+	InjuryDescription        *string
+}
@@ -0,0 +1,74 @@
+package models
+
+// SuggestionCandidate — возможный партнёр и то, что у него общего со зрителем.
+// Рейтинг, город и районы тренировок заполнены, только если игрок их не скрыл.
+type SuggestionCandidate struct {
+	UserID          uint64
+	Name            string
+	Surname         string
+	PhotoThumbnail  *string
+	TownID          *int
+	Rating          *float64 // в виде спорта из запроса
+	SharedSports    []string
+	SharedTimeSlots []string
+	SameTown        bool
+	SharedLocations []string
+	Friend          bool
+	PartnerMatches  int // подтверждённые матчи в одной команде со зрителем
+}
+
+// SuggestionWeights — веса оценки подбора. Одни и те же веса сортируют кандидатов в SQL до лимита
+// и раскладывают оценку на причины в сервисе.
+type SuggestionWeights struct {
+	Rating       float64 // за совпадающий рейтинг; убывает до нуля при разнице RatingSpread
+	RatingSpread float64
+	Sport        float64 // за каждый общий вид спорта, не больше SportMax
+	SportMax     int
+	TimeSlot     float64 // за каждое общее время тренировок, не больше TimeSlotMax
+	TimeSlotMax  int
+	Town         float64
+	Location     float64
+	Friend       float64
+	Partner      float64 // за каждый совместный матч, не больше PartnerMax
+	PartnerMax   int
+}
+
+// SuggestionQuery — выборка кандидатов в подбор. Rating — рейтинг зрителя в SportID,
+// nil — вид спорта не выбран и близость рейтинга не учитывается.
+type SuggestionQuery struct {
+	ViewerID uint64
+	SportID  *int
+	Rating   *float64
+	Weights  SuggestionWeights
+	Limit    int
+}
+
+// SuggestionReason — за что игрок попал в подбор и сколько это дало очков
+type SuggestionReason struct {
+	Kind    string  `json:"kind"`
+	Message string  `json:"message"`
+	Points  float64 `json:"points"`
+}
+
+const (
+	SuggestionReasonRating   = "similar_rating"
+	SuggestionReasonSports   = "shared_sports"
+	SuggestionReasonTime     = "shared_time_slots"
+	SuggestionReasonTown     = "same_town"
+	SuggestionReasonLocation = "shared_locations"
+	SuggestionReasonFriend   = "friend"
+	SuggestionReasonPartner  = "past_partner"
+)
+
+// PlayerSuggestion — игрок из подбора партнёров с объяснением оценки
+type PlayerSuggestion struct {
+	UserID      uint64             `json:"user_id"`
+	Name        string             `json:"name"`
+	Surname     string             `json:"surname"`
+	PhotoURL    *string            `json:"photo_url"`
+	TownID      *int               `json:"town_id"`
+	Rating      *float64           `json:"rating"`
+	Reliability int                `json:"reliability"` // в оценку не входит, только отсекает через min_reliability
+	Score       float64            `json:"score"`
+	Reasons     []SuggestionReason `json:"reasons"`
+}
@@ -0,0 +1,143 @@
+package models
+
+import "time"
+
+type TournamentFormat string
+
+const (
+	TournamentSingleElimination TournamentFormat = "single_elimination"
+	TournamentRoundRobin        TournamentFormat = "round_robin"
+	TournamentSwiss             TournamentFormat = "swiss"
+	TournamentLadder            TournamentFormat = "ladder" // лестница сезона: встречи по вызовам
+)
+
+func (f TournamentFormat) IsValid() bool {
+	switch f {
+	case TournamentSingleElimination, TournamentRoundRobin, TournamentSwiss, TournamentLadder:
+		return true
+	}
+	return false
+}
+
+type TournamentStatus string
+
+const (
+	TournamentStatusDraft      TournamentStatus = "draft" // идёт набор участников
+	TournamentStatusInProgress TournamentStatus = "in_progress"
+	TournamentStatusCompleted  TournamentStatus = "completed"
+)
+
+type FixtureStatus string
+
+const (
+	FixtureStatusWaiting   FixtureStatus = "waiting"   // соперники ещё не определились
+	FixtureStatusScheduled FixtureStatus = "scheduled" // матч создан
+	FixtureStatusCompleted FixtureStatus = "completed"
+)
+
+type Tournament struct {
+	ID                   uint64           `json:"id"`
+	Name                 string           `json:"name"`
+	Format               TournamentFormat `json:"format"`
+	Status               TournamentStatus `json:"status"`
+	SportID              int              `json:"sport_id"`
+	TownID               *int             `json:"town_id"`
+	MatchTypeID          int              `json:"match_type_id"`
+	TeamSize             int              `json:"team_size"`
+	SwissRounds          *int             `json:"swiss_rounds"`
+	ChallengeRange       *int             `json:"challenge_range"`
+	CurrentRound         int              `json:"current_round"`
+	OrganizerID          *uint64          `json:"organizer_id"`
+	EntriesCount         int              `json:"entries_count"`
+	StartsAt             *time.Time       `json:"starts_at"` // первый круг; время вызова лестницы задаёт вызывающий
+	RoundIntervalMinutes int              `json:"round_interval_minutes"`
+	MatchDurationMinutes int              `json:"match_duration_minutes"`
+	StartedAt            *time.Time       `json:"started_at"`
+	CompletedAt          *time.Time       `json:"completed_at"`
+	CreatedAt            time.Time        `json:"created_at"`
+}
+
+// RoundSchedule — время матчей круга по расписанию турнира. Круг, чьё время уже прошло
+// (или турнир без starts_at), начинается с from.
+func (t Tournament) RoundSchedule(round int, from time.Time) (startsAt, endsAt time.Time) {
+	startsAt = from
+	if t.StartsAt != nil {
+		slot := t.StartsAt.Add(time.Duration((round-1)*t.RoundIntervalMinutes) * time.Minute)
+		if slot.After(from) {
+			startsAt = slot
+		}
+	}
+
+	return startsAt, startsAt.Add(time.Duration(t.MatchDurationMinutes) * time.Minute)
+}
+
+// TournamentEntry — участник турнира: игрок или пара. Rating — средний рейтинг игроков на момент старта.
+type TournamentEntry struct {
+	ID           uint64    `json:"id"`
+	TournamentID uint64    `json:"tournament_id"`
+	PlayerIDs    []uint64  `json:"player_ids"`
+	Seed         *int      `json:"seed"`
+	Rating       *float64  `json:"rating"`
+	Position     *int      `json:"position"` // место на лестнице
+	Eliminated   bool      `json:"eliminated"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TournamentDetails — турнир вместе с участниками
+type TournamentDetails struct {
+	Tournament
+	Entries []TournamentEntry `json:"entries"`
+}
+
+// TournamentFixture — встреча сетки. Entry2ID == nil у завершённой встречи — свободный проход.
+type TournamentFixture struct {
+	ID            uint64        `json:"id"`
+	TournamentID  uint64        `json:"tournament_id"`
+	Round         int           `json:"round"`
+	Position      int           `json:"position"`
+	Entry1ID      *uint64       `json:"entry1_id"`
+	Entry2ID      *uint64       `json:"entry2_id"`
+	WinnerEntryID *uint64       `json:"winner_entry_id"`
+	MatchID       *uint64       `json:"match_id"`
+	IsChallenge   bool          `json:"is_challenge"`
+	Status        FixtureStatus `json:"status"`
+	CompletedAt   *time.Time    `json:"completed_at"`
+}
+
+// TournamentStanding — строка турнирной таблицы
+type TournamentStanding struct {
+	Rank       int      `json:"rank"`
+	EntryID    uint64   `json:"entry_id"`
+	PlayerIDs  []uint64 `json:"player_ids"`
+	Seed       *int     `json:"seed"`
+	Position   *int     `json:"position"`
+	Played     int      `json:"played"`
+	Wins       int      `json:"wins"`
+	Losses     int      `json:"losses"`
+	Byes       int      `json:"byes"`
+	Buchholz   int      `json:"buchholz"`
+	Eliminated bool     `json:"eliminated"`
+}
+
+// TournamentFilter — список турниров; nil-поля не фильтруют
+type TournamentFilter struct {
+	Status  *TournamentStatus
+	SportID *int
+	TownID  *int
+	Limit   int
+	Offset  int
+}
+
+// TournamentSeed — посев участника при старте турнира
+type TournamentSeed struct {
+	EntryID uint64
+	Seed    int
+	Rating  float64
+}
+
+// TournamentPlan — то, с чем турнир стартует: посев и встречи первых кругов.
+// Встречи сетки на выбывание создаются сразу на все круги, следующие круги — пустыми.
+type TournamentPlan struct {
+	Seeds    []TournamentSeed
+	Fixtures []TournamentFixture
+}
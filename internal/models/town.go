@@ -0,0 +1,10 @@
+package models
+
+type Town struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`      // NOT NULL, UNIQUE
+	Region    *string  `json:"region"`    // nullable
+	Timezone  *string  `json:"timezone"`  // nullable, IANA name (Europe/Moscow)
+	Latitude  *float64 `json:"latitude"`  // nullable
+	Longitude *float64 `json:"longitude"` // nullable
+}
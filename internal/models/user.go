@@ -17,6 +17,7 @@ type User struct {
 	LocationPreferenceTypeID *int `json:"location_preference_type_id"` // FK nullable
 	TownID                   *int `json:"town_id"`                     // FK nullable
 	RoleID                   *int `json:"role_id"`                     // FK nullable
+	SubscriptionID           *int `json:"subscription_id"`             // FK nullable, текущий тариф
 
 	PhoneNumber     string `json:"phone_number"`      // NOT NULL, UNIQUE
 	IsPhoneVerified bool   `json:"is_phone_verified"` // NOT NULL, DEFAULT false
@@ -27,8 +28,43 @@ type User struct {
 	IsHaveInjury      bool    `json:"is_have_injury"`     // DEFAULT false
 	InjuryDescription *string `json:"injury_description"` // nullable
 	Photo             *string `json:"photo"`              // nullable
+	PhotoThumbnail    *string `json:"photo_thumbnail"`    // nullable
 
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at"` // nullable for soft delete
+	CourtPosition *CourtPosition `json:"court_position"` // nullable
+
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DeletedAt           *time.Time `json:"deleted_at"`            // nullable for soft delete
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at"` // nullable, удаление по запросу владельца
+	BlockedAt           *time.Time `json:"blocked_at"`            // nullable, блокировка администратором
+}
+
+// UserCredentials — данные для проверки пароля. Загружаются только в Login,
+// во всех остальных выборках пользователя хэш пароля не читается.
+type UserCredentials struct {
+	ID           uint64
+	Email        string
+	PasswordHash string
+	BlockedAt    *time.Time
+}
+
+// UserPhoto — ключи объектов в BlobStorage (или внешние URL, заданные при регистрации)
+type UserPhoto struct {
+	Photo          *string `json:"photo"`
+	PhotoThumbnail *string `json:"photo_thumbnail"`
+}
+
+// ContactType — вид контакта, смена которого требует подтверждения
+type ContactType string
+
+const (
+	ContactTypePhone ContactType = "phone"
+	ContactTypeEmail ContactType = "email"
+)
+
+// PendingContactChange — ожидающая подтверждения смена контакта (хранится в Redis)
+type PendingContactChange struct {
+	Value    string `json:"value"`
+	OTP      string `json:"otp"`
+	Attempts int    `json:"attempts"`
 }
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// UserSortField — допустимые поля сортировки справочника пользователей
+type UserSortField string
+
+const (
+	UserSortID        UserSortField = "id"
+	UserSortCreatedAt UserSortField = "created_at"
+	UserSortUpdatedAt UserSortField = "updated_at"
+	UserSortName      UserSortField = "name"
+	UserSortSurname   UserSortField = "surname"
+	UserSortBirthDate UserSortField = "birth_date"
+)
+
+func (f UserSortField) IsValid() bool {
+	switch f {
+	case UserSortID, UserSortCreatedAt, UserSortUpdatedAt, UserSortName, UserSortSurname, UserSortBirthDate:
+		return true
+	default:
+		return false
+	}
+}
+
+// UserFilter — условия выборки пользователей для админки.
+// Пустой срез или nil означает «не фильтровать по этому полю».
+type UserFilter struct {
+	SubscriptionIDs       []int
+	HasSubscription       *bool
+	RoleIDs               []int
+	TownIDs               []int
+	SportActivityLevelIDs []int
+	SportTargetIDs        []int
+	SportIDs              []int // пользователь занимается хотя бы одним из видов спорта
+	IsHaveInjury          *bool
+	IsPhoneVerified       *bool
+	IsEmailVerified       *bool
+	IsBlocked             *bool
+	CreatedFrom           *time.Time
+	CreatedTo             *time.Time // не включительно
+	Search                string     // подстрока имени, фамилии, email или телефона
+
+	SortField UserSortField
+	SortDesc  bool
+	Limit     int // 0 — без ограничения (выгрузка CSV)
+	Offset    int
+}
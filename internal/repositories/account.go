@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// anonymizedMessageBody заменяет текст сообщений удалённого пользователя:
+// переписка собеседников сохраняется, но без его слов
+const anonymizedMessageBody = "[сообщение удалено]"
+
+// RequestUserDeletion ставит аккаунт в очередь на удаление. Повторный запрос не сдвигает срок.
+func (r *Repository) RequestUserDeletion(ctx context.Context, userID uint64) (time.Time, error) {
+	const query = `
+		UPDATE users
+		SET deletion_requested_at = COALESCE(deletion_requested_at, now()),
+		    updated_at = now()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		RETURNING deletion_requested_at
+	`
+
+	var requestedAt time.Time
+	if err := r.postgres.QueryRow(ctx, query, userID).Scan(&requestedAt); err != nil {
+		return time.Time{}, err
+	}
+
+	return requestedAt, nil
+}
+
+// CancelUserDeletion снимает запрос на удаление. pgx.ErrNoRows — запроса не было.
+func (r *Repository) CancelUserDeletion(ctx context.Context, userID uint64) error {
+	const query = `
+		UPDATE users
+		SET deletion_requested_at = NULL,
+		    updated_at = now()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		  AND deletion_requested_at IS NOT NULL
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, userID)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetUsersPendingAnonymization возвращает аккаунты, у которых истёк льготный период
+func (r *Repository) GetUsersPendingAnonymization(ctx context.Context, requestedBefore time.Time, limit int) ([]uint64, error) {
+	const query = `
+		SELECT id
+		FROM users
+		WHERE deletion_requested_at IS NOT NULL
+		  AND deletion_requested_at <= $1
+		  AND anonymized_at IS NULL
+		ORDER BY deletion_requested_at
+		LIMIT $2
+	`
+
+	rows, err := r.postgres.Query(ctx, query, requestedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]uint64, 0)
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// AnonymizeUser необратимо стирает персональные данные пользователя.
+// Строка users остаётся, чтобы не ломать историю матчей соперников и напарников.
+// Если запрос на удаление успели отменить, возвращает pgx.ErrNoRows.
+// Анкет и заказов в схеме пока нет — когда появятся, их нужно чистить здесь же.
+func (r *Repository) AnonymizeUser(ctx context.Context, userID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const anonymizeQuery = `
+		UPDATE users
+		SET name = 'Удалённый',
+		    surname = 'пользователь',
+		    gender = '',
+		    birth_date = DATE '1900-01-01',
+		    height_cm = NULL,
+		    weight_kg = NULL,
+		    sport_activity_level_id = NULL,
+		    sport_target_id = NULL,
+		    location_preference_type_id = NULL,
+		    town_id = NULL,
+		    phone_number = 'deleted-' || id,
+		    is_phone_verified = false,
+		    email = 'deleted-' || id || '@deleted.invalid',
+		    is_email_verified = false,
+		    password = NULL,
+		    is_have_injury = false,
+		    injury_description = NULL,
+		    photo = NULL,
+		    photo_thumbnail = NULL,
+		    court_position = NULL,
+		    anonymized_at = now(),
+		    deleted_at = COALESCE(deleted_at, now()),
+		    updated_at = now()
+		WHERE id = $1
+		  AND deletion_requested_at IS NOT NULL
+		  AND anonymized_at IS NULL
+	`
+
+	ct, err := tx.Exec(ctx, anonymizeQuery, userID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	cleanup := []string{
+		`UPDATE messages SET body = '` + anonymizedMessageBody + `', sender_id = NULL WHERE sender_id = $1`,
+		`DELETE FROM user_preferred_locations WHERE user_id = $1`,
+		`DELETE FROM user_training_time_slots WHERE user_id = $1`,
+		`DELETE FROM user_sports WHERE user_id = $1`,
+		`DELETE FROM user_profile_visibility WHERE user_id = $1`,
+		`DELETE FROM friends WHERE user_id = $1 OR friend_id = $1`,
+		`DELETE FROM friend_requests WHERE sender_id = $1 OR receiver_id = $1`,
+		`DELETE FROM user_blocks WHERE blocker_id = $1 OR blocked_id = $1`,
+		`UPDATE activity_requests SET comment = NULL, status = CASE WHEN status = 'pending' THEN 'cancelled' ELSE status END WHERE user_id = $1`,
+		`DELETE FROM chat_members WHERE user_id = $1`,
+		`UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`,
+	}
+	for _, query := range cleanup {
+		if _, err = tx.Exec(ctx, query, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *Repository) GetUserMessages(ctx context.Context, userID uint64) ([]models.ChatMessage, error) {
+	const query = `
+		SELECT id, chat_id, body, created_at
+		FROM messages
+		WHERE sender_id = $1
+		ORDER BY chat_id, created_at, id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]models.ChatMessage, 0)
+	for rows.Next() {
+		var message models.ChatMessage
+		if err := rows.Scan(&message.ID, &message.ChatID, &message.Body, &message.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,283 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sport-assistance/internal/models"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const activityRequestSelect = `
+	SELECT ar.id, ar.user_id, ar.activity_id, a.name, ar.sport_id, ar.sport_object_id, ar.court_id,
+	       ar.starts_at, ar.ends_at, ar.comment, ar.status, ar.reviewed_by, ar.review_comment,
+	       ar.reviewed_at, ar.calendar_id, ar.created_at
+	FROM activity_requests ar
+	JOIN activities a ON a.id = ar.activity_id
+`
+
+func scanActivityRequest(row pgx.Row) (models.ActivityRequest, error) {
+	var request models.ActivityRequest
+	err := row.Scan(
+		&request.ID,
+		&request.UserID,
+		&request.ActivityID,
+		&request.Activity,
+		&request.SportID,
+		&request.SportObjectID,
+		&request.CourtID,
+		&request.StartsAt,
+		&request.EndsAt,
+		&request.Comment,
+		&request.Status,
+		&request.ReviewedBy,
+		&request.ReviewComment,
+		&request.ReviewedAt,
+		&request.CalendarID,
+		&request.CreatedAt,
+	)
+	return request, err
+}
+
+// ListActivities — каталог активностей; при sportID — подходящие для этого вида спорта
+func (r *Repository) ListActivities(ctx context.Context, sportID *int) ([]models.Activity, error) {
+	const query = `
+		SELECT id, service_id, sport_id, name
+		FROM activities
+		WHERE $1::int IS NULL OR sport_id IS NULL OR sport_id = $1
+		ORDER BY name, id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, sportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := make([]models.Activity, 0)
+	for rows.Next() {
+		var a models.Activity
+		if err = rows.Scan(&a.ID, &a.ServiceID, &a.SportID, &a.Name); err != nil {
+			return nil, err
+		}
+		activities = append(activities, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+func (r *Repository) GetActivity(ctx context.Context, activityID int) (models.Activity, error) {
+	const query = `
+		SELECT id, service_id, sport_id, name
+		FROM activities
+		WHERE id = $1
+	`
+
+	var a models.Activity
+	err := r.postgres.QueryRow(ctx, query, activityID).Scan(&a.ID, &a.ServiceID, &a.SportID, &a.Name)
+	return a, err
+}
+
+func (r *Repository) CreateActivityRequest(ctx context.Context, request models.ActivityRequest) (uint64, error) {
+	const query = `
+		INSERT INTO activity_requests (user_id, activity_id, sport_id, sport_object_id, court_id, starts_at, ends_at, comment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+
+	var requestID uint64
+	err := r.postgres.QueryRow(ctx, query,
+		request.UserID,
+		request.ActivityID,
+		request.SportID,
+		request.SportObjectID,
+		request.CourtID,
+		request.StartsAt,
+		request.EndsAt,
+		request.Comment,
+	).Scan(&requestID)
+	if err != nil {
+		return 0, invalidReference(err)
+	}
+
+	return requestID, nil
+}
+
+func (r *Repository) GetActivityRequest(ctx context.Context, requestID uint64) (models.ActivityRequest, error) {
+	query := activityRequestSelect + ` WHERE ar.id = $1`
+
+	return scanActivityRequest(r.postgres.QueryRow(ctx, query, requestID))
+}
+
+// ListActivityRequests — заявки по фильтру. Заявки пользователя идут новыми сначала,
+// очередь ассистента — по времени начала, ближайшие сначала.
+func (r *Repository) ListActivityRequests(ctx context.Context, filter models.ActivityRequestFilter) ([]models.ActivityRequest, error) {
+	conditions := []string{"true"}
+	args := []any{}
+	add := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	order := "ar.starts_at ASC, ar.id ASC"
+	if filter.UserID != nil {
+		add("ar.user_id = $%d", *filter.UserID)
+		order = "ar.created_at DESC, ar.id DESC"
+	}
+	if filter.Status != nil {
+		add("ar.status = $%d", *filter.Status)
+	}
+
+	query := activityRequestSelect + `
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY ` + order
+
+	rows, err := r.postgres.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]models.ActivityRequest, 0)
+	for rows.Next() {
+		request, err := scanActivityRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// ConfirmActivityRequest подтверждает ожидающую заявку и заносит активность в календарь пользователя.
+// pgx.ErrNoRows — заявка уже не ожидает подтверждения.
+func (r *Repository) ConfirmActivityRequest(ctx context.Context, requestID, reviewerID uint64, comment *string) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const confirmQuery = `
+		UPDATE activity_requests
+		SET status = 'confirmed',
+		    reviewed_by = $2,
+		    review_comment = $3,
+		    reviewed_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+		RETURNING user_id, activity_id, sport_id, sport_object_id, court_id, starts_at, ends_at
+	`
+
+	var request models.ActivityRequest
+	err = tx.QueryRow(ctx, confirmQuery, requestID, reviewerID, comment).Scan(
+		&request.UserID,
+		&request.ActivityID,
+		&request.SportID,
+		&request.SportObjectID,
+		&request.CourtID,
+		&request.StartsAt,
+		&request.EndsAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	const calendarQuery = `
+		INSERT INTO activity_calendars (activity_id, start_time, end_time, sport_id, sport_object_id, court_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	var calendarID uint64
+	err = tx.QueryRow(ctx, calendarQuery,
+		request.ActivityID,
+		request.StartsAt,
+		request.EndsAt,
+		request.SportID,
+		request.SportObjectID,
+		request.CourtID,
+	).Scan(&calendarID)
+	if err != nil {
+		return err
+	}
+
+	const userCalendarQuery = `
+		INSERT INTO user_activity_calendars (user_id, calendar_id)
+		VALUES ($1, $2)
+	`
+	if _, err = tx.Exec(ctx, userCalendarQuery, request.UserID, calendarID); err != nil {
+		return err
+	}
+
+	const linkQuery = `UPDATE activity_requests SET calendar_id = $2 WHERE id = $1`
+	if _, err = tx.Exec(ctx, linkQuery, requestID, calendarID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RejectActivityRequest отклоняет ожидающую заявку. pgx.ErrNoRows — заявка уже не ожидает подтверждения.
+func (r *Repository) RejectActivityRequest(ctx context.Context, requestID, reviewerID uint64, comment *string) error {
+	const query = `
+		UPDATE activity_requests
+		SET status = 'rejected',
+		    reviewed_by = $2,
+		    review_comment = $3,
+		    reviewed_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, requestID, reviewerID, comment)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// CancelActivityRequest отменяет ожидающую или подтверждённую заявку; подтверждённая
+// убирается из календаря. pgx.ErrNoRows — заявку уже нельзя отменить.
+func (r *Repository) CancelActivityRequest(ctx context.Context, requestID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const cancelQuery = `
+		UPDATE activity_requests
+		SET status = 'cancelled'
+		WHERE id = $1
+		  AND status IN ('pending', 'confirmed')
+		RETURNING calendar_id
+	`
+
+	var calendarID *uint64
+	if err = tx.QueryRow(ctx, cancelQuery, requestID).Scan(&calendarID); err != nil {
+		return err
+	}
+
+	if calendarID != nil {
+		if _, err = tx.Exec(ctx, `DELETE FROM activity_calendars WHERE id = $1`, *calendarID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
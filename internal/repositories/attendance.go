@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MarkAttendance записывает неявку или позднюю отмену; повторная отметка того же автора заменяет прежнюю.
+// reportedBy == nil — отметка автоматическая. pgx.ErrNoRows — игрока уже отметил кто-то другой.
+func (r *Repository) MarkAttendance(ctx context.Context, matchID, userID uint64, status models.AttendanceStatus, reportedBy *uint64) (models.AttendanceMark, error) {
+	const query = `
+		INSERT INTO match_attendance (match_id, user_id, status, reported_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (match_id, user_id) DO UPDATE
+		SET status = EXCLUDED.status,
+		    created_at = now()
+		WHERE match_attendance.reported_by IS NOT DISTINCT FROM EXCLUDED.reported_by
+		RETURNING match_id, user_id, status, reported_by, created_at
+	`
+
+	mark, err := scanAttendanceMark(r.postgres.QueryRow(ctx, query, matchID, userID, status, reportedBy))
+	if err != nil {
+		return models.AttendanceMark{}, invalidReference(err)
+	}
+
+	return mark, nil
+}
+
+// ReportNoShow записывает жалобу участника на неявку игрока; повторная жалоба того же автора не учитывается.
+// Когда жалоб набирается required, записывается неявка от автора последней жалобы, если игрока ещё не отметили.
+func (r *Repository) ReportNoShow(ctx context.Context, matchID, userID, reporterID uint64, required int) (models.NoShowReport, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return models.NoShowReport{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	const reportQuery = `
+		INSERT INTO match_no_show_reports (match_id, user_id, reported_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err = tx.Exec(ctx, reportQuery, matchID, userID, reporterID); err != nil {
+		return models.NoShowReport{}, invalidReference(err)
+	}
+
+	report := models.NoShowReport{MatchID: matchID, UserID: userID, Required: required}
+
+	const countQuery = `
+		SELECT count(*)
+		FROM match_no_show_reports
+		WHERE match_id = $1
+		  AND user_id = $2
+	`
+
+	if err = tx.QueryRow(ctx, countQuery, matchID, userID).Scan(&report.Reports); err != nil {
+		return models.NoShowReport{}, err
+	}
+
+	if report.Reports >= required {
+		const markQuery = `
+			INSERT INTO match_attendance (match_id, user_id, status, reported_by)
+			VALUES ($1, $2, 'no_show', $3)
+			ON CONFLICT (match_id, user_id) DO NOTHING
+		`
+
+		if _, err = tx.Exec(ctx, markQuery, matchID, userID, reporterID); err != nil {
+			return models.NoShowReport{}, err
+		}
+	}
+
+	const selectMarkQuery = `
+		SELECT match_id, user_id, status, reported_by, created_at
+		FROM match_attendance
+		WHERE match_id = $1
+		  AND user_id = $2
+	`
+
+	mark, err := scanAttendanceMark(tx.QueryRow(ctx, selectMarkQuery, matchID, userID))
+	switch {
+	case err == nil:
+		report.Mark = &mark
+	case !errors.Is(err, pgx.ErrNoRows):
+		return models.NoShowReport{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.NoShowReport{}, err
+	}
+
+	return report, nil
+}
+
+// ClearAttendance снимает отметку игрока в матче вместе с жалобами на его неявку; pgx.ErrNoRows — отметки не было
+func (r *Repository) ClearAttendance(ctx context.Context, matchID, userID uint64) error {
+	const query = `
+		WITH reports AS (
+			DELETE FROM match_no_show_reports
+			WHERE match_id = $1
+			  AND user_id = $2
+		)
+		DELETE FROM match_attendance
+		WHERE match_id = $1
+		  AND user_id = $2
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, matchID, userID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *Repository) ListMatchAttendance(ctx context.Context, matchID uint64) ([]models.AttendanceMark, error) {
+	const query = `
+		SELECT match_id, user_id, status, reported_by, created_at
+		FROM match_attendance
+		WHERE match_id = $1
+		ORDER BY created_at ASC, user_id ASC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	marks := make([]models.AttendanceMark, 0)
+	for rows.Next() {
+		mark, err := scanAttendanceMark(rows)
+		if err != nil {
+			return nil, err
+		}
+		marks = append(marks, mark)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return marks, nil
+}
+
+// GetReliabilityStats считает обязательства и срывы игроков без оценки. Обязательство — начавшийся
+// неотменённый матч, где игрок остался участником, или поздний выход из такого матча.
+// Отметки в отменённых матчах не учитываются.
+func (r *Repository) GetReliabilityStats(ctx context.Context, userIDs []uint64) (map[uint64]models.Reliability, error) {
+	const query = `
+		SELECT ids.user_id,
+		       (SELECT count(*)
+		        FROM user_matches um
+		        JOIN matches m ON m.id = um.match_id
+		        WHERE um.user_id = ids.user_id
+		          AND m.status <> 'cancelled'
+		          AND m.starts_at < now()),
+		       count(a.match_id) FILTER (WHERE a.status = 'no_show'),
+		       count(a.match_id) FILTER (WHERE a.status = 'late_cancellation'),
+		       count(a.match_id) FILTER (
+		           WHERE a.status = 'late_cancellation'
+		             AND NOT EXISTS (
+		                 SELECT 1
+		                 FROM user_matches um
+		                 WHERE um.match_id = a.match_id
+		                   AND um.user_id = a.user_id
+		             )
+		       )
+		FROM unnest($1::bigint[]) AS ids(user_id)
+		LEFT JOIN match_attendance a ON a.user_id = ids.user_id
+		    AND EXISTS (SELECT 1 FROM matches m WHERE m.id = a.match_id AND m.status <> 'cancelled')
+		GROUP BY ids.user_id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make(map[uint64]models.Reliability, len(userIDs))
+	for rows.Next() {
+		var (
+			rel  models.Reliability
+			left int
+		)
+		if err = rows.Scan(&rel.UserID, &rel.Commitments, &rel.NoShows, &rel.LateCancellations, &left); err != nil {
+			return nil, err
+		}
+		rel.Commitments += left
+		stats[rel.UserID] = rel
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func scanAttendanceMark(row pgx.Row) (models.AttendanceMark, error) {
+	var mark models.AttendanceMark
+	err := row.Scan(
+		&mark.MatchID,
+		&mark.UserID,
+		&mark.Status,
+		&mark.ReportedBy,
+		&mark.CreatedAt,
+	)
+	return mark, err
+}
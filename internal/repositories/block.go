@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BlockUser добавляет пользователя в чёрный список и разрывает связи пары:
+// дружбу, ожидающие заявки в друзья и приглашения в матчи друг от друга
+func (r *Repository) BlockUser(ctx context.Context, blockerID, blockedID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const blockQuery = `
+		INSERT INTO user_blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err = tx.Exec(ctx, blockQuery, blockerID, blockedID); err != nil {
+		return invalidReference(err)
+	}
+
+	const friendsQuery = `
+		DELETE FROM friends
+		WHERE user_id = LEAST($1::int, $2::int)
+		  AND friend_id = GREATEST($1::int, $2::int)
+	`
+	if _, err = tx.Exec(ctx, friendsQuery, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	const requestsQuery = `
+		UPDATE friend_requests
+		SET status = CASE WHEN sender_id = $1 THEN 'cancelled' ELSE 'declined' END,
+		    responded_at = now()
+		WHERE status = 'pending'
+		  AND LEAST(sender_id, receiver_id) = LEAST($1::int, $2::int)
+		  AND GREATEST(sender_id, receiver_id) = GREATEST($1::int, $2::int)
+	`
+	if _, err = tx.Exec(ctx, requestsQuery, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	const invitationsQuery = `
+		UPDATE match_invitations
+		SET status = CASE WHEN inviter_id = $1 THEN 'revoked' ELSE 'declined' END,
+		    responded_at = now()
+		WHERE status = 'pending'
+		  AND ((inviter_id = $1 AND user_id = $2) OR (inviter_id = $2 AND user_id = $1))
+	`
+	if _, err = tx.Exec(ctx, invitationsQuery, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UnblockUser убирает пользователя из чёрного списка. pgx.ErrNoRows — он не был заблокирован.
+func (r *Repository) UnblockUser(ctx context.Context, blockerID, blockedID uint64) error {
+	const query = `
+		DELETE FROM user_blocks
+		WHERE blocker_id = $1
+		  AND blocked_id = $2
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListBlockedUsers — чёрный список пользователя, последние заблокированные сначала
+func (r *Repository) ListBlockedUsers(ctx context.Context, blockerID uint64) ([]models.BlockedUser, error) {
+	const query = `
+		SELECT u.id, u.name, u.surname, u.photo_thumbnail, b.created_at
+		FROM user_blocks b
+		JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC, u.id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocked := make([]models.BlockedUser, 0)
+	for rows.Next() {
+		var b models.BlockedUser
+		if err = rows.Scan(&b.UserID, &b.Name, &b.Surname, &b.PhotoURL, &b.BlockedAt); err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return blocked, nil
+}
+
+// IsBlockedBetween — заблокировал ли кто-то из пары другого
+func (r *Repository) IsBlockedBetween(ctx context.Context, userID, otherID uint64) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_blocks
+			WHERE (blocker_id = $1 AND blocked_id = $2)
+			   OR (blocker_id = $2 AND blocked_id = $1)
+		)
+	`
+
+	var blocked bool
+	err := r.postgres.QueryRow(ctx, query, userID, otherID).Scan(&blocked)
+	return blocked, err
+}
+
+// BlockedAmong — кто из userIDs состоит с пользователем в блокировке в любую сторону
+func (r *Repository) BlockedAmong(ctx context.Context, userID uint64, userIDs []uint64) ([]uint64, error) {
+	const query = `
+		SELECT DISTINCT CASE WHEN blocker_id = $1 THEN blocked_id ELSE blocker_id END
+		FROM user_blocks
+		WHERE (blocker_id = $1 AND blocked_id = ANY($2::bigint[]))
+		   OR (blocked_id = $1 AND blocker_id = ANY($2::bigint[]))
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocked := make([]uint64, 0)
+	for rows.Next() {
+		var id uint64
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return blocked, nil
+}
+
+// HasBlockedParticipant — есть ли среди участников матча тот, кто состоит с пользователем в блокировке
+func (r *Repository) HasBlockedParticipant(ctx context.Context, matchID, userID uint64) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_matches um
+			JOIN user_blocks b
+			  ON (b.blocker_id = $2 AND b.blocked_id = um.user_id)
+			  OR (b.blocked_id = $2 AND b.blocker_id = um.user_id)
+			WHERE um.match_id = $1
+		)
+	`
+
+	var blocked bool
+	err := r.postgres.QueryRow(ctx, query, matchID, userID).Scan(&blocked)
+	return blocked, err
+}
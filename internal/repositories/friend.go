@@ -0,0 +1,352 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// viewerFriendsCTE — друзья смотрящего ($1); по ним считаются общие друзья
+const viewerFriendsCTE = `
+	WITH viewer_friends AS (
+		SELECT friend_id AS id FROM friends WHERE user_id = $1
+		UNION ALL
+		SELECT user_id FROM friends WHERE friend_id = $1
+	)
+`
+
+const friendRequestSelect = `
+	SELECT id, sender_id, receiver_id, status, created_at, responded_at
+	FROM friend_requests
+`
+
+func scanFriendRequest(row pgx.Row) (models.FriendRequest, error) {
+	var request models.FriendRequest
+	err := row.Scan(
+		&request.ID,
+		&request.SenderID,
+		&request.ReceiverID,
+		&request.Status,
+		&request.CreatedAt,
+		&request.RespondedAt,
+	)
+	return request, err
+}
+
+// IsGuest — пользователь без подписки: роль guest или роль не назначена
+func (r *Repository) IsGuest(ctx context.Context, userID uint64) (bool, error) {
+	const query = `
+		SELECT u.role_id IS NULL OR r.name = 'guest'
+		FROM users u
+		LEFT JOIN roles r ON r.id = u.role_id
+		WHERE u.id = $1
+	`
+
+	var guest bool
+	err := r.postgres.QueryRow(ctx, query, userID).Scan(&guest)
+	return guest, err
+}
+
+// HavePlayedTogether — были ли пользователи участниками одного завершённого матча
+func (r *Repository) HavePlayedTogether(ctx context.Context, userID, otherID uint64) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_matches own
+			JOIN user_matches other ON other.match_id = own.match_id AND other.user_id = $2
+			JOIN matches m ON m.id = own.match_id
+			WHERE own.user_id = $1
+			  AND m.status = 'completed'
+		)
+	`
+
+	var played bool
+	err := r.postgres.QueryRow(ctx, query, userID, otherID).Scan(&played)
+	return played, err
+}
+
+func (r *Repository) AreFriends(ctx context.Context, userID, otherID uint64) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM friends
+			WHERE user_id = LEAST($1::int, $2::int)
+			  AND friend_id = GREATEST($1::int, $2::int)
+		)
+	`
+
+	var friends bool
+	err := r.postgres.QueryRow(ctx, query, userID, otherID).Scan(&friends)
+	return friends, err
+}
+
+// CountMutualFriends — общие друзья двух пользователей
+func (r *Repository) CountMutualFriends(ctx context.Context, userID, otherID uint64) (int, error) {
+	query := viewerFriendsCTE + `
+		SELECT count(*)
+		FROM viewer_friends vf
+		JOIN friends f ON f.user_id = LEAST(vf.id, $2::int) AND f.friend_id = GREATEST(vf.id, $2::int)
+	`
+
+	var mutual int
+	err := r.postgres.QueryRow(ctx, query, userID, otherID).Scan(&mutual)
+	return mutual, err
+}
+
+// CreateFriendRequest — ожидающая заявка; если между пользователями уже есть ожидающая
+// заявка в любую сторону — myerrors.ErrFriendRequestExists
+func (r *Repository) CreateFriendRequest(ctx context.Context, senderID, receiverID uint64) (uint64, error) {
+	const query = `
+		INSERT INTO friend_requests (sender_id, receiver_id)
+		VALUES ($1, $2)
+		RETURNING id
+	`
+
+	var requestID uint64
+	if err := r.postgres.QueryRow(ctx, query, senderID, receiverID).Scan(&requestID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return 0, myerrors.ErrFriendRequestExists
+		}
+		return 0, invalidReference(err)
+	}
+
+	return requestID, nil
+}
+
+func (r *Repository) GetFriendRequest(ctx context.Context, requestID uint64) (models.FriendRequest, error) {
+	query := friendRequestSelect + ` WHERE id = $1`
+
+	return scanFriendRequest(r.postgres.QueryRow(ctx, query, requestID))
+}
+
+// GetPendingFriendRequest — ожидающая заявка между пользователями в любую сторону. pgx.ErrNoRows — её нет.
+func (r *Repository) GetPendingFriendRequest(ctx context.Context, userID, otherID uint64) (models.FriendRequest, error) {
+	query := friendRequestSelect + `
+		WHERE status = 'pending'
+		  AND LEAST(sender_id, receiver_id) = LEAST($1::int, $2::int)
+		  AND GREATEST(sender_id, receiver_id) = GREATEST($1::int, $2::int)
+	`
+
+	return scanFriendRequest(r.postgres.QueryRow(ctx, query, userID, otherID))
+}
+
+// ListFriendRequests — ожидающие заявки пользователя, новые сначала: входящие или исходящие
+func (r *Repository) ListFriendRequests(ctx context.Context, userID uint64, incoming bool) ([]models.FriendRequest, error) {
+	query := viewerFriendsCTE + `
+		SELECT fr.id, fr.sender_id, fr.receiver_id, fr.status, fr.created_at, fr.responded_at,
+		       u.id, u.name, u.surname, u.photo_thumbnail,
+		       (SELECT count(*)
+		        FROM viewer_friends vf
+		        JOIN friends f ON f.user_id = LEAST(vf.id, u.id) AND f.friend_id = GREATEST(vf.id, u.id))
+		FROM friend_requests fr
+		JOIN users u ON u.id = CASE WHEN $2 THEN fr.sender_id ELSE fr.receiver_id END
+		WHERE fr.status = 'pending'
+		  AND CASE WHEN $2 THEN fr.receiver_id ELSE fr.sender_id END = $1
+		  AND u.deleted_at IS NULL
+		ORDER BY fr.created_at DESC, fr.id DESC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, incoming)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make([]models.FriendRequest, 0)
+	for rows.Next() {
+		var (
+			request models.FriendRequest
+			user    models.FriendUser
+		)
+		err = rows.Scan(
+			&request.ID,
+			&request.SenderID,
+			&request.ReceiverID,
+			&request.Status,
+			&request.CreatedAt,
+			&request.RespondedAt,
+			&user.UserID,
+			&user.Name,
+			&user.Surname,
+			&user.PhotoURL,
+			&user.MutualFriends,
+		)
+		if err != nil {
+			return nil, err
+		}
+		request.User = &user
+		requests = append(requests, request)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// AcceptFriendRequest принимает ожидающую заявку и добавляет пару в друзья.
+// pgx.ErrNoRows — заявка не ожидает ответа.
+func (r *Repository) AcceptFriendRequest(ctx context.Context, requestID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const acceptQuery = `
+		UPDATE friend_requests
+		SET status = 'accepted',
+		    responded_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+		RETURNING sender_id, receiver_id
+	`
+
+	var senderID, receiverID uint64
+	if err = tx.QueryRow(ctx, acceptQuery, requestID).Scan(&senderID, &receiverID); err != nil {
+		return err
+	}
+
+	const friendsQuery = `
+		INSERT INTO friends (user_id, friend_id)
+		VALUES (LEAST($1::int, $2::int), GREATEST($1::int, $2::int))
+		ON CONFLICT DO NOTHING
+	`
+	if _, err = tx.Exec(ctx, friendsQuery, senderID, receiverID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CloseFriendRequest отклоняет или отзывает ожидающую заявку. pgx.ErrNoRows — заявка не ожидает ответа.
+func (r *Repository) CloseFriendRequest(ctx context.Context, requestID uint64, status models.FriendRequestStatus) error {
+	const query = `
+		UPDATE friend_requests
+		SET status = $2,
+		    responded_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, requestID, status)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// RemoveFriend удаляет пару из друзей. pgx.ErrNoRows — они не друзья.
+func (r *Repository) RemoveFriend(ctx context.Context, userID, friendID uint64) error {
+	const query = `
+		DELETE FROM friends
+		WHERE user_id = LEAST($1::int, $2::int)
+		  AND friend_id = GREATEST($1::int, $2::int)
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, userID, friendID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListFriends — страница друзей пользователя по имени с общими друзьями относительно viewerID
+// и рейтингами по видам спорта. Город и рейтинги скрыты по настройкам видимости друга.
+func (r *Repository) ListFriends(ctx context.Context, userID, viewerID uint64, limit, offset int) ([]models.Friend, error) {
+	query := viewerFriendsCTE + `,
+		list AS (
+			SELECT CASE WHEN user_id = $2 THEN friend_id ELSE user_id END AS id, created_at
+			FROM friends
+			WHERE user_id = $2 OR friend_id = $2
+		)
+		SELECT u.id, u.name, u.surname, u.photo_thumbnail,
+		       CASE WHEN u.id = $1 OR COALESCE(v.show_town, true) THEN u.town_id END,
+		       l.created_at,
+		       (SELECT count(*)
+		        FROM viewer_friends vf
+		        JOIN friends f ON f.user_id = LEAST(vf.id, u.id) AND f.friend_id = GREATEST(vf.id, u.id))
+		FROM list l
+		JOIN users u ON u.id = l.id
+		LEFT JOIN user_profile_visibility v ON v.user_id = u.id
+		WHERE u.deleted_at IS NULL
+		  AND u.blocked_at IS NULL
+		ORDER BY u.name, u.surname, u.id
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.postgres.Query(ctx, query, viewerID, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	friends := make([]models.Friend, 0)
+	index := make(map[uint64]int)
+	for rows.Next() {
+		var f models.Friend
+		err = rows.Scan(&f.UserID, &f.Name, &f.Surname, &f.PhotoURL, &f.TownID, &f.FriendsSince, &f.MutualFriends)
+		if err != nil {
+			return nil, err
+		}
+		f.Ratings = make([]models.PlayerRating, 0)
+		index[f.UserID] = len(friends)
+		friends = append(friends, f)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(friends) == 0 {
+		return friends, nil
+	}
+
+	ids := make([]uint64, 0, len(friends))
+	for _, f := range friends {
+		ids = append(ids, f.UserID)
+	}
+
+	const ratingsQuery = `
+		SELECT pr.user_id, pr.sport_id, s.name, pr.rating, pr.matches_played, pr.updated_at
+		FROM player_ratings pr
+		JOIN sports s ON s.id = pr.sport_id
+		LEFT JOIN user_profile_visibility v ON v.user_id = pr.user_id
+		WHERE pr.user_id = ANY($1::bigint[])
+		  AND (pr.user_id = $2 OR COALESCE(v.show_rating, true))
+		ORDER BY pr.user_id, s.name
+	`
+
+	ratingRows, err := r.postgres.Query(ctx, ratingsQuery, ids, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer ratingRows.Close()
+
+	for ratingRows.Next() {
+		var pr models.PlayerRating
+		if err = ratingRows.Scan(&pr.UserID, &pr.SportID, &pr.Sport, &pr.Rating, &pr.MatchesPlayed, &pr.UpdatedAt); err != nil {
+			return nil, err
+		}
+		f := &friends[index[pr.UserID]]
+		f.Ratings = append(f.Ratings, pr)
+	}
+
+	if err = ratingRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return friends, nil
+}
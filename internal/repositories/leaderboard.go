@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// leaderboardFriends — друзья зрителя; таблица друзей хранит пару один раз
+const leaderboardFriends = `
+	SELECT friend_id FROM friends WHERE user_id = $2
+	UNION
+	SELECT user_id FROM friends WHERE friend_id = $2
+`
+
+// leaderboardSource — выборка таблицы рейтинга из leaderboard_ranks: откуда брать строки
+// и в каких колонках лежат места. Параметры: $1 — вид спорта, $2 — зритель, $3 — город.
+type leaderboardSource struct {
+	from     string
+	where    string
+	rank     string
+	position string
+	args     []any
+}
+
+func newLeaderboardSource(filter models.LeaderboardFilter) leaderboardSource {
+	args := []any{filter.SportID, filter.ViewerID}
+	where := "lr.sport_id = $1"
+	if filter.TownID != nil {
+		args = append(args, *filter.TownID)
+		where += " AND lr.town_id = $3"
+	}
+
+	// среди друзей места пересчитываются по небольшой выборке
+	if filter.Scope == models.LeaderboardScopeFriends {
+		return leaderboardSource{
+			from: `(
+				SELECT lr.sport_id, lr.user_id, lr.town_id, lr.rating, lr.matches_played,
+				       RANK() OVER (ORDER BY lr.rating DESC) AS rank,
+				       ROW_NUMBER() OVER (ORDER BY lr.rating DESC, lr.user_id) AS position
+				FROM leaderboard_ranks lr
+				WHERE ` + where + `
+				  AND (lr.user_id = $2 OR lr.user_id IN (` + leaderboardFriends + `))
+			) lr`,
+			where:    "true",
+			rank:     "lr.rank",
+			position: "lr.position",
+			args:     args,
+		}
+	}
+
+	source := leaderboardSource{
+		from:     "leaderboard_ranks lr",
+		where:    where,
+		rank:     "lr.rank",
+		position: "lr.position",
+		args:     args,
+	}
+	if filter.TownID != nil {
+		source.rank, source.position = "lr.town_rank", "lr.town_position"
+	}
+
+	return source
+}
+
+// argsWith — параметры выборки и дополнительные параметры запроса после них
+func (s leaderboardSource) argsWith(extra ...any) []any {
+	return append(append(make([]any, 0, len(s.args)+len(extra)), s.args...), extra...)
+}
+
+// RefreshLeaderboard пересчитывает места в leaderboard_ranks, не блокируя чтение таблиц рейтинга
+func (r *Repository) RefreshLeaderboard(ctx context.Context) error {
+	_, err := r.postgres.Exec(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY leaderboard_ranks`)
+	return err
+}
+
+// GetLeaderboard отдаёт страницу таблицы рейтинга (или окрестность зрителя) и строку самого
+// зрителя, даже если она не попала в выборку. Места берутся из leaderboard_ranks.
+func (r *Repository) GetLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (models.Leaderboard, error) {
+	source := newLeaderboardSource(filter)
+
+	offset, limit := filter.Offset, filter.Limit
+	if filter.Around != nil {
+		// без своей строки в таблице центр — место, которое занял бы рейтинг Around
+		centerQuery := fmt.Sprintf(`
+			SELECT COALESCE(
+				(SELECT %[1]s FROM %[2]s WHERE %[3]s AND lr.user_id = $2),
+				(SELECT count(*) + 1 FROM %[2]s WHERE %[3]s AND lr.rating > $%[4]d)
+			)
+		`, source.position, source.from, source.where, len(source.args)+1)
+
+		var center int
+		if err := r.postgres.QueryRow(ctx, centerQuery, source.argsWith(*filter.Around)...).Scan(&center); err != nil {
+			return models.Leaderboard{}, err
+		}
+
+		offset = max(center-filter.Radius-1, 0)
+		limit = center + filter.Radius - offset
+	}
+
+	board, err := r.leaderboardPage(ctx, source, filter.ViewerID, offset, limit)
+	if err != nil {
+		return models.Leaderboard{}, err
+	}
+
+	if board.Me == nil {
+		if board.Me, err = r.leaderboardViewer(ctx, source, filter); err != nil {
+			return models.Leaderboard{}, err
+		}
+	}
+
+	return board, nil
+}
+
+func (r *Repository) leaderboardPage(ctx context.Context, source leaderboardSource, viewerID uint64, offset, limit int) (models.Leaderboard, error) {
+	n := len(source.args)
+	query := fmt.Sprintf(`
+		SELECT %[1]s, lr.user_id, u.name, u.surname, u.photo_thumbnail, lr.town_id,
+		       lr.rating, lr.matches_played,
+		       (SELECT COALESCE(max(%[2]s), 0) FROM %[3]s WHERE %[4]s) AS total,
+		       %[2]s > $%[5]d AND %[2]s <= $%[5]d + $%[6]d AS listed
+		FROM %[3]s
+		JOIN users u ON u.id = lr.user_id
+		WHERE %[4]s
+		  AND ((%[2]s > $%[5]d AND %[2]s <= $%[5]d + $%[6]d) OR lr.user_id = $2)
+		ORDER BY %[2]s
+	`, source.rank, source.position, source.from, source.where, n+1, n+2)
+
+	rows, err := r.postgres.Query(ctx, query, source.argsWith(offset, limit)...)
+	if err != nil {
+		return models.Leaderboard{}, err
+	}
+	defer rows.Close()
+
+	board := models.Leaderboard{Entries: make([]models.LeaderboardEntry, 0)}
+	for rows.Next() {
+		var (
+			entry  models.LeaderboardEntry
+			total  int
+			listed bool
+		)
+		err = rows.Scan(
+			&entry.Rank,
+			&entry.UserID,
+			&entry.Name,
+			&entry.Surname,
+			&entry.PhotoURL,
+			&entry.TownID,
+			&entry.Rating,
+			&entry.MatchesPlayed,
+			&total,
+			&listed,
+		)
+		if err != nil {
+			return models.Leaderboard{}, err
+		}
+
+		board.Total = total
+		if entry.UserID == viewerID {
+			me := entry
+			board.Me = &me
+		}
+		if listed {
+			board.Entries = append(board.Entries, entry)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return models.Leaderboard{}, err
+	}
+
+	return board, nil
+}
+
+// leaderboardViewer — строка зрителя, которого нет в leaderboard_ranks (скрыл рейтинг или город):
+// он видит место, которое занял бы, а в таблице для других не появляется
+func (r *Repository) leaderboardViewer(ctx context.Context, source leaderboardSource, filter models.LeaderboardFilter) (*models.LeaderboardEntry, error) {
+	townFilter := ""
+	if filter.TownID != nil {
+		townFilter = "AND u.town_id = $3"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT (SELECT count(*) + 1 FROM %[1]s WHERE %[2]s AND lr.rating > pr.rating),
+		       pr.user_id, u.name, u.surname, u.photo_thumbnail, u.town_id,
+		       pr.rating, pr.matches_played
+		FROM player_ratings pr
+		JOIN users u ON u.id = pr.user_id
+		WHERE pr.sport_id = $1
+		  AND pr.user_id = $2
+		  %[3]s
+	`, source.from, source.where, townFilter)
+
+	var entry models.LeaderboardEntry
+	err := r.postgres.QueryRow(ctx, query, source.args...).Scan(
+		&entry.Rank,
+		&entry.UserID,
+		&entry.Name,
+		&entry.Surname,
+		&entry.PhotoURL,
+		&entry.TownID,
+		&entry.Rating,
+		&entry.MatchesPlayed,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
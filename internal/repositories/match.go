@@ -2,12 +2,19 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
-func (r *Repository) CreateMatch(ctx context.Context, matchTypeID int, participantIDs []uint64) (uint64, error) {
+// CreateMatch создаёт матч: организатор сразу становится участником, остальные получают
+// приглашения до invitationExpiresAt. Несуществующий справочник, объект, корт или пользователь
+// возвращается как myerrors.ErrInvalidReference.
+func (r *Repository) CreateMatch(ctx context.Context, match models.Match, inviteeIDs []uint64, invitationExpiresAt time.Time) (uint64, error) {
 	tx, err := r.postgres.Begin(ctx)
 	if err != nil {
 		return 0, err
@@ -15,27 +22,57 @@ func (r *Repository) CreateMatch(ctx context.Context, matchTypeID int, participa
 	defer tx.Rollback(ctx)
 
 	const insertMatchQuery = `
-		INSERT INTO matches (match_type_id)
-		VALUES ($1)
+		INSERT INTO matches (
+			match_type_id, organizer_id, starts_at, required_players, visibility,
+			sport_id, town_id, venue, min_level_id, max_level_id, capacity,
+			ends_at, sport_object_id, court_id, min_reliability
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id
 	`
 
 	var matchID uint64
-	if err = tx.QueryRow(ctx, insertMatchQuery, matchTypeID).Scan(&matchID); err != nil {
-		return 0, err
+	err = tx.QueryRow(ctx, insertMatchQuery,
+		match.MatchTypeID,
+		match.OrganizerID,
+		match.StartsAt,
+		match.RequiredPlayers,
+		match.Visibility,
+		match.SportID,
+		match.TownID,
+		match.Venue,
+		match.MinLevelID,
+		match.MaxLevelID,
+		match.Capacity,
+		match.EndsAt,
+		match.SportObjectID,
+		match.CourtID,
+		match.MinReliability,
+	).Scan(&matchID)
+	if err != nil {
+		return 0, invalidReference(err)
 	}
 
-	if len(participantIDs) > 0 {
+	if match.OrganizerID != nil {
 		const insertParticipantQuery = `
 			INSERT INTO user_matches (user_id, match_id)
 			VALUES ($1, $2)
-			ON CONFLICT (user_id, match_id) DO NOTHING
 		`
 
-		for _, participantID := range participantIDs {
-			if _, err = tx.Exec(ctx, insertParticipantQuery, participantID, matchID); err != nil {
-				return 0, err
-			}
+		if _, err = tx.Exec(ctx, insertParticipantQuery, *match.OrganizerID, matchID); err != nil {
+			return 0, invalidReference(err)
+		}
+	}
+
+	if len(inviteeIDs) > 0 {
+		const insertInvitationsQuery = `
+			INSERT INTO match_invitations (match_id, user_id, inviter_id, expires_at)
+			SELECT $1, invitee_id, $2, $3
+			FROM unnest($4::bigint[]) AS invitee_id
+		`
+
+		if _, err = tx.Exec(ctx, insertInvitationsQuery, matchID, match.OrganizerID, invitationExpiresAt, inviteeIDs); err != nil {
+			return 0, invalidReference(err)
 		}
 	}
 
@@ -46,25 +83,108 @@ func (r *Repository) CreateMatch(ctx context.Context, matchTypeID int, participa
 	return matchID, nil
 }
 
-func (r *Repository) GetMatchByID(ctx context.Context, matchID uint64) (models.Match, error) {
+// invalidReference подменяет нарушение внешнего ключа на myerrors.ErrInvalidReference
+func invalidReference(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return myerrors.ErrInvalidReference
+	}
+	return err
+}
+
+// syncMatchStatus переводит открытый матч в active, когда участников набралось required_players,
+// и обратно в scheduled, если кто-то вышел
+func syncMatchStatus(ctx context.Context, tx pgx.Tx, matchID uint64) error {
 	const query = `
-		SELECT id, match_type_id, created_at
-		FROM matches
-		WHERE id = $1
+		UPDATE matches m
+		SET status = CASE
+		        WHEN (SELECT count(*) FROM user_matches um WHERE um.match_id = m.id) >= m.required_players
+		            THEN 'active'
+		        ELSE 'scheduled'
+		    END,
+		    updated_at = now()
+		WHERE m.id = $1
+		  AND m.status IN ('scheduled', 'active')
 	`
 
+	_, err := tx.Exec(ctx, query, matchID)
+	return err
+}
+
+// matchSelect — общая выборка матча с названием типа; используется вместе с scanMatch
+const matchSelect = `
+	SELECT m.id, m.match_type_id, COALESCE(mt.name, ''), m.organizer_id, m.status,
+	       m.visibility, m.sport_id, m.town_id, m.venue, m.min_level_id, m.max_level_id,
+	       m.required_players, m.capacity, m.min_reliability,
+	       (SELECT count(*) FROM user_matches pc WHERE pc.match_id = m.id),
+	       m.starts_at, m.ends_at, m.sport_object_id, m.court_id, m.cancelled_at, m.created_at
+	FROM matches m
+	LEFT JOIN match_types mt ON mt.id = m.match_type_id
+`
+
+func scanMatch(row pgx.Row) (models.Match, error) {
 	var match models.Match
-	if err := r.postgres.QueryRow(ctx, query, matchID).Scan(&match.ID, &match.MatchTypeID, &match.CreatedAt); err != nil {
-		return models.Match{}, err
+	err := row.Scan(
+		&match.ID,
+		&match.MatchTypeID,
+		&match.MatchType,
+		&match.OrganizerID,
+		&match.Status,
+		&match.Visibility,
+		&match.SportID,
+		&match.TownID,
+		&match.Venue,
+		&match.MinLevelID,
+		&match.MaxLevelID,
+		&match.RequiredPlayers,
+		&match.Capacity,
+		&match.MinReliability,
+		&match.ParticipantsCount,
+		&match.StartsAt,
+		&match.EndsAt,
+		&match.SportObjectID,
+		&match.CourtID,
+		&match.CancelledAt,
+		&match.CreatedAt,
+	)
+	if err == nil && match.Capacity != nil {
+		free := max(*match.Capacity-match.ParticipantsCount, 0)
+		match.FreeSpots = &free
+	}
+	if err == nil && match.StartsAt != nil && match.EndsAt != nil {
+		duration := int(match.EndsAt.Sub(*match.StartsAt).Minutes())
+		match.DurationMinutes = &duration
 	}
+	return match, err
+}
+
+func collectMatches(rows pgx.Rows) ([]models.Match, error) {
+	defer rows.Close()
 
-	return match, nil
+	matches := make([]models.Match, 0)
+	for rows.Next() {
+		match, err := scanMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func (r *Repository) GetMatchByID(ctx context.Context, matchID uint64) (models.Match, error) {
+	query := matchSelect + ` WHERE m.id = $1`
+
+	return scanMatch(r.postgres.QueryRow(ctx, query, matchID))
 }
 
 func (r *Repository) GetMatchesByUserID(ctx context.Context, userID uint64) ([]models.Match, error) {
-	const query = `
-		SELECT m.id, m.match_type_id, m.created_at
-		FROM matches m
+	query := matchSelect + `
 		JOIN user_matches um ON um.match_id = m.id
 		WHERE um.user_id = $1
 		ORDER BY m.created_at DESC, m.id DESC
@@ -74,22 +194,93 @@ func (r *Repository) GetMatchesByUserID(ctx context.Context, userID uint64) ([]m
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	matches := make([]models.Match, 0)
-	for rows.Next() {
-		var match models.Match
-		if err := rows.Scan(&match.ID, &match.MatchTypeID, &match.CreatedAt); err != nil {
-			return nil, err
-		}
-		matches = append(matches, match)
+	return collectMatches(rows)
+}
+
+// ListUserMatches возвращает предстоящие (ближайшие сначала) или прошедшие (последние сначала) матчи.
+// Отменённые и завершённые матчи всегда считаются прошедшими.
+func (r *Repository) ListUserMatches(ctx context.Context, userID uint64, filter models.MatchListFilter) ([]models.Match, error) {
+	condition := `m.status IN ('scheduled', 'active') AND m.starts_at >= $2`
+	order := `ORDER BY m.starts_at ASC, m.id ASC`
+	if !filter.Upcoming {
+		condition = `(m.status NOT IN ('scheduled', 'active') OR m.starts_at < $2 OR m.starts_at IS NULL)`
+		order = `ORDER BY m.starts_at DESC NULLS LAST, m.id DESC`
 	}
 
-	if err := rows.Err(); err != nil {
+	query := matchSelect + `
+		JOIN user_matches um ON um.match_id = m.id
+		WHERE um.user_id = $1
+		  AND ` + condition + `
+		  AND ($5::bigint IS NULL OR EXISTS (
+			SELECT 1
+			FROM match_results r
+			JOIN match_result_players own ON own.result_id = r.id AND own.user_id = $1
+			JOIN match_result_players other ON other.result_id = r.id AND other.user_id = $5
+			WHERE r.match_id = m.id
+			  AND r.status = 'confirmed'
+			  AND other.team = own.team
+		  ))
+		  AND ($6::bigint IS NULL OR EXISTS (
+			SELECT 1
+			FROM match_results r
+			JOIN match_result_players own ON own.result_id = r.id AND own.user_id = $1
+			JOIN match_result_players other ON other.result_id = r.id AND other.user_id = $6
+			WHERE r.match_id = m.id
+			  AND r.status = 'confirmed'
+			  AND other.team <> own.team
+		  ))
+		` + order + `
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, filter.Now, filter.Limit, filter.Offset, filter.PartnerID, filter.OpponentID)
+	if err != nil {
 		return nil, err
 	}
 
-	return matches, nil
+	return collectMatches(rows)
+}
+
+// CancelMatch отменяет открытый матч и отзывает ожидающие приглашения.
+// pgx.ErrNoRows — матча нет или он уже отменён/завершён.
+func (r *Repository) CancelMatch(ctx context.Context, matchID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const cancelQuery = `
+		UPDATE matches
+		SET status = 'cancelled',
+		    cancelled_at = now(),
+		    updated_at = now()
+		WHERE id = $1
+		  AND status IN ('scheduled', 'active')
+	`
+
+	ct, err := tx.Exec(ctx, cancelQuery, matchID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	const revokeQuery = `
+		UPDATE match_invitations
+		SET status = 'revoked',
+		    responded_at = now()
+		WHERE match_id = $1
+		  AND status = 'pending'
+	`
+
+	if _, err = tx.Exec(ctx, revokeQuery, matchID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *Repository) GetMatchParticipants(ctx context.Context, matchID uint64) ([]uint64, error) {
@@ -133,14 +324,21 @@ func (r *Repository) AddUserToMatch(ctx context.Context, matchID, userID uint64)
 	return err
 }
 
+// RemoveUserFromMatch убирает участника и пересчитывает статус матча
 func (r *Repository) RemoveUserFromMatch(ctx context.Context, matchID, userID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	const query = `
 		DELETE FROM user_matches
 		WHERE user_id = $1
 		  AND match_id = $2
 	`
 
-	ct, err := r.postgres.Exec(ctx, query, userID, matchID)
+	ct, err := tx.Exec(ctx, query, userID, matchID)
 	if err != nil {
 		return err
 	}
@@ -148,7 +346,11 @@ func (r *Repository) RemoveUserFromMatch(ctx context.Context, matchID, userID ui
 		return pgx.ErrNoRows
 	}
 
-	return nil
+	if err = syncMatchStatus(ctx, tx, matchID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *Repository) IsUserInMatch(ctx context.Context, matchID, userID uint64) (bool, error) {
@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// invitationSelect — выборка приглашений; просроченное pending читается как expired
+const invitationSelect = `
+	SELECT i.id, i.match_id, i.user_id, i.inviter_id, i.link_id,
+	       CASE WHEN i.status = 'pending' AND i.expires_at < now() THEN 'expired' ELSE i.status END,
+	       i.expires_at, i.responded_at, i.created_at
+	FROM match_invitations i
+`
+
+func scanInvitation(row pgx.Row) (models.MatchInvitation, error) {
+	var invitation models.MatchInvitation
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.MatchID,
+		&invitation.UserID,
+		&invitation.InviterID,
+		&invitation.LinkID,
+		&invitation.Status,
+		&invitation.ExpiresAt,
+		&invitation.RespondedAt,
+		&invitation.CreatedAt,
+	)
+	return invitation, err
+}
+
+func collectInvitations(rows pgx.Rows) ([]models.MatchInvitation, error) {
+	defer rows.Close()
+
+	invitations := make([]models.MatchInvitation, 0)
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// CreateMatchInvitations приглашает пользователей в матч. Участники матча и те, у кого уже есть
+// действующее приглашение, пропускаются; возвращаются только созданные приглашения.
+func (r *Repository) CreateMatchInvitations(ctx context.Context, matchID, inviterID uint64, userIDs []uint64, expiresAt time.Time) ([]models.MatchInvitation, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// просроченные pending освобождают уникальный индекс для нового приглашения
+	const expireQuery = `
+		UPDATE match_invitations
+		SET status = 'expired'
+		WHERE match_id = $1
+		  AND status = 'pending'
+		  AND expires_at < now()
+	`
+
+	if _, err = tx.Exec(ctx, expireQuery, matchID); err != nil {
+		return nil, err
+	}
+
+	const insertQuery = `
+		INSERT INTO match_invitations (match_id, user_id, inviter_id, expires_at)
+		SELECT $1, invitee_id, $2, $3
+		FROM unnest($4::bigint[]) AS invitee_id
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM user_matches um
+			WHERE um.match_id = $1
+			  AND um.user_id = invitee_id
+		)
+		ON CONFLICT (match_id, user_id) WHERE status = 'pending' DO NOTHING
+		RETURNING id, match_id, user_id, inviter_id, link_id, status, expires_at, responded_at, created_at
+	`
+
+	rows, err := tx.Query(ctx, insertQuery, matchID, inviterID, expiresAt, userIDs)
+	if err != nil {
+		return nil, invalidReference(err)
+	}
+
+	invitations, err := collectInvitations(rows)
+	if err != nil {
+		return nil, invalidReference(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (r *Repository) GetMatchInvitation(ctx context.Context, invitationID uint64) (models.MatchInvitation, error) {
+	query := invitationSelect + ` WHERE i.id = $1`
+
+	return scanInvitation(r.postgres.QueryRow(ctx, query, invitationID))
+}
+
+func (r *Repository) ListMatchInvitations(ctx context.Context, matchID uint64) ([]models.MatchInvitation, error) {
+	query := invitationSelect + `
+		WHERE i.match_id = $1
+		ORDER BY i.created_at DESC, i.id DESC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectInvitations(rows)
+}
+
+// ListUserPendingInvitations — действующие приглашения пользователя в открытые матчи
+func (r *Repository) ListUserPendingInvitations(ctx context.Context, userID uint64) ([]models.MatchInvitation, error) {
+	query := invitationSelect + `
+		JOIN matches m ON m.id = i.match_id
+		WHERE i.user_id = $1
+		  AND i.status = 'pending'
+		  AND i.expires_at >= now()
+		  AND m.status IN ('scheduled', 'active')
+		ORDER BY m.starts_at ASC, i.id ASC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectInvitations(rows)
+}
+
+func (r *Repository) HasPendingMatchInvitation(ctx context.Context, matchID, userID uint64) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM match_invitations
+			WHERE match_id = $1
+			  AND user_id = $2
+			  AND status = 'pending'
+			  AND expires_at >= now()
+		)
+	`
+
+	var exists bool
+	if err := r.postgres.QueryRow(ctx, query, matchID, userID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// AcceptMatchInvitation делает приглашённого участником и пересчитывает статус матча.
+// pgx.ErrNoRows — приглашение уже не действует или матч закрыт; myerrors.ErrMatchFull — мест нет.
+func (r *Repository) AcceptMatchInvitation(ctx context.Context, invitationID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const acceptQuery = `
+		UPDATE match_invitations
+		SET status = 'accepted',
+		    responded_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+		  AND expires_at >= now()
+		RETURNING match_id, user_id
+	`
+
+	var matchID, userID uint64
+	if err = tx.QueryRow(ctx, acceptQuery, invitationID).Scan(&matchID, &userID); err != nil {
+		return err
+	}
+
+	if err = addParticipant(ctx, tx, matchID, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CloseMatchInvitation переводит действующее приглашение в declined или revoked.
+// pgx.ErrNoRows — приглашение уже не pending.
+func (r *Repository) CloseMatchInvitation(ctx context.Context, invitationID uint64, status models.InvitationStatus) error {
+	const query = `
+		UPDATE match_invitations
+		SET status = $2,
+		    responded_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, invitationID, status)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const inviteLinkSelect = `
+	SELECT id, match_id, inviter_id, max_uses, uses_count, expires_at, revoked_at, created_at
+	FROM match_invite_links
+`
+
+func scanInviteLink(row pgx.Row) (models.MatchInviteLink, error) {
+	var link models.MatchInviteLink
+	err := row.Scan(
+		&link.ID,
+		&link.MatchID,
+		&link.InviterID,
+		&link.MaxUses,
+		&link.UsesCount,
+		&link.ExpiresAt,
+		&link.RevokedAt,
+		&link.CreatedAt,
+	)
+	return link, err
+}
+
+func (r *Repository) CreateMatchInviteLink(ctx context.Context, link models.MatchInviteLink) (models.MatchInviteLink, error) {
+	const query = `
+		INSERT INTO match_invite_links (match_id, inviter_id, max_uses, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, match_id, inviter_id, max_uses, uses_count, expires_at, revoked_at, created_at
+	`
+
+	created, err := scanInviteLink(r.postgres.QueryRow(ctx, query, link.MatchID, link.InviterID, link.MaxUses, link.ExpiresAt))
+	if err != nil {
+		return models.MatchInviteLink{}, invalidReference(err)
+	}
+
+	return created, nil
+}
+
+func (r *Repository) GetMatchInviteLink(ctx context.Context, linkID uint64) (models.MatchInviteLink, error) {
+	query := inviteLinkSelect + ` WHERE id = $1`
+
+	return scanInviteLink(r.postgres.QueryRow(ctx, query, linkID))
+}
+
+func (r *Repository) ListMatchInviteLinks(ctx context.Context, matchID uint64) ([]models.MatchInviteLink, error) {
+	query := inviteLinkSelect + `
+		WHERE match_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]models.MatchInviteLink, 0)
+	for rows.Next() {
+		link, err := scanInviteLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// RevokeMatchInviteLink закрывает ссылку. Уже выданные по ней приглашения остаются в силе.
+// pgx.ErrNoRows — ссылки нет или она уже отозвана.
+func (r *Repository) RevokeMatchInviteLink(ctx context.Context, linkID uint64) error {
+	const query = `
+		UPDATE match_invite_links
+		SET revoked_at = now()
+		WHERE id = $1
+		  AND revoked_at IS NULL
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, linkID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// RedeemMatchInviteLink выдаёт пользователю приглашение по ссылке и учитывает использование.
+// Если действующее приглашение в этот матч уже есть, возвращается оно, и использование не списывается.
+// pgx.ErrNoRows — ссылка больше не действует или матч закрыт.
+func (r *Repository) RedeemMatchInviteLink(ctx context.Context, linkID, userID uint64, invitationExpiresAt time.Time) (models.MatchInvitation, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return models.MatchInvitation{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	const lockLinkQuery = `
+		SELECT l.match_id, l.inviter_id
+		FROM match_invite_links l
+		JOIN matches m ON m.id = l.match_id
+		WHERE l.id = $1
+		  AND l.revoked_at IS NULL
+		  AND l.expires_at > now()
+		  AND (l.max_uses IS NULL OR l.uses_count < l.max_uses)
+		  AND m.status IN ('scheduled', 'active')
+		FOR UPDATE OF l
+	`
+
+	var (
+		matchID   uint64
+		inviterID *uint64
+	)
+	if err = tx.QueryRow(ctx, lockLinkQuery, linkID).Scan(&matchID, &inviterID); err != nil {
+		return models.MatchInvitation{}, err
+	}
+
+	const expireQuery = `
+		UPDATE match_invitations
+		SET status = 'expired'
+		WHERE match_id = $1
+		  AND user_id = $2
+		  AND status = 'pending'
+		  AND expires_at < now()
+	`
+
+	if _, err = tx.Exec(ctx, expireQuery, matchID, userID); err != nil {
+		return models.MatchInvitation{}, err
+	}
+
+	existing, err := scanInvitation(tx.QueryRow(ctx, invitationSelect+`
+		WHERE i.match_id = $1
+		  AND i.user_id = $2
+		  AND i.status = 'pending'
+	`, matchID, userID))
+	if err == nil {
+		return existing, tx.Commit(ctx)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return models.MatchInvitation{}, err
+	}
+
+	const insertQuery = `
+		INSERT INTO match_invitations (match_id, user_id, inviter_id, link_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, match_id, user_id, inviter_id, link_id, status, expires_at, responded_at, created_at
+	`
+
+	invitation, err := scanInvitation(tx.QueryRow(ctx, insertQuery, matchID, userID, inviterID, linkID, invitationExpiresAt))
+	if err != nil {
+		return models.MatchInvitation{}, invalidReference(err)
+	}
+
+	const useQuery = `
+		UPDATE match_invite_links
+		SET uses_count = uses_count + 1
+		WHERE id = $1
+	`
+
+	if _, err = tx.Exec(ctx, useQuery, linkID); err != nil {
+		return models.MatchInvitation{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.MatchInvitation{}, err
+	}
+
+	return invitation, nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetMatchLineup — участники матча с командами, рейтингом в виде спорта матча и предпочитаемой стороной
+func (r *Repository) GetMatchLineup(ctx context.Context, matchID uint64) ([]models.LineupPlayer, error) {
+	const query = `
+		SELECT um.user_id, u.name, u.surname, um.team, um.side, pr.rating, u.court_position
+		FROM user_matches um
+		JOIN users u ON u.id = um.user_id
+		JOIN matches m ON m.id = um.match_id
+		LEFT JOIN player_ratings pr ON pr.user_id = um.user_id AND pr.sport_id = m.sport_id
+		WHERE um.match_id = $1
+		ORDER BY um.team NULLS LAST, um.side NULLS LAST, um.user_id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lineup := make([]models.LineupPlayer, 0)
+	for rows.Next() {
+		var p models.LineupPlayer
+		if err = rows.Scan(&p.UserID, &p.Name, &p.Surname, &p.Team, &p.Side, &p.Rating, &p.CourtPosition); err != nil {
+			return nil, err
+		}
+		lineup = append(lineup, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lineup, nil
+}
+
+// SetMatchLineup заменяет состав команд целиком: сначала сбрасывает все назначения, потом ставит новые.
+// pgx.ErrNoRows — матч закрыт или кто-то из списка уже не участник.
+func (r *Repository) SetMatchLineup(ctx context.Context, matchID uint64, assignments []models.LineupAssignment) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// блокировка матча упорядочивает изменения состава с выходом и вступлением участников
+	const lockQuery = `
+		SELECT id
+		FROM matches
+		WHERE id = $1
+		  AND status IN ('scheduled', 'active')
+		FOR UPDATE
+	`
+
+	var id uint64
+	if err = tx.QueryRow(ctx, lockQuery, matchID).Scan(&id); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `UPDATE user_matches SET team = NULL, side = NULL WHERE match_id = $1`, matchID); err != nil {
+		return err
+	}
+
+	const assignQuery = `
+		UPDATE user_matches
+		SET team = $3,
+		    side = $4
+		WHERE match_id = $1
+		  AND user_id = $2
+	`
+
+	for _, a := range assignments {
+		ct, err := tx.Exec(ctx, assignQuery, matchID, a.UserID, a.Team, a.Side)
+		if err != nil {
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ListPublicMatches ищет открытые публичные матчи, которые ещё не начались.
+// Порядок — по времени начала; страница продолжается после (AfterStartsAt, AfterID).
+func (r *Repository) ListPublicMatches(ctx context.Context, filter models.PublicMatchFilter) ([]models.Match, error) {
+	conditions := []string{
+		"m.visibility = 'public'",
+		"m.status IN ('scheduled', 'active')",
+	}
+	args := []any{filter.Now}
+	conditions = append(conditions, "m.starts_at > $1")
+
+	add := func(format string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.SportID != nil {
+		add("m.sport_id = $%d", *filter.SportID)
+	}
+	if filter.TownID != nil {
+		add("m.town_id = $%d", *filter.TownID)
+	}
+	if filter.From != nil {
+		add("m.starts_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("m.starts_at < $%d", *filter.To)
+	}
+	if filter.LevelID != nil {
+		add("(m.min_level_id IS NULL OR m.min_level_id <= $%[1]d) AND (m.max_level_id IS NULL OR m.max_level_id >= $%[1]d)", *filter.LevelID)
+	}
+	if filter.ViewerID != 0 {
+		add(`NOT EXISTS (
+			SELECT 1
+			FROM user_matches bm
+			JOIN user_blocks b
+			  ON (b.blocker_id = $%[1]d AND b.blocked_id = bm.user_id)
+			  OR (b.blocked_id = $%[1]d AND b.blocker_id = bm.user_id)
+			WHERE bm.match_id = m.id
+		)`, filter.ViewerID)
+	}
+	if filter.MinFreeSpots > 0 {
+		add("(m.capacity IS NULL OR m.capacity - (SELECT count(*) FROM user_matches fs WHERE fs.match_id = m.id) >= $%d)", filter.MinFreeSpots)
+	}
+	if filter.AfterStartsAt != nil {
+		args = append(args, *filter.AfterStartsAt, filter.AfterID)
+		conditions = append(conditions, fmt.Sprintf("(m.starts_at, m.id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	args = append(args, filter.Limit)
+	query := matchSelect + `
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY m.starts_at ASC, m.id ASC
+		LIMIT $` + fmt.Sprint(len(args))
+
+	rows, err := r.postgres.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectMatches(rows)
+}
+
+// JoinPublicMatch записывает пользователя в открытый публичный матч. Строка матча блокируется,
+// поэтому параллельные вступления не переполнят capacity. Действующее приглашение в этот матч
+// считается принятым. pgx.ErrNoRows — матч не публичный или закрыт; myerrors.ErrMatchFull — мест нет.
+func (r *Repository) JoinPublicMatch(ctx context.Context, matchID, userID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const publicQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM matches
+			WHERE id = $1
+			  AND visibility = 'public'
+		)
+	`
+
+	var public bool
+	if err = tx.QueryRow(ctx, publicQuery, matchID).Scan(&public); err != nil {
+		return err
+	}
+	if !public {
+		return pgx.ErrNoRows
+	}
+
+	if err = addParticipant(ctx, tx, matchID, userID); err != nil {
+		return err
+	}
+
+	const acceptQuery = `
+		UPDATE match_invitations
+		SET status = 'accepted',
+		    responded_at = now()
+		WHERE match_id = $1
+		  AND user_id = $2
+		  AND status = 'pending'
+	`
+
+	if _, err = tx.Exec(ctx, acceptQuery, matchID, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// addParticipant добавляет участника в открытый матч с проверкой capacity и пересчитывает статус.
+// Матч блокируется до конца транзакции: проверка мест и вставка не разъезжаются.
+func addParticipant(ctx context.Context, tx pgx.Tx, matchID, userID uint64) error {
+	const lockMatchQuery = `
+		SELECT capacity
+		FROM matches
+		WHERE id = $1
+		  AND status IN ('scheduled', 'active')
+		FOR UPDATE
+	`
+
+	var capacity *int
+	if err := tx.QueryRow(ctx, lockMatchQuery, matchID).Scan(&capacity); err != nil {
+		return err
+	}
+
+	if capacity != nil {
+		const countQuery = `SELECT count(*) FROM user_matches WHERE match_id = $1`
+
+		var participants int
+		if err := tx.QueryRow(ctx, countQuery, matchID).Scan(&participants); err != nil {
+			return err
+		}
+		if participants >= *capacity {
+			return myerrors.ErrMatchFull
+		}
+	}
+
+	const insertParticipantQuery = `
+		INSERT INTO user_matches (user_id, match_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, match_id) DO NOTHING
+	`
+
+	if _, err := tx.Exec(ctx, insertParticipantQuery, userID, matchID); err != nil {
+		return err
+	}
+
+	return syncMatchStatus(ctx, tx, matchID)
+}
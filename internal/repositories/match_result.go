@@ -0,0 +1,393 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/rating"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const resultSelect = `
+	SELECT r.id, r.match_id, r.submitted_by, r.winner_team, r.status,
+	       r.confirmed_by, r.confirmed_at, r.disputed_by, r.dispute_reason, r.disputed_at,
+	       r.resolved_by, r.resolution_comment, r.resolved_at, r.created_at
+	FROM match_results r
+`
+
+func scanResult(row pgx.Row) (models.MatchResult, error) {
+	var result models.MatchResult
+	err := row.Scan(
+		&result.ID,
+		&result.MatchID,
+		&result.SubmittedBy,
+		&result.WinnerTeam,
+		&result.Status,
+		&result.ConfirmedBy,
+		&result.ConfirmedAt,
+		&result.DisputedBy,
+		&result.DisputeReason,
+		&result.DisputedAt,
+		&result.ResolvedBy,
+		&result.ResolutionComment,
+		&result.ResolvedAt,
+		&result.CreatedAt,
+	)
+	return result, err
+}
+
+func collectResults(rows pgx.Rows) ([]models.MatchResult, error) {
+	defer rows.Close()
+
+	results := make([]models.MatchResult, 0)
+	for rows.Next() {
+		result, err := scanResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// CreateMatchResult сохраняет результат открытого матча с составами и счётом по сетам.
+// Если у матча не был указан вид спорта, он записывается из sportID.
+// pgx.ErrNoRows — матч уже закрыт; myerrors.ErrResultExists — у матча есть действующий результат.
+func (r *Repository) CreateMatchResult(ctx context.Context, result models.MatchResult, sportID int) (uint64, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	const lockMatchQuery = `
+		UPDATE matches
+		SET sport_id = COALESCE(sport_id, $2),
+		    updated_at = now()
+		WHERE id = $1
+		  AND status IN ('scheduled', 'active')
+		RETURNING id
+	`
+
+	if err = tx.QueryRow(ctx, lockMatchQuery, result.MatchID, sportID).Scan(&result.MatchID); err != nil {
+		return 0, err
+	}
+
+	const insertResultQuery = `
+		INSERT INTO match_results (match_id, submitted_by, winner_team)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+
+	var resultID uint64
+	err = tx.QueryRow(ctx, insertResultQuery, result.MatchID, result.SubmittedBy, result.WinnerTeam).Scan(&resultID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return 0, myerrors.ErrResultExists
+		}
+		return 0, err
+	}
+
+	const insertPlayersQuery = `
+		INSERT INTO match_result_players (result_id, user_id, team)
+		SELECT $1, player_id, 1 FROM unnest($2::bigint[]) AS player_id
+		UNION ALL
+		SELECT $1, player_id, 2 FROM unnest($3::bigint[]) AS player_id
+	`
+
+	if _, err = tx.Exec(ctx, insertPlayersQuery, resultID, result.Team1, result.Team2); err != nil {
+		return 0, err
+	}
+
+	const insertSetQuery = `
+		INSERT INTO match_result_sets (
+			result_id, set_number, team1_games, team2_games, team1_tiebreak, team2_tiebreak, match_tiebreak
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	batch := &pgx.Batch{}
+	for i, set := range result.Sets {
+		batch.Queue(insertSetQuery, resultID, i+1, set.Team1, set.Team2, set.Team1Tiebreak, set.Team2Tiebreak, set.MatchTiebreak)
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return resultID, nil
+}
+
+func (r *Repository) GetMatchResult(ctx context.Context, resultID uint64) (models.MatchResult, error) {
+	query := resultSelect + ` WHERE r.id = $1`
+
+	result, err := scanResult(r.postgres.QueryRow(ctx, query, resultID))
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+
+	results := []models.MatchResult{result}
+	if err = r.loadResultDetails(ctx, results); err != nil {
+		return models.MatchResult{}, err
+	}
+
+	return results[0], nil
+}
+
+// GetActiveMatchResult — действующий (не отклонённый) результат матча
+func (r *Repository) GetActiveMatchResult(ctx context.Context, matchID uint64) (models.MatchResult, error) {
+	query := resultSelect + ` WHERE r.match_id = $1 AND r.status <> 'rejected'`
+
+	result, err := scanResult(r.postgres.QueryRow(ctx, query, matchID))
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+
+	results := []models.MatchResult{result}
+	if err = r.loadResultDetails(ctx, results); err != nil {
+		return models.MatchResult{}, err
+	}
+
+	return results[0], nil
+}
+
+// ListDisputedMatchResults — очередь споров для ассистента, старые сначала
+func (r *Repository) ListDisputedMatchResults(ctx context.Context) ([]models.MatchResult, error) {
+	query := resultSelect + `
+		WHERE r.status = 'disputed'
+		ORDER BY r.disputed_at ASC, r.id ASC
+	`
+
+	rows, err := r.postgres.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := collectResults(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.loadResultDetails(ctx, results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ConfirmMatchResult подтверждает ожидающий результат, завершает матч и пересчитывает рейтинг.
+// pgx.ErrNoRows — результат уже не pending.
+func (r *Repository) ConfirmMatchResult(ctx context.Context, resultID, userID uint64, engine rating.Elo) error {
+	const query = `
+		UPDATE match_results
+		SET status = 'confirmed',
+		    confirmed_by = $2,
+		    confirmed_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+		RETURNING match_id
+	`
+
+	return r.closeMatchResult(ctx, engine, query, resultID, userID)
+}
+
+// DisputeMatchResult оспаривает ожидающий результат. pgx.ErrNoRows — результат уже не pending.
+func (r *Repository) DisputeMatchResult(ctx context.Context, resultID, userID uint64, reason string) error {
+	const query = `
+		UPDATE match_results
+		SET status = 'disputed',
+		    disputed_by = $2,
+		    dispute_reason = $3,
+		    disputed_at = now()
+		WHERE id = $1
+		  AND status = 'pending'
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, resultID, userID, reason)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ResolveMatchResult закрывает спор: подтверждённый результат завершает матч, отклонённый
+// освобождает место для нового ввода. pgx.ErrNoRows — результат уже не disputed.
+func (r *Repository) ResolveMatchResult(ctx context.Context, resultID, resolverID uint64, confirm bool, comment *string, engine rating.Elo) error {
+	if !confirm {
+		const rejectQuery = `
+			UPDATE match_results
+			SET status = 'rejected',
+			    resolved_by = $2,
+			    resolution_comment = $3,
+			    resolved_at = now()
+			WHERE id = $1
+			  AND status = 'disputed'
+		`
+
+		ct, err := r.postgres.Exec(ctx, rejectQuery, resultID, resolverID, comment)
+		if err != nil {
+			return err
+		}
+		if ct.RowsAffected() == 0 {
+			return pgx.ErrNoRows
+		}
+		return nil
+	}
+
+	const confirmQuery = `
+		UPDATE match_results
+		SET status = 'confirmed',
+		    resolved_by = $2,
+		    resolution_comment = $3,
+		    resolved_at = now(),
+		    confirmed_by = $2,
+		    confirmed_at = now()
+		WHERE id = $1
+		  AND status = 'disputed'
+		RETURNING match_id
+	`
+
+	return r.closeMatchResult(ctx, engine, confirmQuery, resultID, resolverID, comment)
+}
+
+// closeMatchResult выполняет подтверждение результата (запрос возвращает match_id),
+// в той же транзакции переводит матч в completed, обновляет рейтинги рейтингового матча
+// и продвигает турнир, если матч — встреча турнира
+func (r *Repository) closeMatchResult(ctx context.Context, engine rating.Elo, query string, resultID uint64, args ...any) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var matchID uint64
+	if err = tx.QueryRow(ctx, query, append([]any{resultID}, args...)...).Scan(&matchID); err != nil {
+		return err
+	}
+
+	const completeQuery = `
+		UPDATE matches
+		SET status = 'completed',
+		    updated_at = now()
+		WHERE id = $1
+		  AND status IN ('scheduled', 'active')
+	`
+
+	if _, err = tx.Exec(ctx, completeQuery, matchID); err != nil {
+		return err
+	}
+
+	if err = applyRatings(ctx, tx, resultID, engine); err != nil {
+		return err
+	}
+
+	if err = advanceTournament(ctx, tx, matchID, resultID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// loadResultDetails дозагружает составы и счёт по сетам
+func (r *Repository) loadResultDetails(ctx context.Context, results []models.MatchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	ids := make([]uint64, len(results))
+	index := make(map[uint64]int, len(results))
+	for i := range results {
+		ids[i] = results[i].ID
+		index[results[i].ID] = i
+		results[i].Team1 = make([]uint64, 0)
+		results[i].Team2 = make([]uint64, 0)
+		results[i].Sets = make([]models.SetScore, 0)
+	}
+
+	const playersQuery = `
+		SELECT result_id, user_id, team
+		FROM match_result_players
+		WHERE result_id = ANY($1)
+		ORDER BY result_id, team, user_id
+	`
+
+	rows, err := r.postgres.Query(ctx, playersQuery, ids)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var (
+			resultID, userID uint64
+			team             int
+		)
+		if err = rows.Scan(&resultID, &userID, &team); err != nil {
+			rows.Close()
+			return err
+		}
+		result := &results[index[resultID]]
+		if team == 1 {
+			result.Team1 = append(result.Team1, userID)
+		} else {
+			result.Team2 = append(result.Team2, userID)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	const setsQuery = `
+		SELECT result_id, team1_games, team2_games, team1_tiebreak, team2_tiebreak, match_tiebreak
+		FROM match_result_sets
+		WHERE result_id = ANY($1)
+		ORDER BY result_id, set_number
+	`
+
+	rows, err = r.postgres.Query(ctx, setsQuery, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			resultID uint64
+			set      models.SetScore
+		)
+		if err = rows.Scan(&resultID, &set.Team1, &set.Team2, &set.Team1Tiebreak, &set.Team2Tiebreak, &set.MatchTiebreak); err != nil {
+			return err
+		}
+		result := &results[index[resultID]]
+		result.Sets = append(result.Sets, set)
+	}
+
+	return rows.Err()
+}
+
+// GetSportName — название вида спорта из справочника. pgx.ErrNoRows — такого нет.
+func (r *Repository) GetSportName(ctx context.Context, sportID int) (string, error) {
+	const query = `SELECT name FROM sports WHERE id = $1`
+
+	var name string
+	if err := r.postgres.QueryRow(ctx, query, sportID).Scan(&name); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+)
+
+// ListPairResults — подтверждённые матчи пользователя построчно с каждым другим игроком,
+// новые первыми. otherID сужает выборку до одного игрока.
+func (r *Repository) ListPairResults(ctx context.Context, userID uint64, otherID *uint64) ([]models.PairResult, error) {
+	const query = `
+		SELECT r.match_id, r.id, other.user_id, u.name, u.surname, u.photo_thumbnail,
+		       other.team = own.team,
+		       own.team = r.winner_team,
+		       COALESCE(m.starts_at, r.confirmed_at, r.created_at)
+		FROM match_result_players own
+		JOIN match_results r ON r.id = own.result_id AND r.status = 'confirmed'
+		JOIN matches m ON m.id = r.match_id
+		JOIN match_result_players other ON other.result_id = own.result_id AND other.user_id <> own.user_id
+		JOIN users u ON u.id = other.user_id
+		WHERE own.user_id = $1
+		  AND ($2::bigint IS NULL OR other.user_id = $2)
+		  AND u.deleted_at IS NULL
+		ORDER BY COALESCE(m.starts_at, r.confirmed_at, r.created_at) DESC, r.id DESC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, otherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]models.PairResult, 0)
+	for rows.Next() {
+		var p models.PairResult
+		err = rows.Scan(
+			&p.MatchID,
+			&p.ResultID,
+			&p.OtherUserID,
+			&p.Name,
+			&p.Surname,
+			&p.PhotoThumbnail,
+			&p.SameTeam,
+			&p.Won,
+			&p.PlayedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
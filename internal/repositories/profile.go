@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *Repository) GetPlayerCard(ctx context.Context, userID uint64) (models.PlayerCard, error) {
+	const query = `
+		SELECT u.id, u.name, u.surname, u.photo_thumbnail, u.town_id, t.name, u.court_position,
+		       COALESCE(
+		           (SELECT array_agg(upl.location_name ORDER BY upl.location_name)
+		            FROM user_preferred_locations upl
+		            WHERE upl.user_id = u.id),
+		           '{}'
+		       )
+		FROM users u
+		LEFT JOIN towns t ON t.id = u.town_id
+		WHERE u.id = $1
+		  AND u.deleted_at IS NULL
+	`
+
+	var card models.PlayerCard
+	if err := r.postgres.QueryRow(ctx, query, userID).Scan(
+		&card.ID,
+		&card.Name,
+		&card.Surname,
+		&card.PhotoThumbnail,
+		&card.TownID,
+		&card.TownName,
+		&card.CourtPosition,
+		&card.TrainingDistricts,
+	); err != nil {
+		return models.PlayerCard{}, err
+	}
+
+	return card, nil
+}
+
+// GetPlayerStats считает сыгранные матчи (завершённые подтверждённым результатом), друзей и напарников —
+// тех, с кем пользователь играл в одной команде в подтверждённых матчах. Рейтинг — в виде спорта, где сыграно больше всего рейтинговых матчей.
+func (r *Repository) GetPlayerStats(ctx context.Context, userID uint64) (models.PlayerStats, error) {
+	const query = `
+		SELECT
+			(SELECT rating FROM player_ratings WHERE user_id = $1 ORDER BY matches_played DESC, rating DESC LIMIT 1),
+			(SELECT count(*)
+			 FROM user_matches um
+			 JOIN matches m ON m.id = um.match_id AND m.status = 'completed'
+			 WHERE um.user_id = $1),
+			(SELECT count(*) FROM friends WHERE user_id = $1 OR friend_id = $1),
+			(SELECT count(DISTINCT other.user_id)
+			 FROM match_result_players own
+			 JOIN match_results r ON r.id = own.result_id AND r.status = 'confirmed'
+			 JOIN match_result_players other
+			   ON other.result_id = own.result_id AND other.team = own.team AND other.user_id <> own.user_id
+			 WHERE own.user_id = $1)
+	`
+
+	var stats models.PlayerStats
+	if err := r.postgres.QueryRow(ctx, query, userID).Scan(
+		&stats.Rating,
+		&stats.MatchesPlayed,
+		&stats.FriendsCount,
+		&stats.PartnersCount,
+	); err != nil {
+		return models.PlayerStats{}, err
+	}
+
+	return stats, nil
+}
+
+// GetProfileVisibility возвращает настройки видимости; если пользователь их не менял — значения по умолчанию
+func (r *Repository) GetProfileVisibility(ctx context.Context, userID uint64) (models.ProfileVisibility, error) {
+	const query = `
+		SELECT show_rating, show_matches_played, show_friends, show_partners,
+		       show_town, show_training_district, show_court_position, show_in_suggestions
+		FROM user_profile_visibility
+		WHERE user_id = $1
+	`
+
+	var visibility models.ProfileVisibility
+	err := r.postgres.QueryRow(ctx, query, userID).Scan(
+		&visibility.ShowRating,
+		&visibility.ShowMatchesPlayed,
+		&visibility.ShowFriends,
+		&visibility.ShowPartners,
+		&visibility.ShowTown,
+		&visibility.ShowTrainingDistrict,
+		&visibility.ShowCourtPosition,
+		&visibility.ShowInSuggestions,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.DefaultProfileVisibility(), nil
+	}
+	if err != nil {
+		return models.ProfileVisibility{}, err
+	}
+
+	return visibility, nil
+}
+
+func (r *Repository) UpsertProfileVisibility(ctx context.Context, userID uint64, visibility models.ProfileVisibility) error {
+	const query = `
+		INSERT INTO user_profile_visibility (
+			user_id, show_rating, show_matches_played, show_friends, show_partners,
+			show_town, show_training_district, show_court_position, show_in_suggestions
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id) DO UPDATE
+		SET show_rating = EXCLUDED.show_rating,
+		    show_matches_played = EXCLUDED.show_matches_played,
+		    show_friends = EXCLUDED.show_friends,
+		    show_partners = EXCLUDED.show_partners,
+		    show_town = EXCLUDED.show_town,
+		    show_training_district = EXCLUDED.show_training_district,
+		    show_court_position = EXCLUDED.show_court_position,
+		    show_in_suggestions = EXCLUDED.show_in_suggestions,
+		    updated_at = now()
+	`
+
+	_, err := r.postgres.Exec(
+		ctx,
+		query,
+		userID,
+		visibility.ShowRating,
+		visibility.ShowMatchesPlayed,
+		visibility.ShowFriends,
+		visibility.ShowPartners,
+		visibility.ShowTown,
+		visibility.ShowTrainingDistrict,
+		visibility.ShowCourtPosition,
+		visibility.ShowInSuggestions,
+	)
+	return err
+}
+
+func (r *Repository) UpdateCourtPosition(ctx context.Context, userID uint64, position *models.CourtPosition) error {
+	const query = `
+		UPDATE users
+		SET court_position = $2,
+		    updated_at = now()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, userID, position)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
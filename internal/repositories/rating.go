@@ -0,0 +1,436 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/rating"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// resultPlayer — игрок подтверждённого результата и его команда
+type resultPlayer struct {
+	userID uint64
+	team   int
+}
+
+// applyRatings обновляет рейтинги по подтверждённому результату внутри транзакции подтверждения.
+// Дружеские матчи и матчи без вида спорта рейтинг не меняют. Повторная встреча тех же составов
+// в окне engine.RepeatWindow меняет рейтинг слабее, а серия встреч с одним победителем
+// попадает ассистенту в rating_flags.
+func applyRatings(ctx context.Context, tx pgx.Tx, resultID uint64, engine rating.Elo) error {
+	const resultQuery = `
+		SELECT r.match_id, r.winner_team, r.confirmed_at, m.sport_id, COALESCE(mt.name, '')
+		FROM match_results r
+		JOIN matches m ON m.id = r.match_id
+		LEFT JOIN match_types mt ON mt.id = m.match_type_id
+		WHERE r.id = $1
+	`
+
+	var (
+		matchID     uint64
+		winnerTeam  int
+		confirmedAt time.Time
+		sportID     *int
+		matchType   string
+	)
+	if err := tx.QueryRow(ctx, resultQuery, resultID).Scan(&matchID, &winnerTeam, &confirmedAt, &sportID, &matchType); err != nil {
+		return err
+	}
+	if matchType != models.MatchTypeRanked || sportID == nil {
+		return nil
+	}
+
+	const playersQuery = `
+		SELECT user_id, team
+		FROM match_result_players
+		WHERE result_id = $1
+		ORDER BY user_id
+	`
+
+	rows, err := tx.Query(ctx, playersQuery, resultID)
+	if err != nil {
+		return err
+	}
+	var players []resultPlayer
+	for rows.Next() {
+		var p resultPlayer
+		if err = rows.Scan(&p.userID, &p.team); err != nil {
+			rows.Close()
+			return err
+		}
+		players = append(players, p)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	userIDs := make([]uint64, len(players))
+	for i, p := range players {
+		userIDs[i] = p.userID
+	}
+
+	matchup := resultMatchup(players, winnerTeam)
+	var previous []rating.Matchup
+	if engine.RepeatWindow > 0 && len(players) > 0 {
+		previous, err = recentMatchups(ctx, tx, *sportID, resultID, players[0].userID, engine.RepeatSince(confirmedAt))
+		if err != nil {
+			return err
+		}
+	}
+	weight := engine.RepeatWeight(rating.Repeats(previous, matchup))
+
+	const ensureQuery = `
+		INSERT INTO player_ratings (user_id, sport_id, rating)
+		SELECT player_id, $2, $3
+		FROM unnest($1::bigint[]) AS player_id
+		ON CONFLICT (user_id, sport_id) DO NOTHING
+	`
+
+	if _, err = tx.Exec(ctx, ensureQuery, userIDs, *sportID, engine.Initial); err != nil {
+		return err
+	}
+
+	// строки блокируются в порядке user_id, чтобы параллельные подтверждения не взаимоблокировались
+	const lockQuery = `
+		SELECT user_id, rating, matches_played
+		FROM player_ratings
+		WHERE sport_id = $1
+		  AND user_id = ANY($2)
+		ORDER BY user_id
+		FOR UPDATE
+	`
+
+	rows, err = tx.Query(ctx, lockQuery, *sportID, userIDs)
+	if err != nil {
+		return err
+	}
+	current := make(map[uint64]rating.Player, len(players))
+	for rows.Next() {
+		var p rating.Player
+		if err = rows.Scan(&p.UserID, &p.Rating, &p.Matches); err != nil {
+			rows.Close()
+			return err
+		}
+		current[p.UserID] = p
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	changes := rateResult(engine, players, winnerTeam, weight, func(userID uint64) rating.Player {
+		return current[userID]
+	})
+
+	const updateQuery = `
+		UPDATE player_ratings
+		SET rating = $3,
+		    matches_played = matches_played + 1,
+		    updated_at = now()
+		WHERE user_id = $1
+		  AND sport_id = $2
+	`
+	const historyQuery = `
+		INSERT INTO rating_history (user_id, sport_id, match_id, result_id, rating_before, rating_after, weight)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	batch := &pgx.Batch{}
+	for _, c := range changes {
+		batch.Queue(updateQuery, c.UserID, *sportID, c.After)
+		batch.Queue(historyQuery, c.UserID, *sportID, matchID, resultID, c.Before, c.After, weight)
+	}
+
+	if engine.Suspicious(previous, matchup) {
+		const flagQuery = `
+			INSERT INTO rating_flags (result_id, match_id, sport_id, reason, details)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (result_id, reason) DO NOTHING
+		`
+
+		details := fmt.Sprintf("%d matches between the same lineups within %d days, all won by the same side",
+			rating.Repeats(previous, matchup)+1, int(engine.RepeatWindow.Hours()/24))
+		batch.Queue(flagQuery, resultID, matchID, *sportID, models.RatingFlagRepeatedMatchup, details)
+	}
+
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+// rateResult раскладывает игроков по командам с их текущими рейтингами и считает изменения
+func rateResult(engine rating.Elo, players []resultPlayer, winnerTeam int, weight float64, current func(userID uint64) rating.Player) []rating.Change {
+	var team1, team2 []rating.Player
+	for _, p := range players {
+		if p.team == 1 {
+			team1 = append(team1, current(p.userID))
+		} else {
+			team2 = append(team2, current(p.userID))
+		}
+	}
+
+	return engine.RateWeighted(team1, team2, winnerTeam, weight)
+}
+
+func resultMatchup(players []resultPlayer, winnerTeam int) rating.Matchup {
+	var team1, team2 []uint64
+	for _, p := range players {
+		if p.team == 1 {
+			team1 = append(team1, p.userID)
+		} else {
+			team2 = append(team2, p.userID)
+		}
+	}
+
+	return rating.NewMatchup(team1, team2, winnerTeam)
+}
+
+// recentMatchups — встречи из рейтинговых результатов вида спорта с участием userID, подтверждённых
+// начиная с since; результат exceptResultID и исключённые из рейтинга не учитываются
+func recentMatchups(ctx context.Context, tx pgx.Tx, sportID int, exceptResultID, userID uint64, since time.Time) ([]rating.Matchup, error) {
+	const query = `
+		SELECT r.id, r.winner_team, p.user_id, p.team
+		FROM match_results r
+		JOIN matches m ON m.id = r.match_id
+		JOIN match_types mt ON mt.id = m.match_type_id
+		JOIN match_result_players p ON p.result_id = r.id
+		WHERE r.status = 'confirmed'
+		  AND NOT r.rating_voided
+		  AND mt.name = $1
+		  AND m.sport_id = $2
+		  AND r.id <> $3
+		  AND r.confirmed_at >= $5
+		  AND EXISTS (
+			SELECT 1
+			FROM match_result_players own
+			WHERE own.result_id = r.id
+			  AND own.user_id = $4
+		  )
+		ORDER BY r.id, p.user_id
+	`
+
+	rows, err := tx.Query(ctx, query, models.MatchTypeRanked, sportID, exceptResultID, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		matchups []rating.Matchup
+		lastID   uint64
+		lastWin  int
+		players  []resultPlayer
+	)
+	flush := func() {
+		if len(players) > 0 {
+			matchups = append(matchups, resultMatchup(players, lastWin))
+		}
+	}
+	for rows.Next() {
+		var (
+			id     uint64
+			winner int
+			p      resultPlayer
+		)
+		if err = rows.Scan(&id, &winner, &p.userID, &p.team); err != nil {
+			return nil, err
+		}
+		if id != lastID {
+			flush()
+			lastID, lastWin, players = id, winner, nil
+		}
+		players = append(players, p)
+	}
+	flush()
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return matchups, nil
+}
+
+// RecalculateRatings строит рейтинги и историю заново, проигрывая по времени все подтверждённые
+// рейтинговые результаты (кроме исключённых ассистентом), начала сезонов и записанные снижения
+// за неактивность. Нужен после смены формулы или её параметров и после исключения результата.
+// Таблицы рейтингов заблокированы до конца пересчёта: новые подтверждения ждут его окончания.
+func (r *Repository) RecalculateRatings(ctx context.Context, engine rating.Elo) (int, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	const lockQuery = `
+		LOCK TABLE player_ratings, rating_history, rating_adjustments, rating_season_standings
+		IN EXCLUSIVE MODE
+	`
+
+	if _, err = tx.Exec(ctx, lockQuery); err != nil {
+		return 0, err
+	}
+
+	results, err := replayedResults(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	seasons, err := replayedSeasons(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	// снижения зависят только от дат матчей, поэтому сохраняются и проигрываются заново
+	decays, err := replayedDecays(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, table := range []string{"rating_history", "rating_adjustments", "rating_season_standings", "player_ratings"} {
+		if _, err = tx.Exec(ctx, `DELETE FROM `+table); err != nil {
+			return 0, err
+		}
+	}
+
+	replay := newRatingReplay(engine)
+	replay.run(results, seasons, decays)
+
+	copies := []struct {
+		table   string
+		columns []string
+		rows    [][]any
+	}{
+		{
+			table:   "rating_history",
+			columns: []string{"user_id", "sport_id", "match_id", "result_id", "rating_before", "rating_after", "weight", "created_at"},
+			rows:    replay.history,
+		},
+		{
+			table:   "rating_adjustments",
+			columns: []string{"user_id", "sport_id", "kind", "season_id", "rating_before", "rating_after", "created_at"},
+			rows:    replay.adjustments,
+		},
+		{
+			table:   "rating_season_standings",
+			columns: []string{"season_id", "user_id", "rank", "rating", "matches_played"},
+			rows:    replay.standings,
+		},
+		{
+			table:   "player_ratings",
+			columns: []string{"user_id", "sport_id", "rating", "matches_played", "updated_at", "decayed_at"},
+			rows:    replay.currentRatings(),
+		},
+	}
+	for _, c := range copies {
+		if _, err = tx.CopyFrom(ctx, pgx.Identifier{c.table}, c.columns, pgx.CopyFromRows(c.rows)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(results), nil
+}
+
+// GetPlayerRatings — рейтинги пользователя во всех видах спорта, где он играл рейтинговые матчи
+func (r *Repository) GetPlayerRatings(ctx context.Context, userID uint64) ([]models.PlayerRating, error) {
+	const query = `
+		SELECT pr.user_id, pr.sport_id, s.name, pr.rating, pr.matches_played, pr.updated_at
+		FROM player_ratings pr
+		JOIN sports s ON s.id = pr.sport_id
+		WHERE pr.user_id = $1
+		ORDER BY s.name
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := make([]models.PlayerRating, 0)
+	for rows.Next() {
+		var pr models.PlayerRating
+		if err = rows.Scan(&pr.UserID, &pr.SportID, &pr.Sport, &pr.Rating, &pr.MatchesPlayed, &pr.UpdatedAt); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, pr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ratings, nil
+}
+
+// ListRatingHistory — изменения рейтинга пользователя, последние сначала
+func (r *Repository) ListRatingHistory(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingChange, error) {
+	const query = `
+		SELECT match_id, result_id, sport_id, rating_before, rating_after, weight, created_at
+		FROM rating_history
+		WHERE user_id = $1
+		  AND ($2::int IS NULL OR sport_id = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, sportID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]models.RatingChange, 0)
+	for rows.Next() {
+		var c models.RatingChange
+		if err = rows.Scan(&c.MatchID, &c.ResultID, &c.SportID, &c.RatingBefore, &c.RatingAfter, &c.Weight, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		c.Delta = math.Round((c.RatingAfter-c.RatingBefore)*100) / 100
+		history = append(history, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// GetSportRatings — рейтинги игроков в виде спорта; у кого рейтинга ещё нет, в ответе отсутствуют
+func (r *Repository) GetSportRatings(ctx context.Context, sportID int, userIDs []uint64) (map[uint64]float64, error) {
+	const query = `
+		SELECT user_id, rating
+		FROM player_ratings
+		WHERE sport_id = $1
+		  AND user_id = ANY($2)
+	`
+
+	rows, err := r.postgres.Query(ctx, query, sportID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ratings := make(map[uint64]float64, len(userIDs))
+	for rows.Next() {
+		var (
+			userID uint64
+			value  float64
+		)
+		if err = rows.Scan(&userID, &value); err != nil {
+			return nil, err
+		}
+		ratings[userID] = value
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ratings, nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const ratingFlagSelect = `
+	SELECT id, result_id, match_id, sport_id, reason, details, status,
+	       reviewed_by, review_comment, reviewed_at, created_at
+	FROM rating_flags
+`
+
+func scanRatingFlag(row pgx.Row) (models.RatingFlag, error) {
+	var flag models.RatingFlag
+	err := row.Scan(
+		&flag.ID,
+		&flag.ResultID,
+		&flag.MatchID,
+		&flag.SportID,
+		&flag.Reason,
+		&flag.Details,
+		&flag.Status,
+		&flag.ReviewedBy,
+		&flag.ReviewComment,
+		&flag.ReviewedAt,
+		&flag.CreatedAt,
+	)
+	return flag, err
+}
+
+func (r *Repository) GetRatingFlag(ctx context.Context, flagID uint64) (models.RatingFlag, error) {
+	query := ratingFlagSelect + ` WHERE id = $1`
+
+	return scanRatingFlag(r.postgres.QueryRow(ctx, query, flagID))
+}
+
+// ListRatingFlags — отметки с указанным статусом от старых к новым, как очередь
+func (r *Repository) ListRatingFlags(ctx context.Context, status models.RatingFlagStatus) ([]models.RatingFlag, error) {
+	query := ratingFlagSelect + `
+		WHERE status = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flags := make([]models.RatingFlag, 0)
+	for rows.Next() {
+		flag, err := scanRatingFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return flags, nil
+}
+
+// ResolveRatingFlag закрывает открытую отметку. При status == voided результат исключается
+// из рейтинга; сами рейтинги меняет только пересчёт. pgx.ErrNoRows — отметка уже не открыта.
+func (r *Repository) ResolveRatingFlag(ctx context.Context, flagID, reviewerID uint64, status models.RatingFlagStatus, comment *string) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	const resolveQuery = `
+		UPDATE rating_flags
+		SET status = $2,
+		    reviewed_by = $3,
+		    review_comment = $4,
+		    reviewed_at = now()
+		WHERE id = $1
+		  AND status = 'open'
+		RETURNING result_id
+	`
+
+	var resultID uint64
+	if err = tx.QueryRow(ctx, resolveQuery, flagID, status, reviewerID, comment).Scan(&resultID); err != nil {
+		return err
+	}
+
+	if status == models.RatingFlagVoided {
+		const voidQuery = `
+			UPDATE match_results
+			SET rating_voided = true
+			WHERE id = $1
+		`
+
+		if _, err = tx.Exec(ctx, voidQuery, resultID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
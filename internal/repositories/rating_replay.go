@@ -0,0 +1,322 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/rating"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ratingKey struct {
+	userID  uint64
+	sportID int
+}
+
+type replayedResult struct {
+	resultID, matchID uint64
+	sportID           int
+	winnerTeam        int
+	confirmedAt       time.Time
+	players           []resultPlayer
+}
+
+type replayedSeason struct {
+	id          uint64
+	sportID     int
+	resetFactor float64
+	startedAt   time.Time
+	played      map[uint64]int // рейтинговые матчи игроков в этом сезоне
+}
+
+type replayedDecay struct {
+	key           ratingKey
+	before, after float64
+	at            time.Time
+}
+
+// replayedResults — подтверждённые рейтинговые результаты, не исключённые ассистентом, в порядке подтверждения
+func replayedResults(ctx context.Context, tx pgx.Tx) ([]replayedResult, error) {
+	const query = `
+		SELECT r.id, r.match_id, m.sport_id, r.winner_team, r.confirmed_at, p.user_id, p.team
+		FROM match_results r
+		JOIN matches m ON m.id = r.match_id
+		JOIN match_types mt ON mt.id = m.match_type_id
+		JOIN match_result_players p ON p.result_id = r.id
+		WHERE r.status = 'confirmed'
+		  AND NOT r.rating_voided
+		  AND mt.name = $1
+		  AND m.sport_id IS NOT NULL
+		ORDER BY r.confirmed_at, r.id, p.user_id
+	`
+
+	rows, err := tx.Query(ctx, query, models.MatchTypeRanked)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []replayedResult
+	for rows.Next() {
+		var (
+			res replayedResult
+			p   resultPlayer
+		)
+		if err = rows.Scan(&res.resultID, &res.matchID, &res.sportID, &res.winnerTeam, &res.confirmedAt, &p.userID, &p.team); err != nil {
+			return nil, err
+		}
+		if n := len(results); n > 0 && results[n-1].resultID == res.resultID {
+			results[n-1].players = append(results[n-1].players, p)
+			continue
+		}
+		res.players = []resultPlayer{p}
+		results = append(results, res)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func replayedSeasons(ctx context.Context, tx pgx.Tx) ([]replayedSeason, error) {
+	const query = `
+		SELECT id, sport_id, reset_factor, started_at
+		FROM rating_seasons
+		ORDER BY started_at, id
+	`
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var seasons []replayedSeason
+	for rows.Next() {
+		var s replayedSeason
+		if err = rows.Scan(&s.id, &s.sportID, &s.resetFactor, &s.startedAt); err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+func replayedDecays(ctx context.Context, tx pgx.Tx) ([]replayedDecay, error) {
+	const query = `
+		SELECT user_id, sport_id, rating_before, rating_after, created_at
+		FROM rating_adjustments
+		WHERE kind = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := tx.Query(ctx, query, models.RatingAdjustmentDecay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decays []replayedDecay
+	for rows.Next() {
+		var d replayedDecay
+		if err = rows.Scan(&d.key.userID, &d.key.sportID, &d.before, &d.after, &d.at); err != nil {
+			return nil, err
+		}
+		decays = append(decays, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return decays, nil
+}
+
+// ratingReplay проигрывает историю рейтинга в памяти и копит строки для записи в таблицы
+type ratingReplay struct {
+	engine    rating.Elo
+	ratings   map[ratingKey]*rating.Player
+	updatedAt map[ratingKey]time.Time
+	decayedAt map[ratingKey]time.Time
+	matchups  map[int]map[string][]time.Time // вид спорта → встреча → когда подтверждена
+	seasons   map[int]*replayedSeason        // открытый сезон вида спорта
+
+	history     [][]any
+	adjustments [][]any
+	standings   [][]any
+}
+
+func newRatingReplay(engine rating.Elo) *ratingReplay {
+	return &ratingReplay{
+		engine:    engine,
+		ratings:   make(map[ratingKey]*rating.Player),
+		updatedAt: make(map[ratingKey]time.Time),
+		decayedAt: make(map[ratingKey]time.Time),
+		matchups:  make(map[int]map[string][]time.Time),
+		seasons:   make(map[int]*replayedSeason),
+	}
+}
+
+// run проигрывает события по времени; в одно время сначала матч, затем снижение, затем начало сезона
+func (rp *ratingReplay) run(results []replayedResult, seasons []replayedSeason, decays []replayedDecay) {
+	type event struct {
+		at    time.Time
+		order int
+		apply func()
+	}
+
+	events := make([]event, 0, len(results)+len(seasons)+len(decays))
+	for i := range results {
+		res := &results[i]
+		events = append(events, event{at: res.confirmedAt, order: 0, apply: func() { rp.applyResult(res) }})
+	}
+	for i := range decays {
+		d := &decays[i]
+		events = append(events, event{at: d.at, order: 1, apply: func() { rp.applyDecay(d) }})
+	}
+	for i := range seasons {
+		season := &seasons[i]
+		events = append(events, event{at: season.startedAt, order: 2, apply: func() { rp.startSeason(season) }})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].at.Equal(events[j].at) {
+			return events[i].at.Before(events[j].at)
+		}
+		return events[i].order < events[j].order
+	})
+	for _, e := range events {
+		e.apply()
+	}
+}
+
+func (rp *ratingReplay) applyResult(res *replayedResult) {
+	matchup := resultMatchup(res.players, res.winnerTeam)
+	byKey := rp.matchups[res.sportID]
+	if byKey == nil {
+		byKey = make(map[string][]time.Time)
+		rp.matchups[res.sportID] = byKey
+	}
+
+	previous := 0
+	if rp.engine.RepeatWindow > 0 {
+		since := rp.engine.RepeatSince(res.confirmedAt)
+		for _, at := range byKey[matchup.Key] {
+			if !at.Before(since) {
+				previous++
+			}
+		}
+	}
+	byKey[matchup.Key] = append(byKey[matchup.Key], res.confirmedAt)
+	weight := rp.engine.RepeatWeight(previous)
+
+	changes := rateResult(rp.engine, res.players, res.winnerTeam, weight, func(userID uint64) rating.Player {
+		if p, ok := rp.ratings[ratingKey{userID, res.sportID}]; ok {
+			return *p
+		}
+		return rating.Player{UserID: userID, Rating: rp.engine.Initial}
+	})
+
+	season := rp.seasons[res.sportID]
+	for _, c := range changes {
+		key := ratingKey{c.UserID, res.sportID}
+		p, ok := rp.ratings[key]
+		if !ok {
+			p = &rating.Player{UserID: c.UserID}
+			rp.ratings[key] = p
+		}
+		p.Rating = c.After
+		p.Matches++
+		rp.updatedAt[key] = res.confirmedAt
+		if season != nil {
+			season.played[c.UserID]++
+		}
+
+		rp.history = append(rp.history, []any{c.UserID, res.sportID, res.matchID, res.resultID, c.Before, c.After, weight, res.confirmedAt})
+	}
+}
+
+// applyDecay снижает рейтинг на столько же, сколько было записано, но не ниже начального
+func (rp *ratingReplay) applyDecay(d *replayedDecay) {
+	p, ok := rp.ratings[d.key]
+	if !ok {
+		return
+	}
+
+	after := rp.engine.Decayed(p.Rating, d.before-d.after)
+	if after == p.Rating {
+		return
+	}
+
+	rp.adjustments = append(rp.adjustments, []any{d.key.userID, d.key.sportID, string(models.RatingAdjustmentDecay), nil, p.Rating, after, d.at})
+	p.Rating = after
+	rp.decayedAt[d.key] = d.at
+}
+
+// startSeason фиксирует таблицу прошлого сезона вида спорта и мягко сбрасывает рейтинги
+func (rp *ratingReplay) startSeason(season *replayedSeason) {
+	if prev := rp.seasons[season.sportID]; prev != nil {
+		rp.snapshot(prev)
+	}
+
+	for key, p := range rp.ratings {
+		if key.sportID != season.sportID {
+			continue
+		}
+		after := rp.engine.SoftReset(p.Rating, season.resetFactor)
+		rp.adjustments = append(rp.adjustments, []any{key.userID, key.sportID, string(models.RatingAdjustmentSeasonReset), season.id, p.Rating, after, season.startedAt})
+		p.Rating = after
+	}
+
+	season.played = make(map[uint64]int)
+	rp.seasons[season.sportID] = season
+}
+
+// snapshot — итоговая таблица сезона; при равном рейтинге место общее
+func (rp *ratingReplay) snapshot(season *replayedSeason) {
+	type row struct {
+		userID uint64
+		rating float64
+	}
+
+	rows := make([]row, 0, len(season.played))
+	for userID := range season.played {
+		rows = append(rows, row{userID, rp.ratings[ratingKey{userID, season.sportID}].Rating})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].rating != rows[j].rating {
+			return rows[i].rating > rows[j].rating
+		}
+		return rows[i].userID < rows[j].userID
+	})
+
+	rank := 0
+	for i, r := range rows {
+		if i == 0 || r.rating != rows[i-1].rating {
+			rank = i + 1
+		}
+		rp.standings = append(rp.standings, []any{season.id, r.userID, rank, r.rating, season.played[r.userID]})
+	}
+}
+
+func (rp *ratingReplay) currentRatings() [][]any {
+	current := make([][]any, 0, len(rp.ratings))
+	for key, p := range rp.ratings {
+		var decayedAt *time.Time
+		if at, ok := rp.decayedAt[key]; ok {
+			decayedAt = &at
+		}
+		current = append(current, []any{key.userID, key.sportID, p.Rating, p.Matches, rp.updatedAt[key], decayedAt})
+	}
+
+	return current
+}
@@ -0,0 +1,353 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/rating"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const ratingSeasonSelect = `
+	SELECT id, sport_id, name, reset_factor, started_by, started_at, ended_at
+	FROM rating_seasons
+`
+
+func scanRatingSeason(row pgx.Row) (models.RatingSeason, error) {
+	var season models.RatingSeason
+	err := row.Scan(
+		&season.ID,
+		&season.SportID,
+		&season.Name,
+		&season.ResetFactor,
+		&season.StartedBy,
+		&season.StartedAt,
+		&season.EndedAt,
+	)
+	return season, err
+}
+
+// StartRatingSeason закрывает открытый сезон вида спорта с итоговой таблицей, открывает новый
+// и мягко сбрасывает рейтинги вида спорта. Рейтинги блокируются, чтобы подтверждения матчей
+// не попали между таблицей и сбросом. Несуществующий вид спорта — myerrors.ErrInvalidReference.
+func (r *Repository) StartRatingSeason(ctx context.Context, season models.RatingSeason, engine rating.Elo) (uint64, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	const lockQuery = `
+		SELECT user_id, rating
+		FROM player_ratings
+		WHERE sport_id = $1
+		ORDER BY user_id
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(ctx, lockQuery, season.SportID)
+	if err != nil {
+		return 0, err
+	}
+	current := make(map[uint64]float64)
+	for rows.Next() {
+		var (
+			userID uint64
+			value  float64
+		)
+		if err = rows.Scan(&userID, &value); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		current[userID] = value
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	const closeQuery = `
+		UPDATE rating_seasons
+		SET ended_at = now()
+		WHERE sport_id = $1
+		  AND ended_at IS NULL
+		RETURNING id, started_at
+	`
+
+	var (
+		prevID        uint64
+		prevStartedAt time.Time
+	)
+	err = tx.QueryRow(ctx, closeQuery, season.SportID).Scan(&prevID, &prevStartedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return 0, err
+	}
+	if err == nil {
+		// в таблицу сезона попадают сыгравшие в нём хотя бы один рейтинговый матч
+		const standingsQuery = `
+			INSERT INTO rating_season_standings (season_id, user_id, rank, rating, matches_played)
+			SELECT $1, pr.user_id, RANK() OVER (ORDER BY pr.rating DESC), pr.rating, h.matches
+			FROM player_ratings pr
+			JOIN (
+				SELECT user_id, count(*) AS matches
+				FROM rating_history
+				WHERE sport_id = $2
+				  AND created_at >= $3
+				GROUP BY user_id
+			) h ON h.user_id = pr.user_id
+			WHERE pr.sport_id = $2
+		`
+
+		if _, err = tx.Exec(ctx, standingsQuery, prevID, season.SportID, prevStartedAt); err != nil {
+			return 0, err
+		}
+	}
+
+	const insertQuery = `
+		INSERT INTO rating_seasons (sport_id, name, reset_factor, started_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var seasonID uint64
+	err = tx.QueryRow(ctx, insertQuery, season.SportID, season.Name, season.ResetFactor, season.StartedBy).Scan(&seasonID)
+	if err != nil {
+		return 0, invalidReference(err)
+	}
+
+	const resetQuery = `
+		UPDATE player_ratings
+		SET rating = $3
+		WHERE user_id = $1
+		  AND sport_id = $2
+	`
+	const adjustmentQuery = `
+		INSERT INTO rating_adjustments (user_id, sport_id, kind, season_id, rating_before, rating_after)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	batch := &pgx.Batch{}
+	for userID, before := range current {
+		after := engine.SoftReset(before, season.ResetFactor)
+		batch.Queue(resetQuery, userID, season.SportID, after)
+		batch.Queue(adjustmentQuery, userID, season.SportID, models.RatingAdjustmentSeasonReset, seasonID, before, after)
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return seasonID, nil
+}
+
+func (r *Repository) GetRatingSeason(ctx context.Context, seasonID uint64) (models.RatingSeason, error) {
+	query := ratingSeasonSelect + ` WHERE id = $1`
+
+	return scanRatingSeason(r.postgres.QueryRow(ctx, query, seasonID))
+}
+
+// ListRatingSeasons — сезоны, последние сначала; sportID сужает до одного вида спорта
+func (r *Repository) ListRatingSeasons(ctx context.Context, sportID *int) ([]models.RatingSeason, error) {
+	query := ratingSeasonSelect + `
+		WHERE ($1::int IS NULL OR sport_id = $1)
+		ORDER BY started_at DESC, id DESC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, sportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seasons := make([]models.RatingSeason, 0)
+	for rows.Next() {
+		season, err := scanRatingSeason(rows)
+		if err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, season)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+// ListSeasonStandings — таблица сезона: закрытого — сохранённая при закрытии, идущего — по текущим
+// рейтингам сыгравших в нём. Места считаются по всем игрокам; удалённые и заблокированные
+// не показываются, скрывшие рейтинг видны только себе.
+func (r *Repository) ListSeasonStandings(ctx context.Context, season models.RatingSeason, viewerID uint64) ([]models.SeasonStanding, error) {
+	var (
+		board string
+		args  []any
+	)
+	if season.EndedAt != nil {
+		board = `
+			SELECT rank, user_id, rating, matches_played
+			FROM rating_season_standings
+			WHERE season_id = $2
+		`
+		args = []any{viewerID, season.ID}
+	} else {
+		board = `
+			SELECT RANK() OVER (ORDER BY pr.rating DESC) AS rank, pr.user_id, pr.rating, h.matches AS matches_played
+			FROM player_ratings pr
+			JOIN (
+				SELECT user_id, count(*) AS matches
+				FROM rating_history
+				WHERE sport_id = $2
+				  AND created_at >= $3
+				GROUP BY user_id
+			) h ON h.user_id = pr.user_id
+			WHERE pr.sport_id = $2
+		`
+		args = []any{viewerID, season.SportID, season.StartedAt}
+	}
+
+	query := `
+		WITH board AS (` + board + `)
+		SELECT b.rank, b.user_id, u.name, u.surname, u.photo_thumbnail, b.rating, b.matches_played
+		FROM board b
+		JOIN users u ON u.id = b.user_id
+		LEFT JOIN user_profile_visibility v ON v.user_id = b.user_id
+		WHERE u.deleted_at IS NULL
+		  AND u.blocked_at IS NULL
+		  AND (b.user_id = $1 OR COALESCE(v.show_rating, true))
+		ORDER BY b.rank, b.user_id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	standings := make([]models.SeasonStanding, 0)
+	for rows.Next() {
+		var s models.SeasonStanding
+		if err = rows.Scan(&s.Rank, &s.UserID, &s.Name, &s.Surname, &s.PhotoURL, &s.Rating, &s.MatchesPlayed); err != nil {
+			return nil, err
+		}
+		standings = append(standings, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// DecayRatings снижает рейтинги выше начального у игроков без рейтинговых матчей дольше decay.After.
+// Повторно рейтинг снижается не раньше чем через половину decay.Every: воркер запускается раз
+// в decay.Every, и перезапуск приложения не должен снижать рейтинг дважды. Возвращает число
+// снижённых рейтингов.
+func (r *Repository) DecayRatings(ctx context.Context, engine rating.Elo, decay rating.Decay, now time.Time) (int, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	const dueQuery = `
+		SELECT user_id, sport_id, rating
+		FROM player_ratings
+		WHERE rating > $1
+		  AND updated_at < $2
+		  AND (decayed_at IS NULL OR decayed_at < $3)
+		ORDER BY user_id, sport_id
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(ctx, dueQuery, engine.Initial, now.Add(-decay.After), now.Add(-decay.Every/2))
+	if err != nil {
+		return 0, err
+	}
+
+	const updateQuery = `
+		UPDATE player_ratings
+		SET rating = $3,
+		    decayed_at = $4
+		WHERE user_id = $1
+		  AND sport_id = $2
+	`
+	const adjustmentQuery = `
+		INSERT INTO rating_adjustments (user_id, sport_id, kind, rating_before, rating_after, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	batch := &pgx.Batch{}
+	for rows.Next() {
+		var (
+			key    ratingKey
+			before float64
+		)
+		if err = rows.Scan(&key.userID, &key.sportID, &before); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		after := engine.Decayed(before, decay.Points)
+		batch.Queue(updateQuery, key.userID, key.sportID, after, now)
+		batch.Queue(adjustmentQuery, key.userID, key.sportID, models.RatingAdjustmentDecay, before, after, now)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	decayed := batch.Len() / 2
+	if decayed == 0 {
+		return 0, nil
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return decayed, nil
+}
+
+// ListRatingAdjustments — сбросы и снижения рейтинга пользователя, последние сначала
+func (r *Repository) ListRatingAdjustments(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingAdjustment, error) {
+	const query = `
+		SELECT sport_id, kind, season_id, rating_before, rating_after, created_at
+		FROM rating_adjustments
+		WHERE user_id = $1
+		  AND ($2::int IS NULL OR sport_id = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, sportID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	adjustments := make([]models.RatingAdjustment, 0)
+	for rows.Next() {
+		var a models.RatingAdjustment
+		if err = rows.Scan(&a.SportID, &a.Kind, &a.SeasonID, &a.RatingBefore, &a.RatingAfter, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		a.Delta = math.Round((a.RatingAfter-a.RatingBefore)*100) / 100
+		adjustments = append(adjustments, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return adjustments, nil
+}
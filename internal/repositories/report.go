@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const reportSelect = `
+	SELECT id, reporter_id, target_type, target_id, reported_user_id, reason, comment,
+	       status, reviewed_by, resolution, reviewed_at, created_at
+	FROM reports
+`
+
+func scanReport(row pgx.Row) (models.Report, error) {
+	var report models.Report
+	err := row.Scan(
+		&report.ID,
+		&report.ReporterID,
+		&report.TargetType,
+		&report.TargetID,
+		&report.ReportedUserID,
+		&report.Reason,
+		&report.Comment,
+		&report.Status,
+		&report.ReviewedBy,
+		&report.Resolution,
+		&report.ReviewedAt,
+		&report.CreatedAt,
+	)
+	return report, err
+}
+
+// CreateReport — открытая жалоба; если у автора уже есть открытая жалоба на этот объект —
+// myerrors.ErrReportExists
+func (r *Repository) CreateReport(ctx context.Context, report models.Report) (uint64, error) {
+	const query = `
+		INSERT INTO reports (reporter_id, target_type, target_id, reported_user_id, reason, comment)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	var reportID uint64
+	err := r.postgres.QueryRow(ctx, query,
+		report.ReporterID,
+		report.TargetType,
+		report.TargetID,
+		report.ReportedUserID,
+		report.Reason,
+		report.Comment,
+	).Scan(&reportID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return 0, myerrors.ErrReportExists
+		}
+		return 0, invalidReference(err)
+	}
+
+	return reportID, nil
+}
+
+func (r *Repository) GetReport(ctx context.Context, reportID uint64) (models.Report, error) {
+	query := reportSelect + ` WHERE id = $1`
+
+	return scanReport(r.postgres.QueryRow(ctx, query, reportID))
+}
+
+// ListReports — жалобы с указанным статусом от старых к новым, как очередь;
+// targetType сужает выборку до одного типа объектов
+func (r *Repository) ListReports(ctx context.Context, status models.ReportStatus, targetType *models.ReportTargetType) ([]models.Report, error) {
+	query := reportSelect + `
+		WHERE status = $1
+		  AND ($2::text IS NULL OR target_type = $2)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.postgres.Query(ctx, query, status, targetType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := make([]models.Report, 0)
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// ResolveReport закрывает открытую жалобу решением модератора. pgx.ErrNoRows — жалоба уже не открыта.
+func (r *Repository) ResolveReport(ctx context.Context, reportID, reviewerID uint64, status models.ReportStatus, resolution *string) error {
+	const query = `
+		UPDATE reports
+		SET status = $2,
+		    reviewed_by = $3,
+		    resolution = $4,
+		    reviewed_at = now()
+		WHERE id = $1
+		  AND status = 'open'
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, reportID, status, reviewerID, resolution)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetReportableMessage — автор сообщения, на которое жалуется участник чата.
+// pgx.ErrNoRows — сообщения нет или пользователь не состоит в его чате.
+func (r *Repository) GetReportableMessage(ctx context.Context, messageID, userID uint64) (*uint64, error) {
+	const query = `
+		SELECT m.sender_id
+		FROM messages m
+		JOIN chat_members cm ON cm.chat_id = m.chat_id AND cm.user_id = $2
+		WHERE m.id = $1
+	`
+
+	var senderID *uint64
+	err := r.postgres.QueryRow(ctx, query, messageID, userID).Scan(&senderID)
+	return senderID, err
+}
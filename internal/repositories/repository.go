@@ -6,6 +6,12 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// pgUniqueViolation — код ошибки PostgreSQL при нарушении UNIQUE
+const pgUniqueViolation = "23505"
+
+// pgForeignKeyViolation — код ошибки PostgreSQL при ссылке на несуществующую запись
+const pgForeignKeyViolation = "23503"
+
 type Repository struct {
 	postgres *pgxpool.Pool
 	logger   *slog.Logger
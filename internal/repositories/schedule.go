@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+	"time"
+)
+
+// FindScheduleConflicts ищет события пользователя, пересекающиеся с интервалом [startsAt, endsAt):
+// открытые матчи, где он участник, и подтверждённые записи календаря активностей.
+// Матч excludeMatchID не учитывается — это тот матч, в который пользователь вступает.
+func (r *Repository) FindScheduleConflicts(ctx context.Context, userID uint64, startsAt, endsAt time.Time, excludeMatchID uint64) ([]models.ScheduleConflict, error) {
+	const query = `
+		SELECT 'match', m.id, COALESCE(mt.name, ''), m.starts_at, m.ends_at
+		FROM user_matches um
+		JOIN matches m ON m.id = um.match_id
+		LEFT JOIN match_types mt ON mt.id = m.match_type_id
+		WHERE um.user_id = $1
+		  AND m.id <> $4
+		  AND m.status IN ('scheduled', 'active')
+		  AND m.starts_at < $3
+		  AND m.ends_at > $2
+
+		UNION ALL
+
+		SELECT 'activity', ac.id::bigint, COALESCE(a.name, ''), ac.start_time, ac.end_time
+		FROM user_activity_calendars uac
+		JOIN activity_calendars ac ON ac.id = uac.calendar_id
+		LEFT JOIN activities a ON a.id = ac.activity_id
+		WHERE uac.user_id = $1
+		  AND ac.start_time < $3
+		  AND ac.end_time > $2
+
+		ORDER BY 4, 2
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, startsAt, endsAt, excludeMatchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := make([]models.ScheduleConflict, 0)
+	for rows.Next() {
+		var conflict models.ScheduleConflict
+		if err = rows.Scan(&conflict.Kind, &conflict.ID, &conflict.Title, &conflict.StartsAt, &conflict.EndsAt); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return conflicts, nil
+}
+
+// ListScheduleEvents — расписание пользователя в интервале [from, to): открытые матчи,
+// где он участник, и подтверждённые активности из календаря
+func (r *Repository) ListScheduleEvents(ctx context.Context, userID uint64, from, to time.Time) ([]models.ScheduleEvent, error) {
+	const query = `
+		SELECT 'match', m.id, COALESCE(mt.name, ''), m.sport_id, m.sport_object_id, m.court_id, m.starts_at, m.ends_at
+		FROM user_matches um
+		JOIN matches m ON m.id = um.match_id
+		LEFT JOIN match_types mt ON mt.id = m.match_type_id
+		WHERE um.user_id = $1
+		  AND m.status IN ('scheduled', 'active')
+		  AND m.starts_at < $3
+		  AND m.ends_at > $2
+
+		UNION ALL
+
+		SELECT 'activity', ac.id::bigint, COALESCE(a.name, ''), ac.sport_id, ac.sport_object_id, ac.court_id,
+		       ac.start_time, ac.end_time
+		FROM user_activity_calendars uac
+		JOIN activity_calendars ac ON ac.id = uac.calendar_id
+		LEFT JOIN activities a ON a.id = ac.activity_id
+		WHERE uac.user_id = $1
+		  AND ac.start_time < $3
+		  AND ac.end_time > $2
+
+		ORDER BY 7, 2
+	`
+
+	rows, err := r.postgres.Query(ctx, query, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]models.ScheduleEvent, 0)
+	for rows.Next() {
+		var e models.ScheduleEvent
+		err = rows.Scan(&e.Kind, &e.ID, &e.Title, &e.SportID, &e.SportObjectID, &e.CourtID, &e.StartsAt, &e.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetCourtSportObjectID возвращает объект, к которому относится корт. pgx.ErrNoRows — корта нет.
+func (r *Repository) GetCourtSportObjectID(ctx context.Context, courtID int) (int, error) {
+	const query = `SELECT sport_object_id FROM courts WHERE id = $1`
+
+	var sportObjectID int
+	if err := r.postgres.QueryRow(ctx, query, courtID).Scan(&sportObjectID); err != nil {
+		return 0, err
+	}
+
+	return sportObjectID, nil
+}
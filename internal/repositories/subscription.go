@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (r *Repository) GetSubscriptions(ctx context.Context) ([]models.Subscription, error) {
+	const query = `SELECT id, name FROM subscriptions ORDER BY id`
+
+	rows, err := r.postgres.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]models.Subscription, 0)
+	for rows.Next() {
+		var subscription models.Subscription
+		if err := rows.Scan(&subscription.ID, &subscription.Name); err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *Repository) GetSubscriptionByID(ctx context.Context, subscriptionID int) (models.Subscription, error) {
+	const query = `SELECT id, name FROM subscriptions WHERE id = $1`
+
+	var subscription models.Subscription
+	if err := r.postgres.QueryRow(ctx, query, subscriptionID).Scan(&subscription.ID, &subscription.Name); err != nil {
+		return models.Subscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// GetActiveUserSubscription возвращает действующую подписку; pgx.ErrNoRows — подписки нет
+func (r *Repository) GetActiveUserSubscription(ctx context.Context, userID uint64) (models.UserSubscription, error) {
+	const query = `
+		SELECT us.id, us.user_id, us.subscription_id, s.name, us.status, us.starts_at, us.ends_at
+		FROM user_subscriptions us
+		JOIN subscriptions s ON s.id = us.subscription_id
+		WHERE us.user_id = $1
+		  AND us.status = 'active'
+	`
+
+	var subscription models.UserSubscription
+	err := r.postgres.QueryRow(ctx, query, userID).Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.SubscriptionID,
+		&subscription.SubscriptionName,
+		&subscription.Status,
+		&subscription.StartsAt,
+		&subscription.EndsAt,
+	)
+	if err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// ActivateUserSubscription оформляет тариф: прежняя активная подписка помечается заменённой,
+// гость становится клиентом. Роли ассистента и администратора не трогаются.
+func (r *Repository) ActivateUserSubscription(ctx context.Context, userID uint64, subscriptionID int, endsAt time.Time) (models.UserSubscription, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return models.UserSubscription{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Блокируем пользователя, чтобы параллельные оформления не создали две активные подписки
+	var lockedID uint64
+	if err = tx.QueryRow(ctx, `SELECT id FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, userID).Scan(&lockedID); err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	const replaceQuery = `
+		UPDATE user_subscriptions
+		SET status = 'replaced'
+		WHERE user_id = $1
+		  AND status = 'active'
+	`
+	if _, err = tx.Exec(ctx, replaceQuery, userID); err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	const insertQuery = `
+		INSERT INTO user_subscriptions (user_id, subscription_id, ends_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, user_id, subscription_id,
+		          (SELECT name FROM subscriptions WHERE id = $2),
+		          status, starts_at, ends_at
+	`
+	var subscription models.UserSubscription
+	err = tx.QueryRow(ctx, insertQuery, userID, subscriptionID, endsAt).Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.SubscriptionID,
+		&subscription.SubscriptionName,
+		&subscription.Status,
+		&subscription.StartsAt,
+		&subscription.EndsAt,
+	)
+	if err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	const upgradeQuery = `
+		UPDATE users
+		SET subscription_id = $2,
+		    role_id = CASE
+		        WHEN role_id IS NULL OR role_id = (SELECT id FROM roles WHERE name = 'guest')
+		            THEN (SELECT id FROM roles WHERE name = 'client')
+		        ELSE role_id
+		    END,
+		    updated_at = now()
+		WHERE id = $1
+	`
+	if _, err = tx.Exec(ctx, upgradeQuery, userID, subscriptionID); err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// ExpireUserSubscriptions закрывает истёкшие подписки и возвращает клиентов в гости.
+// Анкета, матчи и остальная история пользователя не меняются.
+// Возвращает id пользователей, у которых закончилась подписка.
+func (r *Repository) ExpireUserSubscriptions(ctx context.Context, now time.Time, limit int) ([]uint64, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	const expireQuery = `
+		WITH due AS (
+			SELECT id
+			FROM user_subscriptions
+			WHERE status = 'active'
+			  AND ends_at <= $1
+			ORDER BY ends_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE user_subscriptions us
+		SET status = 'expired'
+		FROM due
+		WHERE us.id = due.id
+		RETURNING us.user_id
+	`
+
+	rows, err := tx.Query(ctx, expireQuery, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uint64, 0)
+	for rows.Next() {
+		var userID uint64
+		if err = rows.Scan(&userID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(userIDs) > 0 {
+		const downgradeQuery = `
+			UPDATE users
+			SET subscription_id = NULL,
+			    role_id = CASE
+			        WHEN role_id = (SELECT id FROM roles WHERE name = 'client')
+			            THEN (SELECT id FROM roles WHERE name = 'guest')
+			        ELSE role_id
+			    END,
+			    updated_at = now()
+			WHERE id = ANY($1)
+		`
+		if _, err = tx.Exec(ctx, downgradeQuery, userIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
+
+func (r *Repository) UpdateExtendedProfile(ctx context.Context, userID uint64, profile models.ExtendedProfile) error {
+	const query = `
+		UPDATE users
+		SET height_cm = COALESCE($2, height_cm),
+		    weight_kg = COALESCE($3, weight_kg),
+		    sport_activity_level_id = COALESCE($4, sport_activity_level_id),
+		    sport_target_id = COALESCE($5, sport_target_id),
+		    location_preference_type_id = COALESCE($6, location_preference_type_id),
+		    town_id = COALESCE($7, town_id),
+		    is_have_injury = COALESCE($8, is_have_injury),
+		    injury_description = CASE
+		        WHEN NOT COALESCE($8::boolean, is_have_injury) THEN NULL
+		        WHEN $9::text IS NULL THEN injury_description
+		        ELSE NULLIF($9::text, '')
+		    END,
+		    updated_at = now()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	ct, err := r.postgres.Exec(
+		ctx,
+		query,
+		userID,
+		profile.HeightCm,
+		profile.WeightKg,
+		profile.SportActivityLevelID,
+		profile.SportTargetID,
+		profile.LocationPreferenceTypeID,
+		profile.TownID,
+		profile.IsHaveInjury,
+		profile.InjuryDescription,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return myerrors.ErrInvalidReference
+		}
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+)
+
+// ListSuggestionCandidates — игроки, у которых есть хоть что-то общее со зрителем: близкий рейтинг, вид спорта,
+// время тренировок, город, районы, дружба или совместные матчи. Удалённые, заблокированные, отключившие
+// подбор и состоящие со зрителем в блокировке не попадают. При SportID остаются только играющие в этот вид спорта.
+// Оценка по весам из запроса считается здесь же для всех кандидатов, и лимит отсекает худших по ней;
+// причины оценки раскладывает сервис.
+func (r *Repository) ListSuggestionCandidates(ctx context.Context, query models.SuggestionQuery) ([]models.SuggestionCandidate, error) {
+	const q = `
+		WITH candidates AS (
+			SELECT u.id, u.name, u.surname, u.photo_thumbnail, u.town_id,
+			       COALESCE(v.show_rating, true) AS show_rating,
+			       COALESCE(v.show_town, true) AS show_town,
+			       COALESCE(v.show_training_district, true) AS show_district
+			FROM users u
+			LEFT JOIN user_profile_visibility v ON v.user_id = u.id
+			WHERE u.id <> $1
+			  AND u.deleted_at IS NULL
+			  AND u.blocked_at IS NULL
+			  AND COALESCE(v.show_in_suggestions, true)
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM user_blocks b
+				WHERE (b.blocker_id = $1 AND b.blocked_id = u.id)
+				   OR (b.blocker_id = u.id AND b.blocked_id = $1)
+			  )
+			  AND (
+				$2::int IS NULL
+				OR EXISTS (SELECT 1 FROM user_sports us WHERE us.user_id = u.id AND us.sport_id = $2)
+				OR EXISTS (SELECT 1 FROM player_ratings pr WHERE pr.user_id = u.id AND pr.sport_id = $2)
+			  )
+		),
+		scored AS (
+			SELECT c.id, c.name, c.surname, c.photo_thumbnail,
+			       CASE WHEN c.show_town THEN c.town_id END AS town_id,
+			       CASE WHEN c.show_rating THEN
+			           (SELECT pr.rating FROM player_ratings pr WHERE pr.user_id = c.id AND pr.sport_id = $2)
+			       END AS rating,
+			       ARRAY(
+			           SELECT s.name
+			           FROM user_sports own
+			           JOIN user_sports other ON other.sport_id = own.sport_id AND other.user_id = c.id
+			           JOIN sports s ON s.id = own.sport_id
+			           WHERE own.user_id = $1
+			           ORDER BY s.name
+			       ) AS shared_sports,
+			       ARRAY(
+			           SELECT ts.name
+			           FROM user_training_time_slots own
+			           JOIN user_training_time_slots other
+			             ON other.training_time_slot_id = own.training_time_slot_id AND other.user_id = c.id
+			           JOIN training_time_slots ts ON ts.id = own.training_time_slot_id
+			           WHERE own.user_id = $1
+			           ORDER BY ts.id
+			       ) AS shared_time_slots,
+			       c.show_town AND c.town_id = (SELECT town_id FROM users WHERE id = $1) AS same_town,
+			       CASE WHEN c.show_district THEN ARRAY(
+			           SELECT own.location_name
+			           FROM user_preferred_locations own
+			           JOIN user_preferred_locations other
+			             ON other.location_name = own.location_name AND other.user_id = c.id
+			           WHERE own.user_id = $1
+			           ORDER BY own.location_name
+			       ) ELSE '{}' END AS shared_locations,
+			       EXISTS (
+			           SELECT 1
+			           FROM friends f
+			           WHERE f.user_id = LEAST($1::int, c.id)
+			             AND f.friend_id = GREATEST($1::int, c.id)
+			       ) AS friend,
+			       (SELECT count(DISTINCT own.result_id)
+			        FROM match_result_players own
+			        JOIN match_result_players other
+			          ON other.result_id = own.result_id AND other.team = own.team AND other.user_id = c.id
+			        JOIN match_results r ON r.id = own.result_id AND r.status = 'confirmed'
+			        WHERE own.user_id = $1) AS partner_matches
+			FROM candidates c
+		),
+		ranked AS (
+			SELECT scored.*,
+			       CASE WHEN $4::float8 IS NOT NULL AND rating IS NOT NULL
+			            THEN GREATEST($5::float8 * (1 - abs(rating - $4) / $6::float8), 0)
+			            ELSE 0 END
+			       + $7::float8 * LEAST(cardinality(shared_sports), $8::int)
+			       + $9::float8 * LEAST(cardinality(shared_time_slots), $10::int)
+			       + CASE WHEN same_town THEN $11::float8 ELSE 0 END
+			       + CASE WHEN cardinality(shared_locations) > 0 THEN $12::float8 ELSE 0 END
+			       + CASE WHEN friend THEN $13::float8 ELSE 0 END
+			       + $14::float8 * LEAST(partner_matches, $15::int) AS score
+			FROM scored
+		)
+		SELECT id, name, surname, photo_thumbnail, town_id, rating,
+		       shared_sports, shared_time_slots, COALESCE(same_town, false), shared_locations,
+		       friend, partner_matches
+		FROM ranked
+		WHERE score > 0
+		ORDER BY score DESC, id
+		LIMIT $3
+	`
+
+	w := query.Weights
+	args := []any{
+		query.ViewerID, query.SportID, query.Limit, query.Rating,
+		w.Rating, w.RatingSpread, w.Sport, w.SportMax, w.TimeSlot, w.TimeSlotMax,
+		w.Town, w.Location, w.Friend, w.Partner, w.PartnerMax,
+	}
+
+	rows, err := r.postgres.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := make([]models.SuggestionCandidate, 0)
+	for rows.Next() {
+		var c models.SuggestionCandidate
+		err = rows.Scan(
+			&c.UserID,
+			&c.Name,
+			&c.Surname,
+			&c.PhotoThumbnail,
+			&c.TownID,
+			&c.Rating,
+			&c.SharedSports,
+			&c.SharedTimeSlots,
+			&c.SameTown,
+			&c.SharedLocations,
+			&c.Friend,
+			&c.PartnerMatches,
+		)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,823 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const tournamentSelect = `
+	SELECT t.id, t.name, t.format, t.status, t.sport_id, t.town_id, t.match_type_id, t.team_size,
+	       t.swiss_rounds, t.challenge_range, t.current_round, t.organizer_id,
+	       (SELECT count(*) FROM tournament_entries e WHERE e.tournament_id = t.id),
+	       t.starts_at, t.round_interval_minutes, t.match_duration_minutes, t.started_at, t.completed_at, t.created_at
+	FROM tournaments t
+`
+
+func scanTournament(row pgx.Row) (models.Tournament, error) {
+	var t models.Tournament
+	err := row.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Format,
+		&t.Status,
+		&t.SportID,
+		&t.TownID,
+		&t.MatchTypeID,
+		&t.TeamSize,
+		&t.SwissRounds,
+		&t.ChallengeRange,
+		&t.CurrentRound,
+		&t.OrganizerID,
+		&t.EntriesCount,
+		&t.StartsAt,
+		&t.RoundIntervalMinutes,
+		&t.MatchDurationMinutes,
+		&t.StartedAt,
+		&t.CompletedAt,
+		&t.CreatedAt,
+	)
+	return t, err
+}
+
+const fixtureSelect = `
+	SELECT f.id, f.tournament_id, f.round, f.position, f.entry1_id, f.entry2_id, f.winner_entry_id,
+	       f.match_id, f.is_challenge, f.status, f.completed_at
+	FROM tournament_fixtures f
+`
+
+func scanFixture(row pgx.Row) (models.TournamentFixture, error) {
+	var f models.TournamentFixture
+	err := row.Scan(
+		&f.ID,
+		&f.TournamentID,
+		&f.Round,
+		&f.Position,
+		&f.Entry1ID,
+		&f.Entry2ID,
+		&f.WinnerEntryID,
+		&f.MatchID,
+		&f.IsChallenge,
+		&f.Status,
+		&f.CompletedAt,
+	)
+	return f, err
+}
+
+func collectFixtures(rows pgx.Rows) ([]models.TournamentFixture, error) {
+	defer rows.Close()
+
+	fixtures := make([]models.TournamentFixture, 0)
+	for rows.Next() {
+		f, err := scanFixture(rows)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fixtures, nil
+}
+
+// CreateTournament создаёт турнир в статусе draft. Несуществующий вид спорта, город или тип матча —
+// myerrors.ErrInvalidReference.
+func (r *Repository) CreateTournament(ctx context.Context, t models.Tournament) (uint64, error) {
+	const query = `
+		INSERT INTO tournaments (
+			name, format, sport_id, town_id, match_type_id, team_size, swiss_rounds, challenge_range, organizer_id,
+			starts_at, round_interval_minutes, match_duration_minutes
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`
+
+	var id uint64
+	err := r.postgres.QueryRow(ctx, query,
+		t.Name,
+		t.Format,
+		t.SportID,
+		t.TownID,
+		t.MatchTypeID,
+		t.TeamSize,
+		t.SwissRounds,
+		t.ChallengeRange,
+		t.OrganizerID,
+		t.StartsAt,
+		t.RoundIntervalMinutes,
+		t.MatchDurationMinutes,
+	).Scan(&id)
+	if err != nil {
+		return 0, invalidReference(err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetTournament(ctx context.Context, tournamentID uint64) (models.Tournament, error) {
+	return scanTournament(r.postgres.QueryRow(ctx, tournamentSelect+` WHERE t.id = $1`, tournamentID))
+}
+
+// ListTournaments — турниры по фильтру, новые сначала
+func (r *Repository) ListTournaments(ctx context.Context, filter models.TournamentFilter) ([]models.Tournament, error) {
+	query := tournamentSelect + `
+		WHERE ($1::text IS NULL OR t.status = $1)
+		  AND ($2::int IS NULL OR t.sport_id = $2)
+		  AND ($3::int IS NULL OR t.town_id = $3)
+		ORDER BY t.created_at DESC, t.id DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.postgres.Query(ctx, query, filter.Status, filter.SportID, filter.TownID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tournaments := make([]models.Tournament, 0)
+	for rows.Next() {
+		t, err := scanTournament(rows)
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tournaments, nil
+}
+
+// AddTournamentEntry заявляет игрока или пару в турнир, который ещё набирает участников.
+// pgx.ErrNoRows — турнир уже стартовал; myerrors.ErrAlreadyEntered — кто-то из игроков уже заявлен.
+func (r *Repository) AddTournamentEntry(ctx context.Context, tournamentID uint64, playerIDs []uint64) (uint64, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// блокировка турнира упорядочивает заявки со стартом
+	const lockQuery = `
+		SELECT id
+		FROM tournaments
+		WHERE id = $1
+		  AND status = 'draft'
+		FOR UPDATE
+	`
+
+	if err = tx.QueryRow(ctx, lockQuery, tournamentID).Scan(&tournamentID); err != nil {
+		return 0, err
+	}
+
+	var entryID uint64
+	if err = tx.QueryRow(ctx, `INSERT INTO tournament_entries (tournament_id) VALUES ($1) RETURNING id`, tournamentID).Scan(&entryID); err != nil {
+		return 0, err
+	}
+
+	const playersQuery = `
+		INSERT INTO tournament_entry_players (entry_id, tournament_id, user_id)
+		SELECT $1, $2, player_id
+		FROM unnest($3::bigint[]) AS player_id
+	`
+
+	if _, err = tx.Exec(ctx, playersQuery, entryID, tournamentID, playerIDs); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return 0, myerrors.ErrAlreadyEntered
+		}
+		return 0, invalidReference(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return entryID, nil
+}
+
+// RemoveTournamentEntry снимает участника до старта турнира. pgx.ErrNoRows — участника нет или турнир уже идёт.
+func (r *Repository) RemoveTournamentEntry(ctx context.Context, tournamentID, entryID uint64) error {
+	const query = `
+		DELETE FROM tournament_entries e
+		USING tournaments t
+		WHERE e.id = $2
+		  AND e.tournament_id = $1
+		  AND t.id = e.tournament_id
+		  AND t.status = 'draft'
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, tournamentID, entryID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListTournamentEntries — участники турнира: по месту на лестнице, затем по посеву, затем по порядку заявки
+func (r *Repository) ListTournamentEntries(ctx context.Context, tournamentID uint64) ([]models.TournamentEntry, error) {
+	const query = `
+		SELECT e.id, e.tournament_id, array_agg(ep.user_id ORDER BY ep.user_id),
+		       e.seed, e.rating, e.position, e.eliminated, e.created_at
+		FROM tournament_entries e
+		JOIN tournament_entry_players ep ON ep.entry_id = e.id
+		WHERE e.tournament_id = $1
+		GROUP BY e.id
+		ORDER BY e.position NULLS LAST, e.seed NULLS LAST, e.id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.TournamentEntry, 0)
+	for rows.Next() {
+		var e models.TournamentEntry
+		if err = rows.Scan(&e.ID, &e.TournamentID, &e.PlayerIDs, &e.Seed, &e.Rating, &e.Position, &e.Eliminated, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *Repository) GetTournamentFixture(ctx context.Context, fixtureID uint64) (models.TournamentFixture, error) {
+	return scanFixture(r.postgres.QueryRow(ctx, fixtureSelect+` WHERE f.id = $1`, fixtureID))
+}
+
+// GetMatchFixture — встреча, которой принадлежит матч; pgx.ErrNoRows — матч вне турнира
+func (r *Repository) GetMatchFixture(ctx context.Context, matchID uint64) (models.TournamentFixture, error) {
+	return scanFixture(r.postgres.QueryRow(ctx, fixtureSelect+` WHERE f.match_id = $1`, matchID))
+}
+
+// ListTournamentFixtures — встречи турнира по кругам; вызовы лестницы — в порядке создания
+func (r *Repository) ListTournamentFixtures(ctx context.Context, tournamentID uint64) ([]models.TournamentFixture, error) {
+	query := fixtureSelect + `
+		WHERE f.tournament_id = $1
+		ORDER BY f.round, f.position, f.id
+	`
+
+	rows, err := r.postgres.Query(ctx, query, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return collectFixtures(rows)
+}
+
+// StartTournament сохраняет посев, создаёт встречи плана и матчи для встреч с известными соперниками.
+// Свободные проходы сразу засчитываются. pgx.ErrNoRows — турнир уже стартовал или состав участников
+// изменился после расчёта плана.
+func (r *Repository) StartTournament(ctx context.Context, tournamentID uint64, plan models.TournamentPlan) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := lockTournament(ctx, tx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if t.Status != models.TournamentStatusDraft || t.EntriesCount != len(plan.Seeds) {
+		return pgx.ErrNoRows
+	}
+
+	const seedQuery = `
+		UPDATE tournament_entries
+		SET seed = $3,
+		    rating = $4,
+		    position = CASE WHEN $5 THEN $3 END
+		WHERE id = $2
+		  AND tournament_id = $1
+	`
+
+	ladder := t.Format == models.TournamentLadder
+	batch := &pgx.Batch{}
+	for _, seed := range plan.Seeds {
+		batch.Queue(seedQuery, tournamentID, seed.EntryID, seed.Seed, seed.Rating, ladder)
+	}
+	if err = tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	const startQuery = `
+		UPDATE tournaments
+		SET status = 'in_progress',
+		    started_at = now()
+		WHERE id = $1
+	`
+
+	if _, err = tx.Exec(ctx, startQuery, tournamentID); err != nil {
+		return err
+	}
+	t.Status = models.TournamentStatusInProgress
+
+	if err = insertFixtures(ctx, tx, tournamentID, plan.Fixtures); err != nil {
+		return err
+	}
+
+	switch t.Format {
+	case models.TournamentSingleElimination:
+		// победитель встречи position p проходит во встречу (p+1)/2 следующего круга
+		const linkQuery = `
+			UPDATE tournament_fixtures f
+			SET next_fixture_id = n.id,
+			    next_slot = CASE WHEN f.position % 2 = 1 THEN 1 ELSE 2 END
+			FROM tournament_fixtures n
+			WHERE f.tournament_id = $1
+			  AND n.tournament_id = f.tournament_id
+			  AND n.round = f.round + 1
+			  AND n.position = (f.position + 1) / 2
+		`
+
+		if _, err = tx.Exec(ctx, linkQuery, tournamentID); err != nil {
+			return err
+		}
+		err = scheduleRound(ctx, tx, t, 1)
+	case models.TournamentRoundRobin:
+		// круговой турнир не ждёт результатов: матчи создаются сразу на все круги
+		rounds := 0
+		for _, f := range plan.Fixtures {
+			rounds = max(rounds, f.Round)
+		}
+		for round := 1; round <= rounds; round++ {
+			if err = scheduleRound(ctx, tx, t, round); err != nil {
+				return err
+			}
+		}
+	case models.TournamentSwiss:
+		err = scheduleRound(ctx, tx, t, 1)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CreateLadderChallenge записывает вызов лестницы и создаёт его матч на заданное время. Вызывающий записывается первым.
+// pgx.ErrNoRows — лестница не идёт; myerrors.ErrOpenChallenge — у кого-то из двоих уже есть открытый вызов.
+func (r *Repository) CreateLadderChallenge(ctx context.Context, tournamentID, challengerID, defenderID uint64, startsAt, endsAt time.Time) (uint64, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := lockTournament(ctx, tx, tournamentID)
+	if err != nil {
+		return 0, err
+	}
+	if t.Format != models.TournamentLadder || t.Status != models.TournamentStatusInProgress {
+		return 0, pgx.ErrNoRows
+	}
+
+	// вызов открыт, пока его матч не завершён и не отменён
+	const openQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM tournament_fixtures f
+			JOIN matches m ON m.id = f.match_id
+			WHERE f.tournament_id = $1
+			  AND f.is_challenge
+			  AND f.status = 'scheduled'
+			  AND m.status IN ('scheduled', 'active')
+			  AND (f.entry1_id IN ($2, $3) OR f.entry2_id IN ($2, $3))
+		)
+	`
+
+	var open bool
+	if err = tx.QueryRow(ctx, openQuery, tournamentID, challengerID, defenderID).Scan(&open); err != nil {
+		return 0, err
+	}
+	if open {
+		return 0, myerrors.ErrOpenChallenge
+	}
+
+	const insertQuery = `
+		INSERT INTO tournament_fixtures (tournament_id, round, position, entry1_id, entry2_id, is_challenge)
+		VALUES ($1, 0, 0, $2, $3, true)
+		RETURNING id
+	`
+
+	var fixtureID uint64
+	if err = tx.QueryRow(ctx, insertQuery, tournamentID, challengerID, defenderID).Scan(&fixtureID); err != nil {
+		return 0, err
+	}
+
+	if err = createFixtureMatch(ctx, tx, t, fixtureID, startsAt, endsAt); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return fixtureID, nil
+}
+
+// ScheduleSwissRound записывает составленный сервисом круг швейцарки и создаёт его матчи.
+// pgx.ErrNoRows — турнир не идёт, предыдущий круг не доигран или этот круг уже составлен.
+func (r *Repository) ScheduleSwissRound(ctx context.Context, tournamentID uint64, round int, fixtures []models.TournamentFixture) error {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	t, err := lockTournament(ctx, tx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if t.Format != models.TournamentSwiss || t.Status != models.TournamentStatusInProgress || t.CurrentRound != round-1 {
+		return pgx.ErrNoRows
+	}
+
+	var open bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM tournament_fixtures WHERE tournament_id = $1 AND round = $2 AND status <> 'completed'
+		)
+	`, tournamentID, round-1).Scan(&open)
+	if err != nil {
+		return err
+	}
+	if open {
+		return pgx.ErrNoRows
+	}
+
+	if err = insertFixtures(ctx, tx, tournamentID, fixtures); err != nil {
+		return err
+	}
+	if err = scheduleRound(ctx, tx, t, round); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CompleteLadder закрывает лестницу по окончании сезона. pgx.ErrNoRows — лестница уже не идёт.
+func (r *Repository) CompleteLadder(ctx context.Context, tournamentID uint64) error {
+	const query = `
+		UPDATE tournaments
+		SET status = 'completed',
+		    completed_at = now()
+		WHERE id = $1
+		  AND format = 'ladder'
+		  AND status = 'in_progress'
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, tournamentID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func lockTournament(ctx context.Context, tx pgx.Tx, tournamentID uint64) (models.Tournament, error) {
+	return scanTournament(tx.QueryRow(ctx, tournamentSelect+` WHERE t.id = $1 FOR UPDATE`, tournamentID))
+}
+
+func insertFixtures(ctx context.Context, tx pgx.Tx, tournamentID uint64, fixtures []models.TournamentFixture) error {
+	const query = `
+		INSERT INTO tournament_fixtures (tournament_id, round, position, entry1_id, entry2_id)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	batch := &pgx.Batch{}
+	for _, f := range fixtures {
+		batch.Queue(query, tournamentID, f.Round, f.Position, f.Entry1ID, f.Entry2ID)
+	}
+
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+// scheduleRound создаёт матчи встреч круга, где оба соперника известны, и засчитывает свободные проходы
+func scheduleRound(ctx context.Context, tx pgx.Tx, t models.Tournament, round int) error {
+	const query = `
+		SELECT id, entry2_id IS NOT NULL
+		FROM tournament_fixtures
+		WHERE tournament_id = $1
+		  AND round = $2
+		  AND status = 'waiting'
+		  AND entry1_id IS NOT NULL
+		  AND NOT is_challenge
+		ORDER BY position
+	`
+
+	rows, err := tx.Query(ctx, query, t.ID, round)
+	if err != nil {
+		return err
+	}
+
+	type pending struct {
+		id     uint64
+		paired bool
+	}
+	fixtures := make([]pending, 0)
+	for rows.Next() {
+		var p pending
+		if err = rows.Scan(&p.id, &p.paired); err != nil {
+			rows.Close()
+			return err
+		}
+		fixtures = append(fixtures, p)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	// сначала матчи, потом проходы: иначе проход мог бы посчитать круг сыгранным
+	startsAt, endsAt := t.RoundSchedule(round, time.Now().UTC())
+	for _, f := range fixtures {
+		if f.paired {
+			if err = createFixtureMatch(ctx, tx, t, f.id, startsAt, endsAt); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range fixtures {
+		if !f.paired {
+			if err = completeFixture(ctx, tx, t, f.id, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// createFixtureMatch создаёт приватный матч встречи: первый участник — команда 1, второй — команда 2.
+// Организатор турнира становится организатором матча, но не участником.
+func createFixtureMatch(ctx context.Context, tx pgx.Tx, t models.Tournament, fixtureID uint64, startsAt, endsAt time.Time) error {
+	const matchQuery = `
+		INSERT INTO matches (
+			match_type_id, organizer_id, required_players, capacity, visibility, sport_id, town_id, starts_at, ends_at
+		)
+		VALUES ($1, $2, $3, $3, 'private', $4, $5, $6, $7)
+		RETURNING id
+	`
+
+	var matchID uint64
+	err := tx.QueryRow(ctx, matchQuery,
+		t.MatchTypeID,
+		t.OrganizerID,
+		t.TeamSize*2,
+		t.SportID,
+		t.TownID,
+		startsAt,
+		endsAt,
+	).Scan(&matchID)
+	if err != nil {
+		return err
+	}
+
+	const playersQuery = `
+		INSERT INTO user_matches (user_id, match_id, team)
+		SELECT ep.user_id, $1, CASE WHEN ep.entry_id = f.entry1_id THEN 1 ELSE 2 END
+		FROM tournament_fixtures f
+		JOIN tournament_entry_players ep ON ep.entry_id IN (f.entry1_id, f.entry2_id)
+		WHERE f.id = $2
+	`
+
+	if _, err = tx.Exec(ctx, playersQuery, matchID, fixtureID); err != nil {
+		return err
+	}
+
+	const fixtureQuery = `
+		UPDATE tournament_fixtures
+		SET match_id = $2,
+		    status = 'scheduled'
+		WHERE id = $1
+		RETURNING round
+	`
+
+	var round int
+	if err = tx.QueryRow(ctx, fixtureQuery, fixtureID, matchID).Scan(&round); err != nil {
+		return err
+	}
+
+	// current_round — последний круг, матчи которого уже созданы
+	const roundQuery = `
+		UPDATE tournaments
+		SET current_round = GREATEST(current_round, $2)
+		WHERE id = $1
+	`
+
+	if _, err = tx.Exec(ctx, roundQuery, t.ID, round); err != nil {
+		return err
+	}
+
+	return syncMatchStatus(ctx, tx, matchID)
+}
+
+// advanceTournament продвигает турнир по подтверждённому результату матча встречи.
+// Матч вне турнира ничего не меняет.
+func advanceTournament(ctx context.Context, tx pgx.Tx, matchID, resultID uint64) error {
+	var tournamentID uint64
+	err := tx.QueryRow(ctx, `SELECT tournament_id FROM tournament_fixtures WHERE match_id = $1`, matchID).Scan(&tournamentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// турнир блокируется раньше встречи: тот же порядок, что при старте и вызовах
+	t, err := lockTournament(ctx, tx, tournamentID)
+	if err != nil {
+		return err
+	}
+
+	// победитель определяется по игрокам результата, команда — только запасной вариант
+	const winnerQuery = `
+		SELECT f.id,
+		       COALESCE(
+		           (SELECT ep.entry_id
+		            FROM match_result_players rp
+		            JOIN tournament_entry_players ep
+		              ON ep.user_id = rp.user_id AND ep.entry_id IN (f.entry1_id, f.entry2_id)
+		            WHERE rp.result_id = r.id
+		              AND rp.team = r.winner_team
+		            LIMIT 1),
+		           CASE r.winner_team WHEN 1 THEN f.entry1_id ELSE f.entry2_id END
+		       )
+		FROM tournament_fixtures f
+		JOIN match_results r ON r.id = $2
+		WHERE f.match_id = $1
+		  AND f.status = 'scheduled'
+		FOR UPDATE OF f
+	`
+
+	var fixtureID, winnerID uint64
+	err = tx.QueryRow(ctx, winnerQuery, matchID, resultID).Scan(&fixtureID, &winnerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return completeFixture(ctx, tx, t, fixtureID, winnerID)
+}
+
+// completeFixture записывает победителя встречи (0 — свободный проход: побеждает первый участник)
+// и двигает турнир дальше по правилам формата
+func completeFixture(ctx context.Context, tx pgx.Tx, t models.Tournament, fixtureID, winnerID uint64) error {
+	const query = `
+		UPDATE tournament_fixtures
+		SET winner_entry_id = COALESCE(NULLIF($2, 0), entry1_id),
+		    status = 'completed',
+		    completed_at = now()
+		WHERE id = $1
+		RETURNING round, entry1_id, entry2_id, winner_entry_id, next_fixture_id, next_slot
+	`
+
+	var (
+		round          int
+		entry1, winner uint64
+		entry2, next   *uint64
+		nextSlot       *int
+	)
+	if err := tx.QueryRow(ctx, query, fixtureID, winnerID).Scan(&round, &entry1, &entry2, &winner, &next, &nextSlot); err != nil {
+		return err
+	}
+
+	// результат матча после завершения турнира только записывается
+	if t.Status != models.TournamentStatusInProgress {
+		return nil
+	}
+
+	switch t.Format {
+	case models.TournamentSingleElimination:
+		return advanceElimination(ctx, tx, t, entry1, entry2, winner, next, nextSlot)
+	case models.TournamentRoundRobin:
+		return completeIfPlayed(ctx, tx, t.ID)
+	case models.TournamentSwiss:
+		return advanceSwiss(ctx, tx, t, round)
+	case models.TournamentLadder:
+		if entry2 != nil && winner == entry1 {
+			return swapLadderPositions(ctx, tx, t.ID, entry1, *entry2)
+		}
+	}
+
+	return nil
+}
+
+func advanceElimination(ctx context.Context, tx pgx.Tx, t models.Tournament, entry1 uint64, entry2 *uint64, winner uint64, next *uint64, nextSlot *int) error {
+	if entry2 != nil {
+		loser := entry1
+		if winner == entry1 {
+			loser = *entry2
+		}
+		if _, err := tx.Exec(ctx, `UPDATE tournament_entries SET eliminated = true WHERE id = $1`, loser); err != nil {
+			return err
+		}
+	}
+
+	// финал
+	if next == nil || nextSlot == nil {
+		return completeTournament(ctx, tx, t.ID)
+	}
+
+	const fillQuery = `
+		UPDATE tournament_fixtures
+		SET entry1_id = CASE WHEN $2 = 1 THEN $3 ELSE entry1_id END,
+		    entry2_id = CASE WHEN $2 = 2 THEN $3 ELSE entry2_id END
+		WHERE id = $1
+		RETURNING round, entry1_id IS NOT NULL AND entry2_id IS NOT NULL
+	`
+
+	var (
+		round int
+		ready bool
+	)
+	if err := tx.QueryRow(ctx, fillQuery, *next, *nextSlot, winner).Scan(&round, &ready); err != nil {
+		return err
+	}
+	if !ready {
+		return nil
+	}
+
+	startsAt, endsAt := t.RoundSchedule(round, time.Now().UTC())
+	return createFixtureMatch(ctx, tx, t, *next, startsAt, endsAt)
+}
+
+// advanceSwiss завершает турнир после последней встречи последнего круга.
+// Следующий круг составляет сервис по таблице (ScheduleSwissRound).
+func advanceSwiss(ctx context.Context, tx pgx.Tx, t models.Tournament, round int) error {
+	if t.SwissRounds != nil && round < *t.SwissRounds {
+		return nil
+	}
+
+	return completeIfPlayed(ctx, tx, t.ID)
+}
+
+// swapLadderPositions — победивший вызывающий занимает место соперника, все между ними сдвигаются на одно вниз
+func swapLadderPositions(ctx context.Context, tx pgx.Tx, tournamentID, challengerID, defenderID uint64) error {
+	const query = `
+		UPDATE tournament_entries e
+		SET position = CASE WHEN e.id = $2 THEN d.position ELSE e.position + 1 END
+		FROM tournament_entries c, tournament_entries d
+		WHERE c.id = $2
+		  AND d.id = $3
+		  AND d.position < c.position
+		  AND e.tournament_id = $1
+		  AND e.position BETWEEN d.position AND c.position
+	`
+
+	_, err := tx.Exec(ctx, query, tournamentID, challengerID, defenderID)
+	return err
+}
+
+func completeIfPlayed(ctx context.Context, tx pgx.Tx, tournamentID uint64) error {
+	var open bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM tournament_fixtures WHERE tournament_id = $1 AND status <> 'completed')
+	`, tournamentID).Scan(&open)
+	if err != nil || open {
+		return err
+	}
+
+	return completeTournament(ctx, tx, tournamentID)
+}
+
+func completeTournament(ctx context.Context, tx pgx.Tx, tournamentID uint64) error {
+	const query = `
+		UPDATE tournaments
+		SET status = 'completed',
+		    completed_at = now()
+		WHERE id = $1
+		  AND status = 'in_progress'
+	`
+
+	_, err := tx.Exec(ctx, query, tournamentID)
+	return err
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+	"strconv"
+	"strings"
+)
+
+// townSimilarityThreshold — минимальная схожесть trigram для опечаток ("Масква" → "Москва")
+const townSimilarityThreshold = 0.3
+
+// SearchTowns ищет города по префиксу и с допуском опечаток.
+// Оба условия (LIKE и оператор %) обслуживает idx_towns_name_trgm; similarity() — только для сортировки.
+// Порядок выдачи: точное совпадение, совпадение по префиксу, похожесть, длина названия.
+func (r *Repository) SearchTowns(ctx context.Context, query string, limit int) ([]models.Town, error) {
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// порог оператора % действует только до конца транзакции
+	const thresholdQuery = `SELECT set_config('pg_trgm.similarity_threshold', $1, true)`
+	if _, err = tx.Exec(ctx, thresholdQuery, strconv.FormatFloat(townSimilarityThreshold, 'f', -1, 64)); err != nil {
+		return nil, err
+	}
+
+	const q = `
+		SELECT id, name, region, timezone, latitude, longitude
+		FROM towns
+		WHERE lower(name) LIKE $2 || '%'
+		   OR lower(name) % $1
+		ORDER BY
+			lower(name) = $1 DESC,
+			lower(name) LIKE $2 || '%' DESC,
+			similarity(lower(name), $1) DESC,
+			length(name),
+			name
+		LIMIT $3
+	`
+
+	normalized := strings.ToLower(query)
+	rows, err := tx.Query(ctx, q, normalized, escapeLike(normalized), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	towns := make([]models.Town, 0)
+	for rows.Next() {
+		var town models.Town
+		if err := rows.Scan(
+			&town.ID,
+			&town.Name,
+			&town.Region,
+			&town.Timezone,
+			&town.Latitude,
+			&town.Longitude,
+		); err != nil {
+			return nil, err
+		}
+		towns = append(towns, town)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return towns, nil
+}
+
+func (r *Repository) GetTownByID(ctx context.Context, townID int) (models.Town, error) {
+	const q = `
+		SELECT id, name, region, timezone, latitude, longitude
+		FROM towns
+		WHERE id = $1
+	`
+
+	var town models.Town
+	if err := r.postgres.QueryRow(ctx, q, townID).Scan(
+		&town.ID,
+		&town.Name,
+		&town.Region,
+		&town.Timezone,
+		&town.Latitude,
+		&town.Longitude,
+	); err != nil {
+		return models.Town{}, err
+	}
+
+	return town, nil
+}
+
+// escapeLike экранирует спецсимволы LIKE, чтобы ввод пользователя искался буквально
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
@@ -2,10 +2,14 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sport-assistance/internal/models"
 	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 func (r *Repository) CreateUser(ctx context.Context, user models.User) (uint64, error) {
@@ -71,39 +75,42 @@ func (r *Repository) CreateUser(ctx context.Context, user models.User) (uint64,
 	return id, nil
 }
 
-func (r *Repository) GetUserByID(ctx context.Context, userID uint64) (dto.UserDto, error) {
-	query := `
-		SELECT
-			id,
-			name,
-			surname,
-			gender,
-			birth_date,
-			height_cm,
-			weight_kg,
-			sport_activity_level_id,
-			sport_target_id,
-			location_preference_type_id,
-			town_id,
-			role_id,
-			phone_number,
-			is_phone_verified,
-			email,
-			is_email_verified,
-			password,
-			is_have_injury,
-			injury_description,
-			photo,
-			created_at,
-			updated_at,
-			deleted_at
-		FROM users
-		WHERE id = $1
-		  AND deleted_at IS NULL
-		`
-
+// userColumns — колонки для чтения анкеты. Пароля здесь нет намеренно:
+// хэш читает только GetUserCredentialsByEmail.
+const userColumns = `
+	id,
+	name,
+	surname,
+	gender,
+	birth_date,
+	height_cm,
+	weight_kg,
+	sport_activity_level_id,
+	sport_target_id,
+	location_preference_type_id,
+	town_id,
+	role_id,
+	subscription_id,
+	phone_number,
+	is_phone_verified,
+	email,
+	is_email_verified,
+	is_have_injury,
+	injury_description,
+	photo,
+	photo_thumbnail,
+	court_position,
+	created_at,
+	updated_at,
+	deleted_at,
+	deletion_requested_at,
+	blocked_at
+`
+
+// scanUser читает строку, выбранную по userColumns
+func scanUser(row pgx.Row) (dto.UserDto, error) {
 	var user models.User
-	err := r.postgres.QueryRow(ctx, query, userID).Scan(
+	err := row.Scan(
 		&user.ID,
 		&user.Name,
 		&user.Surname,
@@ -116,51 +123,110 @@ func (r *Repository) GetUserByID(ctx context.Context, userID uint64) (dto.UserDt
 		&user.LocationPreferenceTypeID,
 		&user.TownID,
 		&user.RoleID,
+		&user.SubscriptionID,
 		&user.PhoneNumber,
 		&user.IsPhoneVerified,
 		&user.Email,
 		&user.IsEmailVerified,
-		&user.Password,
 		&user.IsHaveInjury,
 		&user.InjuryDescription,
 		&user.Photo,
+		&user.PhotoThumbnail,
+		&user.CourtPosition,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.DeletedAt,
+		&user.DeletionRequestedAt,
+		&user.BlockedAt,
 	)
-
 	if err != nil {
 		return dto.UserDto{}, err
 	}
 
-	userDTO, err := dto.UserToDto(user)
+	return dto.UserToDto(user)
+}
+
+func (r *Repository) GetUserByID(ctx context.Context, userID uint64) (dto.UserDto, error) {
+	query := `SELECT ` + userColumns + `
+		FROM users
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	return scanUser(r.postgres.QueryRow(ctx, query, userID))
+}
+
+// GetUserCredentialsByEmail — единственное место, где читается хэш пароля (путь логина)
+func (r *Repository) GetUserCredentialsByEmail(ctx context.Context, email string) (models.UserCredentials, error) {
+	query := `SELECT id, email, password, blocked_at FROM users WHERE email = $1 AND deleted_at IS NULL`
+
+	var credentials models.UserCredentials
+	err := r.postgres.QueryRow(ctx, query, email).Scan(&credentials.ID, &credentials.Email, &credentials.PasswordHash, &credentials.BlockedAt)
 	if err != nil {
-		return dto.UserDto{}, err
+		return models.UserCredentials{}, err
 	}
 
-	return userDTO, nil
+	return credentials, nil
 }
 
-func (r *Repository) GetUserByEmail(ctx context.Context, email string) (dto.UserDto, error) {
-	q := `SELECT id, email, password FROM users WHERE email = $1 AND deleted_at IS NULL`
-	var user models.User
-	err := r.postgres.QueryRow(ctx, q, email).Scan(&user.ID, &user.Email, &user.Password)
+// BlockUserAccount блокирует аккаунт администратором и отзывает все refresh-токены пользователя.
+// Повторная блокировка сохраняет исходное время.
+func (r *Repository) BlockUserAccount(ctx context.Context, userID uint64) error {
+	tx, err := r.postgres.Begin(ctx)
 	if err != nil {
-		return dto.UserDto{}, err
+		return err
 	}
+	defer tx.Rollback(ctx)
 
-	userDTO, err := dto.UserToDto(user)
+	const blockQuery = `
+		UPDATE users
+		SET blocked_at = COALESCE(blocked_at, now()),
+		    updated_at = now()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	ct, err := tx.Exec(ctx, blockQuery, userID)
 	if err != nil {
-		return dto.UserDto{}, err
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
 	}
 
-	return userDTO, nil
+	const revokeQuery = `UPDATE refresh_tokens SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`
+	if _, err = tx.Exec(ctx, revokeQuery, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UnblockUserAccount снимает блокировку администратора
+func (r *Repository) UnblockUserAccount(ctx context.Context, userID uint64) error {
+	const q = `
+		UPDATE users
+		SET blocked_at = NULL,
+		    updated_at = now()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	ct, err := r.postgres.Exec(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
 }
 
 func (r *Repository) GetUserByPhone(ctx context.Context, phone string) (dto.UserDto, error) {
-	q := `SELECT id, email FROM users WHERE phone_number = $1 AND deleted_at IS NULL`
+	q := `SELECT id, email, blocked_at FROM users WHERE phone_number = $1 AND deleted_at IS NULL`
 	var user models.User
-	err := r.postgres.QueryRow(ctx, q, phone).Scan(&user.ID, &user.Email)
+	err := r.postgres.QueryRow(ctx, q, phone).Scan(&user.ID, &user.Email, &user.BlockedAt)
 	if err != nil {
 		return dto.UserDto{}, err
 	}
@@ -191,89 +257,9 @@ func (r *Repository) UserExistsByEmail(ctx context.Context, email string) (bool,
 	return exists, nil
 }
 
-func (r *Repository) GetUsers(ctx context.Context) ([]dto.UserDto, error) {
-	query := `
-		SELECT
-			id,
-			name,
-			surname,
-			gender,
-			birth_date,
-			height_cm,
-			weight_kg,
-			sport_activity_level_id,
-			sport_target_id,
-			location_preference_type_id,
-			town_id,
-			role_id,
-			phone_number,
-			is_phone_verified,
-			email,
-			is_email_verified,
-			password,
-			is_have_injury,
-			injury_description,
-			photo,
-			created_at,
-			updated_at,
-			deleted_at
-		FROM users
-		WHERE deleted_at IS NULL
-		ORDER BY id
-	`
-
-	rows, err := r.postgres.Query(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	users := make([]dto.UserDto, 0)
-	for rows.Next() {
-		var user models.User
-		if err := rows.Scan(
-			&user.ID,
-			&user.Name,
-			&user.Surname,
-			&user.Gender,
-			&user.BirthDate,
-			&user.HeightCm,
-			&user.WeightKg,
-			&user.SportActivityLevelID,
-			&user.SportTargetID,
-			&user.LocationPreferenceTypeID,
-			&user.TownID,
-			&user.RoleID,
-			&user.PhoneNumber,
-			&user.IsPhoneVerified,
-			&user.Email,
-			&user.IsEmailVerified,
-			&user.Password,
-			&user.IsHaveInjury,
-			&user.InjuryDescription,
-			&user.Photo,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-			&user.DeletedAt,
-		); err != nil {
-			return nil, err
-		}
-
-		userDTO, err := dto.UserToDto(user)
-		if err != nil {
-			return nil, err
-		}
-
-		users = append(users, userDTO)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return users, nil
-}
-
+// UpdateUser обновляет анкету пользователя.
+// Телефон, email, флаги их подтверждения и пароль здесь не меняются:
+// контакты меняются только через подтверждение (UpdateUserContact).
 func (r *Repository) UpdateUser(ctx context.Context, userID uint64, user models.User) error {
 	query := `
 		UPDATE users
@@ -289,14 +275,9 @@ func (r *Repository) UpdateUser(ctx context.Context, userID uint64, user models.
 			location_preference_type_id = $10,
 			town_id = $11,
 			role_id = $12,
-			phone_number = $13,
-			is_phone_verified = $14,
-			email = $15,
-			is_email_verified = $16,
-			password = $17,
-			is_have_injury = $18,
-			injury_description = $19,
-			photo = $20,
+			is_have_injury = $13,
+			injury_description = $14,
+			photo = $15,
 			updated_at = now()
 		WHERE id = $1
 		  AND deleted_at IS NULL
@@ -317,11 +298,6 @@ func (r *Repository) UpdateUser(ctx context.Context, userID uint64, user models.
 		user.LocationPreferenceTypeID,
 		user.TownID,
 		user.RoleID,
-		user.PhoneNumber,
-		user.IsPhoneVerified,
-		user.Email,
-		user.IsEmailVerified,
-		user.Password,
 		user.IsHaveInjury,
 		user.InjuryDescription,
 		user.Photo,
@@ -356,3 +332,129 @@ func (r *Repository) DeleteUser(ctx context.Context, userID uint64) error {
 
 	return nil
 }
+
+func (r *Repository) GetUserPhoto(ctx context.Context, userID uint64) (models.UserPhoto, error) {
+	query := `
+		SELECT photo, photo_thumbnail
+		FROM users
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	var photo models.UserPhoto
+	if err := r.postgres.QueryRow(ctx, query, userID).Scan(&photo.Photo, &photo.PhotoThumbnail); err != nil {
+		return models.UserPhoto{}, err
+	}
+
+	return photo, nil
+}
+
+func (r *Repository) UpdateUserPhoto(ctx context.Context, userID uint64, photo models.UserPhoto) error {
+	query := `
+		UPDATE users
+		SET photo = $2,
+		    photo_thumbnail = $3,
+		    updated_at = now()
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	ct, err := r.postgres.Exec(ctx, query, userID, photo.Photo, photo.PhotoThumbnail)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// contactColumns возвращает колонки значения и флага подтверждения для вида контакта
+func contactColumns(contactType models.ContactType) (string, string, error) {
+	switch contactType {
+	case models.ContactTypePhone:
+		return "phone_number", "is_phone_verified", nil
+	case models.ContactTypeEmail:
+		return "email", "is_email_verified", nil
+	default:
+		return "", "", fmt.Errorf("unknown contact type: %s", contactType)
+	}
+}
+
+func (r *Repository) ContactExists(ctx context.Context, contactType models.ContactType, value string, excludeUserID uint64) (bool, error) {
+	column, _, err := contactColumns(contactType)
+	if err != nil {
+		return false, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM users
+			WHERE lower(%s) = lower($1)
+			  AND id <> $2
+			  AND deleted_at IS NULL
+		)
+	`, column)
+
+	var exists bool
+	if err = r.postgres.QueryRow(ctx, query, value, excludeUserID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// UpdateUserContact заменяет телефон или email на подтверждённый и возвращает прежнее значение.
+// Уникальность проверяется повторно в транзакции: между запросом кода и подтверждением
+// контакт мог занять другой пользователь.
+func (r *Repository) UpdateUserContact(ctx context.Context, userID uint64, contactType models.ContactType, value string) (string, error) {
+	column, verifiedColumn, err := contactColumns(contactType)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := r.postgres.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	var previous string
+	selectQuery := fmt.Sprintf(`SELECT %s FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, column)
+	if err = tx.QueryRow(ctx, selectQuery, userID).Scan(&previous); err != nil {
+		return "", err
+	}
+
+	var taken bool
+	existsQuery := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM users WHERE lower(%s) = lower($1) AND id <> $2 AND deleted_at IS NULL)`, column)
+	if err = tx.QueryRow(ctx, existsQuery, value, userID).Scan(&taken); err != nil {
+		return "", err
+	}
+	if taken {
+		return "", myerrors.ErrContactAlreadyUsed
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE users
+		SET %s = $2,
+		    %s = true,
+		    updated_at = now()
+		WHERE id = $1
+	`, column, verifiedColumn)
+	if _, err = tx.Exec(ctx, updateQuery, userID, value); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return "", myerrors.ErrContactAlreadyUsed
+		}
+		return "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", err
+	}
+
+	return previous, nil
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"strings"
+)
+
+// userSortColumns сопоставляет поле сортировки с выражением ORDER BY.
+// Значения из запроса в SQL не попадают — только через эту таблицу.
+var userSortColumns = map[models.UserSortField]string{
+	models.UserSortID:        "id",
+	models.UserSortCreatedAt: "created_at",
+	models.UserSortUpdatedAt: "updated_at",
+	models.UserSortName:      "lower(name)",
+	models.UserSortSurname:   "lower(surname)",
+	models.UserSortBirthDate: "birth_date",
+}
+
+// buildUserFilter собирает WHERE и аргументы для выборки пользователей
+func buildUserFilter(filter models.UserFilter) (string, []any) {
+	conditions := []string{"deleted_at IS NULL"}
+	args := make([]any, 0)
+
+	// add добавляет условие; %[1]d в шаблоне заменяется номером аргумента
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	addFlag := func(column string, value *bool) {
+		if value != nil {
+			add(column+" = $%[1]d", *value)
+		}
+	}
+	addNullFlag := func(column string, notNull *bool) {
+		if notNull == nil {
+			return
+		}
+		if *notNull {
+			conditions = append(conditions, column+" IS NOT NULL")
+		} else {
+			conditions = append(conditions, column+" IS NULL")
+		}
+	}
+
+	if len(filter.SubscriptionIDs) > 0 {
+		add("subscription_id = ANY($%[1]d)", filter.SubscriptionIDs)
+	}
+	if len(filter.RoleIDs) > 0 {
+		add("role_id = ANY($%[1]d)", filter.RoleIDs)
+	}
+	if len(filter.TownIDs) > 0 {
+		add("town_id = ANY($%[1]d)", filter.TownIDs)
+	}
+	if len(filter.SportActivityLevelIDs) > 0 {
+		add("sport_activity_level_id = ANY($%[1]d)", filter.SportActivityLevelIDs)
+	}
+	if len(filter.SportTargetIDs) > 0 {
+		add("sport_target_id = ANY($%[1]d)", filter.SportTargetIDs)
+	}
+	if len(filter.SportIDs) > 0 {
+		add("EXISTS (SELECT 1 FROM user_sports us WHERE us.user_id = users.id AND us.sport_id = ANY($%[1]d))", filter.SportIDs)
+	}
+	addNullFlag("subscription_id", filter.HasSubscription)
+	addNullFlag("blocked_at", filter.IsBlocked)
+	addFlag("is_have_injury", filter.IsHaveInjury)
+	addFlag("is_phone_verified", filter.IsPhoneVerified)
+	addFlag("is_email_verified", filter.IsEmailVerified)
+	if filter.CreatedFrom != nil {
+		add("created_at >= $%[1]d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		add("created_at < $%[1]d", *filter.CreatedTo)
+	}
+	if filter.Search != "" {
+		add(`(name ILIKE $%[1]d OR surname ILIKE $%[1]d OR email ILIKE $%[1]d OR phone_number ILIKE $%[1]d)`,
+			"%"+escapeLike(filter.Search)+"%")
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// userOrderBy всегда добавляет id, чтобы страницы не пересекались при равных значениях
+func userOrderBy(filter models.UserFilter) string {
+	column, ok := userSortColumns[filter.SortField]
+	if !ok {
+		column = userSortColumns[models.UserSortID]
+	}
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+
+	if column == "id" {
+		return fmt.Sprintf("ORDER BY id %s", direction)
+	}
+	return fmt.Sprintf("ORDER BY %s %s, id %s", column, direction, direction)
+}
+
+func (r *Repository) CountUsers(ctx context.Context, filter models.UserFilter) (int, error) {
+	where, args := buildUserFilter(filter)
+	query := `SELECT count(*) FROM users ` + where
+
+	var total int
+	if err := r.postgres.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// StreamUsers отдаёт пользователей по одному, не собирая выборку в память
+func (r *Repository) StreamUsers(ctx context.Context, filter models.UserFilter, fn func(user dto.UserDto) error) error {
+	where, args := buildUserFilter(filter)
+	query := `SELECT ` + userColumns + ` FROM users ` + where + ` ` + userOrderBy(filter)
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit, filter.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	rows, err := r.postgres.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return err
+		}
+		if err = fn(user); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *Repository) ListUsers(ctx context.Context, filter models.UserFilter) ([]dto.UserDto, error) {
+	users := make([]dto.UserDto, 0)
+	err := r.StreamUsers(ctx, filter, func(user dto.UserDto) error {
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
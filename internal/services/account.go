@@ -0,0 +1,133 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/pkg/myerrors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RequestAccountDeletion ставит аккаунт на удаление. До конца льготного периода
+// пользователь может войти и отменить удаление, затем данные обезличиваются.
+func (s *Service) RequestAccountDeletion(ctx context.Context, userID uint64) (responses.AccountDeletionResponse, error) {
+	requestedAt, err := s.repository.RequestUserDeletion(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return responses.AccountDeletionResponse{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return responses.AccountDeletionResponse{}, myerrors.NewRepositoryErr("failed to request account deletion", err)
+	}
+
+	return responses.AccountDeletionResponse{
+		DeletionRequestedAt: requestedAt,
+		AnonymizeAfter:      requestedAt.Add(s.cfg.AccountConfig.DeletionGracePeriod),
+	}, nil
+}
+
+func (s *Service) CancelAccountDeletion(ctx context.Context, userID uint64) error {
+	if err := s.repository.CancelUserDeletion(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewValidationError("account deletion is not requested", err)
+		}
+		return myerrors.NewRepositoryErr("failed to cancel account deletion", err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedAccounts обезличивает аккаунты с истёкшим льготным периодом.
+// Вызывается фоновым воркером; возвращает число обработанных аккаунтов.
+func (s *Service) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	before := time.Now().Add(-s.cfg.AccountConfig.DeletionGracePeriod)
+	ids, err := s.repository.GetUsersPendingAnonymization(ctx, before, s.cfg.AccountConfig.PurgeBatchSize)
+	if err != nil {
+		return 0, myerrors.NewRepositoryErr("failed to fetch accounts pending anonymization", err)
+	}
+
+	purged := 0
+	for _, userID := range ids {
+		// Ключи фото читаем до обезличивания: после него ссылки на объекты пропадут
+		photo, err := s.repository.GetUserPhoto(ctx, userID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("failed to fetch photo of deleted account", "user_id", userID, "err", err)
+		}
+
+		if err = s.repository.AnonymizeUser(ctx, userID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue // удаление отменили, пока шла выборка
+			}
+			return purged, myerrors.NewRepositoryErr(fmt.Sprintf("failed to anonymize user %d", userID), err)
+		}
+
+		s.deleteObjects(ctx, storedObjectKeys(photo)...)
+		s.dropAccessToken(ctx, userID)
+		purged++
+	}
+
+	if purged > 0 {
+		s.refreshLeaderboard(ctx)
+	}
+	return purged, nil
+}
+
+// ExportUserData собирает ZIP с JSON-файлами персональных данных пользователя.
+// Анкет, заказов и кошелька в схеме пока нет, поэтому в архиве их тоже нет.
+func (s *Service) ExportUserData(ctx context.Context, userID uint64) ([]byte, error) {
+	profile, err := s.GetMe(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	visibility, err := s.repository.GetProfileVisibility(ctx, userID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch profile visibility", err)
+	}
+
+	matches, err := s.repository.GetMatchesByUserID(ctx, userID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch matches", err)
+	}
+
+	messages, err := s.repository.GetUserMessages(ctx, userID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch messages", err)
+	}
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", profile},
+		{"profile_visibility.json", visibility},
+		{"matches.json", matches},
+		{"chats.json", messages},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
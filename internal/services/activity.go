@@ -0,0 +1,330 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	activityDefaultDuration  = time.Hour
+	activityMinDuration      = 30 * time.Minute
+	activityMaxDuration      = 8 * time.Hour
+	activityCommentMaxLength = 1000
+
+	scheduleDefaultDays = 7
+	scheduleMaxDays     = 62
+)
+
+func (s *Service) ListActivities(ctx context.Context, req requests.ActivitiesRequest) ([]models.Activity, error) {
+	activities, err := s.repository.ListActivities(ctx, req.SportID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list activities", err)
+	}
+
+	return activities, nil
+}
+
+// CreateActivityRequest отправляет заявку на активность ассистенту. В расписание она попадёт
+// только после подтверждения; пересечение с уже запланированными событиями сразу даёт 409.
+func (s *Service) CreateActivityRequest(ctx context.Context, userID uint64, req requests.CreateActivityRequestRequest) (models.ActivityRequest, error) {
+	if req.ActivityID <= 0 {
+		return models.ActivityRequest{}, myerrors.NewValidationError("activity_id is required", errors.New("missing activity"))
+	}
+	if req.SportID <= 0 {
+		return models.ActivityRequest{}, myerrors.NewValidationError("sport_id is required", errors.New("missing sport"))
+	}
+	if req.SportObjectID == nil && req.CourtID == nil {
+		return models.ActivityRequest{}, myerrors.NewValidationError("sport_object_id or court_id is required", errors.New("missing venue"))
+	}
+	if req.StartsAt.IsZero() || !req.StartsAt.After(time.Now()) {
+		return models.ActivityRequest{}, myerrors.NewValidationError("starts_at must be in the future", errors.New("invalid start time"))
+	}
+
+	startsAt := req.StartsAt.UTC()
+	endsAt, err := activityEndsAt(startsAt, req.EndsAt, req.DurationMinutes)
+	if err != nil {
+		return models.ActivityRequest{}, err
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		if c := strings.TrimSpace(*req.Comment); c != "" {
+			if len([]rune(c)) > activityCommentMaxLength {
+				return models.ActivityRequest{}, myerrors.NewValidationError(
+					fmt.Sprintf("comment must be at most %d characters", activityCommentMaxLength), errors.New("comment too long"))
+			}
+			comment = &c
+		}
+	}
+
+	activity, err := s.repository.GetActivity(ctx, req.ActivityID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ActivityRequest{}, myerrors.NewValidationError("unknown activity", err)
+		}
+		return models.ActivityRequest{}, myerrors.NewRepositoryErr("failed to fetch activity", err)
+	}
+	if activity.SportID != nil && *activity.SportID != req.SportID {
+		return models.ActivityRequest{}, myerrors.NewValidationError("activity is not available for this sport", errors.New("sport mismatch"))
+	}
+
+	sportObjectID, err := s.matchSportObject(ctx, req.SportObjectID, req.CourtID)
+	if err != nil {
+		return models.ActivityRequest{}, err
+	}
+
+	if err = s.checkActivityConflicts(ctx, userID, startsAt, endsAt); err != nil {
+		return models.ActivityRequest{}, err
+	}
+
+	requestID, err := s.repository.CreateActivityRequest(ctx, models.ActivityRequest{
+		UserID:        userID,
+		ActivityID:    req.ActivityID,
+		SportID:       req.SportID,
+		SportObjectID: *sportObjectID,
+		CourtID:       req.CourtID,
+		StartsAt:      startsAt,
+		EndsAt:        endsAt,
+		Comment:       comment,
+	})
+	if err != nil {
+		if errors.Is(err, myerrors.ErrInvalidReference) {
+			return models.ActivityRequest{}, myerrors.NewValidationError("unknown sport or sport object", err)
+		}
+		return models.ActivityRequest{}, myerrors.NewRepositoryErr("failed to create activity request", err)
+	}
+
+	return s.getActivityRequest(ctx, requestID)
+}
+
+// ListMyActivityRequests — заявки пользователя, новые сначала
+func (s *Service) ListMyActivityRequests(ctx context.Context, userID uint64, req requests.ActivityRequestsRequest) ([]models.ActivityRequest, error) {
+	filter := models.ActivityRequestFilter{UserID: &userID}
+	if req.Status != "" {
+		status, err := parseActivityRequestStatus(req.Status)
+		if err != nil {
+			return nil, err
+		}
+		filter.Status = &status
+	}
+
+	return s.listActivityRequests(ctx, filter)
+}
+
+// ListActivityRequestQueue — очередь ассистента: по умолчанию ожидающие подтверждения, ближайшие сначала
+func (s *Service) ListActivityRequestQueue(ctx context.Context, req requests.ActivityRequestsRequest) ([]models.ActivityRequest, error) {
+	status := models.ActivityRequestPending
+	if req.Status != "" {
+		var err error
+		if status, err = parseActivityRequestStatus(req.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.listActivityRequests(ctx, models.ActivityRequestFilter{Status: &status})
+}
+
+// ConfirmActivityRequest — ассистент подтверждает заявку, и активность появляется в расписании пользователя.
+// Время ещё раз сверяется с расписанием: за время ожидания пользователь мог вступить в матч.
+func (s *Service) ConfirmActivityRequest(ctx context.Context, reviewerID, requestID uint64, req requests.ReviewActivityRequestRequest) (models.ActivityRequest, error) {
+	request, err := s.pendingActivityRequest(ctx, requestID)
+	if err != nil {
+		return models.ActivityRequest{}, err
+	}
+	if !request.StartsAt.After(time.Now()) {
+		return models.ActivityRequest{}, myerrors.NewConflictErr("activity has already started", errors.New("activity started"))
+	}
+	if err = s.checkActivityConflicts(ctx, request.UserID, request.StartsAt, request.EndsAt); err != nil {
+		return models.ActivityRequest{}, err
+	}
+
+	if err = s.repository.ConfirmActivityRequest(ctx, requestID, reviewerID, reviewComment(req.Comment)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ActivityRequest{}, myerrors.NewConflictErr("activity request is no longer pending", err)
+		}
+		return models.ActivityRequest{}, myerrors.NewRepositoryErr("failed to confirm activity request", err)
+	}
+
+	return s.getActivityRequest(ctx, requestID)
+}
+
+func (s *Service) RejectActivityRequest(ctx context.Context, reviewerID, requestID uint64, req requests.ReviewActivityRequestRequest) (models.ActivityRequest, error) {
+	if _, err := s.pendingActivityRequest(ctx, requestID); err != nil {
+		return models.ActivityRequest{}, err
+	}
+
+	if err := s.repository.RejectActivityRequest(ctx, requestID, reviewerID, reviewComment(req.Comment)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ActivityRequest{}, myerrors.NewConflictErr("activity request is no longer pending", err)
+		}
+		return models.ActivityRequest{}, myerrors.NewRepositoryErr("failed to reject activity request", err)
+	}
+
+	return s.getActivityRequest(ctx, requestID)
+}
+
+// CancelActivityRequest — пользователь отзывает заявку или отменяет подтверждённую активность до её начала;
+// подтверждённая активность убирается из расписания
+func (s *Service) CancelActivityRequest(ctx context.Context, userID, requestID uint64) (models.ActivityRequest, error) {
+	request, err := s.getActivityRequest(ctx, requestID)
+	if err != nil {
+		return models.ActivityRequest{}, err
+	}
+	// чужая заявка выглядит несуществующей
+	if request.UserID != userID {
+		return models.ActivityRequest{}, myerrors.NewNotFoundErr("activity request not found", errors.New("foreign activity request"))
+	}
+	if request.Status != models.ActivityRequestPending && request.Status != models.ActivityRequestConfirmed {
+		return models.ActivityRequest{}, myerrors.NewConflictErr("activity request is already "+string(request.Status), errors.New("activity request is closed"))
+	}
+	if !request.StartsAt.After(time.Now()) {
+		return models.ActivityRequest{}, myerrors.NewConflictErr("activity has already started", errors.New("activity started"))
+	}
+
+	if err = s.repository.CancelActivityRequest(ctx, requestID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ActivityRequest{}, myerrors.NewConflictErr("activity request is already closed", err)
+		}
+		return models.ActivityRequest{}, myerrors.NewRepositoryErr("failed to cancel activity request", err)
+	}
+
+	return s.getActivityRequest(ctx, requestID)
+}
+
+// GetSchedule — личное расписание: открытые матчи и подтверждённые активности в интервале дат
+func (s *Service) GetSchedule(ctx context.Context, userID uint64, req requests.ScheduleRequest) (responses.ScheduleResponse, error) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if req.From != "" {
+		parsed, err := time.Parse(matchFilterDateFormat, req.From)
+		if err != nil {
+			return responses.ScheduleResponse{}, myerrors.NewValidationError("from must be in format YYYY-MM-DD", err)
+		}
+		from = parsed
+	}
+
+	// to включительно: храним начало следующего дня
+	to := from.AddDate(0, 0, scheduleDefaultDays)
+	if req.To != "" {
+		parsed, err := time.Parse(matchFilterDateFormat, req.To)
+		if err != nil {
+			return responses.ScheduleResponse{}, myerrors.NewValidationError("to must be in format YYYY-MM-DD", err)
+		}
+		to = parsed.AddDate(0, 0, 1)
+	}
+	if !from.Before(to) {
+		return responses.ScheduleResponse{}, myerrors.NewValidationError("from must not be after to", errors.New("invalid date range"))
+	}
+	if to.Sub(from) > scheduleMaxDays*24*time.Hour {
+		return responses.ScheduleResponse{}, myerrors.NewValidationError(
+			fmt.Sprintf("date range must be at most %d days", scheduleMaxDays), errors.New("date range too long"))
+	}
+
+	events, err := s.repository.ListScheduleEvents(ctx, userID, from, to)
+	if err != nil {
+		return responses.ScheduleResponse{}, myerrors.NewRepositoryErr("failed to fetch schedule", err)
+	}
+
+	return responses.ScheduleResponse{
+		From:   from.Format(matchFilterDateFormat),
+		To:     to.AddDate(0, 0, -1).Format(matchFilterDateFormat),
+		Events: events,
+	}, nil
+}
+
+// activityEndsAt вычисляет окончание активности: явный ends_at или начало плюс длительность
+func activityEndsAt(startsAt time.Time, endsAt *time.Time, durationMinutes int) (time.Time, error) {
+	duration := activityDefaultDuration
+	switch {
+	case endsAt != nil:
+		duration = endsAt.UTC().Sub(startsAt)
+	case durationMinutes != 0:
+		duration = time.Duration(durationMinutes) * time.Minute
+	}
+
+	if duration < activityMinDuration || duration > activityMaxDuration {
+		return time.Time{}, myerrors.NewValidationError(
+			fmt.Sprintf("activity must last between %d minutes and %d hours", int(activityMinDuration.Minutes()), int(activityMaxDuration.Hours())),
+			errors.New("invalid activity duration"),
+		)
+	}
+
+	return startsAt.Add(duration), nil
+}
+
+// checkActivityConflicts — как checkScheduleConflicts, но с сообщением об активности
+func (s *Service) checkActivityConflicts(ctx context.Context, userID uint64, startsAt, endsAt time.Time) error {
+	conflicts, err := s.repository.FindScheduleConflicts(ctx, userID, startsAt, endsAt, 0)
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to check schedule conflicts", err)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return myerrors.NewConflictErr("activity overlaps with other events in the schedule", myerrors.ErrScheduleConflict).
+		WithDetails(ScheduleConflictDetails{Conflicts: conflicts})
+}
+
+func parseActivityRequestStatus(value string) (models.ActivityRequestStatus, error) {
+	status := models.ActivityRequestStatus(value)
+	switch status {
+	case models.ActivityRequestPending, models.ActivityRequestConfirmed,
+		models.ActivityRequestRejected, models.ActivityRequestCancelled:
+		return status, nil
+	}
+
+	return "", myerrors.NewValidationError("status must be pending, confirmed, rejected or cancelled", errors.New("invalid status"))
+}
+
+func reviewComment(comment *string) *string {
+	if comment == nil {
+		return nil
+	}
+	if c := strings.TrimSpace(*comment); c != "" {
+		return &c
+	}
+
+	return nil
+}
+
+func (s *Service) listActivityRequests(ctx context.Context, filter models.ActivityRequestFilter) ([]models.ActivityRequest, error) {
+	activityRequests, err := s.repository.ListActivityRequests(ctx, filter)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list activity requests", err)
+	}
+
+	return activityRequests, nil
+}
+
+func (s *Service) pendingActivityRequest(ctx context.Context, requestID uint64) (models.ActivityRequest, error) {
+	request, err := s.getActivityRequest(ctx, requestID)
+	if err != nil {
+		return models.ActivityRequest{}, err
+	}
+	if request.Status != models.ActivityRequestPending {
+		return models.ActivityRequest{}, myerrors.NewConflictErr("activity request is already "+string(request.Status), errors.New("activity request is not pending"))
+	}
+
+	return request, nil
+}
+
+func (s *Service) getActivityRequest(ctx context.Context, requestID uint64) (models.ActivityRequest, error) {
+	request, err := s.repository.GetActivityRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ActivityRequest{}, myerrors.NewNotFoundErr("activity request not found", err)
+		}
+		return models.ActivityRequest{}, myerrors.NewRepositoryErr("failed to fetch activity request", err)
+	}
+
+	return request, nil
+}
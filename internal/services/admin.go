@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	adminUsersDefaultPageSize = 20
+	adminUsersMaxPageSize     = 100
+	adminFilterDateFormat     = "2006-01-02"
+)
+
+var adminUsersCSVHeader = []string{
+	"id", "name", "surname", "gender", "birth_date", "phone_number", "is_phone_verified",
+	"email", "is_email_verified", "role_id", "subscription_id", "town_id", "sport_activity_level_id",
+	"sport_target_id", "is_have_injury", "blocked_at", "created_at",
+}
+
+// ListAdminUsers возвращает страницу справочника пользователей и общее число найденных
+func (s *Service) ListAdminUsers(ctx context.Context, req requests.AdminUsersRequest) (responses.AdminUsersResponse, error) {
+	filter, err := s.adminUserFilter(req)
+	if err != nil {
+		return responses.AdminUsersResponse{}, err
+	}
+
+	page := max(req.Page, 1)
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = adminUsersDefaultPageSize
+	}
+	pageSize = min(pageSize, adminUsersMaxPageSize)
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	total, err := s.repository.CountUsers(ctx, filter)
+	if err != nil {
+		return responses.AdminUsersResponse{}, myerrors.NewRepositoryErr("failed to count users", err)
+	}
+
+	users, err := s.repository.ListUsers(ctx, filter)
+	if err != nil {
+		return responses.AdminUsersResponse{}, myerrors.NewRepositoryErr("failed to list users", err)
+	}
+
+	items := make([]dto.AdminUserDto, 0, len(users))
+	for _, user := range users {
+		photoURL, err := s.photoURL(ctx, user.PhotoThumbnail)
+		if err != nil {
+			return responses.AdminUsersResponse{}, err
+		}
+		items = append(items, dto.ToAdminUser(user, photoURL))
+	}
+
+	return responses.AdminUsersResponse{
+		Users:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// ExportAdminUsersCSV пишет в w всех пользователей по фильтру (без пагинации).
+// Ошибка валидации возвращается до первой записи в w.
+func (s *Service) ExportAdminUsersCSV(ctx context.Context, req requests.AdminUsersRequest, w io.Writer) error {
+	filter, err := s.adminUserFilter(req)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err = writer.Write(adminUsersCSVHeader); err != nil {
+		return err
+	}
+
+	err = s.repository.StreamUsers(ctx, filter, func(user dto.UserDto) error {
+		return writer.Write(userCSVRecord(user))
+	})
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to stream users", err)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// AdminBlockUser блокирует аккаунт: вход и обновление токенов запрещены, текущая сессия обрывается.
+// Заблокированные не попадают в рейтинги, подбор и списки друзей.
+func (s *Service) AdminBlockUser(ctx context.Context, adminID, userID uint64) (dto.AdminUserDto, error) {
+	if adminID == userID {
+		return dto.AdminUserDto{}, myerrors.NewValidationError("cannot block yourself", errors.New("self block"))
+	}
+
+	if err := s.repository.BlockUserAccount(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.AdminUserDto{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return dto.AdminUserDto{}, myerrors.NewRepositoryErr("failed to block user", err)
+	}
+
+	s.dropAccessToken(ctx, userID)
+	s.refreshLeaderboard(ctx)
+	return s.adminUser(ctx, userID)
+}
+
+// AdminUnblockUser снимает блокировку аккаунта
+func (s *Service) AdminUnblockUser(ctx context.Context, userID uint64) (dto.AdminUserDto, error) {
+	if err := s.repository.UnblockUserAccount(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.AdminUserDto{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return dto.AdminUserDto{}, myerrors.NewRepositoryErr("failed to unblock user", err)
+	}
+
+	s.refreshLeaderboard(ctx)
+	return s.adminUser(ctx, userID)
+}
+
+func (s *Service) adminUser(ctx context.Context, userID uint64) (dto.AdminUserDto, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return dto.AdminUserDto{}, err
+	}
+
+	photoURL, err := s.photoURL(ctx, user.PhotoThumbnail)
+	if err != nil {
+		return dto.AdminUserDto{}, err
+	}
+
+	return dto.ToAdminUser(user, photoURL), nil
+}
+
+func (s *Service) adminUserFilter(req requests.AdminUsersRequest) (models.UserFilter, error) {
+	filter := models.UserFilter{
+		SubscriptionIDs:       req.SubscriptionIDs,
+		HasSubscription:       req.HasSubscription,
+		RoleIDs:               req.RoleIDs,
+		TownIDs:               req.TownIDs,
+		SportActivityLevelIDs: req.SportActivityLevelIDs,
+		SportTargetIDs:        req.SportTargetIDs,
+		SportIDs:              req.SportIDs,
+		IsHaveInjury:          req.IsHaveInjury,
+		IsPhoneVerified:       req.IsPhoneVerified,
+		IsEmailVerified:       req.IsEmailVerified,
+		IsBlocked:             req.IsBlocked,
+		Search:                strings.TrimSpace(req.Query),
+		SortField:             models.UserSortID,
+	}
+
+	if req.CreatedFrom != "" {
+		from, err := time.Parse(adminFilterDateFormat, req.CreatedFrom)
+		if err != nil {
+			return models.UserFilter{}, myerrors.NewValidationError("created_from must be in format YYYY-MM-DD", err)
+		}
+		filter.CreatedFrom = &from
+	}
+	if req.CreatedTo != "" {
+		to, err := time.Parse(adminFilterDateFormat, req.CreatedTo)
+		if err != nil {
+			return models.UserFilter{}, myerrors.NewValidationError("created_to must be in format YYYY-MM-DD", err)
+		}
+		// created_to включительно: берём начало следующего дня
+		to = to.AddDate(0, 0, 1)
+		filter.CreatedTo = &to
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return models.UserFilter{}, myerrors.NewValidationError("created_from must not be after created_to", errors.New("invalid date range"))
+	}
+
+	if sort := strings.TrimSpace(req.Sort); sort != "" {
+		filter.SortDesc = strings.HasPrefix(sort, "-")
+		filter.SortField = models.UserSortField(strings.TrimPrefix(sort, "-"))
+		if !filter.SortField.IsValid() {
+			return models.UserFilter{}, myerrors.NewValidationError(
+				"sort must be one of id, created_at, updated_at, name, surname, birth_date",
+				errors.New("invalid sort field"),
+			)
+		}
+	}
+
+	return filter, nil
+}
+
+func userCSVRecord(user dto.UserDto) []string {
+	return []string{
+		strconv.FormatUint(user.ID, 10),
+		csvSafe(user.Name),
+		csvSafe(user.Surname),
+		csvSafe(user.Gender),
+		user.BirthDate.Format(adminFilterDateFormat),
+		csvSafe(user.PhoneNumber),
+		strconv.FormatBool(user.IsPhoneVerified),
+		csvSafe(user.Email),
+		strconv.FormatBool(user.IsEmailVerified),
+		csvInt(user.RoleID),
+		csvInt(user.SubscriptionID),
+		csvInt(user.TownID),
+		csvInt(user.SportActivityLevelID),
+		csvInt(user.SportTargetID),
+		strconv.FormatBool(user.IsHaveInjury),
+		csvTime(user.BlockedAt),
+		user.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// csvSafe не даёт табличным редакторам исполнить введённый пользователем текст как формулу
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func csvInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func csvTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
@@ -50,6 +50,9 @@ func (s *Service) Register(ctx context.Context, req requests.CreateUserRequest)
 		return responses.JWTResponse{}, err
 	}
 
+	// ключ тот же, что в ConfirmOTP: телефон в нижнем регистре без пробелов по краям
+	s.redeemPendingInvite(ctx, userId, strings.TrimSpace(strings.ToLower(req.PhoneNumber)))
+
 	access, refresh, err := s.CreateTokens(ctx, userId, req.Email)
 	if err != nil {
 		return responses.JWTResponse{}, err
@@ -64,18 +67,21 @@ func (s *Service) Register(ctx context.Context, req requests.CreateUserRequest)
 // Переписать логику обработки логина на номер телефона, не через email
 func (s *Service) Login(ctx context.Context, req requests.LoginRequest) (responses.JWTResponse, error) {
 	email := strings.TrimSpace(req.Email)
-	user, err := s.repository.GetUserByEmail(ctx, email)
+	credentials, err := s.repository.GetUserCredentialsByEmail(ctx, email)
 	if err != nil {
 		return responses.JWTResponse{}, myerrors.NewRepositoryErr(myerrors.UserDoesNotExistErrorMessage, err)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	err = bcrypt.CompareHashAndPassword([]byte(credentials.PasswordHash), []byte(req.Password))
 	if err != nil {
-		fmt.Printf("bcrypt err: %T %v, passLen=%d\n", err, err, len(req.Password))
 		return responses.JWTResponse{}, errors.New("invalid email or password")
 	}
 
-	accessToken, refreshToken, err := s.CreateTokens(ctx, user.ID, user.Email)
+	if credentials.BlockedAt != nil {
+		return responses.JWTResponse{}, myerrors.NewForbiddenErr("account is blocked", errors.New("user blocked"))
+	}
+
+	accessToken, refreshToken, err := s.CreateTokens(ctx, credentials.ID, credentials.Email)
 	if err != nil {
 		return responses.JWTResponse{}, err
 	}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BlockUser добавляет пользователя в чёрный список. Дружба, ожидающие заявки и приглашения
+// между ними закрываются; дальше они не видят профили друг друга и не встречаются в подборе.
+func (s *Service) BlockUser(ctx context.Context, userID uint64, req requests.BlockUserRequest) error {
+	if req.UserID == 0 {
+		return myerrors.NewValidationError("user_id is required", errors.New("missing user"))
+	}
+	if req.UserID == userID {
+		return myerrors.NewValidationError("you cannot block yourself", errors.New("self block"))
+	}
+
+	if _, err := s.repository.GetPlayerCard(ctx, req.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewNotFoundErr("user not found", err)
+		}
+		return myerrors.NewRepositoryErr("failed to fetch user", err)
+	}
+
+	if err := s.repository.BlockUser(ctx, userID, req.UserID); err != nil {
+		if errors.Is(err, myerrors.ErrInvalidReference) {
+			return myerrors.NewNotFoundErr("user not found", err)
+		}
+		return myerrors.NewRepositoryErr("failed to block user", err)
+	}
+
+	return nil
+}
+
+func (s *Service) UnblockUser(ctx context.Context, userID, blockedID uint64) error {
+	if err := s.repository.UnblockUser(ctx, userID, blockedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewNotFoundErr("user is not blocked", err)
+		}
+		return myerrors.NewRepositoryErr("failed to unblock user", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ListBlockedUsers(ctx context.Context, userID uint64) ([]models.BlockedUser, error) {
+	blocked, err := s.repository.ListBlockedUsers(ctx, userID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list blocked users", err)
+	}
+	for i := range blocked {
+		if blocked[i].PhotoURL, err = s.photoURL(ctx, blocked[i].PhotoURL); err != nil {
+			return nil, err
+		}
+	}
+
+	return blocked, nil
+}
+
+// checkNotBlocked скрывает пользователя, если кто-то из пары заблокировал другого:
+// для смотрящего он выглядит несуществующим
+func (s *Service) checkNotBlocked(ctx context.Context, viewerID, userID uint64) error {
+	if viewerID == userID {
+		return nil
+	}
+
+	blocked, err := s.repository.IsBlockedBetween(ctx, viewerID, userID)
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to check blocks", err)
+	}
+	if blocked {
+		return myerrors.NewNotFoundErr("user not found", errors.New("blocked"))
+	}
+
+	return nil
+}
+
+// withoutBlocked убирает из приглашённых тех, кто состоит с приглашающим в блокировке.
+// Приглашение им молча не отправляется, чтобы не раскрывать блокировку.
+func (s *Service) withoutBlocked(ctx context.Context, userID uint64, invitees []uint64) ([]uint64, error) {
+	if len(invitees) == 0 {
+		return invitees, nil
+	}
+
+	blocked, err := s.repository.BlockedAmong(ctx, userID, invitees)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to check blocks", err)
+	}
+	if len(blocked) == 0 {
+		return invitees, nil
+	}
+
+	allowed := make([]uint64, 0, len(invitees))
+	for _, id := range invitees {
+		if !containsID(blocked, id) {
+			allowed = append(allowed, id)
+		}
+	}
+
+	return allowed, nil
+}
+
+// checkMatchBlocks не пускает в матч, где есть участник из блокировок пользователя
+func (s *Service) checkMatchBlocks(ctx context.Context, userID, matchID uint64) error {
+	blocked, err := s.repository.HasBlockedParticipant(ctx, matchID, userID)
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to check blocks", err)
+	}
+	if blocked {
+		return myerrors.NewForbiddenErr("you cannot join this match", errors.New("blocked participant"))
+	}
+
+	return nil
+}
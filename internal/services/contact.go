@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+const contactChangeMaxAttempts = 5
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{10,15}$`)
+
+// RequestContactChange запоминает новый телефон/email и отправляет на него код подтверждения.
+// Сам контакт в профиле не меняется до ConfirmContactChange.
+func (s *Service) RequestContactChange(ctx context.Context, userID uint64, contactType models.ContactType, value string) (responses.SendOTPResponse, error) {
+	normalized, err := normalizeContact(contactType, value)
+	if err != nil {
+		return responses.SendOTPResponse{}, err
+	}
+
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return responses.SendOTPResponse{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return responses.SendOTPResponse{}, myerrors.NewRepositoryErr("failed to fetch user", err)
+	}
+
+	current := user.PhoneNumber
+	if contactType == models.ContactTypeEmail {
+		current = user.Email
+	}
+	if strings.EqualFold(current, normalized) {
+		return responses.SendOTPResponse{}, myerrors.NewValidationError("new contact matches the current one", errors.New("contact unchanged"))
+	}
+
+	taken, err := s.repository.ContactExists(ctx, contactType, normalized, userID)
+	if err != nil {
+		return responses.SendOTPResponse{}, myerrors.NewRepositoryErr("failed to check contact uniqueness", err)
+	}
+	if taken {
+		return responses.SendOTPResponse{}, myerrors.NewConflictErr("contact is already used by another user", myerrors.ErrContactAlreadyUsed)
+	}
+
+	pending, err := json.Marshal(models.PendingContactChange{Value: normalized, OTP: otpStubValue})
+	if err != nil {
+		return responses.SendOTPResponse{}, err
+	}
+
+	key := s.contactChangeKey(userID, contactType)
+	if err = s.redisClient.Set(ctx, key, pending, otpTTL).Err(); err != nil {
+		return responses.SendOTPResponse{}, myerrors.NewTokenErr("failed to save contact change in redis", err)
+	}
+
+	if err = s.deliverOTP(ctx, contactType, normalized, otpStubValue); err != nil {
+		return responses.SendOTPResponse{}, err
+	}
+
+	return responses.SendOTPResponse{
+		OTPSent: true,
+		Message: "OTP sent to the new contact",
+	}, nil
+}
+
+// ConfirmContactChange проверяет код и заменяет контакт.
+// Старый контакт получает уведомление о смене, клиенту выдаётся новая пара токенов
+// (email входит в access-токен, старый после смены email перестаёт проходить AuthMiddleware).
+func (s *Service) ConfirmContactChange(ctx context.Context, userID uint64, contactType models.ContactType, otp string) (responses.JWTResponse, error) {
+	if _, err := contactTypeLabel(contactType); err != nil {
+		return responses.JWTResponse{}, err
+	}
+
+	key := s.contactChangeKey(userID, contactType)
+	raw, err := s.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return responses.JWTResponse{}, myerrors.NewValidationError("no pending contact change or it has expired", err)
+		}
+		return responses.JWTResponse{}, myerrors.NewTokenErr("failed to read contact change from redis", err)
+	}
+
+	var pending models.PendingContactChange
+	if err = json.Unmarshal(raw, &pending); err != nil {
+		return responses.JWTResponse{}, err
+	}
+
+	if pending.OTP != strings.TrimSpace(otp) {
+		pending.Attempts++
+		if pending.Attempts >= contactChangeMaxAttempts {
+			s.redisClient.Del(ctx, key)
+			return responses.JWTResponse{}, myerrors.NewTooManyRequestsErr("too many invalid attempts, request a new code", errors.New("otp attempts exceeded"))
+		}
+
+		if updated, marshalErr := json.Marshal(pending); marshalErr == nil {
+			s.redisClient.Set(ctx, key, updated, redis.KeepTTL)
+		}
+		return responses.JWTResponse{}, myerrors.NewValidationError("otp does not match", errors.New("mismatch otp"))
+	}
+
+	previous, err := s.repository.UpdateUserContact(ctx, userID, contactType, pending.Value)
+	if err != nil {
+		if errors.Is(err, myerrors.ErrContactAlreadyUsed) {
+			return responses.JWTResponse{}, myerrors.NewConflictErr("contact is already used by another user", err)
+		}
+		return responses.JWTResponse{}, myerrors.NewRepositoryErr("failed to update contact", err)
+	}
+
+	if err = s.redisClient.Del(ctx, key).Err(); err != nil {
+		s.logger.Warn("failed to delete contact change from redis", "key", key, "err", err)
+	}
+
+	s.notifyContactChanged(ctx, contactType, previous, pending.Value)
+
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return responses.JWTResponse{}, myerrors.NewRepositoryErr("failed to fetch user", err)
+	}
+
+	access, refresh, err := s.CreateTokens(ctx, user.ID, user.Email)
+	if err != nil {
+		return responses.JWTResponse{}, err
+	}
+
+	return responses.JWTResponse{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+func (s *Service) contactChangeKey(userID uint64, contactType models.ContactType) string {
+	return fmt.Sprintf(s.cfg.SecurityConfig.ContactChangeRedisPrefix, userID, contactType)
+}
+
+// deliverOTP отправляет код по SMS или на email
+func (s *Service) deliverOTP(ctx context.Context, contactType models.ContactType, recipient, code string) error {
+	text := fmt.Sprintf("Код подтверждения Sport Assistance: %s", code)
+
+	var err error
+	if contactType == models.ContactTypeEmail {
+		err = s.notifier.SendEmail(ctx, recipient, "Код подтверждения", text)
+	} else {
+		err = s.notifier.SendSMS(ctx, recipient, text)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to deliver otp: %w", err)
+	}
+
+	return nil
+}
+
+// notifyContactChanged предупреждает владельца старого контакта о смене.
+// Ошибка доставки не откатывает смену: контакт уже подтверждён.
+func (s *Service) notifyContactChanged(ctx context.Context, contactType models.ContactType, previous, current string) {
+	label, _ := contactTypeLabel(contactType)
+	text := fmt.Sprintf(
+		"В профиле Sport Assistance изменён %s на %s. Если это были не вы, обратитесь в поддержку.",
+		label, maskContact(contactType, current),
+	)
+
+	var err error
+	if contactType == models.ContactTypeEmail {
+		err = s.notifier.SendEmail(ctx, previous, "Email изменён", text)
+	} else {
+		err = s.notifier.SendSMS(ctx, previous, text)
+	}
+	if err != nil {
+		s.logger.Warn("failed to notify previous contact", "type", contactType, "err", err)
+	}
+}
+
+func normalizeContact(contactType models.ContactType, value string) (string, error) {
+	switch contactType {
+	case models.ContactTypePhone:
+		phone := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(strings.TrimSpace(value))
+		if !phonePattern.MatchString(phone) {
+			return "", myerrors.NewValidationError("phone number is invalid", fmt.Errorf("invalid phone %q", value))
+		}
+		return phone, nil
+	case models.ContactTypeEmail:
+		email := strings.ToLower(strings.TrimSpace(value))
+		address, err := mail.ParseAddress(email)
+		if err != nil || address.Address != email {
+			return "", myerrors.NewValidationError("email is invalid", fmt.Errorf("invalid email %q", value))
+		}
+		return email, nil
+	default:
+		return "", myerrors.NewValidationError("contact type must be phone or email", fmt.Errorf("unknown contact type %q", contactType))
+	}
+}
+
+func contactTypeLabel(contactType models.ContactType) (string, error) {
+	switch contactType {
+	case models.ContactTypePhone:
+		return "номер телефона", nil
+	case models.ContactTypeEmail:
+		return "email", nil
+	default:
+		return "", myerrors.NewValidationError("contact type must be phone or email", fmt.Errorf("unknown contact type %q", contactType))
+	}
+}
+
+// maskContact скрывает середину контакта в уведомлениях: +7999***4567, iv***@mail.ru
+func maskContact(contactType models.ContactType, value string) string {
+	if contactType == models.ContactTypeEmail {
+		local, domain, found := strings.Cut(value, "@")
+		if !found || len(local) <= 2 {
+			return "***@" + domain
+		}
+		return local[:2] + "***@" + domain
+	}
+
+	if len(value) <= 8 {
+		return "***"
+	}
+	return value[:5] + "***" + value[len(value)-4:]
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "sport-assistance/internal/models"
+
+type TownDto struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// PublicProfileDto — карточка игрока для других пользователей.
+// Скрытые владельцем поля не попадают в ответ (nil + omitempty).
+type PublicProfileDto struct {
+	ID                uint64                `json:"id"`
+	Name              string                `json:"name"`
+	Surname           string                `json:"surname"`
+	PhotoURL          *string               `json:"photo_url"`
+	Rating            *float64              `json:"rating,omitempty"`
+	MatchesPlayed     *int                  `json:"matches_played,omitempty"`
+	FriendsCount      *int                  `json:"friends_count,omitempty"`
+	MutualFriends     *int                  `json:"mutual_friends,omitempty"` // общие друзья со смотрящим; в своей карточке нет
+	PartnersCount     *int                  `json:"partners_count,omitempty"`
+	Town              *TownDto              `json:"town,omitempty"`
+	TrainingDistricts []string              `json:"training_districts,omitempty"`
+	CourtPosition     *models.CourtPosition `json:"court_position,omitempty"`
+}
+
+// ToPublicProfile собирает карточку с учётом настроек видимости
+func ToPublicProfile(card models.PlayerCard, stats models.PlayerStats, visibility models.ProfileVisibility) PublicProfileDto {
+	profile := PublicProfileDto{
+		ID:      card.ID,
+		Name:    card.Name,
+		Surname: card.Surname,
+	}
+
+	if visibility.ShowRating {
+		profile.Rating = stats.Rating
+	}
+	if visibility.ShowMatchesPlayed {
+		profile.MatchesPlayed = &stats.MatchesPlayed
+	}
+	if visibility.ShowFriends {
+		profile.FriendsCount = &stats.FriendsCount
+	}
+	if visibility.ShowPartners {
+		profile.PartnersCount = &stats.PartnersCount
+	}
+	if visibility.ShowTown && card.TownID != nil && card.TownName != nil {
+		profile.Town = &TownDto{ID: *card.TownID, Name: *card.TownName}
+	}
+	if visibility.ShowTrainingDistrict {
+		profile.TrainingDistricts = card.TrainingDistricts
+	}
+	if visibility.ShowCourtPosition {
+		profile.CourtPosition = card.CourtPosition
+	}
+
+	return profile
+}
@@ -5,27 +5,36 @@ import (
 	"time"
 )
 
+// UserDto — пользователь, как его читает репозиторий. Хэша пароля здесь нет и быть не должно:
+// наружу UserDto не отдаётся, ответы собираются через ProjectUser.
 type UserDto struct {
-	ID                       uint64    `json:"id"`
-	Name                     string    `json:"name"`
-	Surname                  string    `json:"surname"`
-	Gender                   string    `json:"gender"`
-	BirthDate                time.Time `json:"birth_date"`
-	HeightCm                 *int      `json:"height_cm"`
-	WeightKg                 *int      `json:"weight_kg"`
-	SportActivityLevelID     *int      `json:"sport_activity_level_id"`
-	SportTargetID            *int      `json:"sport_target_id"`
-	LocationPreferenceTypeID *int      `json:"location_preference_type_id"`
-	TownID                   *int      `json:"town_id"`
-	RoleID                   *int      `json:"role_id"`
-	PhoneNumber              string    `json:"phone_number"`
-	IsPhoneVerified          bool      `json:"is_phone_verified"`
-	Email                    string    `json:"email"`
-	IsEmailVerified          bool      `json:"is_email_verified"`
-	Password                 string    `json:"password"`
-	IsHaveInjury             bool      `json:"is_have_injury"`
-	InjuryDescription        *string   `json:"injury_description"`
-	Photo                    *string   `json:"photo"`
+	ID                       uint64                `json:"id"`
+	Name                     string                `json:"name"`
+	Surname                  string                `json:"surname"`
+	Gender                   string                `json:"gender"`
+	BirthDate                time.Time             `json:"birth_date"`
+	HeightCm                 *int                  `json:"height_cm"`
+	WeightKg                 *int                  `json:"weight_kg"`
+	SportActivityLevelID     *int                  `json:"sport_activity_level_id"`
+	SportTargetID            *int                  `json:"sport_target_id"`
+	LocationPreferenceTypeID *int                  `json:"location_preference_type_id"`
+	TownID                   *int                  `json:"town_id"`
+	RoleID                   *int                  `json:"role_id"`
+	SubscriptionID           *int                  `json:"subscription_id"`
+	PhoneNumber              string                `json:"phone_number"`
+	IsPhoneVerified          bool                  `json:"is_phone_verified"`
+	Email                    string                `json:"email"`
+	IsEmailVerified          bool                  `json:"is_email_verified"`
+	IsHaveInjury             bool                  `json:"is_have_injury"`
+	InjuryDescription        *string               `json:"injury_description"`
+	Photo                    *string               `json:"photo"`
+	PhotoThumbnail           *string               `json:"photo_thumbnail"`
+	CourtPosition            *models.CourtPosition `json:"court_position"`
+	CreatedAt                time.Time             `json:"created_at"`
+	UpdatedAt                time.Time             `json:"updated_at"`
+	DeletedAt                *time.Time            `json:"deleted_at"`
+	DeletionRequestedAt      *time.Time            `json:"deletion_requested_at"`
+	BlockedAt                *time.Time            `json:"blocked_at"`
 }
 
 func UserToDto(userModel models.User) (UserDto, error) {
@@ -42,13 +51,20 @@ func UserToDto(userModel models.User) (UserDto, error) {
 		LocationPreferenceTypeID: userModel.LocationPreferenceTypeID,
 		TownID:                   userModel.TownID,
 		RoleID:                   userModel.RoleID,
+		SubscriptionID:           userModel.SubscriptionID,
 		PhoneNumber:              userModel.PhoneNumber,
 		IsPhoneVerified:          userModel.IsPhoneVerified,
 		Email:                    userModel.Email,
 		IsEmailVerified:          userModel.IsEmailVerified,
-		Password:                 userModel.Password,
 		IsHaveInjury:             userModel.IsHaveInjury,
 		InjuryDescription:        userModel.InjuryDescription,
 		Photo:                    userModel.Photo,
+		PhotoThumbnail:           userModel.PhotoThumbnail,
+		CourtPosition:            userModel.CourtPosition,
+		CreatedAt:                userModel.CreatedAt,
+		UpdatedAt:                userModel.UpdatedAt,
+		DeletedAt:                userModel.DeletedAt,
+		DeletionRequestedAt:      userModel.DeletionRequestedAt,
+		BlockedAt:                userModel.BlockedAt,
 	}, nil
 }
@@ -0,0 +1,152 @@
+package dto
+
+import (
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"time"
+)
+
+// UserProjection — набор полей пользователя, который видит вызывающий
+type UserProjection string
+
+const (
+	ProjectionPublic    UserProjection = "public"
+	ProjectionSelf      UserProjection = "self"
+	ProjectionAssistant UserProjection = "assistant"
+	ProjectionAdmin     UserProjection = "admin"
+)
+
+// PublicUserDto — то, что видит любой авторизованный пользователь: без контактов,
+// даты рождения и сведений о здоровье. Скрытые владельцем поля не попадают в ответ (nil + omitempty).
+type PublicUserDto struct {
+	ID            uint64                `json:"id"`
+	Name          string                `json:"name"`
+	Surname       string                `json:"surname"`
+	PhotoURL      *string               `json:"photo_url"`
+	TownID        *int                  `json:"town_id,omitempty"`
+	CourtPosition *models.CourtPosition `json:"court_position,omitempty"`
+}
+
+// SelfUserDto — анкета владельца
+type SelfUserDto struct {
+	PublicUserDto
+	Gender                   string     `json:"gender"`
+	BirthDate                time.Time  `json:"birth_date"`
+	HeightCm                 *int       `json:"height_cm"`
+	WeightKg                 *int       `json:"weight_kg"`
+	SportActivityLevelID     *int       `json:"sport_activity_level_id"`
+	SportTargetID            *int       `json:"sport_target_id"`
+	LocationPreferenceTypeID *int       `json:"location_preference_type_id"`
+	PhoneNumber              string     `json:"phone_number"`
+	IsPhoneVerified          bool       `json:"is_phone_verified"`
+	Email                    string     `json:"email"`
+	IsEmailVerified          bool       `json:"is_email_verified"`
+	IsHaveInjury             bool       `json:"is_have_injury"`
+	InjuryDescription        *string    `json:"injury_description"`
+	CreatedAt                time.Time  `json:"created_at"`
+	DeletionRequestedAt      *time.Time `json:"deletion_requested_at"`
+}
+
+// AssistantUserDto — карточка клиента в CRM ассистента: анкета плюс роль и тариф
+type AssistantUserDto struct {
+	SelfUserDto
+	RoleID         *int `json:"role_id"`
+	SubscriptionID *int `json:"subscription_id"`
+}
+
+// AdminUserDto — полная запись для администратора (кроме учётных данных)
+type AdminUserDto struct {
+	AssistantUserDto
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	BlockedAt *time.Time `json:"blocked_at"`
+}
+
+// ResolveProjection выбирает проекцию по правам вызывающего.
+// Права важнее владения: администратор и в своей анкете видит административные поля.
+func ResolveProjection(viewerID, subjectID uint64, permissions []string) UserProjection {
+	switch {
+	case commons.HasPermission(permissions, commons.PermissionAdminUsersManage):
+		return ProjectionAdmin
+	case commons.HasPermission(permissions, commons.PermissionProfileViewAny):
+		return ProjectionAssistant
+	case viewerID == subjectID:
+		return ProjectionSelf
+	default:
+		return ProjectionPublic
+	}
+}
+
+// ProjectUser собирает ответ нужной проекции. photoURL — уже подписанная ссылка на фото.
+func ProjectUser(user UserDto, projection UserProjection, photoURL *string) any {
+	switch projection {
+	case ProjectionAdmin:
+		return ToAdminUser(user, photoURL)
+	case ProjectionAssistant:
+		return ToAssistantUser(user, photoURL)
+	case ProjectionSelf:
+		return ToSelfUser(user, photoURL)
+	default:
+		return ToPublicUser(user, photoURL)
+	}
+}
+
+func ToPublicUser(user UserDto, photoURL *string) PublicUserDto {
+	return PublicUserDto{
+		ID:            user.ID,
+		Name:          user.Name,
+		Surname:       user.Surname,
+		PhotoURL:      photoURL,
+		TownID:        user.TownID,
+		CourtPosition: user.CourtPosition,
+	}
+}
+
+// WithVisibility убирает из публичной проекции то, что владелец скрыл настройками видимости
+func (u PublicUserDto) WithVisibility(visibility models.ProfileVisibility) PublicUserDto {
+	if !visibility.ShowTown {
+		u.TownID = nil
+	}
+	if !visibility.ShowCourtPosition {
+		u.CourtPosition = nil
+	}
+	return u
+}
+
+func ToSelfUser(user UserDto, photoURL *string) SelfUserDto {
+	return SelfUserDto{
+		PublicUserDto:            ToPublicUser(user, photoURL),
+		Gender:                   user.Gender,
+		BirthDate:                user.BirthDate,
+		HeightCm:                 user.HeightCm,
+		WeightKg:                 user.WeightKg,
+		SportActivityLevelID:     user.SportActivityLevelID,
+		SportTargetID:            user.SportTargetID,
+		LocationPreferenceTypeID: user.LocationPreferenceTypeID,
+		PhoneNumber:              user.PhoneNumber,
+		IsPhoneVerified:          user.IsPhoneVerified,
+		Email:                    user.Email,
+		IsEmailVerified:          user.IsEmailVerified,
+		IsHaveInjury:             user.IsHaveInjury,
+		InjuryDescription:        user.InjuryDescription,
+		CreatedAt:                user.CreatedAt,
+		DeletionRequestedAt:      user.DeletionRequestedAt,
+	}
+}
+
+func ToAssistantUser(user UserDto, photoURL *string) AssistantUserDto {
+	return AssistantUserDto{
+		SelfUserDto:    ToSelfUser(user, photoURL),
+		RoleID:         user.RoleID,
+		SubscriptionID: user.SubscriptionID,
+	}
+}
+
+func ToAdminUser(user UserDto, photoURL *string) AdminUserDto {
+	return AdminUserDto{
+		AssistantUserDto: ToAssistantUser(user, photoURL),
+		UpdatedAt:        user.UpdatedAt,
+		DeletedAt:        user.DeletedAt,
+		BlockedAt:        user.BlockedAt,
+	}
+}
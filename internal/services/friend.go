@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	friendsDefaultPageSize = 50
+	friendsMaxPageSize     = 100
+
+	friendRequestsIncoming = "incoming"
+	friendRequestsOutgoing = "outgoing"
+)
+
+// SendFriendRequest отправляет заявку в друзья. Встречная ожидающая заявка от того же пользователя
+// принимается сразу. Гость может дружить только с теми, с кем сыграл завершённый матч.
+func (s *Service) SendFriendRequest(ctx context.Context, userID uint64, req requests.SendFriendRequestRequest) (models.FriendRequest, error) {
+	if req.UserID == 0 {
+		return models.FriendRequest{}, myerrors.NewValidationError("user_id is required", errors.New("missing user"))
+	}
+	if req.UserID == userID {
+		return models.FriendRequest{}, myerrors.NewValidationError("you cannot add yourself as a friend", errors.New("self friend request"))
+	}
+
+	if _, err := s.repository.GetPlayerCard(ctx, req.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.FriendRequest{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to fetch user", err)
+	}
+	if err := s.checkNotBlocked(ctx, userID, req.UserID); err != nil {
+		return models.FriendRequest{}, err
+	}
+
+	friends, err := s.repository.AreFriends(ctx, userID, req.UserID)
+	if err != nil {
+		return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to check friendship", err)
+	}
+	if friends {
+		return models.FriendRequest{}, myerrors.NewConflictErr("you are already friends", errors.New("already friends"))
+	}
+
+	if err = s.checkCanBefriend(ctx, userID, req.UserID); err != nil {
+		return models.FriendRequest{}, err
+	}
+
+	pending, err := s.repository.GetPendingFriendRequest(ctx, userID, req.UserID)
+	switch {
+	case err == nil && pending.SenderID == userID:
+		return models.FriendRequest{}, myerrors.NewConflictErr("friend request is already sent", errors.New("duplicate friend request"))
+	case err == nil:
+		// встречная заявка: второй пользователь уже хочет дружить
+		if err = s.repository.AcceptFriendRequest(ctx, pending.ID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to accept friend request", err)
+		}
+		return s.getFriendRequest(ctx, pending.ID)
+	case !errors.Is(err, pgx.ErrNoRows):
+		return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to fetch friend request", err)
+	}
+
+	requestID, err := s.repository.CreateFriendRequest(ctx, userID, req.UserID)
+	if err != nil {
+		if errors.Is(err, myerrors.ErrFriendRequestExists) {
+			return models.FriendRequest{}, myerrors.NewConflictErr("friend request is already pending", err)
+		}
+		if errors.Is(err, myerrors.ErrInvalidReference) {
+			return models.FriendRequest{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to create friend request", err)
+	}
+
+	return s.getFriendRequest(ctx, requestID)
+}
+
+// ListFriendRequests — ожидающие заявки: входящие или исходящие
+func (s *Service) ListFriendRequests(ctx context.Context, userID uint64, req requests.FriendRequestsRequest) ([]models.FriendRequest, error) {
+	var incoming bool
+	switch req.Direction {
+	case "", friendRequestsIncoming:
+		incoming = true
+	case friendRequestsOutgoing:
+	default:
+		return nil, myerrors.NewValidationError("direction must be incoming or outgoing", errors.New("invalid direction"))
+	}
+
+	list, err := s.repository.ListFriendRequests(ctx, userID, incoming)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list friend requests", err)
+	}
+	for i := range list {
+		if list[i].User.PhotoURL, err = s.photoURL(ctx, list[i].User.PhotoURL); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// AcceptFriendRequest — получатель принимает заявку; для гостя действует то же правило, что при отправке
+func (s *Service) AcceptFriendRequest(ctx context.Context, userID, requestID uint64) (models.FriendRequest, error) {
+	request, err := s.pendingFriendRequest(ctx, requestID, userID, false)
+	if err != nil {
+		return models.FriendRequest{}, err
+	}
+	if err = s.checkCanBefriend(ctx, userID, request.SenderID); err != nil {
+		return models.FriendRequest{}, err
+	}
+
+	if err = s.repository.AcceptFriendRequest(ctx, requestID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.FriendRequest{}, myerrors.NewConflictErr("friend request is no longer pending", err)
+		}
+		return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to accept friend request", err)
+	}
+
+	return s.getFriendRequest(ctx, requestID)
+}
+
+// DeclineFriendRequest — получатель отклоняет заявку
+func (s *Service) DeclineFriendRequest(ctx context.Context, userID, requestID uint64) (models.FriendRequest, error) {
+	if _, err := s.pendingFriendRequest(ctx, requestID, userID, false); err != nil {
+		return models.FriendRequest{}, err
+	}
+
+	return s.closeFriendRequest(ctx, requestID, models.FriendRequestDeclined)
+}
+
+// CancelFriendRequest — отправитель отзывает заявку
+func (s *Service) CancelFriendRequest(ctx context.Context, userID, requestID uint64) (models.FriendRequest, error) {
+	if _, err := s.pendingFriendRequest(ctx, requestID, userID, true); err != nil {
+		return models.FriendRequest{}, err
+	}
+
+	return s.closeFriendRequest(ctx, requestID, models.FriendRequestCancelled)
+}
+
+func (s *Service) RemoveFriend(ctx context.Context, userID, friendID uint64) error {
+	if err := s.repository.RemoveFriend(ctx, userID, friendID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewNotFoundErr("user is not in your friends", err)
+		}
+		return myerrors.NewRepositoryErr("failed to remove friend", err)
+	}
+
+	return nil
+}
+
+// ListFriends — друзья пользователя с рейтингами и общими друзьями со смотрящим.
+// Чужой список скрыт, если владелец выключил show_friends.
+func (s *Service) ListFriends(ctx context.Context, viewerID, userID uint64, req requests.FriendsRequest) (responses.FriendsResponse, error) {
+	if err := s.checkStatsVisible(ctx, viewerID, userID, func(v models.ProfileVisibility) bool { return v.ShowFriends }); err != nil {
+		return responses.FriendsResponse{}, err
+	}
+
+	page := max(req.Page, 1)
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = friendsDefaultPageSize
+	}
+	pageSize = min(pageSize, friendsMaxPageSize)
+
+	friends, err := s.repository.ListFriends(ctx, userID, viewerID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return responses.FriendsResponse{}, myerrors.NewRepositoryErr("failed to list friends", err)
+	}
+	for i := range friends {
+		if friends[i].PhotoURL, err = s.photoURL(ctx, friends[i].PhotoURL); err != nil {
+			return responses.FriendsResponse{}, err
+		}
+	}
+
+	return responses.FriendsResponse{Friends: friends, Page: page, PageSize: pageSize}, nil
+}
+
+// checkCanBefriend — гость может дружить только с теми, с кем сыграл завершённый матч
+func (s *Service) checkCanBefriend(ctx context.Context, userID, otherID uint64) error {
+	guest, err := s.repository.IsGuest(ctx, userID)
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to fetch user role", err)
+	}
+	if !guest {
+		return nil
+	}
+
+	played, err := s.repository.HavePlayedTogether(ctx, userID, otherID)
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to check match history", err)
+	}
+	if !played {
+		return myerrors.NewForbiddenErr("guests can only add players they have played a match with", errors.New("no shared match"))
+	}
+
+	return nil
+}
+
+// pendingFriendRequest — ожидающая заявка, где userID — отправитель (sender) или получатель
+func (s *Service) pendingFriendRequest(ctx context.Context, requestID, userID uint64, sender bool) (models.FriendRequest, error) {
+	request, err := s.getFriendRequest(ctx, requestID)
+	if err != nil {
+		return models.FriendRequest{}, err
+	}
+
+	// чужая заявка выглядит несуществующей
+	if (sender && request.SenderID != userID) || (!sender && request.ReceiverID != userID) {
+		return models.FriendRequest{}, myerrors.NewNotFoundErr("friend request not found", errors.New("not a party of the request"))
+	}
+	if request.Status != models.FriendRequestPending {
+		return models.FriendRequest{}, myerrors.NewConflictErr("friend request is already "+string(request.Status), errors.New("request is closed"))
+	}
+
+	return request, nil
+}
+
+func (s *Service) closeFriendRequest(ctx context.Context, requestID uint64, status models.FriendRequestStatus) (models.FriendRequest, error) {
+	if err := s.repository.CloseFriendRequest(ctx, requestID, status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.FriendRequest{}, myerrors.NewConflictErr("friend request is no longer pending", err)
+		}
+		return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to update friend request", err)
+	}
+
+	return s.getFriendRequest(ctx, requestID)
+}
+
+func (s *Service) getFriendRequest(ctx context.Context, requestID uint64) (models.FriendRequest, error) {
+	request, err := s.repository.GetFriendRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.FriendRequest{}, myerrors.NewNotFoundErr("friend request not found", err)
+		}
+		return models.FriendRequest{}, myerrors.NewRepositoryErr("failed to fetch friend request", err)
+	}
+
+	return request, nil
+}
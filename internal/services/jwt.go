@@ -46,8 +46,6 @@ func (s *Service) CreateTokens(ctx context.Context, userID uint64, email string)
 func (s *Service) RefreshTokens(ctx context.Context, req requests.RefreshTokensRequest) (responses.JWTResponse, error) {
 	oldRefreshToken := req.RefreshToken
 	claims := &models.CustomClaims{}
-	now := time.Now()
-	expiresAtRefreshToken := now.Add(s.cfg.SecurityConfig.RefreshTokenTTL)
 
 	token, err := jwt.ParseWithClaims(req.RefreshToken, claims, func(t *jwt.Token) (interface{}, error) {
 		if t.Method != jwt.SigningMethodHS256 {
@@ -77,22 +75,38 @@ func (s *Service) RefreshTokens(ctx context.Context, req requests.RefreshTokensR
 		return responses.JWTResponse{}, err
 	}
 
-	newRefreshToken, err := s.createRefreshToken(ctx, user.ID, now)
+	if user.BlockedAt != nil {
+		return responses.JWTResponse{}, myerrors.NewForbiddenErr("account is blocked", errors.New("user blocked"))
+	}
+
+	accessToken, newRefreshToken, err := s.rotateTokens(ctx, user.ID, user.Email, oldRefreshToken)
 	if err != nil {
 		return responses.JWTResponse{}, err
 	}
 
-	err = s.repository.RotateRefreshToken(ctx, user.ID, oldRefreshToken, newRefreshToken, expiresAtRefreshToken)
+	return responses.JWTResponse{AccessToken: accessToken, RefreshToken: newRefreshToken}, nil
+}
+
+// rotateTokens отзывает oldRefreshToken и выдаёт новую пару с актуальными правами пользователя
+func (s *Service) rotateTokens(ctx context.Context, userID uint64, email, oldRefreshToken string) (string, string, error) {
+	now := time.Now()
+
+	newRefreshToken, err := s.createRefreshToken(ctx, userID, now)
 	if err != nil {
-		return responses.JWTResponse{}, err
+		return "", "", err
 	}
 
-	accessToken, err := s.createAccessToken(ctx, refreshToken.UserID, user.Email, now)
+	err = s.repository.RotateRefreshToken(ctx, userID, oldRefreshToken, newRefreshToken, now.Add(s.cfg.SecurityConfig.RefreshTokenTTL))
 	if err != nil {
-		return responses.JWTResponse{}, err
+		return "", "", err
 	}
 
-	return responses.JWTResponse{AccessToken: accessToken, RefreshToken: newRefreshToken}, nil
+	accessToken, err := s.createAccessToken(ctx, userID, email, now)
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, newRefreshToken, nil
 }
 
 func (s *Service) IsTokenExpired(expiresAt time.Time) bool {
@@ -172,3 +186,12 @@ func (s *Service) saveToRedis(ctx context.Context, key, token string, expiresAt
 	ttl := time.Until(expiresAt)
 	return s.redisClient.Set(ctx, key, token, ttl).Err()
 }
+
+// dropAccessToken отзывает текущий access-токен пользователя: следующий запрос получит 401,
+// и клиент обновит пару токенов уже с актуальными правами
+func (s *Service) dropAccessToken(ctx context.Context, userID uint64) {
+	key := fmt.Sprintf(s.cfg.SecurityConfig.AccessTokenRedisPrefix, userID)
+	if err := s.redisClient.Del(ctx, key).Err(); err != nil {
+		s.logger.Warn("failed to drop access token", "user_id", userID, "err", err)
+	}
+}
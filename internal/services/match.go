@@ -1 +1,306 @@
 package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	matchesDefaultPageSize = 20
+	matchesMaxPageSize     = 100
+	matchMinPlayers        = 2
+	matchMaxParticipants   = 32
+
+	matchDefaultDuration = 90 * time.Minute
+	matchMinDuration     = 15 * time.Minute
+	matchMaxDuration     = 24 * time.Hour
+
+	matchScopeUpcoming = "upcoming"
+	matchScopePast     = "past"
+)
+
+// CreateMatch создаёт матч: организатор становится участником сразу, остальные получают приглашения
+func (s *Service) CreateMatch(ctx context.Context, organizerID uint64, req requests.CreateMatchRequest, permissions []string) (models.MatchDetails, error) {
+	if req.MatchTypeID <= 0 {
+		return models.MatchDetails{}, myerrors.NewValidationError("match_type_id is required", errors.New("missing match type"))
+	}
+	now := time.Now()
+	if req.StartsAt.IsZero() || !req.StartsAt.After(now) {
+		return models.MatchDetails{}, myerrors.NewValidationError("starts_at must be in the future", errors.New("invalid start time"))
+	}
+
+	requiredPlayers := req.RequiredPlayers
+	if requiredPlayers == 0 {
+		requiredPlayers = matchMinPlayers
+	}
+	if requiredPlayers < matchMinPlayers || requiredPlayers > matchMaxParticipants {
+		return models.MatchDetails{}, myerrors.NewValidationError(
+			fmt.Sprintf("required_players must be between %d and %d", matchMinPlayers, matchMaxParticipants),
+			errors.New("invalid required players"),
+		)
+	}
+
+	invitees := uniqueInvitees(req.InviteUserIDs, organizerID)
+	// приглашать при создании можно только с тем же правом, что и через отдельный эндпоинт
+	if len(invitees) > 0 && !commons.HasPermission(permissions, commons.PermissionMatchInviteUsers) {
+		return models.MatchDetails{}, myerrors.NewForbiddenErr("inviting players is not allowed", errors.New("missing match.invite.users"))
+	}
+	if len(invitees)+1 > matchMaxParticipants {
+		return models.MatchDetails{}, myerrors.NewValidationError("too many invited players", errors.New("participants limit exceeded"))
+	}
+
+	visibility := models.MatchVisibility(req.Visibility)
+	switch visibility {
+	case "":
+		visibility = models.MatchVisibilityPrivate
+	case models.MatchVisibilityPrivate:
+	case models.MatchVisibilityPublic:
+		if req.SportID == nil || req.TownID == nil {
+			return models.MatchDetails{}, myerrors.NewValidationError("public match requires sport_id and town_id", errors.New("missing public match location"))
+		}
+	default:
+		return models.MatchDetails{}, myerrors.NewValidationError("visibility must be private or public", errors.New("invalid visibility"))
+	}
+	if req.Capacity != nil && (*req.Capacity < requiredPlayers || *req.Capacity > matchMaxParticipants) {
+		return models.MatchDetails{}, myerrors.NewValidationError(
+			fmt.Sprintf("capacity must be between required_players and %d", matchMaxParticipants),
+			errors.New("invalid capacity"),
+		)
+	}
+	if req.MinLevelID != nil && req.MaxLevelID != nil && *req.MinLevelID > *req.MaxLevelID {
+		return models.MatchDetails{}, myerrors.NewValidationError("min_level_id must not be greater than max_level_id", errors.New("invalid level band"))
+	}
+	if req.MinReliability != nil {
+		if visibility != models.MatchVisibilityPublic {
+			return models.MatchDetails{}, myerrors.NewValidationError("min_reliability applies only to public matches", errors.New("reliability on private match"))
+		}
+		if *req.MinReliability < 0 || *req.MinReliability > reliabilityMaxScore {
+			return models.MatchDetails{}, myerrors.NewValidationError(
+				fmt.Sprintf("min_reliability must be between 0 and %d", reliabilityMaxScore),
+				errors.New("invalid min reliability"),
+			)
+		}
+	}
+
+	var venue *string
+	if req.Venue != nil {
+		if v := strings.TrimSpace(*req.Venue); v != "" {
+			venue = &v
+		}
+	}
+
+	startsAt := req.StartsAt.UTC()
+	endsAt, err := matchEndsAt(startsAt, req.EndsAt, req.DurationMinutes)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	sportObjectID, err := s.matchSportObject(ctx, req.SportObjectID, req.CourtID)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	if err = s.checkScheduleConflicts(ctx, organizerID, startsAt, endsAt, 0); err != nil {
+		return models.MatchDetails{}, err
+	}
+	if invitees, err = s.withoutBlocked(ctx, organizerID, invitees); err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	matchID, err := s.repository.CreateMatch(ctx, models.Match{
+		MatchTypeID:     req.MatchTypeID,
+		OrganizerID:     &organizerID,
+		Visibility:      visibility,
+		SportID:         req.SportID,
+		TownID:          req.TownID,
+		Venue:           venue,
+		MinLevelID:      req.MinLevelID,
+		MaxLevelID:      req.MaxLevelID,
+		RequiredPlayers: requiredPlayers,
+		Capacity:        req.Capacity,
+		MinReliability:  req.MinReliability,
+		StartsAt:        &startsAt,
+		EndsAt:          &endsAt,
+		SportObjectID:   sportObjectID,
+		CourtID:         req.CourtID,
+	}, invitees, s.invitationExpiresAt(now, startsAt))
+	if err != nil {
+		if errors.Is(err, myerrors.ErrInvalidReference) {
+			return models.MatchDetails{}, myerrors.NewValidationError("unknown match type, sport, town, level, sport object or invited user", err)
+		}
+		return models.MatchDetails{}, myerrors.NewRepositoryErr("failed to create match", err)
+	}
+
+	return s.matchDetails(ctx, matchID)
+}
+
+// GetMatch отдаёт публичный матч любому пользователю, а приватный — участнику, организатору,
+// приглашённому или пользователю с правом match.manage.any
+func (s *Service) GetMatch(ctx context.Context, viewerID, matchID uint64, permissions []string) (models.MatchDetails, error) {
+	details, err := s.matchDetails(ctx, matchID)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	if details.Visibility == models.MatchVisibilityPublic ||
+		isMatchOrganizer(details.Match, viewerID) ||
+		containsID(details.ParticipantIDs, viewerID) ||
+		commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return details, nil
+	}
+
+	// приглашённый видит матч, чтобы решить, принимать ли приглашение
+	invited, err := s.repository.HasPendingMatchInvitation(ctx, matchID, viewerID)
+	if err != nil {
+		return models.MatchDetails{}, myerrors.NewRepositoryErr("failed to check match invitation", err)
+	}
+	if !invited {
+		return models.MatchDetails{}, myerrors.NewForbiddenErr("you are not a participant of this match", errors.New("not a participant"))
+	}
+
+	return details, nil
+}
+
+// ListMyMatches возвращает страницу предстоящих или прошедших матчей пользователя
+func (s *Service) ListMyMatches(ctx context.Context, userID uint64, req requests.MyMatchesRequest) (responses.MatchesResponse, error) {
+	filter := models.MatchListFilter{
+		Now:        time.Now().UTC(),
+		PartnerID:  req.PartnerID,
+		OpponentID: req.OpponentID,
+	}
+	scope := req.Scope
+	if scope == "" && (req.PartnerID != nil || req.OpponentID != nil) {
+		// у напарников и соперников бывают только сыгранные матчи
+		scope = matchScopePast
+	}
+	switch scope {
+	case "", matchScopeUpcoming:
+		filter.Upcoming = true
+	case matchScopePast:
+	default:
+		return responses.MatchesResponse{}, myerrors.NewValidationError("scope must be upcoming or past", errors.New("invalid scope"))
+	}
+
+	page := max(req.Page, 1)
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = matchesDefaultPageSize
+	}
+	pageSize = min(pageSize, matchesMaxPageSize)
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	matches, err := s.repository.ListUserMatches(ctx, userID, filter)
+	if err != nil {
+		return responses.MatchesResponse{}, myerrors.NewRepositoryErr("failed to list matches", err)
+	}
+
+	return responses.MatchesResponse{
+		Matches:  matches,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// LeaveMatch убирает участника из открытого матча, пока тот не начался. Организатор выйти не может —
+// ему остаётся отменить матч. Выход незадолго до начала записывается как поздняя отмена.
+func (s *Service) LeaveMatch(ctx context.Context, userID, matchID uint64) error {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	if !match.Status.IsOpen() {
+		return myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+	// после начала неявка фиксируется отметкой посещаемости, а не выходом из матча
+	if match.StartsAt != nil && !match.StartsAt.After(time.Now()) {
+		return myerrors.NewConflictErr("match has already started", errors.New("match started"))
+	}
+	if isMatchOrganizer(match, userID) {
+		return myerrors.NewConflictErr("organizer cannot leave the match, cancel it instead", errors.New("organizer leave"))
+	}
+
+	if err = s.repository.RemoveUserFromMatch(ctx, matchID, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewForbiddenErr("you are not a participant of this match", err)
+		}
+		return myerrors.NewRepositoryErr("failed to leave match", err)
+	}
+	s.recordLateCancellation(ctx, userID, match)
+
+	return nil
+}
+
+// CancelMatch отменяет матч и отзывает ожидающие приглашения.
+// Доступно организатору и пользователю с правом match.manage.any.
+func (s *Service) CancelMatch(ctx context.Context, userID, matchID uint64, permissions []string) (models.MatchDetails, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+	if !isMatchOrganizer(match, userID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return models.MatchDetails{}, myerrors.NewForbiddenErr("only the organizer can cancel the match", errors.New("not an organizer"))
+	}
+	if !match.Status.IsOpen() {
+		return models.MatchDetails{}, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+
+	if err = s.repository.CancelMatch(ctx, matchID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchDetails{}, myerrors.NewConflictErr("match is already closed", err)
+		}
+		return models.MatchDetails{}, myerrors.NewRepositoryErr("failed to cancel match", err)
+	}
+
+	return s.matchDetails(ctx, matchID)
+}
+
+func (s *Service) getMatch(ctx context.Context, matchID uint64) (models.Match, error) {
+	match, err := s.repository.GetMatchByID(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Match{}, myerrors.NewNotFoundErr("match not found", err)
+		}
+		return models.Match{}, myerrors.NewRepositoryErr("failed to fetch match", err)
+	}
+
+	return match, nil
+}
+
+func (s *Service) matchDetails(ctx context.Context, matchID uint64) (models.MatchDetails, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	participants, err := s.repository.GetMatchParticipants(ctx, matchID)
+	if err != nil {
+		return models.MatchDetails{}, myerrors.NewRepositoryErr("failed to fetch match participants", err)
+	}
+
+	return models.MatchDetails{Match: match, ParticipantIDs: participants}, nil
+}
+
+func isMatchOrganizer(match models.Match, userID uint64) bool {
+	return match.OrganizerID != nil && *match.OrganizerID == userID
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
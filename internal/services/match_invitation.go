@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// InviteToMatch приглашает игроков в открытый матч. Участники и уже приглашённые пропускаются.
+// Приглашать может организатор или пользователь с правом match.manage.any.
+func (s *Service) InviteToMatch(ctx context.Context, userID, matchID uint64, req requests.InviteToMatchRequest, permissions []string) ([]models.MatchInvitation, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMatchOrganizer(match, userID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return nil, myerrors.NewForbiddenErr("only the organizer can invite players", errors.New("not an organizer"))
+	}
+	if !match.Status.IsOpen() {
+		return nil, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+
+	invitees := uniqueInvitees(req.UserIDs, userID)
+	if len(invitees) == 0 {
+		return nil, myerrors.NewValidationError("user_ids is required", errors.New("no invitees"))
+	}
+	if len(invitees) >= matchMaxParticipants {
+		return nil, myerrors.NewValidationError("too many invited players", errors.New("participants limit exceeded"))
+	}
+
+	// у матчей, созданных до появления starts_at, срок ограничен только TTL
+	now := time.Now()
+	deadline := now.Add(s.cfg.MatchConfig.InvitationTTL)
+	if match.StartsAt != nil {
+		if !match.StartsAt.After(now) {
+			return nil, myerrors.NewConflictErr("match has already started", errors.New("match started"))
+		}
+		deadline = *match.StartsAt
+	}
+
+	if invitees, err = s.withoutBlocked(ctx, userID, invitees); err != nil {
+		return nil, err
+	}
+	if len(invitees) == 0 {
+		return []models.MatchInvitation{}, nil
+	}
+
+	invitations, err := s.repository.CreateMatchInvitations(ctx, matchID, userID, invitees, s.invitationExpiresAt(now, deadline))
+	if err != nil {
+		if errors.Is(err, myerrors.ErrInvalidReference) {
+			return nil, myerrors.NewValidationError("unknown invited user", err)
+		}
+		return nil, myerrors.NewRepositoryErr("failed to invite players", err)
+	}
+
+	return invitations, nil
+}
+
+// ListMatchInvitations показывает приглашения матча его участникам и организатору
+func (s *Service) ListMatchInvitations(ctx context.Context, userID, matchID uint64, permissions []string) ([]models.MatchInvitation, error) {
+	details, err := s.matchDetails(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMatchOrganizer(details.Match, userID) &&
+		!containsID(details.ParticipantIDs, userID) &&
+		!commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return nil, myerrors.NewForbiddenErr("you are not a participant of this match", errors.New("not a participant"))
+	}
+
+	invitations, err := s.repository.ListMatchInvitations(ctx, matchID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list match invitations", err)
+	}
+
+	return invitations, nil
+}
+
+// ListMyInvitations — действующие приглашения пользователя, ждущие ответа
+func (s *Service) ListMyInvitations(ctx context.Context, userID uint64) ([]models.MatchInvitation, error) {
+	invitations, err := s.repository.ListUserPendingInvitations(ctx, userID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list invitations", err)
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation подтверждает участие. Когда подтвердили required_players игроков, матч становится active.
+func (s *Service) AcceptInvitation(ctx context.Context, userID, invitationID uint64) (models.MatchDetails, error) {
+	invitation, err := s.ownInvitation(ctx, userID, invitationID)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	match, err := s.getMatch(ctx, invitation.MatchID)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+	if !match.Status.IsOpen() {
+		return models.MatchDetails{}, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+	if err = s.checkMatchBlocks(ctx, userID, invitation.MatchID); err != nil {
+		return models.MatchDetails{}, err
+	}
+	if err = s.checkMatchScheduleConflicts(ctx, userID, match); err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	if err = s.repository.AcceptMatchInvitation(ctx, invitationID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchDetails{}, myerrors.NewConflictErr("invitation is no longer valid", err)
+		}
+		if errors.Is(err, myerrors.ErrMatchFull) {
+			return models.MatchDetails{}, myerrors.NewConflictErr("match has no free spots", err)
+		}
+		return models.MatchDetails{}, myerrors.NewRepositoryErr("failed to accept invitation", err)
+	}
+
+	return s.matchDetails(ctx, invitation.MatchID)
+}
+
+func (s *Service) DeclineInvitation(ctx context.Context, userID, invitationID uint64) error {
+	if _, err := s.ownInvitation(ctx, userID, invitationID); err != nil {
+		return err
+	}
+
+	return s.closeInvitation(ctx, invitationID, models.InvitationStatusDeclined)
+}
+
+// RevokeInvitation отзывает приглашение. Доступно организатору и пользователю с правом match.manage.any.
+func (s *Service) RevokeInvitation(ctx context.Context, userID, invitationID uint64, permissions []string) error {
+	invitation, err := s.getInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+
+	match, err := s.getMatch(ctx, invitation.MatchID)
+	if err != nil {
+		return err
+	}
+	if !isMatchOrganizer(match, userID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return myerrors.NewForbiddenErr("only the organizer can revoke invitations", errors.New("not an organizer"))
+	}
+	if invitation.Status != models.InvitationStatusPending {
+		return myerrors.NewConflictErr("invitation is already "+string(invitation.Status), errors.New("invitation is not pending"))
+	}
+
+	return s.closeInvitation(ctx, invitationID, models.InvitationStatusRevoked)
+}
+
+func (s *Service) getInvitation(ctx context.Context, invitationID uint64) (models.MatchInvitation, error) {
+	invitation, err := s.repository.GetMatchInvitation(ctx, invitationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchInvitation{}, myerrors.NewNotFoundErr("invitation not found", err)
+		}
+		return models.MatchInvitation{}, myerrors.NewRepositoryErr("failed to fetch invitation", err)
+	}
+
+	return invitation, nil
+}
+
+// ownInvitation возвращает действующее приглашение, адресованное пользователю.
+// Чужое приглашение не раскрывается: для него ответ такой же, как для несуществующего.
+func (s *Service) ownInvitation(ctx context.Context, userID, invitationID uint64) (models.MatchInvitation, error) {
+	invitation, err := s.getInvitation(ctx, invitationID)
+	if err != nil {
+		return models.MatchInvitation{}, err
+	}
+	if invitation.UserID != userID {
+		return models.MatchInvitation{}, myerrors.NewNotFoundErr("invitation not found", errors.New("foreign invitation"))
+	}
+	if invitation.Status != models.InvitationStatusPending {
+		return models.MatchInvitation{}, myerrors.NewConflictErr("invitation is already "+string(invitation.Status), errors.New("invitation is not pending"))
+	}
+
+	return invitation, nil
+}
+
+func (s *Service) closeInvitation(ctx context.Context, invitationID uint64, status models.InvitationStatus) error {
+	if err := s.repository.CloseMatchInvitation(ctx, invitationID, status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewConflictErr("invitation is no longer pending", err)
+		}
+		return myerrors.NewRepositoryErr("failed to update invitation", err)
+	}
+
+	return nil
+}
+
+// invitationExpiresAt — срок приглашения: MATCH_INVITATION_TTL, но не позже начала матча
+func (s *Service) invitationExpiresAt(now, startsAt time.Time) time.Time {
+	expiresAt := now.Add(s.cfg.MatchConfig.InvitationTTL)
+	if s.cfg.MatchConfig.InvitationTTL <= 0 || expiresAt.After(startsAt) {
+		expiresAt = startsAt
+	}
+
+	return expiresAt.UTC()
+}
+
+// uniqueInvitees убирает дубли, нули и самого приглашающего
+func uniqueInvitees(userIDs []uint64, inviterID uint64) []uint64 {
+	seen := map[uint64]bool{inviterID: true}
+	invitees := make([]uint64, 0, len(userIDs))
+	for _, id := range userIDs {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		invitees = append(invitees, id)
+	}
+
+	return invitees
+}
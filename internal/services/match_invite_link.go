@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const inviteLinkMaxHours = 24 * 90
+
+var errInviteLinksDisabled = errors.New("MATCH_INVITE_LINK_SECRET is not configured")
+
+// CreateInviteLink выпускает ссылку-приглашение в открытый матч. Доступно организатору
+// и пользователю с правом match.manage.any. Ссылка живёт не дольше начала матча.
+func (s *Service) CreateInviteLink(ctx context.Context, userID, matchID uint64, req requests.CreateInviteLinkRequest, permissions []string) (models.MatchInviteLink, error) {
+	if s.cfg.MatchConfig.InviteLinkSecret == "" {
+		return models.MatchInviteLink{}, errInviteLinksDisabled
+	}
+	if req.MaxUses != nil && *req.MaxUses <= 0 {
+		return models.MatchInviteLink{}, myerrors.NewValidationError("max_uses must be positive", errors.New("invalid max uses"))
+	}
+	if req.ExpiresInHours < 0 || req.ExpiresInHours > inviteLinkMaxHours {
+		return models.MatchInviteLink{}, myerrors.NewValidationError(
+			fmt.Sprintf("expires_in_hours must be between 1 and %d", inviteLinkMaxHours), errors.New("invalid link ttl"))
+	}
+
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return models.MatchInviteLink{}, err
+	}
+	if !isMatchOrganizer(match, userID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return models.MatchInviteLink{}, myerrors.NewForbiddenErr("only the organizer can share invite links", errors.New("not an organizer"))
+	}
+	if !match.Status.IsOpen() {
+		return models.MatchInviteLink{}, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+
+	now := time.Now()
+	ttl := s.cfg.MatchConfig.InviteLinkTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	expiresAt := now.Add(ttl)
+	if match.StartsAt != nil {
+		if !match.StartsAt.After(now) {
+			return models.MatchInviteLink{}, myerrors.NewConflictErr("match has already started", errors.New("match started"))
+		}
+		if expiresAt.After(*match.StartsAt) {
+			expiresAt = *match.StartsAt
+		}
+	}
+
+	link, err := s.repository.CreateMatchInviteLink(ctx, models.MatchInviteLink{
+		MatchID:   matchID,
+		InviterID: &userID,
+		MaxUses:   req.MaxUses,
+		// секунды: подпись токена считается по Unix-времени
+		ExpiresAt: expiresAt.UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		return models.MatchInviteLink{}, myerrors.NewRepositoryErr("failed to create invite link", err)
+	}
+
+	return s.withInviteToken(link), nil
+}
+
+// ListInviteLinks показывает ссылки матча с числом использований
+func (s *Service) ListInviteLinks(ctx context.Context, userID, matchID uint64, permissions []string) ([]models.MatchInviteLink, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMatchOrganizer(match, userID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return nil, myerrors.NewForbiddenErr("only the organizer can see invite links", errors.New("not an organizer"))
+	}
+
+	links, err := s.repository.ListMatchInviteLinks(ctx, matchID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list invite links", err)
+	}
+
+	for i := range links {
+		links[i] = s.withInviteToken(links[i])
+	}
+
+	return links, nil
+}
+
+// RevokeInviteLink закрывает ссылку; приглашения, уже выданные по ней, не отзываются
+func (s *Service) RevokeInviteLink(ctx context.Context, userID, linkID uint64, permissions []string) error {
+	link, err := s.getInviteLink(ctx, linkID)
+	if err != nil {
+		return err
+	}
+
+	match, err := s.getMatch(ctx, link.MatchID)
+	if err != nil {
+		return err
+	}
+	if !isMatchOrganizer(match, userID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return myerrors.NewForbiddenErr("only the organizer can revoke invite links", errors.New("not an organizer"))
+	}
+
+	if err = s.repository.RevokeMatchInviteLink(ctx, linkID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewConflictErr("invite link is already revoked", err)
+		}
+		return myerrors.NewRepositoryErr("failed to revoke invite link", err)
+	}
+
+	return nil
+}
+
+// RedeemInviteLink выдаёт пользователю приглашение по ссылке; участие он подтверждает
+// так же, как для обычного приглашения
+func (s *Service) RedeemInviteLink(ctx context.Context, userID uint64, token string) (models.MatchInvitation, error) {
+	linkID, err := s.parseInviteToken(token)
+	if err != nil {
+		return models.MatchInvitation{}, err
+	}
+
+	link, err := s.getInviteLink(ctx, linkID)
+	if err != nil {
+		return models.MatchInvitation{}, err
+	}
+	now := time.Now()
+	if !link.IsUsable(now) {
+		return models.MatchInvitation{}, myerrors.NewConflictErr("invite link is no longer valid", errors.New("link is not usable"))
+	}
+
+	match, err := s.getMatch(ctx, link.MatchID)
+	if err != nil {
+		return models.MatchInvitation{}, err
+	}
+	if !match.Status.IsOpen() {
+		return models.MatchInvitation{}, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+
+	participant, err := s.repository.IsUserInMatch(ctx, link.MatchID, userID)
+	if err != nil {
+		return models.MatchInvitation{}, myerrors.NewRepositoryErr("failed to check match participation", err)
+	}
+	if participant {
+		return models.MatchInvitation{}, myerrors.NewConflictErr("you are already a participant of this match", errors.New("already participant"))
+	}
+	if err = s.checkMatchBlocks(ctx, userID, link.MatchID); err != nil {
+		return models.MatchInvitation{}, err
+	}
+
+	deadline := link.ExpiresAt
+	if match.StartsAt != nil {
+		deadline = *match.StartsAt
+	}
+
+	invitation, err := s.repository.RedeemMatchInviteLink(ctx, linkID, userID, s.invitationExpiresAt(now, deadline))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchInvitation{}, myerrors.NewConflictErr("invite link is no longer valid", err)
+		}
+		return models.MatchInvitation{}, myerrors.NewRepositoryErr("failed to redeem invite link", err)
+	}
+
+	return invitation, nil
+}
+
+// rememberInviteForPhone сохраняет ссылку до регистрации: Register выдаст по ней приглашение
+func (s *Service) rememberInviteForPhone(ctx context.Context, phone, token string) error {
+	linkID, err := s.parseInviteToken(token)
+	if err != nil {
+		return err
+	}
+
+	link, err := s.getInviteLink(ctx, linkID)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(link.ExpiresAt)
+	if !link.IsUsable(time.Now()) || ttl <= 0 {
+		return myerrors.NewConflictErr("invite link is no longer valid", errors.New("link is not usable"))
+	}
+
+	key := fmt.Sprintf(s.cfg.MatchConfig.InviteLinkRedisPrefix, phone)
+	if err = s.redisClient.Set(ctx, key, token, ttl).Err(); err != nil {
+		return myerrors.NewTokenErr("failed to save invite link in redis", err)
+	}
+
+	return nil
+}
+
+// redeemPendingInvite выдаёт новому пользователю приглашение по ссылке, с которой он пришёл.
+// Регистрацию это не ломает: ошибка только логируется.
+func (s *Service) redeemPendingInvite(ctx context.Context, userID uint64, phone string) {
+	key := fmt.Sprintf(s.cfg.MatchConfig.InviteLinkRedisPrefix, phone)
+	token, err := s.redisClient.GetDel(ctx, key).Result()
+	if err != nil {
+		return
+	}
+
+	if _, err = s.RedeemInviteLink(ctx, userID, token); err != nil {
+		s.logger.Warn("failed to redeem pending invite link", "user_id", userID, "err", err)
+	}
+}
+
+func (s *Service) getInviteLink(ctx context.Context, linkID uint64) (models.MatchInviteLink, error) {
+	link, err := s.repository.GetMatchInviteLink(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchInviteLink{}, myerrors.NewNotFoundErr("invite link not found", err)
+		}
+		return models.MatchInviteLink{}, myerrors.NewRepositoryErr("failed to fetch invite link", err)
+	}
+
+	return link, nil
+}
+
+func (s *Service) withInviteToken(link models.MatchInviteLink) models.MatchInviteLink {
+	link.Token = s.signInviteToken(link.ID, link.ExpiresAt.Unix())
+	link.URL = s.cfg.MatchConfig.InviteLinkBaseURL + link.Token
+	return link
+}
+
+// signInviteToken собирает токен вида <id>.<expires>.<hmac>
+func (s *Service) signInviteToken(linkID uint64, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.MatchConfig.InviteLinkSecret))
+	mac.Write([]byte(strconv.FormatUint(linkID, 10)))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+
+	return fmt.Sprintf("%d.%d.%s", linkID, expires, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// parseInviteToken проверяет подпись и срок токена и возвращает id ссылки.
+// Отзыв и лимит использований проверяются уже по записи в базе.
+func (s *Service) parseInviteToken(token string) (uint64, error) {
+	if s.cfg.MatchConfig.InviteLinkSecret == "" {
+		return 0, errInviteLinksDisabled
+	}
+
+	token = strings.TrimSpace(token)
+	invalid := myerrors.NewValidationError("invite link is invalid or expired", errors.New("invalid invite token"))
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, invalid
+	}
+	linkID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, invalid
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return 0, invalid
+	}
+	if !hmac.Equal([]byte(s.signInviteToken(linkID, expires)), []byte(token)) {
+		return 0, invalid
+	}
+
+	return linkID, nil
+}
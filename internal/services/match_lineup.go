@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/bits"
+	"sort"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// до такого числа участников автобаланс перебирает все разбиения, дальше — раздача «змейкой»
+const lineupBruteForceMaxPlayers = 12
+
+// GetMatchLineup — состав команд матча; видят те же, кто видит матч
+func (s *Service) GetMatchLineup(ctx context.Context, viewerID, matchID uint64, permissions []string) (responses.MatchLineupResponse, error) {
+	if _, err := s.GetMatch(ctx, viewerID, matchID, permissions); err != nil {
+		return responses.MatchLineupResponse{}, err
+	}
+
+	return s.matchLineup(ctx, matchID)
+}
+
+// SetMatchLineup — организатор (или match.manage.any) расставляет участников по командам и сторонам.
+// Состав заменяется целиком; не перечисленные участники остаются без команды.
+func (s *Service) SetMatchLineup(ctx context.Context, userID, matchID uint64, req requests.SetMatchLineupRequest, permissions []string) (responses.MatchLineupResponse, error) {
+	if _, err := s.lineupMatch(ctx, userID, matchID, permissions); err != nil {
+		return responses.MatchLineupResponse{}, err
+	}
+
+	lineup, err := s.repository.GetMatchLineup(ctx, matchID)
+	if err != nil {
+		return responses.MatchLineupResponse{}, myerrors.NewRepositoryErr("failed to fetch match lineup", err)
+	}
+	if err = validateLineup(lineup, req.Players); err != nil {
+		return responses.MatchLineupResponse{}, err
+	}
+
+	return s.saveLineup(ctx, matchID, req.Players)
+}
+
+// AutoBalanceLineup делит участников на две команды с минимальной разницей среднего рейтинга
+// в виде спорта матча (без рейтинга — начальный). В парах левую сторону получает тот, кто её
+// предпочитает, а при равных предпочтениях — более сильный игрок.
+func (s *Service) AutoBalanceLineup(ctx context.Context, userID, matchID uint64, permissions []string) (responses.MatchLineupResponse, error) {
+	if _, err := s.lineupMatch(ctx, userID, matchID, permissions); err != nil {
+		return responses.MatchLineupResponse{}, err
+	}
+
+	lineup, err := s.repository.GetMatchLineup(ctx, matchID)
+	if err != nil {
+		return responses.MatchLineupResponse{}, myerrors.NewRepositoryErr("failed to fetch match lineup", err)
+	}
+	if len(lineup) < 2 {
+		return responses.MatchLineupResponse{}, myerrors.NewConflictErr("at least two participants are needed to form teams", errors.New("not enough players"))
+	}
+	// результат принимает только равные команды из всех участников
+	if len(lineup)%2 != 0 {
+		return responses.MatchLineupResponse{}, myerrors.NewConflictErr("an even number of participants is needed to form teams", errors.New("odd number of players"))
+	}
+
+	return s.saveLineup(ctx, matchID, balanceLineup(lineup, s.cfg.RatingConfig.InitialRating))
+}
+
+func (s *Service) lineupMatch(ctx context.Context, userID, matchID uint64, permissions []string) (models.Match, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return models.Match{}, err
+	}
+	if !isMatchOrganizer(match, userID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return models.Match{}, myerrors.NewForbiddenErr("only the organizer can change teams", errors.New("not an organizer"))
+	}
+	if !match.Status.IsOpen() {
+		return models.Match{}, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+
+	return match, nil
+}
+
+func (s *Service) saveLineup(ctx context.Context, matchID uint64, assignments []models.LineupAssignment) (responses.MatchLineupResponse, error) {
+	if err := s.repository.SetMatchLineup(ctx, matchID, assignments); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return responses.MatchLineupResponse{}, myerrors.NewConflictErr("match is closed or its participants have changed", err)
+		}
+		return responses.MatchLineupResponse{}, myerrors.NewRepositoryErr("failed to save match lineup", err)
+	}
+
+	return s.matchLineup(ctx, matchID)
+}
+
+func (s *Service) matchLineup(ctx context.Context, matchID uint64) (responses.MatchLineupResponse, error) {
+	lineup, err := s.repository.GetMatchLineup(ctx, matchID)
+	if err != nil {
+		return responses.MatchLineupResponse{}, myerrors.NewRepositoryErr("failed to fetch match lineup", err)
+	}
+
+	resp := responses.MatchLineupResponse{MatchID: matchID, Players: lineup}
+	for team := 1; team <= 2; team++ {
+		var ratings []float64
+		for _, p := range lineup {
+			if p.Team != nil && *p.Team == team {
+				ratings = append(ratings, s.lineupRating(p))
+			}
+		}
+		if len(ratings) == 0 {
+			continue
+		}
+		avg := math.Round(averageRating(ratings)*100) / 100
+		if team == 1 {
+			resp.Team1Rating = &avg
+		} else {
+			resp.Team2Rating = &avg
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Service) lineupRating(p models.LineupPlayer) float64 {
+	if p.Rating != nil {
+		return *p.Rating
+	}
+	return s.cfg.RatingConfig.InitialRating
+}
+
+// lineupTeams — команды из состава матча, если распределены все участники
+func lineupTeams(lineup []models.LineupPlayer) (team1, team2 []uint64, ok bool) {
+	for _, p := range lineup {
+		switch {
+		case p.Team == nil:
+			return nil, nil, false
+		case *p.Team == 1:
+			team1 = append(team1, p.UserID)
+		default:
+			team2 = append(team2, p.UserID)
+		}
+	}
+
+	return team1, team2, len(team1) > 0 && len(team2) > 0
+}
+
+func validateLineup(lineup []models.LineupPlayer, assignments []models.LineupAssignment) error {
+	participants := make([]uint64, 0, len(lineup))
+	for _, p := range lineup {
+		participants = append(participants, p.UserID)
+	}
+	// равные команды из нечётного числа участников не собрать, а неполный состав не примет результат
+	if len(assignments) > 0 && len(participants)%2 != 0 {
+		return myerrors.NewValidationError("teams need an even number of participants", errors.New("odd number of players"))
+	}
+	maxTeam := len(participants) / 2
+
+	seen := make(map[uint64]struct{}, len(assignments))
+	sizes := map[int]int{}
+	sides := map[int]map[models.MatchSide]struct{}{1: {}, 2: {}}
+	for _, a := range assignments {
+		if !containsID(participants, a.UserID) {
+			return myerrors.NewValidationError("all players must be participants of the match", errors.New("unknown player"))
+		}
+		if _, ok := seen[a.UserID]; ok {
+			return myerrors.NewValidationError("a player is listed twice", errors.New("duplicate player"))
+		}
+		seen[a.UserID] = struct{}{}
+
+		if a.Team != 1 && a.Team != 2 {
+			return myerrors.NewValidationError("team must be 1 or 2", errors.New("invalid team"))
+		}
+		sizes[a.Team]++
+		if sizes[a.Team] > maxTeam {
+			return myerrors.NewValidationError("teams cannot be larger than half of the participants", errors.New("team too large"))
+		}
+
+		if a.Side != nil {
+			if !a.Side.IsValid() {
+				return myerrors.NewValidationError("side must be left or right", errors.New("invalid side"))
+			}
+			if _, ok := sides[a.Team][*a.Side]; ok {
+				return myerrors.NewValidationError("two players of a team cannot take the same side", errors.New("side taken"))
+			}
+			sides[a.Team][*a.Side] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// balanceLineup подбирает разбиение с минимальной разницей средних рейтингов
+func balanceLineup(lineup []models.LineupPlayer, initial float64) []models.LineupAssignment {
+	players := append([]models.LineupPlayer(nil), lineup...)
+	rating := func(p models.LineupPlayer) float64 {
+		if p.Rating != nil {
+			return *p.Rating
+		}
+		return initial
+	}
+	// сильные первыми; при равенстве — по id, чтобы результат не зависел от порядка выборки
+	sort.SliceStable(players, func(i, j int) bool {
+		ri, rj := rating(players[i]), rating(players[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return players[i].UserID < players[j].UserID
+	})
+
+	n := len(players)
+	team1Size := n / 2
+	inTeam1 := make([]bool, n)
+
+	if n <= lineupBruteForceMaxPlayers {
+		best := math.Inf(1)
+		// первый игрок всегда в команде 1: зеркальные разбиения не перебираются
+		for mask := 1; mask < 1<<n; mask += 2 {
+			if bits.OnesCount(uint(mask)) != team1Size {
+				continue
+			}
+			var sum1, sum2 []float64
+			for i, p := range players {
+				if mask&(1<<i) != 0 {
+					sum1 = append(sum1, rating(p))
+				} else {
+					sum2 = append(sum2, rating(p))
+				}
+			}
+			if diff := math.Abs(averageRating(sum1) - averageRating(sum2)); diff < best {
+				best = diff
+				for i := range players {
+					inTeam1[i] = mask&(1<<i) != 0
+				}
+			}
+		}
+	} else {
+		// змейка: 1, 2, 2, 1, 1, 2, ...
+		for i := range players {
+			inTeam1[i] = i%4 == 0 || i%4 == 3
+		}
+	}
+
+	var team1, team2 []models.LineupPlayer
+	for i, p := range players {
+		if inTeam1[i] {
+			team1 = append(team1, p)
+		} else {
+			team2 = append(team2, p)
+		}
+	}
+
+	assignments := make([]models.LineupAssignment, 0, n)
+	assignments = append(assignments, teamAssignments(1, team1)...)
+	assignments = append(assignments, teamAssignments(2, team2)...)
+	return assignments
+}
+
+// teamAssignments ставит команду; стороны назначаются только паре. Игроки идут от сильного к слабому.
+func teamAssignments(team int, players []models.LineupPlayer) []models.LineupAssignment {
+	assignments := make([]models.LineupAssignment, 0, len(players))
+	for _, p := range players {
+		assignments = append(assignments, models.LineupAssignment{UserID: p.UserID, Team: team})
+	}
+	if len(players) != 2 {
+		return assignments
+	}
+
+	prefers := func(p models.LineupPlayer, side models.CourtPosition) bool {
+		return p.CourtPosition != nil && *p.CourtPosition == side
+	}
+	// по умолчанию слева сильнейший; меняем, если второй хочет влево, а первый — нет,
+	// или первый хочет вправо, а второй — нет
+	leftIdx := 0
+	if (prefers(players[1], models.CourtPositionLeft) && !prefers(players[0], models.CourtPositionLeft)) ||
+		(prefers(players[0], models.CourtPositionRight) && !prefers(players[1], models.CourtPositionRight)) {
+		leftIdx = 1
+	}
+
+	left, right := models.MatchSideLeft, models.MatchSideRight
+	assignments[leftIdx].Side = &left
+	assignments[1-leftIdx].Side = &right
+	return assignments
+}
+
+func averageRating(ratings []float64) float64 {
+	if len(ratings) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, r := range ratings {
+		sum += r
+	}
+	return sum / float64(len(ratings))
+}
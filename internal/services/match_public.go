@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const matchFilterDateFormat = "2006-01-02"
+
+// DiscoverPublicMatches ищет публичные матчи, в которые ещё можно вступить.
+// Пагинация курсором: next_cursor передаётся в следующий запрос как cursor.
+// Матчи с участниками из блокировок пользователя не показываются.
+func (s *Service) DiscoverPublicMatches(ctx context.Context, userID uint64, req requests.PublicMatchesRequest) (responses.PublicMatchesResponse, error) {
+	filter := models.PublicMatchFilter{
+		SportID:      req.SportID,
+		TownID:       req.TownID,
+		LevelID:      req.LevelID,
+		MinFreeSpots: 1,
+		ViewerID:     userID,
+		Now:          time.Now().UTC(),
+	}
+
+	if req.MinFreeSpots != nil {
+		if *req.MinFreeSpots < 0 || *req.MinFreeSpots > matchMaxParticipants {
+			return responses.PublicMatchesResponse{}, myerrors.NewValidationError(
+				fmt.Sprintf("min_free_spots must be between 0 and %d", matchMaxParticipants), errors.New("invalid free spots"))
+		}
+		filter.MinFreeSpots = *req.MinFreeSpots
+	}
+
+	if req.From != "" {
+		from, err := time.Parse(matchFilterDateFormat, req.From)
+		if err != nil {
+			return responses.PublicMatchesResponse{}, myerrors.NewValidationError("from must be in format YYYY-MM-DD", err)
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(matchFilterDateFormat, req.To)
+		if err != nil {
+			return responses.PublicMatchesResponse{}, myerrors.NewValidationError("to must be in format YYYY-MM-DD", err)
+		}
+		// to включительно: берём начало следующего дня
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return responses.PublicMatchesResponse{}, myerrors.NewValidationError("from must not be after to", errors.New("invalid date range"))
+	}
+
+	if req.Cursor != "" {
+		startsAt, id, err := decodeMatchCursor(req.Cursor)
+		if err != nil {
+			return responses.PublicMatchesResponse{}, myerrors.NewValidationError("cursor is invalid", err)
+		}
+		filter.AfterStartsAt = &startsAt
+		filter.AfterID = id
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = matchesDefaultPageSize
+	}
+	limit = min(limit, matchesMaxPageSize)
+	// лишняя запись показывает, есть ли следующая страница
+	filter.Limit = limit + 1
+
+	matches, err := s.repository.ListPublicMatches(ctx, filter)
+	if err != nil {
+		return responses.PublicMatchesResponse{}, myerrors.NewRepositoryErr("failed to list public matches", err)
+	}
+
+	resp := responses.PublicMatchesResponse{Matches: matches}
+	if len(matches) > limit {
+		resp.Matches = matches[:limit]
+		last := resp.Matches[limit-1]
+		if last.StartsAt != nil {
+			resp.NextCursor = encodeMatchCursor(*last.StartsAt, last.ID)
+		}
+	}
+
+	return resp, nil
+}
+
+// JoinMatch записывает пользователя в публичный матч без приглашения.
+// Свободные места проверяются в транзакции, поэтому матч не переполнится.
+// Игрока с надёжностью ниже порога матча не пускают.
+func (s *Service) JoinMatch(ctx context.Context, userID, matchID uint64) (models.MatchDetails, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return models.MatchDetails{}, err
+	}
+	if match.Visibility != models.MatchVisibilityPublic {
+		return models.MatchDetails{}, myerrors.NewForbiddenErr("match is joinable by invitation only", errors.New("match is private"))
+	}
+	if !match.Status.IsOpen() {
+		return models.MatchDetails{}, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+	if match.StartsAt != nil && !match.StartsAt.After(time.Now()) {
+		return models.MatchDetails{}, myerrors.NewConflictErr("match has already started", errors.New("match started"))
+	}
+
+	participant, err := s.repository.IsUserInMatch(ctx, matchID, userID)
+	if err != nil {
+		return models.MatchDetails{}, myerrors.NewRepositoryErr("failed to check match participation", err)
+	}
+	if participant {
+		return models.MatchDetails{}, myerrors.NewConflictErr("you are already a participant of this match", errors.New("already participant"))
+	}
+	if err = s.checkMatchBlocks(ctx, userID, matchID); err != nil {
+		return models.MatchDetails{}, err
+	}
+	if err = s.checkReliability(ctx, userID, match); err != nil {
+		return models.MatchDetails{}, err
+	}
+	if err = s.checkMatchScheduleConflicts(ctx, userID, match); err != nil {
+		return models.MatchDetails{}, err
+	}
+
+	if err = s.repository.JoinPublicMatch(ctx, matchID, userID); err != nil {
+		if errors.Is(err, myerrors.ErrMatchFull) {
+			return models.MatchDetails{}, myerrors.NewConflictErr("match has no free spots", err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchDetails{}, myerrors.NewConflictErr("match is already closed", err)
+		}
+		return models.MatchDetails{}, myerrors.NewRepositoryErr("failed to join match", err)
+	}
+
+	return s.matchDetails(ctx, matchID)
+}
+
+// encodeMatchCursor упаковывает позицию последнего матча страницы: <starts_at unix nano>:<id>
+func encodeMatchCursor(startsAt time.Time, id uint64) string {
+	raw := strconv.FormatInt(startsAt.UnixNano(), 10) + ":" + strconv.FormatUint(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeMatchCursor(cursor string) (time.Time, uint64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	startsPart, idPart, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, 0, errors.New("malformed cursor")
+	}
+	nanos, err := strconv.ParseInt(startsPart, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	id, err := strconv.ParseUint(idPart, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return time.Unix(0, nanos).UTC(), id, nil
+}
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	resultDecisionConfirm = "confirm"
+	resultDecisionReject  = "reject"
+
+	disputeReasonMaxLength = 1000
+)
+
+// SubmitMatchResult записывает счёт начавшегося матча. Результат ждёт подтверждения
+// игроком другой команды; до этого его можно оспорить.
+func (s *Service) SubmitMatchResult(ctx context.Context, userID, matchID uint64, req requests.SubmitMatchResultRequest) (models.MatchResult, error) {
+	match, err := s.getMatch(ctx, matchID)
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+	if !match.Status.IsOpen() {
+		return models.MatchResult{}, myerrors.NewConflictErr("match is already "+string(match.Status), errors.New("match is closed"))
+	}
+	if match.StartsAt != nil && match.StartsAt.After(time.Now()) {
+		return models.MatchResult{}, myerrors.NewConflictErr("match has not started yet", errors.New("match not started"))
+	}
+
+	participants, err := s.repository.GetMatchParticipants(ctx, matchID)
+	if err != nil {
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to fetch match participants", err)
+	}
+	if !containsID(participants, userID) {
+		return models.MatchResult{}, myerrors.NewForbiddenErr("you are not a participant of this match", errors.New("not a participant"))
+	}
+
+	sportID := match.SportID
+	if sportID == nil {
+		sportID = req.SportID
+	}
+	if sportID == nil {
+		return models.MatchResult{}, myerrors.NewValidationError("sport_id is required: the match has no sport", errors.New("missing sport"))
+	}
+	sport, err := s.repository.GetSportName(ctx, *sportID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchResult{}, myerrors.NewValidationError("unknown sport", err)
+		}
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to fetch sport", err)
+	}
+	if sport != models.SportTennis && sport != models.SportPadel {
+		return models.MatchResult{}, myerrors.NewValidationError("scores are supported for tennis and padel only", errors.New("unsupported sport"))
+	}
+
+	// распределённый состав матча подставляется в результат и должен с ним совпадать
+	team1, team2 := req.Team1, req.Team2
+	lineup, err := s.repository.GetMatchLineup(ctx, matchID)
+	if err != nil {
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to fetch match lineup", err)
+	}
+	if lineup1, lineup2, ok := lineupTeams(lineup); ok {
+		if len(team1) == 0 && len(team2) == 0 {
+			team1, team2 = lineup1, lineup2
+		} else if !sameIDs(team1, lineup1) || !sameIDs(team2, lineup2) {
+			return models.MatchResult{}, myerrors.NewValidationError("teams do not match the match lineup", errors.New("lineup mismatch"))
+		}
+	}
+
+	if err = validateTeams(sport, participants, team1, team2); err != nil {
+		return models.MatchResult{}, err
+	}
+	winner, err := validateScore(sport, req.BestOf, req.Sets)
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+	if req.WinnerTeam != winner {
+		return models.MatchResult{}, myerrors.NewValidationError("winner_team does not match the score", errors.New("winner mismatch"))
+	}
+
+	resultID, err := s.repository.CreateMatchResult(ctx, models.MatchResult{
+		MatchID:     matchID,
+		SubmittedBy: &userID,
+		WinnerTeam:  winner,
+		Team1:       team1,
+		Team2:       team2,
+		Sets:        req.Sets,
+	}, *sportID)
+	if err != nil {
+		if errors.Is(err, myerrors.ErrResultExists) {
+			return models.MatchResult{}, myerrors.NewConflictErr("match already has a result", err)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchResult{}, myerrors.NewConflictErr("match is already closed", err)
+		}
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to save match result", err)
+	}
+
+	return s.getMatchResult(ctx, resultID)
+}
+
+// GetMatchResult отдаёт действующий результат матча участнику или пользователю с правом match.manage.any
+func (s *Service) GetMatchResult(ctx context.Context, viewerID, matchID uint64, permissions []string) (models.MatchResult, error) {
+	participant, err := s.repository.IsUserInMatch(ctx, matchID, viewerID)
+	if err != nil {
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to check match participation", err)
+	}
+	if !participant && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return models.MatchResult{}, myerrors.NewForbiddenErr("you are not a participant of this match", errors.New("not a participant"))
+	}
+
+	result, err := s.repository.GetActiveMatchResult(ctx, matchID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchResult{}, myerrors.NewNotFoundErr("match has no result yet", err)
+		}
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to fetch match result", err)
+	}
+
+	return result, nil
+}
+
+// ConfirmMatchResult — подтверждение игроком команды, которая результат не вводила. Матч завершается,
+// рейтинговый матч в той же транзакции меняет рейтинг игроков.
+func (s *Service) ConfirmMatchResult(ctx context.Context, userID, resultID uint64) (models.MatchResult, error) {
+	result, err := s.getMatchResult(ctx, resultID)
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+
+	team := result.TeamOf(userID)
+	if team == 0 {
+		return models.MatchResult{}, myerrors.NewForbiddenErr("you did not play in this match", errors.New("not a player"))
+	}
+	if result.SubmittedBy != nil && result.TeamOf(*result.SubmittedBy) == team {
+		return models.MatchResult{}, myerrors.NewForbiddenErr("the result must be confirmed by the other side", errors.New("same team"))
+	}
+	if result.Status != models.ResultStatusPending {
+		return models.MatchResult{}, myerrors.NewConflictErr("result is already "+string(result.Status), errors.New("result is not pending"))
+	}
+
+	if err = s.repository.ConfirmMatchResult(ctx, resultID, userID, s.ratingEngine()); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchResult{}, myerrors.NewConflictErr("result is no longer pending", err)
+		}
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to confirm match result", err)
+	}
+
+	s.refreshLeaderboard(ctx)
+	s.pairNextSwissRound(ctx, result.MatchID)
+	return s.getMatchResult(ctx, resultID)
+}
+
+// DisputeMatchResult — любой игрок матча оспаривает ожидающий результат; спор уходит ассистенту
+func (s *Service) DisputeMatchResult(ctx context.Context, userID, resultID uint64, req requests.DisputeMatchResultRequest) (models.MatchResult, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || len([]rune(reason)) > disputeReasonMaxLength {
+		return models.MatchResult{}, myerrors.NewValidationError("reason is required and must be at most 1000 characters", errors.New("invalid reason"))
+	}
+
+	result, err := s.getMatchResult(ctx, resultID)
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+	if result.TeamOf(userID) == 0 {
+		return models.MatchResult{}, myerrors.NewForbiddenErr("you did not play in this match", errors.New("not a player"))
+	}
+	if result.Status != models.ResultStatusPending {
+		return models.MatchResult{}, myerrors.NewConflictErr("result is already "+string(result.Status), errors.New("result is not pending"))
+	}
+
+	if err = s.repository.DisputeMatchResult(ctx, resultID, userID, reason); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchResult{}, myerrors.NewConflictErr("result is no longer pending", err)
+		}
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to dispute match result", err)
+	}
+
+	return s.getMatchResult(ctx, resultID)
+}
+
+// ListResultDisputes — очередь оспоренных результатов для ассистента
+func (s *Service) ListResultDisputes(ctx context.Context) ([]models.MatchResult, error) {
+	results, err := s.repository.ListDisputedMatchResults(ctx)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list disputed results", err)
+	}
+
+	return results, nil
+}
+
+// ResolveResultDispute — решение ассистента: confirm завершает матч, reject позволяет ввести счёт заново
+func (s *Service) ResolveResultDispute(ctx context.Context, userID, resultID uint64, req requests.ResolveMatchResultRequest) (models.MatchResult, error) {
+	var confirm bool
+	switch req.Decision {
+	case resultDecisionConfirm:
+		confirm = true
+	case resultDecisionReject:
+	default:
+		return models.MatchResult{}, myerrors.NewValidationError("decision must be confirm or reject", errors.New("invalid decision"))
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		if c := strings.TrimSpace(*req.Comment); c != "" {
+			comment = &c
+		}
+	}
+
+	if err := s.repository.ResolveMatchResult(ctx, resultID, userID, confirm, comment, s.ratingEngine()); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// различаем «нет такого результата» и «результат не в споре»
+			if _, getErr := s.getMatchResult(ctx, resultID); getErr != nil {
+				return models.MatchResult{}, getErr
+			}
+			return models.MatchResult{}, myerrors.NewConflictErr("result is not disputed", err)
+		}
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to resolve dispute", err)
+	}
+
+	result, err := s.getMatchResult(ctx, resultID)
+	if err != nil {
+		return models.MatchResult{}, err
+	}
+	if confirm {
+		s.refreshLeaderboard(ctx)
+		s.pairNextSwissRound(ctx, result.MatchID)
+	}
+	return result, nil
+}
+
+func (s *Service) getMatchResult(ctx context.Context, resultID uint64) (models.MatchResult, error) {
+	result, err := s.repository.GetMatchResult(ctx, resultID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.MatchResult{}, myerrors.NewNotFoundErr("match result not found", err)
+		}
+		return models.MatchResult{}, myerrors.NewRepositoryErr("failed to fetch match result", err)
+	}
+
+	return result, nil
+}
+
+// validateTeams проверяет составы: все участники матча распределены ровно в одну команду,
+// команды равны; в паделе играют только пары, в теннисе — одиночка или пары.
+func validateTeams(sport string, participants, team1, team2 []uint64) error {
+	size := len(team1)
+	if size == 0 || size != len(team2) {
+		return myerrors.NewValidationError("teams must be non-empty and of equal size", errors.New("invalid teams"))
+	}
+	if size > 2 || (sport == models.SportPadel && size != 2) {
+		return myerrors.NewValidationError("tennis is played 1v1 or 2v2, padel 2v2", errors.New("invalid team size"))
+	}
+
+	seen := make(map[uint64]struct{}, size*2)
+	for _, id := range append(append([]uint64{}, team1...), team2...) {
+		if _, ok := seen[id]; ok {
+			return myerrors.NewValidationError("a player cannot be in both teams or listed twice", errors.New("duplicate player"))
+		}
+		if !containsID(participants, id) {
+			return myerrors.NewValidationError("all players must be participants of the match", errors.New("unknown player"))
+		}
+		seen[id] = struct{}{}
+	}
+	if len(seen) != len(participants) {
+		return myerrors.NewValidationError("every participant must be assigned to a team", errors.New("unassigned participants"))
+	}
+
+	return nil
+}
+
+// sameIDs — одинаковые ли наборы игроков без учёта порядка
+func sameIDs(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, id := range a {
+		if !containsID(b, id) {
+			return false
+		}
+	}
+	return true
+}
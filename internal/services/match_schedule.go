@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ScheduleConflictDetails — тело 409, когда матч пересекается с другими событиями пользователя
+type ScheduleConflictDetails struct {
+	Conflicts []models.ScheduleConflict `json:"conflicts"`
+}
+
+// matchEndsAt вычисляет окончание матча: явный ends_at или начало плюс длительность
+func matchEndsAt(startsAt time.Time, endsAt *time.Time, durationMinutes int) (time.Time, error) {
+	duration := matchDefaultDuration
+	switch {
+	case endsAt != nil:
+		duration = endsAt.UTC().Sub(startsAt)
+	case durationMinutes != 0:
+		duration = time.Duration(durationMinutes) * time.Minute
+	}
+
+	if duration < matchMinDuration || duration > matchMaxDuration {
+		return time.Time{}, myerrors.NewValidationError(
+			fmt.Sprintf("match must last between %d minutes and %d hours", int(matchMinDuration.Minutes()), int(matchMaxDuration.Hours())),
+			errors.New("invalid match duration"),
+		)
+	}
+
+	return startsAt.Add(duration), nil
+}
+
+// matchSportObject сверяет корт с объектом; если задан только корт, объект берётся из него
+func (s *Service) matchSportObject(ctx context.Context, sportObjectID, courtID *int) (*int, error) {
+	if courtID == nil {
+		return sportObjectID, nil
+	}
+
+	courtObjectID, err := s.repository.GetCourtSportObjectID(ctx, *courtID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, myerrors.NewValidationError("unknown court", err)
+		}
+		return nil, myerrors.NewRepositoryErr("failed to fetch court", err)
+	}
+	if sportObjectID != nil && *sportObjectID != courtObjectID {
+		return nil, myerrors.NewValidationError("court does not belong to the sport object", errors.New("court mismatch"))
+	}
+
+	return &courtObjectID, nil
+}
+
+// checkScheduleConflicts не даёт пользователю оказаться в двух местах одновременно.
+// Пересечения отдаются в details ошибки, чтобы клиент показал, с чем именно конфликт.
+func (s *Service) checkScheduleConflicts(ctx context.Context, userID uint64, startsAt, endsAt time.Time, excludeMatchID uint64) error {
+	conflicts, err := s.repository.FindScheduleConflicts(ctx, userID, startsAt, endsAt, excludeMatchID)
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to check schedule conflicts", err)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return myerrors.NewConflictErr("match overlaps with other events in your schedule", myerrors.ErrScheduleConflict).
+		WithDetails(ScheduleConflictDetails{Conflicts: conflicts})
+}
+
+// checkMatchScheduleConflicts проверяет пересечения для вступающего в существующий матч.
+// Матчи без времени начала и окончания не проверяются.
+func (s *Service) checkMatchScheduleConflicts(ctx context.Context, userID uint64, match models.Match) error {
+	if match.StartsAt == nil || match.EndsAt == nil {
+		return nil
+	}
+
+	return s.checkScheduleConflicts(ctx, userID, *match.StartsAt, *match.EndsAt, match.ID)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+)
+
+const (
+	setGames          = 6  // сет выигран при 6 геймах и разнице в два
+	tiebreakPoints    = 7  // тай-брейк при 6:6 — до 7 очков с разницей в два
+	matchTiebreakGoal = 10 // решающий тай-брейк вместо третьего (пятого) сета — до 10 очков
+)
+
+// validateScore проверяет счёт по правилам тенниса и падела и возвращает команду-победителя.
+// Сет: 6:0–6:4, 7:5 или 7:6 с тай-брейком; решающий сет можно заменить тай-брейком до 10.
+// Падел играется только до двух выигранных сетов, теннис — до двух или трёх.
+func validateScore(sport string, bestOf int, sets []models.SetScore) (int, error) {
+	if bestOf == 0 {
+		bestOf = 3
+	}
+	if bestOf != 3 && !(bestOf == 5 && sport == models.SportTennis) {
+		return 0, scoreError("best_of must be 3, or 5 for tennis")
+	}
+	setsToWin := bestOf/2 + 1
+
+	won := [3]int{}
+	for i, set := range sets {
+		if won[1] == setsToWin || won[2] == setsToWin {
+			return 0, scoreError(fmt.Sprintf("set %d is played after the match is decided", i+1))
+		}
+
+		var (
+			winner int
+			err    error
+		)
+		if set.MatchTiebreak {
+			if won[1] != setsToWin-1 || won[2] != setsToWin-1 {
+				return 0, scoreError(fmt.Sprintf("set %d: match tiebreak is allowed only as the deciding set", i+1))
+			}
+			winner, err = matchTiebreakWinner(set)
+		} else {
+			winner, err = setWinner(set)
+		}
+		if err != nil {
+			return 0, scoreError(fmt.Sprintf("set %d: %s", i+1, err))
+		}
+		won[winner]++
+	}
+
+	switch {
+	case won[1] == setsToWin:
+		return 1, nil
+	case won[2] == setsToWin:
+		return 2, nil
+	default:
+		return 0, scoreError("match is not finished: no team has won enough sets")
+	}
+}
+
+func setWinner(set models.SetScore) (int, error) {
+	winner, w, l := leader(set.Team1, set.Team2)
+	if winner == 0 {
+		return 0, errors.New("set cannot end in a draw")
+	}
+
+	hasTiebreak := set.Team1Tiebreak != nil || set.Team2Tiebreak != nil
+	switch {
+	case w == setGames && l <= setGames-2, w == setGames+1 && l == setGames-1:
+		if hasTiebreak {
+			return 0, errors.New("tiebreak is recorded only for a 7:6 set")
+		}
+	case w == setGames+1 && l == setGames:
+		if set.Team1Tiebreak == nil || set.Team2Tiebreak == nil {
+			return 0, errors.New("7:6 set requires tiebreak points")
+		}
+		tbWinner, tw, tl := leader(*set.Team1Tiebreak, *set.Team2Tiebreak)
+		if tbWinner != winner || tw != max(tiebreakPoints, tl+2) {
+			return 0, errors.New("invalid tiebreak score")
+		}
+	default:
+		return 0, fmt.Errorf("invalid set score %d:%d", set.Team1, set.Team2)
+	}
+
+	return winner, nil
+}
+
+func matchTiebreakWinner(set models.SetScore) (int, error) {
+	if set.Team1Tiebreak != nil || set.Team2Tiebreak != nil {
+		return 0, errors.New("match tiebreak points go to team1 and team2")
+	}
+
+	winner, w, l := leader(set.Team1, set.Team2)
+	if winner == 0 || w != max(matchTiebreakGoal, l+2) {
+		return 0, fmt.Errorf("invalid match tiebreak score %d:%d", set.Team1, set.Team2)
+	}
+
+	return winner, nil
+}
+
+// leader возвращает ведущую команду (0 — ничья) и счёт победителя и проигравшего
+func leader(team1, team2 int) (int, int, int) {
+	switch {
+	case team1 < 0 || team2 < 0 || team1 == team2:
+		return 0, team1, team2
+	case team1 > team2:
+		return 1, team1, team2
+	default:
+		return 2, team2, team1
+	}
+}
+
+func scoreError(message string) error {
+	return myerrors.NewValidationError(message, errors.New("invalid score"))
+}
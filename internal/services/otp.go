@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
 	"sport-assistance/pkg/myerrors"
 	"strings"
 	"time"
@@ -28,13 +29,20 @@ func (s *Service) SendOTP(ctx context.Context, identifier string) (responses.Sen
 		return responses.SendOTPResponse{}, myerrors.NewTokenErr("failed to save otp in redis", err)
 	}
 
+	if err := s.deliverOTP(ctx, models.ContactTypePhone, normalizedIdentifier, otpStubValue); err != nil {
+		return responses.SendOTPResponse{}, err
+	}
+
 	return responses.SendOTPResponse{
 		OTPSent: true,
 		Message: "OTP sent",
 	}, nil
 }
 
-func (s *Service) ConfirmOTP(ctx context.Context, identifier, otp string) (responses.ConfirmOTPResponse, error) {
+// ConfirmOTP подтверждает код. Если приложение открыли по ссылке-приглашению, inviteToken
+// сразу превращается в приглашение, а для незарегистрированного — ждёт регистрации.
+// Ошибка ссылки вход не ломает: пользователь просто не получит приглашение.
+func (s *Service) ConfirmOTP(ctx context.Context, identifier, otp, inviteToken string) (responses.ConfirmOTPResponse, error) {
 	normalizedIdentifier := strings.TrimSpace(strings.ToLower(identifier))
 	normalizedOTP := strings.TrimSpace(otp)
 
@@ -61,26 +69,48 @@ func (s *Service) ConfirmOTP(ctx context.Context, identifier, otp string) (respo
 	user, err := s.repository.GetUserByPhone(ctx, normalizedIdentifier)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return responses.ConfirmOTPResponse{
+			response := responses.ConfirmOTPResponse{
 				OTPConfirmed: true,
 				IsRegistered: false,
 				Message:      "OTP confirmed, user is not registered",
-			}, nil
+			}
+			if inviteToken != "" {
+				if err = s.rememberInviteForPhone(ctx, normalizedIdentifier, inviteToken); err != nil {
+					s.logger.Warn("failed to save invite link for registration", "err", err)
+				} else {
+					response.InvitePending = true
+				}
+			}
+
+			return response, nil
 		}
 
 		return responses.ConfirmOTPResponse{}, myerrors.NewRepositoryErr("failed to fetch user by phone", err)
 	}
+	if user.BlockedAt != nil {
+		return responses.ConfirmOTPResponse{}, myerrors.NewForbiddenErr("account is blocked", errors.New("user blocked"))
+	}
 
 	accessToken, refreshToken, err := s.CreateTokens(ctx, user.ID, user.Email)
 	if err != nil {
 		return responses.ConfirmOTPResponse{}, err
 	}
 
-	return responses.ConfirmOTPResponse{
+	response := responses.ConfirmOTPResponse{
 		OTPConfirmed: true,
 		IsRegistered: true,
 		Message:      "OTP confirmed",
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-	}, nil
+	}
+	if inviteToken != "" {
+		invitation, err := s.RedeemInviteLink(ctx, user.ID, inviteToken)
+		if err != nil {
+			s.logger.Warn("failed to redeem invite link", "user_id", user.ID, "err", err)
+		} else {
+			response.InvitationID = &invitation.ID
+		}
+	}
+
+	return response, nil
 }
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+)
+
+// ListPartners — напарники игрока (одна команда в подтверждённых матчах) с победами, поражениями,
+// текущей серией и датой последней игры. Чужой список скрыт, если владелец выключил show_partners.
+func (s *Service) ListPartners(ctx context.Context, viewerID, userID uint64) ([]models.PairStats, error) {
+	if err := s.checkStatsVisible(ctx, viewerID, userID, func(v models.ProfileVisibility) bool { return v.ShowPartners }); err != nil {
+		return nil, err
+	}
+
+	return s.pairStats(ctx, userID, true)
+}
+
+// ListOpponents — соперники игрока и счёт встреч с каждым. Скрыт вместе с show_matches_played.
+func (s *Service) ListOpponents(ctx context.Context, viewerID, userID uint64) ([]models.PairStats, error) {
+	if err := s.checkStatsVisible(ctx, viewerID, userID, func(v models.ProfileVisibility) bool { return v.ShowMatchesPlayed }); err != nil {
+		return nil, err
+	}
+
+	return s.pairStats(ctx, userID, false)
+}
+
+// GetHeadToHead — личные встречи игрока с opponentID: счёт против него, игры в паре и сами матчи
+func (s *Service) GetHeadToHead(ctx context.Context, viewerID, userID, opponentID uint64) (responses.HeadToHeadResponse, error) {
+	if userID == opponentID {
+		return responses.HeadToHeadResponse{}, myerrors.NewValidationError("opponent must be another player", errors.New("same player"))
+	}
+	if err := s.checkStatsVisible(ctx, viewerID, userID, func(v models.ProfileVisibility) bool { return v.ShowMatchesPlayed }); err != nil {
+		return responses.HeadToHeadResponse{}, err
+	}
+
+	results, err := s.repository.ListPairResults(ctx, userID, &opponentID)
+	if err != nil {
+		return responses.HeadToHeadResponse{}, myerrors.NewRepositoryErr("failed to fetch head-to-head", err)
+	}
+
+	resp := responses.HeadToHeadResponse{
+		UserID:     userID,
+		OpponentID: opponentID,
+		Matches:    results,
+	}
+	if against := aggregatePairs(results, false); len(against) > 0 {
+		resp.Against = &against[0]
+	}
+	if together := aggregatePairs(results, true); len(together) > 0 {
+		resp.Together = &together[0]
+	}
+
+	return resp, nil
+}
+
+func (s *Service) pairStats(ctx context.Context, userID uint64, sameTeam bool) ([]models.PairStats, error) {
+	results, err := s.repository.ListPairResults(ctx, userID, nil)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch match history", err)
+	}
+
+	stats := aggregatePairs(results, sameTeam)
+	for i := range stats {
+		if stats[i].PhotoURL, err = s.photoURL(ctx, stats[i].PhotoURL); err != nil {
+			return nil, err
+		}
+	}
+
+	return stats, nil
+}
+
+// checkStatsVisible пропускает владельца всегда, остальных — если флаг видимости включён
+func (s *Service) checkStatsVisible(ctx context.Context, viewerID, userID uint64, visible func(models.ProfileVisibility) bool) error {
+	if viewerID == userID {
+		return nil
+	}
+	if err := s.checkNotBlocked(ctx, viewerID, userID); err != nil {
+		return err
+	}
+
+	visibility, err := s.repository.GetProfileVisibility(ctx, userID)
+	if err != nil {
+		return myerrors.NewRepositoryErr("failed to fetch profile visibility", err)
+	}
+	if !visible(visibility) {
+		return myerrors.NewForbiddenErr("the player has hidden this statistics", errors.New("hidden by visibility settings"))
+	}
+
+	return nil
+}
+
+// aggregatePairs сводит матчи по игрокам. results идут от новых к старым, поэтому
+// серия считается с начала списка и обрывается на первом другом исходе.
+func aggregatePairs(results []models.PairResult, sameTeam bool) []models.PairStats {
+	index := make(map[uint64]int)
+	streakOpen := make(map[uint64]bool)
+	stats := make([]models.PairStats, 0)
+
+	for _, r := range results {
+		if r.SameTeam != sameTeam {
+			continue
+		}
+
+		i, ok := index[r.OtherUserID]
+		if !ok {
+			i = len(stats)
+			index[r.OtherUserID] = i
+			streakOpen[r.OtherUserID] = true
+			stats = append(stats, models.PairStats{
+				UserID:       r.OtherUserID,
+				Name:         r.Name,
+				Surname:      r.Surname,
+				PhotoURL:     r.PhotoThumbnail,
+				LastPlayedAt: r.PlayedAt,
+			})
+		}
+
+		p := &stats[i]
+		p.Matches++
+		if r.Won {
+			p.Wins++
+		} else {
+			p.Losses++
+		}
+
+		if streakOpen[r.OtherUserID] {
+			switch {
+			case r.Won && p.Streak >= 0:
+				p.Streak++
+			case !r.Won && p.Streak <= 0:
+				p.Streak--
+			default:
+				streakOpen[r.OtherUserID] = false
+			}
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Matches != stats[j].Matches {
+			return stats[i].Matches > stats[j].Matches
+		}
+		return stats[i].LastPlayedAt.After(stats[j].LastPlayedAt)
+	})
+
+	return stats
+}
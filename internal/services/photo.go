@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/png"
+	"io"
+	"net/http"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/imaging"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/storage"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	photoMaxSide      = 2048
+	photoMaxPixels    = 40_000_000 // защита от "декомпрессионных бомб"
+	photoThumbSide    = 256
+	photoJPEGQuality  = 85
+	photoContentType  = "image/jpeg"
+	photoSniffLength  = 512
+	photoKeyURLPrefix = "http"
+)
+
+var allowedPhotoTypes = map[string]struct{}{
+	"image/jpeg": {},
+	"image/png":  {},
+	"image/webp": {},
+}
+
+// UploadUserPhoto проверяет, очищает от метаданных и сохраняет фото профиля вместе с миниатюрой
+func (s *Service) UploadUserPhoto(ctx context.Context, userID uint64, file io.Reader) (responses.UserPhotoResponse, error) {
+	maxSize := s.cfg.StorageConfig.MaxPhotoSize
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return responses.UserPhotoResponse{}, myerrors.NewValidationError("failed to read photo", err)
+	}
+	if int64(len(data)) > maxSize {
+		return responses.UserPhotoResponse{}, myerrors.NewValidationError(
+			fmt.Sprintf("photo is larger than %d bytes", maxSize),
+			errors.New("photo too large"),
+		)
+	}
+
+	contentType := http.DetectContentType(data[:min(len(data), photoSniffLength)])
+	if _, ok := allowedPhotoTypes[contentType]; !ok {
+		return responses.UserPhotoResponse{}, myerrors.NewValidationError(
+			"photo must be JPEG, PNG or WebP",
+			fmt.Errorf("unsupported content type %s", contentType),
+		)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return responses.UserPhotoResponse{}, myerrors.NewValidationError("photo is corrupted", err)
+	}
+	if cfg.Width*cfg.Height > photoMaxPixels {
+		return responses.UserPhotoResponse{}, myerrors.NewValidationError("photo resolution is too large", errors.New("too many pixels"))
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return responses.UserPhotoResponse{}, myerrors.NewValidationError("photo is corrupted", err)
+	}
+	if contentType == "image/jpeg" {
+		img = imaging.ApplyOrientation(img, imaging.JPEGOrientation(data))
+	}
+
+	// Перекодирование отбрасывает EXIF (в т.ч. GPS-координаты) и прочие метаданные
+	photoData, err := imaging.EncodeJPEG(imaging.Fit(img, photoMaxSide), photoJPEGQuality)
+	if err != nil {
+		return responses.UserPhotoResponse{}, err
+	}
+	thumbData, err := imaging.EncodeJPEG(imaging.Thumbnail(img, photoThumbSide), photoJPEGQuality)
+	if err != nil {
+		return responses.UserPhotoResponse{}, err
+	}
+
+	previous, err := s.repository.GetUserPhoto(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return responses.UserPhotoResponse{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return responses.UserPhotoResponse{}, myerrors.NewRepositoryErr("failed to fetch user photo", err)
+	}
+
+	objectID := uuid.NewString()
+	photoKey := fmt.Sprintf("users/%d/photo/%s.jpg", userID, objectID)
+	thumbKey := fmt.Sprintf("users/%d/photo/%s_thumb.jpg", userID, objectID)
+
+	if err = s.storage.Put(ctx, photoKey, bytes.NewReader(photoData), int64(len(photoData)), photoContentType); err != nil {
+		return responses.UserPhotoResponse{}, err
+	}
+	if err = s.storage.Put(ctx, thumbKey, bytes.NewReader(thumbData), int64(len(thumbData)), photoContentType); err != nil {
+		s.deleteObjects(ctx, photoKey)
+		return responses.UserPhotoResponse{}, err
+	}
+
+	photo := models.UserPhoto{Photo: &photoKey, PhotoThumbnail: &thumbKey}
+	if err = s.repository.UpdateUserPhoto(ctx, userID, photo); err != nil {
+		s.deleteObjects(ctx, photoKey, thumbKey)
+		return responses.UserPhotoResponse{}, myerrors.NewRepositoryErr("failed to save user photo", err)
+	}
+
+	s.deleteObjects(ctx, storedObjectKeys(previous)...)
+
+	return s.photoResponse(ctx, photo)
+}
+
+func (s *Service) GetUserPhoto(ctx context.Context, userID uint64) (responses.UserPhotoResponse, error) {
+	photo, err := s.repository.GetUserPhoto(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return responses.UserPhotoResponse{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return responses.UserPhotoResponse{}, myerrors.NewRepositoryErr("failed to fetch user photo", err)
+	}
+
+	return s.photoResponse(ctx, photo)
+}
+
+func (s *Service) DeleteUserPhoto(ctx context.Context, userID uint64) error {
+	previous, err := s.repository.GetUserPhoto(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewNotFoundErr("user not found", err)
+		}
+		return myerrors.NewRepositoryErr("failed to fetch user photo", err)
+	}
+
+	if err = s.repository.UpdateUserPhoto(ctx, userID, models.UserPhoto{}); err != nil {
+		return myerrors.NewRepositoryErr("failed to delete user photo", err)
+	}
+
+	s.deleteObjects(ctx, storedObjectKeys(previous)...)
+	return nil
+}
+
+// OpenFile отдаёт объект локального хранилища по подписанной ссылке
+func (s *Service) OpenFile(ctx context.Context, key string, expires int64, signature string) (io.ReadCloser, string, error) {
+	reader, ok := s.storage.(storage.SignedReader)
+	if !ok {
+		return nil, "", myerrors.NewNotFoundErr("file not found", errors.New("storage does not serve files"))
+	}
+
+	file, contentType, err := reader.OpenSigned(ctx, key, expires, signature)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidSignature):
+			return nil, "", myerrors.NewForbiddenErr("link is invalid or expired", err)
+		case errors.Is(err, storage.ErrObjectNotFound):
+			return nil, "", myerrors.NewNotFoundErr("file not found", err)
+		default:
+			return nil, "", err
+		}
+	}
+
+	return file, contentType, nil
+}
+
+// photoURL превращает ключ объекта в подписанную ссылку.
+// Внешние URL (заданные при регистрации до появления загрузки) возвращаются как есть.
+func (s *Service) photoURL(ctx context.Context, value *string) (*string, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(*value, photoKeyURLPrefix) {
+		return value, nil
+	}
+
+	url, err := s.storage.SignedURL(ctx, *value, s.cfg.StorageConfig.SignedURLTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &url, nil
+}
+
+func (s *Service) photoResponse(ctx context.Context, photo models.UserPhoto) (responses.UserPhotoResponse, error) {
+	photoURL, err := s.photoURL(ctx, photo.Photo)
+	if err != nil {
+		return responses.UserPhotoResponse{}, err
+	}
+	thumbURL, err := s.photoURL(ctx, photo.PhotoThumbnail)
+	if err != nil {
+		return responses.UserPhotoResponse{}, err
+	}
+
+	return responses.UserPhotoResponse{
+		PhotoURL:     photoURL,
+		ThumbnailURL: thumbURL,
+		ExpiresIn:    int64(s.cfg.StorageConfig.SignedURLTTL.Seconds()),
+	}, nil
+}
+
+// deleteObjects удаляет объекты без прерывания основного сценария: "висячий" файл лучше, чем ошибка пользователю
+func (s *Service) deleteObjects(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			s.logger.Warn("failed to delete object from storage", "key", key, "err", err)
+		}
+	}
+}
+
+func storedObjectKeys(photo models.UserPhoto) []string {
+	keys := make([]string, 0, 2)
+	for _, value := range []*string{photo.Photo, photo.PhotoThumbnail} {
+		if value != nil && *value != "" && !strings.HasPrefix(*value, photoKeyURLPrefix) {
+			keys = append(keys, *value)
+		}
+	}
+	return keys
+}
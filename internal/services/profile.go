@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetPublicProfile возвращает карточку игрока. Владелец видит свою карточку целиком,
+// остальные — только поля, которые владелец не скрыл.
+func (s *Service) GetPublicProfile(ctx context.Context, viewerID, userID uint64) (dto.PublicProfileDto, error) {
+	if err := s.checkNotBlocked(ctx, viewerID, userID); err != nil {
+		return dto.PublicProfileDto{}, err
+	}
+
+	card, err := s.repository.GetPlayerCard(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.PublicProfileDto{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return dto.PublicProfileDto{}, myerrors.NewRepositoryErr("failed to fetch player card", err)
+	}
+
+	stats, err := s.repository.GetPlayerStats(ctx, userID)
+	if err != nil {
+		return dto.PublicProfileDto{}, myerrors.NewRepositoryErr("failed to fetch player stats", err)
+	}
+
+	visibility := models.DefaultProfileVisibility()
+	if viewerID != userID {
+		visibility, err = s.repository.GetProfileVisibility(ctx, userID)
+		if err != nil {
+			return dto.PublicProfileDto{}, myerrors.NewRepositoryErr("failed to fetch profile visibility", err)
+		}
+	}
+
+	profile := dto.ToPublicProfile(card, stats, visibility)
+	if viewerID != userID && visibility.ShowFriends {
+		mutual, err := s.repository.CountMutualFriends(ctx, viewerID, userID)
+		if err != nil {
+			return dto.PublicProfileDto{}, myerrors.NewRepositoryErr("failed to count mutual friends", err)
+		}
+		profile.MutualFriends = &mutual
+	}
+	if profile.PhotoURL, err = s.photoURL(ctx, card.PhotoThumbnail); err != nil {
+		return dto.PublicProfileDto{}, err
+	}
+
+	return profile, nil
+}
+
+func (s *Service) GetProfileVisibility(ctx context.Context, userID uint64) (models.ProfileVisibility, error) {
+	visibility, err := s.repository.GetProfileVisibility(ctx, userID)
+	if err != nil {
+		return models.ProfileVisibility{}, myerrors.NewRepositoryErr("failed to fetch profile visibility", err)
+	}
+
+	return visibility, nil
+}
+
+// UpdateProfileVisibility меняет только переданные флаги, остальные остаются как были
+func (s *Service) UpdateProfileVisibility(ctx context.Context, userID uint64, req requests.UpdateProfileVisibilityRequest) (models.ProfileVisibility, error) {
+	visibility, err := s.repository.GetProfileVisibility(ctx, userID)
+	if err != nil {
+		return models.ProfileVisibility{}, myerrors.NewRepositoryErr("failed to fetch profile visibility", err)
+	}
+	previous := visibility
+
+	applyFlag(&visibility.ShowRating, req.ShowRating)
+	applyFlag(&visibility.ShowMatchesPlayed, req.ShowMatchesPlayed)
+	applyFlag(&visibility.ShowFriends, req.ShowFriends)
+	applyFlag(&visibility.ShowPartners, req.ShowPartners)
+	applyFlag(&visibility.ShowTown, req.ShowTown)
+	applyFlag(&visibility.ShowTrainingDistrict, req.ShowTrainingDistrict)
+	applyFlag(&visibility.ShowCourtPosition, req.ShowCourtPosition)
+	applyFlag(&visibility.ShowInSuggestions, req.ShowInSuggestions)
+
+	if err = s.repository.UpsertProfileVisibility(ctx, userID, visibility); err != nil {
+		return models.ProfileVisibility{}, myerrors.NewRepositoryErr("failed to save profile visibility", err)
+	}
+
+	// таблицы рейтинга зависят от открытости рейтинга и города
+	if visibility.ShowRating != previous.ShowRating || visibility.ShowTown != previous.ShowTown {
+		s.refreshLeaderboard(ctx)
+	}
+	return visibility, nil
+}
+
+func (s *Service) UpdateCourtPosition(ctx context.Context, userID uint64, position *models.CourtPosition) error {
+	if position != nil && !position.IsValid() {
+		return myerrors.NewValidationError("court position must be left, right or both", errors.New("invalid court position"))
+	}
+
+	if err := s.repository.UpdateCourtPosition(ctx, userID, position); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewNotFoundErr("user not found", err)
+		}
+		return myerrors.NewRepositoryErr("failed to update court position", err)
+	}
+
+	return nil
+}
+
+func applyFlag(target *bool, value *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
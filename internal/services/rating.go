@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/rating"
+)
+
+const (
+	ratingHistoryDefaultLimit = 50
+	ratingHistoryMaxLimit     = 200
+
+	leaderboardDefaultPageSize = 50
+	leaderboardMaxPageSize     = 100
+	nearbyDefaultRadius        = 5
+	nearbyMaxRadius            = 25
+)
+
+// GetMyRatings — рейтинги пользователя по видам спорта, последние изменения по матчам,
+// сбросы сезонов и снижения за неактивность
+func (s *Service) GetMyRatings(ctx context.Context, userID uint64, req requests.RatingHistoryRequest) (responses.MyRatingsResponse, error) {
+	ratings, err := s.repository.GetPlayerRatings(ctx, userID)
+	if err != nil {
+		return responses.MyRatingsResponse{}, myerrors.NewRepositoryErr("failed to fetch ratings", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = ratingHistoryDefaultLimit
+	}
+	limit = min(limit, ratingHistoryMaxLimit)
+
+	history, err := s.repository.ListRatingHistory(ctx, userID, req.SportID, limit)
+	if err != nil {
+		return responses.MyRatingsResponse{}, myerrors.NewRepositoryErr("failed to fetch rating history", err)
+	}
+
+	adjustments, err := s.repository.ListRatingAdjustments(ctx, userID, req.SportID, limit)
+	if err != nil {
+		return responses.MyRatingsResponse{}, myerrors.NewRepositoryErr("failed to fetch rating adjustments", err)
+	}
+
+	return responses.MyRatingsResponse{Ratings: ratings, History: history, Adjustments: adjustments}, nil
+}
+
+// RecalculateRatings заново проигрывает все подтверждённые рейтинговые результаты,
+// начала сезонов и снижения за неактивность
+func (s *Service) RecalculateRatings(ctx context.Context) (int, error) {
+	replayed, err := s.repository.RecalculateRatings(ctx, s.ratingEngine())
+	if err != nil {
+		return 0, myerrors.NewRepositoryErr("failed to recalculate ratings", err)
+	}
+
+	s.refreshLeaderboard(ctx)
+	return replayed, nil
+}
+
+// refreshLeaderboard пересчитывает места в таблицах рейтинга после изменения рейтингов или видимости.
+// Изменение уже сохранено, поэтому ошибка только логируется: места обновятся при следующем пересчёте.
+func (s *Service) refreshLeaderboard(ctx context.Context) {
+	if err := s.repository.RefreshLeaderboard(ctx); err != nil {
+		s.logger.Warn("failed to refresh leaderboard", "err", err)
+	}
+}
+
+func (s *Service) ratingEngine() rating.Elo {
+	return rating.NewElo(s.cfg.RatingConfig)
+}
+
+// GetLeaderboard — страница таблицы рейтинга по виду спорта: общая или среди друзей,
+// по всем городам или по городу игрока (users.town_id)
+func (s *Service) GetLeaderboard(ctx context.Context, userID uint64, req requests.LeaderboardRequest) (responses.LeaderboardResponse, error) {
+	scope, err := leaderboardScope(req.SportID, req.Scope)
+	if err != nil {
+		return responses.LeaderboardResponse{}, err
+	}
+
+	page := max(req.Page, 1)
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = leaderboardDefaultPageSize
+	}
+	pageSize = min(pageSize, leaderboardMaxPageSize)
+
+	resp, err := s.leaderboard(ctx, models.LeaderboardFilter{
+		SportID:  req.SportID,
+		ViewerID: userID,
+		TownID:   req.TownID,
+		Scope:    scope,
+		Limit:    pageSize,
+		Offset:   (page - 1) * pageSize,
+	})
+	if err != nil {
+		return responses.LeaderboardResponse{}, err
+	}
+
+	resp.Page = page
+	resp.PageSize = pageSize
+	return resp, nil
+}
+
+// GetNearbyPlayers — игроки близкого уровня: radius мест выше и ниже пользователя.
+// Без рейтинга в этом виде спорта — вокруг места, которое занял бы начальный рейтинг.
+func (s *Service) GetNearbyPlayers(ctx context.Context, userID uint64, req requests.NearbyPlayersRequest) (responses.LeaderboardResponse, error) {
+	scope, err := leaderboardScope(req.SportID, req.Scope)
+	if err != nil {
+		return responses.LeaderboardResponse{}, err
+	}
+
+	radius := req.Radius
+	if radius <= 0 {
+		radius = nearbyDefaultRadius
+	}
+	radius = min(radius, nearbyMaxRadius)
+
+	return s.leaderboard(ctx, models.LeaderboardFilter{
+		SportID:  req.SportID,
+		ViewerID: userID,
+		TownID:   req.TownID,
+		Scope:    scope,
+		Around:   &s.cfg.RatingConfig.InitialRating,
+		Radius:   radius,
+	})
+}
+
+func (s *Service) leaderboard(ctx context.Context, filter models.LeaderboardFilter) (responses.LeaderboardResponse, error) {
+	board, err := s.repository.GetLeaderboard(ctx, filter)
+	if err != nil {
+		return responses.LeaderboardResponse{}, myerrors.NewRepositoryErr("failed to fetch leaderboard", err)
+	}
+
+	for i := range board.Entries {
+		if board.Entries[i].PhotoURL, err = s.photoURL(ctx, board.Entries[i].PhotoURL); err != nil {
+			return responses.LeaderboardResponse{}, err
+		}
+	}
+	if board.Me != nil {
+		if board.Me.PhotoURL, err = s.photoURL(ctx, board.Me.PhotoURL); err != nil {
+			return responses.LeaderboardResponse{}, err
+		}
+	}
+
+	return responses.LeaderboardResponse{
+		SportID: filter.SportID,
+		Scope:   filter.Scope,
+		TownID:  filter.TownID,
+		Entries: board.Entries,
+		Me:      board.Me,
+		Total:   board.Total,
+	}, nil
+}
+
+func leaderboardScope(sportID int, scope string) (models.LeaderboardScope, error) {
+	if sportID <= 0 {
+		return "", myerrors.NewValidationError("sport_id is required", errors.New("missing sport"))
+	}
+
+	switch models.LeaderboardScope(scope) {
+	case "", models.LeaderboardScopeAll:
+		return models.LeaderboardScopeAll, nil
+	case models.LeaderboardScopeFriends:
+		return models.LeaderboardScopeFriends, nil
+	default:
+		return "", myerrors.NewValidationError(
+			fmt.Sprintf("scope must be %s or %s", models.LeaderboardScopeAll, models.LeaderboardScopeFriends),
+			errors.New("invalid scope"),
+		)
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	flagDecisionDismiss = "dismiss"
+	flagDecisionVoid    = "void"
+)
+
+// ListRatingFlags — очередь подозрительных рейтинговых результатов; по умолчанию нерассмотренные
+func (s *Service) ListRatingFlags(ctx context.Context, req requests.RatingFlagsRequest) ([]models.RatingFlag, error) {
+	status := models.RatingFlagStatus(req.Status)
+	switch status {
+	case "":
+		status = models.RatingFlagOpen
+	case models.RatingFlagOpen, models.RatingFlagDismissed, models.RatingFlagVoided:
+	default:
+		return nil, myerrors.NewValidationError("status must be open, dismissed or voided", errors.New("invalid status"))
+	}
+
+	flags, err := s.repository.ListRatingFlags(ctx, status)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list rating flags", err)
+	}
+
+	return flags, nil
+}
+
+// ResolveRatingFlag — решение ассистента: dismiss оставляет результат в рейтинге,
+// void исключает его и пересчитывает рейтинги заново
+func (s *Service) ResolveRatingFlag(ctx context.Context, userID, flagID uint64, req requests.ResolveRatingFlagRequest) (models.RatingFlag, error) {
+	var status models.RatingFlagStatus
+	switch req.Decision {
+	case flagDecisionDismiss:
+		status = models.RatingFlagDismissed
+	case flagDecisionVoid:
+		status = models.RatingFlagVoided
+	default:
+		return models.RatingFlag{}, myerrors.NewValidationError("decision must be dismiss or void", errors.New("invalid decision"))
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		if c := strings.TrimSpace(*req.Comment); c != "" {
+			comment = &c
+		}
+	}
+
+	if err := s.repository.ResolveRatingFlag(ctx, flagID, userID, status, comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// различаем «нет такой отметки» и «отметка уже рассмотрена»
+			if _, getErr := s.getRatingFlag(ctx, flagID); getErr != nil {
+				return models.RatingFlag{}, getErr
+			}
+			return models.RatingFlag{}, myerrors.NewConflictErr("flag is already reviewed", err)
+		}
+		return models.RatingFlag{}, myerrors.NewRepositoryErr("failed to resolve rating flag", err)
+	}
+
+	if status == models.RatingFlagVoided {
+		if _, err := s.repository.RecalculateRatings(ctx, s.ratingEngine()); err != nil {
+			return models.RatingFlag{}, myerrors.NewRepositoryErr("failed to recalculate ratings", err)
+		}
+		s.refreshLeaderboard(ctx)
+	}
+
+	return s.getRatingFlag(ctx, flagID)
+}
+
+func (s *Service) getRatingFlag(ctx context.Context, flagID uint64) (models.RatingFlag, error) {
+	flag, err := s.repository.GetRatingFlag(ctx, flagID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.RatingFlag{}, myerrors.NewNotFoundErr("rating flag not found", err)
+		}
+		return models.RatingFlag{}, myerrors.NewRepositoryErr("failed to fetch rating flag", err)
+	}
+
+	return flag, nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/rating"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const ratingSeasonNameMaxLength = 100
+
+// StartRatingSeason закрывает текущий сезон вида спорта с итоговой таблицей и открывает новый:
+// рейтинги сдвигаются к начальному на долю reset_factor
+func (s *Service) StartRatingSeason(ctx context.Context, userID uint64, req requests.StartRatingSeasonRequest) (models.RatingSeason, error) {
+	if req.SportID <= 0 {
+		return models.RatingSeason{}, myerrors.NewValidationError("sport_id is required", errors.New("missing sport"))
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len([]rune(name)) > ratingSeasonNameMaxLength {
+		return models.RatingSeason{}, myerrors.NewValidationError(
+			fmt.Sprintf("name is required and must be at most %d characters", ratingSeasonNameMaxLength), errors.New("invalid name"))
+	}
+	resetFactor := s.cfg.RatingConfig.SeasonResetFactor
+	if req.ResetFactor != nil {
+		resetFactor = *req.ResetFactor
+	}
+	if resetFactor < 0 || resetFactor > 1 {
+		return models.RatingSeason{}, myerrors.NewValidationError("reset_factor must be between 0 and 1", errors.New("invalid reset factor"))
+	}
+
+	seasonID, err := s.repository.StartRatingSeason(ctx, models.RatingSeason{
+		SportID:     req.SportID,
+		Name:        name,
+		ResetFactor: resetFactor,
+		StartedBy:   &userID,
+	}, s.ratingEngine())
+	if err != nil {
+		if errors.Is(err, myerrors.ErrInvalidReference) {
+			return models.RatingSeason{}, myerrors.NewValidationError("unknown sport", err)
+		}
+		return models.RatingSeason{}, myerrors.NewRepositoryErr("failed to start rating season", err)
+	}
+
+	s.refreshLeaderboard(ctx)
+	return s.getRatingSeason(ctx, seasonID)
+}
+
+// ListRatingSeasons — сезоны рейтинга, последние сначала
+func (s *Service) ListRatingSeasons(ctx context.Context, req requests.RatingSeasonsRequest) ([]models.RatingSeason, error) {
+	seasons, err := s.repository.ListRatingSeasons(ctx, req.SportID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list rating seasons", err)
+	}
+
+	return seasons, nil
+}
+
+// GetSeasonStandings — таблица сезона: итоговая для завершённого, текущая для идущего
+func (s *Service) GetSeasonStandings(ctx context.Context, viewerID, seasonID uint64) (responses.SeasonStandingsResponse, error) {
+	season, err := s.getRatingSeason(ctx, seasonID)
+	if err != nil {
+		return responses.SeasonStandingsResponse{}, err
+	}
+
+	standings, err := s.repository.ListSeasonStandings(ctx, season, viewerID)
+	if err != nil {
+		return responses.SeasonStandingsResponse{}, myerrors.NewRepositoryErr("failed to fetch season standings", err)
+	}
+	for i := range standings {
+		if standings[i].PhotoURL, err = s.photoURL(ctx, standings[i].PhotoURL); err != nil {
+			return responses.SeasonStandingsResponse{}, err
+		}
+	}
+
+	return responses.SeasonStandingsResponse{Season: season, Standings: standings}, nil
+}
+
+// DecayRatings снижает рейтинги неактивных игроков; вызывается воркером раз в RATING_DECAY_INTERVAL
+func (s *Service) DecayRatings(ctx context.Context) (int, error) {
+	decay := rating.NewDecay(s.cfg.RatingConfig)
+	if decay.After <= 0 || decay.Points <= 0 {
+		return 0, nil
+	}
+
+	decayed, err := s.repository.DecayRatings(ctx, s.ratingEngine(), decay, time.Now().UTC())
+	if err != nil {
+		return 0, myerrors.NewRepositoryErr("failed to decay ratings", err)
+	}
+
+	if decayed > 0 {
+		s.refreshLeaderboard(ctx)
+	}
+	return decayed, nil
+}
+
+func (s *Service) getRatingSeason(ctx context.Context, seasonID uint64) (models.RatingSeason, error) {
+	season, err := s.repository.GetRatingSeason(ctx, seasonID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.RatingSeason{}, myerrors.NewNotFoundErr("rating season not found", err)
+		}
+		return models.RatingSeason{}, myerrors.NewRepositoryErr("failed to fetch rating season", err)
+	}
+
+	return season, nil
+}
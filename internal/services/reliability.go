@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// новичок начинает как игрок с reliabilityPrior безупречными матчами: первая неявка
+	// снижает оценку заметно, но не обнуляет её
+	reliabilityPrior       = 5.0
+	reliabilityLatePenalty = 0.5 // поздняя отмена весит вдвое меньше неявки
+	reliabilityMaxScore    = 100
+)
+
+// MarkAttendance отмечает неявку игрока после начала матча. Отмечать могут организатор матча и пользователь
+// с правом match.manage.any; себя отметить нельзя. Остальные участники подают жалобы через ReportNoShow.
+// Поздняя отмена записывается только автоматически при выходе из матча.
+func (s *Service) MarkAttendance(ctx context.Context, reporterID, matchID uint64, req requests.MarkAttendanceRequest, permissions []string) (models.AttendanceMark, error) {
+	status := models.AttendanceStatus(req.Status)
+	if status != models.AttendanceNoShow {
+		return models.AttendanceMark{}, myerrors.NewValidationError(
+			"status must be no_show: late cancellations are recorded when a player leaves the match", errors.New("invalid attendance status"))
+	}
+	if req.UserID == 0 {
+		return models.AttendanceMark{}, myerrors.NewValidationError("user_id is required", errors.New("missing user"))
+	}
+	if req.UserID == reporterID {
+		return models.AttendanceMark{}, myerrors.NewValidationError("you cannot mark your own attendance", errors.New("self mark"))
+	}
+
+	details, err := s.matchDetails(ctx, matchID)
+	if err != nil {
+		return models.AttendanceMark{}, err
+	}
+	if !isMatchOrganizer(details.Match, reporterID) && !commons.HasPermission(permissions, commons.PermissionMatchManageAny) {
+		return models.AttendanceMark{}, myerrors.NewForbiddenErr("only the organizer can mark attendance", errors.New("not an organizer"))
+	}
+	if details.Status == models.MatchStatusCancelled {
+		return models.AttendanceMark{}, myerrors.NewConflictErr("match is cancelled", errors.New("match cancelled"))
+	}
+	if details.StartsAt == nil || details.StartsAt.After(time.Now()) {
+		return models.AttendanceMark{}, myerrors.NewConflictErr("attendance can be marked only after the match has started", errors.New("match not started"))
+	}
+	if !containsID(details.ParticipantIDs, req.UserID) {
+		return models.AttendanceMark{}, myerrors.NewValidationError("user is not a participant of this match", errors.New("target not a participant"))
+	}
+
+	mark, err := s.repository.MarkAttendance(ctx, matchID, req.UserID, status, &reporterID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.AttendanceMark{}, myerrors.NewConflictErr("player is already marked by someone else", err)
+		}
+		return models.AttendanceMark{}, myerrors.NewRepositoryErr("failed to mark attendance", err)
+	}
+
+	return mark, nil
+}
+
+// ReportNoShow принимает жалобу участника на неявку другого участника после начала матча. Неявка засчитывается,
+// когда жалобу подало большинство остальных участников, так что один игрок не испортит надёжность другому.
+func (s *Service) ReportNoShow(ctx context.Context, reporterID, matchID uint64, req requests.ReportNoShowRequest) (models.NoShowReport, error) {
+	if req.UserID == 0 {
+		return models.NoShowReport{}, myerrors.NewValidationError("user_id is required", errors.New("missing user"))
+	}
+	if req.UserID == reporterID {
+		return models.NoShowReport{}, myerrors.NewValidationError("you cannot report your own attendance", errors.New("self report"))
+	}
+
+	details, err := s.matchDetails(ctx, matchID)
+	if err != nil {
+		return models.NoShowReport{}, err
+	}
+	if !containsID(details.ParticipantIDs, reporterID) {
+		return models.NoShowReport{}, myerrors.NewForbiddenErr("only participants can report a no-show", errors.New("not a participant"))
+	}
+	if details.Status == models.MatchStatusCancelled {
+		return models.NoShowReport{}, myerrors.NewConflictErr("match is cancelled", errors.New("match cancelled"))
+	}
+	if details.StartsAt == nil || details.StartsAt.After(time.Now()) {
+		return models.NoShowReport{}, myerrors.NewConflictErr("no-show can be reported only after the match has started", errors.New("match not started"))
+	}
+	if !containsID(details.ParticipantIDs, req.UserID) {
+		return models.NoShowReport{}, myerrors.NewValidationError("user is not a participant of this match", errors.New("target not a participant"))
+	}
+
+	// большинство среди участников, кроме самого игрока
+	required := (len(details.ParticipantIDs)-1)/2 + 1
+	report, err := s.repository.ReportNoShow(ctx, matchID, req.UserID, reporterID, required)
+	if err != nil {
+		return models.NoShowReport{}, myerrors.NewRepositoryErr("failed to report no-show", err)
+	}
+
+	return report, nil
+}
+
+// ListMatchAttendance — отметки матча; видны тем же, кому виден сам матч
+func (s *Service) ListMatchAttendance(ctx context.Context, viewerID, matchID uint64, permissions []string) ([]models.AttendanceMark, error) {
+	if _, err := s.GetMatch(ctx, viewerID, matchID, permissions); err != nil {
+		return nil, err
+	}
+
+	marks, err := s.repository.ListMatchAttendance(ctx, matchID)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch match attendance", err)
+	}
+
+	return marks, nil
+}
+
+// ClearAttendance снимает ошибочную отметку; маршрут доступен только с правом match.manage.any
+func (s *Service) ClearAttendance(ctx context.Context, matchID, userID uint64) error {
+	if err := s.repository.ClearAttendance(ctx, matchID, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewNotFoundErr("attendance mark not found", err)
+		}
+		return myerrors.NewRepositoryErr("failed to clear attendance", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetUserReliability(ctx context.Context, userID uint64) (models.Reliability, error) {
+	if _, err := s.repository.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Reliability{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return models.Reliability{}, myerrors.NewRepositoryErr("failed to fetch user", err)
+	}
+
+	reliability, err := s.reliabilities(ctx, []uint64{userID})
+	if err != nil {
+		return models.Reliability{}, err
+	}
+
+	return reliability[userID], nil
+}
+
+// reliabilities — надёжность игроков с посчитанной оценкой; у каждого из userIDs есть запись
+func (s *Service) reliabilities(ctx context.Context, userIDs []uint64) (map[uint64]models.Reliability, error) {
+	stats, err := s.repository.GetReliabilityStats(ctx, userIDs)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch reliability", err)
+	}
+
+	result := make(map[uint64]models.Reliability, len(userIDs))
+	for _, id := range userIDs {
+		rel := stats[id]
+		rel.UserID = id
+		rel.Score = reliabilityScore(rel)
+		result[id] = rel
+	}
+
+	return result, nil
+}
+
+// checkReliability не пускает в публичный матч игрока ниже порога матча. Рейтинговый матч
+// без своего порога берёт его из настроек.
+func (s *Service) checkReliability(ctx context.Context, userID uint64, match models.Match) error {
+	threshold := 0
+	switch {
+	case match.MinReliability != nil:
+		threshold = *match.MinReliability
+	case match.MatchType == models.MatchTypeRanked:
+		threshold = s.cfg.MatchConfig.RankedMinReliability
+	}
+	if threshold <= 0 {
+		return nil
+	}
+
+	reliability, err := s.reliabilities(ctx, []uint64{userID})
+	if err != nil {
+		return err
+	}
+	if score := reliability[userID].Score; score < threshold {
+		return myerrors.NewForbiddenErr(
+			fmt.Sprintf("your reliability %d is below the required %d", score, threshold),
+			errors.New("reliability too low"),
+		)
+	}
+
+	return nil
+}
+
+// recordLateCancellation отмечает выход из матча незадолго до начала. Ошибка не мешает выходу.
+func (s *Service) recordLateCancellation(ctx context.Context, userID uint64, match models.Match) {
+	window := s.cfg.MatchConfig.LateCancellationWindow
+	if window <= 0 || match.StartsAt == nil || time.Until(*match.StartsAt) > window {
+		return
+	}
+
+	if _, err := s.repository.MarkAttendance(ctx, match.ID, userID, models.AttendanceLateCancellation, nil); err != nil {
+		s.logger.Warn("failed to record late cancellation", "match_id", match.ID, "user_id", userID, "err", err)
+	}
+}
+
+// reliabilityScore — доля выполненных обязательств с поправкой на новичков, от 0 до 100
+func reliabilityScore(r models.Reliability) int {
+	commitments := float64(r.Commitments) + reliabilityPrior
+	kept := commitments - float64(r.NoShows) - reliabilityLatePenalty*float64(r.LateCancellations)
+	score := int(math.Round(reliabilityMaxScore * kept / commitments))
+
+	return max(0, min(score, reliabilityMaxScore))
+}
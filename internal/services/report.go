@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	reportCommentMaxLength = 1000
+
+	reportDecisionResolve = "resolve"
+	reportDecisionDismiss = "dismiss"
+)
+
+// CreateReport отправляет жалобу на пользователя, сообщение или матч в очередь модерации.
+// На сообщение жалуется только участник чата, на закрытый матч — только его участник.
+func (s *Service) CreateReport(ctx context.Context, userID uint64, req requests.CreateReportRequest) (models.Report, error) {
+	if req.TargetID == 0 {
+		return models.Report{}, myerrors.NewValidationError("target_id is required", errors.New("missing target"))
+	}
+
+	reason := models.ReportReason(req.Reason)
+	switch reason {
+	case models.ReportReasonSpam, models.ReportReasonHarassment, models.ReportReasonInappropriateContent,
+		models.ReportReasonCheating, models.ReportReasonFakeProfile, models.ReportReasonOther:
+	default:
+		return models.Report{}, myerrors.NewValidationError(
+			"reason must be spam, harassment, inappropriate_content, cheating, fake_profile or other", errors.New("invalid reason"))
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		if c := strings.TrimSpace(*req.Comment); c != "" {
+			if len([]rune(c)) > reportCommentMaxLength {
+				return models.Report{}, myerrors.NewValidationError(
+					fmt.Sprintf("comment must be at most %d characters", reportCommentMaxLength), errors.New("comment too long"))
+			}
+			comment = &c
+		}
+	}
+	if reason == models.ReportReasonOther && comment == nil {
+		return models.Report{}, myerrors.NewValidationError("comment is required for reason other", errors.New("missing comment"))
+	}
+
+	targetType := models.ReportTargetType(req.TargetType)
+	reportedUserID, err := s.reportTarget(ctx, userID, targetType, req.TargetID)
+	if err != nil {
+		return models.Report{}, err
+	}
+
+	reportID, err := s.repository.CreateReport(ctx, models.Report{
+		ReporterID:     &userID,
+		TargetType:     targetType,
+		TargetID:       req.TargetID,
+		ReportedUserID: reportedUserID,
+		Reason:         reason,
+		Comment:        comment,
+	})
+	if err != nil {
+		if errors.Is(err, myerrors.ErrReportExists) {
+			return models.Report{}, myerrors.NewConflictErr("you have already reported this", err)
+		}
+		return models.Report{}, myerrors.NewRepositoryErr("failed to create report", err)
+	}
+
+	return s.getReport(ctx, reportID)
+}
+
+// ListReports — очередь жалоб для модераторов; по умолчанию открытые
+func (s *Service) ListReports(ctx context.Context, req requests.ReportsRequest) ([]models.Report, error) {
+	status := models.ReportStatus(req.Status)
+	switch status {
+	case "":
+		status = models.ReportOpen
+	case models.ReportOpen, models.ReportResolved, models.ReportDismissed:
+	default:
+		return nil, myerrors.NewValidationError("status must be open, resolved or dismissed", errors.New("invalid status"))
+	}
+
+	var targetType *models.ReportTargetType
+	if req.TargetType != "" {
+		t := models.ReportTargetType(req.TargetType)
+		if !validReportTarget(t) {
+			return nil, myerrors.NewValidationError("target_type must be user, message or match", errors.New("invalid target type"))
+		}
+		targetType = &t
+	}
+
+	reports, err := s.repository.ListReports(ctx, status, targetType)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to list reports", err)
+	}
+
+	return reports, nil
+}
+
+// ResolveReport — решение модератора по открытой жалобе: resolve подтверждает нарушение,
+// dismiss отклоняет жалобу. Меры к нарушителю принимаются отдельно, например блокировкой аккаунта.
+func (s *Service) ResolveReport(ctx context.Context, userID, reportID uint64, req requests.ResolveReportRequest) (models.Report, error) {
+	var status models.ReportStatus
+	switch req.Decision {
+	case reportDecisionResolve:
+		status = models.ReportResolved
+	case reportDecisionDismiss:
+		status = models.ReportDismissed
+	default:
+		return models.Report{}, myerrors.NewValidationError("decision must be resolve or dismiss", errors.New("invalid decision"))
+	}
+
+	var comment *string
+	if req.Comment != nil {
+		if c := strings.TrimSpace(*req.Comment); c != "" {
+			comment = &c
+		}
+	}
+
+	if err := s.repository.ResolveReport(ctx, reportID, userID, status, comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// различаем «нет такой жалобы» и «жалоба уже рассмотрена»
+			if _, getErr := s.getReport(ctx, reportID); getErr != nil {
+				return models.Report{}, getErr
+			}
+			return models.Report{}, myerrors.NewConflictErr("report is already reviewed", err)
+		}
+		return models.Report{}, myerrors.NewRepositoryErr("failed to resolve report", err)
+	}
+
+	return s.getReport(ctx, reportID)
+}
+
+// reportTarget проверяет, что объект жалобы существует и доступен автору, и находит,
+// на кого жалоба: сам пользователь, автор сообщения или организатор матча
+func (s *Service) reportTarget(ctx context.Context, userID uint64, targetType models.ReportTargetType, targetID uint64) (*uint64, error) {
+	switch targetType {
+	case models.ReportTargetUser:
+		if targetID == userID {
+			return nil, myerrors.NewValidationError("you cannot report yourself", errors.New("self report"))
+		}
+		if _, err := s.repository.GetPlayerCard(ctx, targetID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, myerrors.NewNotFoundErr("user not found", err)
+			}
+			return nil, myerrors.NewRepositoryErr("failed to fetch user", err)
+		}
+		return &targetID, nil
+
+	case models.ReportTargetMessage:
+		senderID, err := s.repository.GetReportableMessage(ctx, targetID, userID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, myerrors.NewNotFoundErr("message not found", err)
+			}
+			return nil, myerrors.NewRepositoryErr("failed to fetch message", err)
+		}
+		if senderID != nil && *senderID == userID {
+			return nil, myerrors.NewValidationError("you cannot report your own message", errors.New("self report"))
+		}
+		return senderID, nil
+
+	case models.ReportTargetMatch:
+		match, err := s.getMatch(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if isMatchOrganizer(match, userID) {
+			return nil, myerrors.NewValidationError("you cannot report your own match", errors.New("self report"))
+		}
+		if match.Visibility != models.MatchVisibilityPublic {
+			participant, err := s.repository.IsUserInMatch(ctx, targetID, userID)
+			if err != nil {
+				return nil, myerrors.NewRepositoryErr("failed to check match participation", err)
+			}
+			if !participant {
+				return nil, myerrors.NewNotFoundErr("match not found", errors.New("not a participant"))
+			}
+		}
+		return match.OrganizerID, nil
+	}
+
+	return nil, myerrors.NewValidationError("target_type must be user, message or match", errors.New("invalid target type"))
+}
+
+func validReportTarget(t models.ReportTargetType) bool {
+	switch t {
+	case models.ReportTargetUser, models.ReportTargetMessage, models.ReportTargetMatch:
+		return true
+	}
+
+	return false
+}
+
+func (s *Service) getReport(ctx context.Context, reportID uint64) (models.Report, error) {
+	report, err := s.repository.GetReport(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Report{}, myerrors.NewNotFoundErr("report not found", err)
+		}
+		return models.Report{}, myerrors.NewRepositoryErr("failed to fetch report", err)
+	}
+
+	return report, nil
+}
@@ -6,6 +6,9 @@ import (
 	"sport-assistance/internal/models"
 	"sport-assistance/internal/services/dto"
 	"sport-assistance/pkg/configs"
+	"sport-assistance/pkg/notifier"
+	"sport-assistance/pkg/rating"
+	"sport-assistance/pkg/storage"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -14,13 +17,171 @@ import (
 type IRepository interface {
 	// User
 	CreateUser(ctx context.Context, user models.User) (uint64, error)
-	GetUsers(ctx context.Context) ([]dto.UserDto, error)
+	ListUsers(ctx context.Context, filter models.UserFilter) ([]dto.UserDto, error)
+	CountUsers(ctx context.Context, filter models.UserFilter) (int, error)
+	StreamUsers(ctx context.Context, filter models.UserFilter, fn func(user dto.UserDto) error) error
 	GetUserByID(ctx context.Context, userID uint64) (dto.UserDto, error)
-	GetUserByEmail(ctx context.Context, email string) (dto.UserDto, error)
+	GetUserCredentialsByEmail(ctx context.Context, email string) (models.UserCredentials, error)
 	GetUserByPhone(ctx context.Context, phone string) (dto.UserDto, error)
 	UpdateUser(ctx context.Context, userID uint64, user models.User) error
 	DeleteUser(ctx context.Context, userID uint64) error
+	GetUserPhoto(ctx context.Context, userID uint64) (models.UserPhoto, error)
+	UpdateUserPhoto(ctx context.Context, userID uint64, photo models.UserPhoto) error
+	ContactExists(ctx context.Context, contactType models.ContactType, value string, excludeUserID uint64) (bool, error)
+	UpdateUserContact(ctx context.Context, userID uint64, contactType models.ContactType, value string) (string, error)
 	UserExistsByEmail(ctx context.Context, email string) (bool, error)
+	BlockUserAccount(ctx context.Context, userID uint64) error
+	UnblockUserAccount(ctx context.Context, userID uint64) error
+
+	// Account
+	RequestUserDeletion(ctx context.Context, userID uint64) (time.Time, error)
+	CancelUserDeletion(ctx context.Context, userID uint64) error
+	GetUsersPendingAnonymization(ctx context.Context, requestedBefore time.Time, limit int) ([]uint64, error)
+	AnonymizeUser(ctx context.Context, userID uint64) error
+	GetUserMessages(ctx context.Context, userID uint64) ([]models.ChatMessage, error)
+
+	// Profile
+	GetPlayerCard(ctx context.Context, userID uint64) (models.PlayerCard, error)
+	GetPlayerStats(ctx context.Context, userID uint64) (models.PlayerStats, error)
+	GetProfileVisibility(ctx context.Context, userID uint64) (models.ProfileVisibility, error)
+	UpsertProfileVisibility(ctx context.Context, userID uint64, visibility models.ProfileVisibility) error
+	UpdateCourtPosition(ctx context.Context, userID uint64, position *models.CourtPosition) error
+
+	// Subscriptions
+	GetSubscriptions(ctx context.Context) ([]models.Subscription, error)
+	GetSubscriptionByID(ctx context.Context, subscriptionID int) (models.Subscription, error)
+	GetActiveUserSubscription(ctx context.Context, userID uint64) (models.UserSubscription, error)
+	ActivateUserSubscription(ctx context.Context, userID uint64, subscriptionID int, endsAt time.Time) (models.UserSubscription, error)
+	ExpireUserSubscriptions(ctx context.Context, now time.Time, limit int) ([]uint64, error)
+	UpdateExtendedProfile(ctx context.Context, userID uint64, profile models.ExtendedProfile) error
+
+	// Matches
+	CreateMatch(ctx context.Context, match models.Match, inviteeIDs []uint64, invitationExpiresAt time.Time) (uint64, error)
+	GetMatchByID(ctx context.Context, matchID uint64) (models.Match, error)
+	GetMatchesByUserID(ctx context.Context, userID uint64) ([]models.Match, error)
+	ListUserMatches(ctx context.Context, userID uint64, filter models.MatchListFilter) ([]models.Match, error)
+	GetMatchParticipants(ctx context.Context, matchID uint64) ([]uint64, error)
+	IsUserInMatch(ctx context.Context, matchID, userID uint64) (bool, error)
+	RemoveUserFromMatch(ctx context.Context, matchID, userID uint64) error
+	CancelMatch(ctx context.Context, matchID uint64) error
+	ListPublicMatches(ctx context.Context, filter models.PublicMatchFilter) ([]models.Match, error)
+	JoinPublicMatch(ctx context.Context, matchID, userID uint64) error
+	GetCourtSportObjectID(ctx context.Context, courtID int) (int, error)
+	FindScheduleConflicts(ctx context.Context, userID uint64, startsAt, endsAt time.Time, excludeMatchID uint64) ([]models.ScheduleConflict, error)
+	ListScheduleEvents(ctx context.Context, userID uint64, from, to time.Time) ([]models.ScheduleEvent, error)
+
+	// Match invitations
+	CreateMatchInvitations(ctx context.Context, matchID, inviterID uint64, userIDs []uint64, expiresAt time.Time) ([]models.MatchInvitation, error)
+	GetMatchInvitation(ctx context.Context, invitationID uint64) (models.MatchInvitation, error)
+	ListMatchInvitations(ctx context.Context, matchID uint64) ([]models.MatchInvitation, error)
+	ListUserPendingInvitations(ctx context.Context, userID uint64) ([]models.MatchInvitation, error)
+	HasPendingMatchInvitation(ctx context.Context, matchID, userID uint64) (bool, error)
+	AcceptMatchInvitation(ctx context.Context, invitationID uint64) error
+	CloseMatchInvitation(ctx context.Context, invitationID uint64, status models.InvitationStatus) error
+	CreateMatchInviteLink(ctx context.Context, link models.MatchInviteLink) (models.MatchInviteLink, error)
+	GetMatchInviteLink(ctx context.Context, linkID uint64) (models.MatchInviteLink, error)
+	ListMatchInviteLinks(ctx context.Context, matchID uint64) ([]models.MatchInviteLink, error)
+	RevokeMatchInviteLink(ctx context.Context, linkID uint64) error
+	RedeemMatchInviteLink(ctx context.Context, linkID, userID uint64, invitationExpiresAt time.Time) (models.MatchInvitation, error)
+
+	// Match lineup
+	GetMatchLineup(ctx context.Context, matchID uint64) ([]models.LineupPlayer, error)
+	SetMatchLineup(ctx context.Context, matchID uint64, assignments []models.LineupAssignment) error
+
+	// Match results
+	CreateMatchResult(ctx context.Context, result models.MatchResult, sportID int) (uint64, error)
+	GetMatchResult(ctx context.Context, resultID uint64) (models.MatchResult, error)
+	GetActiveMatchResult(ctx context.Context, matchID uint64) (models.MatchResult, error)
+	ListDisputedMatchResults(ctx context.Context) ([]models.MatchResult, error)
+	ConfirmMatchResult(ctx context.Context, resultID, userID uint64, engine rating.Elo) error
+	DisputeMatchResult(ctx context.Context, resultID, userID uint64, reason string) error
+	ResolveMatchResult(ctx context.Context, resultID, resolverID uint64, confirm bool, comment *string, engine rating.Elo) error
+	GetSportName(ctx context.Context, sportID int) (string, error)
+	GetRatingFlag(ctx context.Context, flagID uint64) (models.RatingFlag, error)
+	ListRatingFlags(ctx context.Context, status models.RatingFlagStatus) ([]models.RatingFlag, error)
+	ResolveRatingFlag(ctx context.Context, flagID, reviewerID uint64, status models.RatingFlagStatus, comment *string) error
+
+	// Match attendance
+	MarkAttendance(ctx context.Context, matchID, userID uint64, status models.AttendanceStatus, reportedBy *uint64) (models.AttendanceMark, error)
+	ReportNoShow(ctx context.Context, matchID, userID, reporterID uint64, required int) (models.NoShowReport, error)
+	ClearAttendance(ctx context.Context, matchID, userID uint64) error
+	ListMatchAttendance(ctx context.Context, matchID uint64) ([]models.AttendanceMark, error)
+	GetReliabilityStats(ctx context.Context, userIDs []uint64) (map[uint64]models.Reliability, error)
+
+	// Ratings
+	GetPlayerRatings(ctx context.Context, userID uint64) ([]models.PlayerRating, error)
+	ListRatingHistory(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingChange, error)
+	RecalculateRatings(ctx context.Context, engine rating.Elo) (int, error)
+	GetLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (models.Leaderboard, error)
+	RefreshLeaderboard(ctx context.Context) error
+	ListRatingAdjustments(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingAdjustment, error)
+	DecayRatings(ctx context.Context, engine rating.Elo, decay rating.Decay, now time.Time) (int, error)
+	StartRatingSeason(ctx context.Context, season models.RatingSeason, engine rating.Elo) (uint64, error)
+	GetRatingSeason(ctx context.Context, seasonID uint64) (models.RatingSeason, error)
+	ListRatingSeasons(ctx context.Context, sportID *int) ([]models.RatingSeason, error)
+	ListSeasonStandings(ctx context.Context, season models.RatingSeason, viewerID uint64) ([]models.SeasonStanding, error)
+
+	// Tournaments
+	CreateTournament(ctx context.Context, t models.Tournament) (uint64, error)
+	GetTournament(ctx context.Context, tournamentID uint64) (models.Tournament, error)
+	ListTournaments(ctx context.Context, filter models.TournamentFilter) ([]models.Tournament, error)
+	AddTournamentEntry(ctx context.Context, tournamentID uint64, playerIDs []uint64) (uint64, error)
+	RemoveTournamentEntry(ctx context.Context, tournamentID, entryID uint64) error
+	ListTournamentEntries(ctx context.Context, tournamentID uint64) ([]models.TournamentEntry, error)
+	StartTournament(ctx context.Context, tournamentID uint64, plan models.TournamentPlan) error
+	GetTournamentFixture(ctx context.Context, fixtureID uint64) (models.TournamentFixture, error)
+	GetMatchFixture(ctx context.Context, matchID uint64) (models.TournamentFixture, error)
+	ScheduleSwissRound(ctx context.Context, tournamentID uint64, round int, fixtures []models.TournamentFixture) error
+	ListTournamentFixtures(ctx context.Context, tournamentID uint64) ([]models.TournamentFixture, error)
+	CreateLadderChallenge(ctx context.Context, tournamentID, challengerID, defenderID uint64, startsAt, endsAt time.Time) (uint64, error)
+	CompleteLadder(ctx context.Context, tournamentID uint64) error
+	GetSportRatings(ctx context.Context, sportID int, userIDs []uint64) (map[uint64]float64, error)
+
+	// Player statistics
+	ListPairResults(ctx context.Context, userID uint64, otherID *uint64) ([]models.PairResult, error)
+
+	// Friends
+	IsGuest(ctx context.Context, userID uint64) (bool, error)
+	HavePlayedTogether(ctx context.Context, userID, otherID uint64) (bool, error)
+	AreFriends(ctx context.Context, userID, otherID uint64) (bool, error)
+	CountMutualFriends(ctx context.Context, userID, otherID uint64) (int, error)
+	CreateFriendRequest(ctx context.Context, senderID, receiverID uint64) (uint64, error)
+	GetFriendRequest(ctx context.Context, requestID uint64) (models.FriendRequest, error)
+	GetPendingFriendRequest(ctx context.Context, userID, otherID uint64) (models.FriendRequest, error)
+	ListFriendRequests(ctx context.Context, userID uint64, incoming bool) ([]models.FriendRequest, error)
+	AcceptFriendRequest(ctx context.Context, requestID uint64) error
+	CloseFriendRequest(ctx context.Context, requestID uint64, status models.FriendRequestStatus) error
+	RemoveFriend(ctx context.Context, userID, friendID uint64) error
+	ListFriends(ctx context.Context, userID, viewerID uint64, limit, offset int) ([]models.Friend, error)
+
+	// Activities
+	ListActivities(ctx context.Context, sportID *int) ([]models.Activity, error)
+	GetActivity(ctx context.Context, activityID int) (models.Activity, error)
+	CreateActivityRequest(ctx context.Context, request models.ActivityRequest) (uint64, error)
+	GetActivityRequest(ctx context.Context, requestID uint64) (models.ActivityRequest, error)
+	ListActivityRequests(ctx context.Context, filter models.ActivityRequestFilter) ([]models.ActivityRequest, error)
+	ConfirmActivityRequest(ctx context.Context, requestID, reviewerID uint64, comment *string) error
+	RejectActivityRequest(ctx context.Context, requestID, reviewerID uint64, comment *string) error
+	CancelActivityRequest(ctx context.Context, requestID uint64) error
+
+	// Blocks and reports
+	BlockUser(ctx context.Context, blockerID, blockedID uint64) error
+	UnblockUser(ctx context.Context, blockerID, blockedID uint64) error
+	ListBlockedUsers(ctx context.Context, blockerID uint64) ([]models.BlockedUser, error)
+	IsBlockedBetween(ctx context.Context, userID, otherID uint64) (bool, error)
+	BlockedAmong(ctx context.Context, userID uint64, userIDs []uint64) ([]uint64, error)
+	HasBlockedParticipant(ctx context.Context, matchID, userID uint64) (bool, error)
+	CreateReport(ctx context.Context, report models.Report) (uint64, error)
+	GetReport(ctx context.Context, reportID uint64) (models.Report, error)
+	ListReports(ctx context.Context, status models.ReportStatus, targetType *models.ReportTargetType) ([]models.Report, error)
+	ResolveReport(ctx context.Context, reportID, reviewerID uint64, status models.ReportStatus, resolution *string) error
+	GetReportableMessage(ctx context.Context, messageID, userID uint64) (*uint64, error)
+
+	// Player suggestions
+	ListSuggestionCandidates(ctx context.Context, query models.SuggestionQuery) ([]models.SuggestionCandidate, error)
+
+	// Towns
+	SearchTowns(ctx context.Context, query string, limit int) ([]models.Town, error)
 
 	// Permissions
 	GetPermissionsByRoleId(ctx context.Context, roleId uint64) ([]string, error)
@@ -37,13 +198,24 @@ type Service struct {
 	logger      *slog.Logger
 	cfg         *configs.Config
 	redisClient *redis.Client
+	storage     storage.BlobStorage
+	notifier    notifier.Notifier
 }
 
-func NewService(repo IRepository, log *slog.Logger, cfg *configs.Config, redisClient *redis.Client) *Service {
+func NewService(
+	repo IRepository,
+	log *slog.Logger,
+	cfg *configs.Config,
+	redisClient *redis.Client,
+	blobStorage storage.BlobStorage,
+	notify notifier.Notifier,
+) *Service {
 	return &Service{
 		repository:  repo,
 		logger:      log,
 		cfg:         cfg,
 		redisClient: redisClient,
+		storage:     blobStorage,
+		notifier:    notify,
 	}
 }
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	minHeightCm = 50
+	maxHeightCm = 260
+	minWeightKg = 20
+	maxWeightKg = 300
+)
+
+func (s *Service) GetSubscriptions(ctx context.Context) ([]models.Subscription, error) {
+	subscriptions, err := s.repository.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch subscriptions", err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetUpgradeStatus показывает, каких полей анкеты не хватает для оформления подписки
+func (s *Service) GetUpgradeStatus(ctx context.Context, userID uint64) (responses.UpgradeStatusResponse, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return responses.UpgradeStatusResponse{}, err
+	}
+
+	missing := missingClientFields(user)
+	status := responses.UpgradeStatusResponse{
+		ProfileComplete: len(missing) == 0,
+		MissingFields:   missing,
+	}
+
+	subscription, err := s.repository.GetActiveUserSubscription(ctx, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return responses.UpgradeStatusResponse{}, myerrors.NewRepositoryErr("failed to fetch subscription", err)
+	}
+	if err == nil {
+		status.Subscription = &subscription
+	}
+
+	return status, nil
+}
+
+// UpdateExtendedProfile сохраняет переданные поля анкеты клиента. Остальные поля, уже введённые
+// при регистрации данные и история матчей остаются как есть.
+func (s *Service) UpdateExtendedProfile(ctx context.Context, userID uint64, req requests.ExtendedProfileRequest) (dto.SelfUserDto, error) {
+	if req.HeightCm != nil && (*req.HeightCm < minHeightCm || *req.HeightCm > maxHeightCm) {
+		return dto.SelfUserDto{}, myerrors.NewValidationError(
+			fmt.Sprintf("height_cm must be between %d and %d", minHeightCm, maxHeightCm), errors.New("invalid height"))
+	}
+	if req.WeightKg != nil && (*req.WeightKg < minWeightKg || *req.WeightKg > maxWeightKg) {
+		return dto.SelfUserDto{}, myerrors.NewValidationError(
+			fmt.Sprintf("weight_kg must be between %d and %d", minWeightKg, maxWeightKg), errors.New("invalid weight"))
+	}
+
+	current, err := s.getUser(ctx, userID)
+	if err != nil {
+		return dto.SelfUserDto{}, err
+	}
+
+	profile := models.ExtendedProfile{
+		HeightCm:                 req.HeightCm,
+		WeightKg:                 req.WeightKg,
+		SportActivityLevelID:     req.SportActivityLevelID,
+		SportTargetID:            req.SportTargetID,
+		LocationPreferenceTypeID: req.LocationPreferenceTypeID,
+		TownID:                   req.TownID,
+		IsHaveInjury:             req.IsHaveInjury,
+	}
+	// описание травмы хранится только при отмеченной травме; пустая строка его очищает
+	hasInjury := current.IsHaveInjury
+	if req.IsHaveInjury != nil {
+		hasInjury = *req.IsHaveInjury
+	}
+	if hasInjury && req.InjuryDescription != nil {
+		description := strings.TrimSpace(*req.InjuryDescription)
+		profile.InjuryDescription = &description
+	}
+
+	if err = s.repository.UpdateExtendedProfile(ctx, userID, profile); err != nil {
+		switch {
+		case errors.Is(err, myerrors.ErrInvalidReference):
+			return dto.SelfUserDto{}, myerrors.NewValidationError("unknown town, level, target or location preference", err)
+		case errors.Is(err, pgx.ErrNoRows):
+			return dto.SelfUserDto{}, myerrors.NewNotFoundErr("user not found", err)
+		default:
+			return dto.SelfUserDto{}, myerrors.NewRepositoryErr("failed to update profile", err)
+		}
+	}
+
+	// смена города переносит игрока в другую таблицу рейтинга по городу
+	if profile.TownID != nil && !equalIntPtr(current.TownID, profile.TownID) {
+		s.refreshLeaderboard(ctx)
+	}
+	return s.GetMe(ctx, userID)
+}
+
+// PurchaseSubscription оформляет тариф самим пользователем и сразу выдаёт токены с правами клиента.
+// Переданный refresh-токен отзывается, как при обновлении пары, чтобы старые права нельзя было продлить.
+// Оплата пока не подключена: подписка активируется без списания, как и OTP-заглушка.
+func (s *Service) PurchaseSubscription(ctx context.Context, userID uint64, req requests.PurchaseSubscriptionRequest) (responses.SubscriptionActivatedResponse, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return responses.SubscriptionActivatedResponse{}, err
+	}
+
+	if missing := missingClientFields(user); len(missing) > 0 {
+		return responses.SubscriptionActivatedResponse{}, myerrors.NewValidationError(
+			"extended profile is incomplete: "+strings.Join(missing, ", "),
+			errors.New("profile incomplete"),
+		)
+	}
+
+	// токен проверяем до активации, чтобы не оформить тариф без выдачи новой пары
+	if err = s.checkOwnRefreshToken(ctx, userID, req.RefreshToken); err != nil {
+		return responses.SubscriptionActivatedResponse{}, err
+	}
+
+	subscription, err := s.activateSubscription(ctx, userID, req.SubscriptionID, s.cfg.SubscriptionConfig.Period)
+	if err != nil {
+		return responses.SubscriptionActivatedResponse{}, err
+	}
+
+	access, refresh, err := s.rotateTokens(ctx, userID, user.Email, req.RefreshToken)
+	if err != nil {
+		return responses.SubscriptionActivatedResponse{}, err
+	}
+
+	return responses.SubscriptionActivatedResponse{
+		Subscription: subscription,
+		AccessToken:  access,
+		RefreshToken: refresh,
+	}, nil
+}
+
+// checkOwnRefreshToken проверяет, что refresh-токен выдан этому пользователю и ещё действует
+func (s *Service) checkOwnRefreshToken(ctx context.Context, userID uint64, refreshToken string) error {
+	token, err := s.repository.GetRefreshToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, myerrors.ErrRefreshTokenNotFound) {
+			return myerrors.NewUnauthorizedErr("invalid refresh token", err)
+		}
+		return myerrors.NewRepositoryErr("failed to fetch refresh token", err)
+	}
+
+	if token.UserID != userID || token.RevokedAt != nil || s.IsTokenExpired(token.ExpiresAt) {
+		return myerrors.NewUnauthorizedErr("invalid refresh token", myerrors.ErrRefreshTokenInvalid)
+	}
+
+	return nil
+}
+
+// AdminActivateSubscription оформляет тариф пользователю из админки. Анкета не проверяется.
+// Текущий access-токен пользователя отзывается, чтобы новые права применились при обновлении пары.
+func (s *Service) AdminActivateSubscription(ctx context.Context, userID uint64, req requests.AdminActivateSubscriptionRequest) (models.UserSubscription, error) {
+	if req.Days < 0 {
+		return models.UserSubscription{}, myerrors.NewValidationError("days must not be negative", errors.New("invalid days"))
+	}
+
+	period := s.cfg.SubscriptionConfig.Period
+	if req.Days > 0 {
+		period = time.Duration(req.Days) * 24 * time.Hour
+	}
+
+	subscription, err := s.activateSubscription(ctx, userID, req.SubscriptionID, period)
+	if err != nil {
+		return models.UserSubscription{}, err
+	}
+
+	s.dropAccessToken(ctx, userID)
+	return subscription, nil
+}
+
+// ExpireSubscriptions переводит пользователей с истёкшей подпиской обратно в гости.
+// Вызывается фоновым воркером; возвращает число обработанных подписок.
+func (s *Service) ExpireSubscriptions(ctx context.Context) (int, error) {
+	userIDs, err := s.repository.ExpireUserSubscriptions(ctx, time.Now(), s.cfg.SubscriptionConfig.ExpiryBatchSize)
+	if err != nil {
+		return 0, myerrors.NewRepositoryErr("failed to expire subscriptions", err)
+	}
+
+	for _, userID := range userIDs {
+		s.dropAccessToken(ctx, userID)
+	}
+
+	return len(userIDs), nil
+}
+
+func (s *Service) activateSubscription(ctx context.Context, userID uint64, subscriptionID int, period time.Duration) (models.UserSubscription, error) {
+	if _, err := s.repository.GetSubscriptionByID(ctx, subscriptionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserSubscription{}, myerrors.NewValidationError("unknown subscription", err)
+		}
+		return models.UserSubscription{}, myerrors.NewRepositoryErr("failed to fetch subscription", err)
+	}
+
+	subscription, err := s.repository.ActivateUserSubscription(ctx, userID, subscriptionID, time.Now().Add(period))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.UserSubscription{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return models.UserSubscription{}, myerrors.NewRepositoryErr("failed to activate subscription", err)
+	}
+
+	return subscription, nil
+}
+
+// missingClientFields перечисляет незаполненные поля анкеты, обязательные для клиента
+func missingClientFields(user dto.UserDto) []string {
+	missing := make([]string, 0)
+	required := []struct {
+		name  string
+		value *int
+	}{
+		{"height_cm", user.HeightCm},
+		{"weight_kg", user.WeightKg},
+		{"sport_activity_level_id", user.SportActivityLevelID},
+		{"sport_target_id", user.SportTargetID},
+		{"town_id", user.TownID},
+	}
+	for _, field := range required {
+		if field.value == nil {
+			missing = append(missing, field.name)
+		}
+	}
+
+	return missing
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
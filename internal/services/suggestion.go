@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+)
+
+const (
+	suggestionsDefaultLimit = 20
+	suggestionsMaxLimit     = 50
+	// кандидатов из базы берётся с запасом: база отбирает лучших по той же оценке, сервис объясняет её
+	suggestionsCandidatePool = 500
+)
+
+// suggestionWeights — веса оценки: близкий рейтинг важнее остального, но не перекрывает всё вместе
+var suggestionWeights = models.SuggestionWeights{
+	Rating:       40,
+	RatingSpread: 400, // при такой разнице рейтингов очков за рейтинг уже нет
+	Sport:        10,
+	SportMax:     3,
+	TimeSlot:     8,
+	TimeSlotMax:  3,
+	Town:         15,
+	Location:     10,
+	Friend:       10,
+	Partner:      5,
+	PartnerMax:   3,
+}
+
+// SuggestPlayers подбирает партнёров: близкий рейтинг в выбранном виде спорта, общие виды спорта
+// и время тренировок, тот же город или районы, дружба и совместные матчи. У каждого игрока —
+// оценка и причины, из которых она сложилась. min_reliability отсекает ненадёжных игроков.
+func (s *Service) SuggestPlayers(ctx context.Context, userID uint64, req requests.PlayerSuggestionsRequest) ([]models.PlayerSuggestion, error) {
+	if req.SportID != nil && *req.SportID <= 0 {
+		return nil, myerrors.NewValidationError("sport_id must be positive", errors.New("invalid sport"))
+	}
+	if req.MinReliability != nil && (*req.MinReliability < 0 || *req.MinReliability > reliabilityMaxScore) {
+		return nil, myerrors.NewValidationError(
+			fmt.Sprintf("min_reliability must be between 0 and %d", reliabilityMaxScore),
+			errors.New("invalid min reliability"),
+		)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = suggestionsDefaultLimit
+	}
+	limit = min(limit, suggestionsMaxLimit)
+
+	var ownRating *float64
+	if req.SportID != nil {
+		rating, err := s.playerRating(ctx, userID, *req.SportID)
+		if err != nil {
+			return nil, err
+		}
+		ownRating = &rating
+	}
+
+	candidates, err := s.repository.ListSuggestionCandidates(ctx, models.SuggestionQuery{
+		ViewerID: userID,
+		SportID:  req.SportID,
+		Rating:   ownRating,
+		Weights:  suggestionWeights,
+		Limit:    suggestionsCandidatePool,
+	})
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to fetch player suggestions", err)
+	}
+
+	candidateIDs := make([]uint64, 0, len(candidates))
+	for _, c := range candidates {
+		candidateIDs = append(candidateIDs, c.UserID)
+	}
+	reliability, err := s.reliabilities(ctx, candidateIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]models.PlayerSuggestion, 0, len(candidates))
+	for _, c := range candidates {
+		score := reliability[c.UserID].Score
+		if req.MinReliability != nil && score < *req.MinReliability {
+			continue
+		}
+		suggestion := scoreSuggestion(c, ownRating, suggestionWeights)
+		suggestion.Reliability = score
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	for i := range suggestions {
+		if suggestions[i].PhotoURL, err = s.photoURL(ctx, suggestions[i].PhotoURL); err != nil {
+			return nil, err
+		}
+	}
+
+	return suggestions, nil
+}
+
+// playerRating — рейтинг пользователя в виде спорта; без рейтинговых матчей — начальный
+func (s *Service) playerRating(ctx context.Context, userID uint64, sportID int) (float64, error) {
+	ratings, err := s.repository.GetPlayerRatings(ctx, userID)
+	if err != nil {
+		return 0, myerrors.NewRepositoryErr("failed to fetch ratings", err)
+	}
+
+	for _, r := range ratings {
+		if r.SportID == sportID {
+			return r.Rating, nil
+		}
+	}
+
+	return s.cfg.RatingConfig.InitialRating, nil
+}
+
+// scoreSuggestion складывает оценку кандидата; ownRating == nil — вид спорта не выбран
+func scoreSuggestion(c models.SuggestionCandidate, ownRating *float64, w models.SuggestionWeights) models.PlayerSuggestion {
+	suggestion := models.PlayerSuggestion{
+		UserID:   c.UserID,
+		Name:     c.Name,
+		Surname:  c.Surname,
+		PhotoURL: c.PhotoThumbnail,
+		TownID:   c.TownID,
+		Rating:   c.Rating,
+		Reasons:  make([]models.SuggestionReason, 0),
+	}
+
+	add := func(kind string, points float64, message string) {
+		if points <= 0 {
+			return
+		}
+		points = math.Round(points*100) / 100
+		suggestion.Score += points
+		suggestion.Reasons = append(suggestion.Reasons, models.SuggestionReason{Kind: kind, Message: message, Points: points})
+	}
+
+	if ownRating != nil && c.Rating != nil {
+		diff := math.Abs(*c.Rating - *ownRating)
+		add(models.SuggestionReasonRating, w.Rating*(1-diff/w.RatingSpread),
+			fmt.Sprintf("similar rating: %.0f, yours is %.0f", *c.Rating, *ownRating))
+	}
+	if len(c.SharedSports) > 0 {
+		add(models.SuggestionReasonSports, w.Sport*float64(min(len(c.SharedSports), w.SportMax)),
+			"also plays "+strings.Join(c.SharedSports, ", "))
+	}
+	if len(c.SharedTimeSlots) > 0 {
+		add(models.SuggestionReasonTime, w.TimeSlot*float64(min(len(c.SharedTimeSlots), w.TimeSlotMax)),
+			"trains at the same time: "+strings.Join(c.SharedTimeSlots, ", "))
+	}
+	if c.SameTown {
+		add(models.SuggestionReasonTown, w.Town, "lives in your town")
+	}
+	if len(c.SharedLocations) > 0 {
+		add(models.SuggestionReasonLocation, w.Location, "trains in the same places: "+strings.Join(c.SharedLocations, ", "))
+	}
+	if c.Friend {
+		add(models.SuggestionReasonFriend, w.Friend, "your friend")
+	}
+	if c.PartnerMatches > 0 {
+		add(models.SuggestionReasonPartner, w.Partner*float64(min(c.PartnerMatches, w.PartnerMax)),
+			fmt.Sprintf("your partner in %d matches", c.PartnerMatches))
+	}
+
+	suggestion.Score = math.Round(suggestion.Score*100) / 100
+	return suggestion
+}
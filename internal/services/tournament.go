@@ -0,0 +1,636 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/handlers/responses"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/tournament"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	tournamentsDefaultPageSize = 20
+	tournamentsMaxPageSize     = 100
+	tournamentNameMaxLength    = 200
+	tournamentMinEntries       = 2
+	tournamentMaxEntries       = 128
+
+	tournamentDefaultRoundInterval = 24 * time.Hour
+
+	ladderDefaultChallengeRange = 3
+)
+
+// CreateTournament создаёт турнир в статусе draft; участники заявляются до старта
+func (s *Service) CreateTournament(ctx context.Context, organizerID uint64, req requests.CreateTournamentRequest) (models.TournamentDetails, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len([]rune(name)) > tournamentNameMaxLength {
+		return models.TournamentDetails{}, myerrors.NewValidationError(
+			fmt.Sprintf("name is required and must be at most %d characters", tournamentNameMaxLength), errors.New("invalid name"))
+	}
+	format := models.TournamentFormat(req.Format)
+	if !format.IsValid() {
+		return models.TournamentDetails{}, myerrors.NewValidationError(
+			"format must be single_elimination, round_robin, swiss or ladder", errors.New("invalid format"))
+	}
+	if req.MatchTypeID <= 0 {
+		return models.TournamentDetails{}, myerrors.NewValidationError("match_type_id is required", errors.New("missing match type"))
+	}
+
+	teamSize := req.TeamSize
+	if teamSize == 0 {
+		teamSize = 1
+	}
+	if teamSize != 1 && teamSize != 2 {
+		return models.TournamentDetails{}, myerrors.NewValidationError("team_size must be 1 or 2", errors.New("invalid team size"))
+	}
+
+	// встречи турнира — матчи со счётом, а счёт ведётся только в теннисе и паделе
+	sport, err := s.repository.GetSportName(ctx, req.SportID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TournamentDetails{}, myerrors.NewValidationError("unknown sport", err)
+		}
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to fetch sport", err)
+	}
+	if sport != models.SportTennis && sport != models.SportPadel {
+		return models.TournamentDetails{}, myerrors.NewValidationError("tournaments are supported for tennis and padel only", errors.New("unsupported sport"))
+	}
+
+	t := models.Tournament{
+		Name:        name,
+		Format:      format,
+		SportID:     req.SportID,
+		TownID:      req.TownID,
+		MatchTypeID: req.MatchTypeID,
+		TeamSize:    teamSize,
+		OrganizerID: &organizerID,
+	}
+	switch format {
+	case models.TournamentSwiss:
+		if req.SwissRounds == nil || *req.SwissRounds <= 0 {
+			return models.TournamentDetails{}, myerrors.NewValidationError("swiss_rounds is required for swiss format", errors.New("invalid swiss rounds"))
+		}
+		t.SwissRounds = req.SwissRounds
+	case models.TournamentLadder:
+		challengeRange := ladderDefaultChallengeRange
+		if req.ChallengeRange != nil {
+			challengeRange = *req.ChallengeRange
+		}
+		if challengeRange <= 0 {
+			return models.TournamentDetails{}, myerrors.NewValidationError("challenge_range must be positive", errors.New("invalid challenge range"))
+		}
+		t.ChallengeRange = &challengeRange
+	}
+	if err = tournamentSchedule(&t, req); err != nil {
+		return models.TournamentDetails{}, err
+	}
+
+	tournamentID, err := s.repository.CreateTournament(ctx, t)
+	if err != nil {
+		if errors.Is(err, myerrors.ErrInvalidReference) {
+			return models.TournamentDetails{}, myerrors.NewValidationError("unknown town or match type", err)
+		}
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to create tournament", err)
+	}
+
+	return s.tournamentDetails(ctx, tournamentID)
+}
+
+// ListTournaments возвращает страницу турниров, новые сначала
+func (s *Service) ListTournaments(ctx context.Context, req requests.TournamentsRequest) (responses.TournamentsResponse, error) {
+	filter := models.TournamentFilter{SportID: req.SportID, TownID: req.TownID}
+	if req.Status != "" {
+		status := models.TournamentStatus(req.Status)
+		switch status {
+		case models.TournamentStatusDraft, models.TournamentStatusInProgress, models.TournamentStatusCompleted:
+		default:
+			return responses.TournamentsResponse{}, myerrors.NewValidationError("status must be draft, in_progress or completed", errors.New("invalid status"))
+		}
+		filter.Status = &status
+	}
+
+	page := max(req.Page, 1)
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = tournamentsDefaultPageSize
+	}
+	pageSize = min(pageSize, tournamentsMaxPageSize)
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	tournaments, err := s.repository.ListTournaments(ctx, filter)
+	if err != nil {
+		return responses.TournamentsResponse{}, myerrors.NewRepositoryErr("failed to list tournaments", err)
+	}
+
+	return responses.TournamentsResponse{
+		Tournaments: tournaments,
+		Page:        page,
+		PageSize:    pageSize,
+	}, nil
+}
+
+func (s *Service) GetTournament(ctx context.Context, tournamentID uint64) (models.TournamentDetails, error) {
+	return s.tournamentDetails(ctx, tournamentID)
+}
+
+// AddTournamentEntry заявляет игрока или пару в турнир до старта
+func (s *Service) AddTournamentEntry(ctx context.Context, tournamentID uint64, req requests.AddTournamentEntryRequest) (models.TournamentDetails, error) {
+	t, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentDetails{}, err
+	}
+	if t.Status != models.TournamentStatusDraft {
+		return models.TournamentDetails{}, myerrors.NewConflictErr("tournament has already started", errors.New("tournament is not a draft"))
+	}
+	if len(req.PlayerIDs) != t.TeamSize || len(uniqueInvitees(req.PlayerIDs, 0)) != t.TeamSize {
+		return models.TournamentDetails{}, myerrors.NewValidationError(
+			fmt.Sprintf("player_ids must contain %d different players", t.TeamSize), errors.New("invalid entry players"))
+	}
+	if t.EntriesCount >= tournamentMaxEntries {
+		return models.TournamentDetails{}, myerrors.NewConflictErr(
+			fmt.Sprintf("tournament already has %d entries", tournamentMaxEntries), errors.New("entries limit exceeded"))
+	}
+
+	if _, err = s.repository.AddTournamentEntry(ctx, tournamentID, req.PlayerIDs); err != nil {
+		switch {
+		case errors.Is(err, myerrors.ErrAlreadyEntered):
+			return models.TournamentDetails{}, myerrors.NewConflictErr("player is already entered in this tournament", err)
+		case errors.Is(err, myerrors.ErrInvalidReference):
+			return models.TournamentDetails{}, myerrors.NewValidationError("unknown player", err)
+		case errors.Is(err, pgx.ErrNoRows):
+			return models.TournamentDetails{}, myerrors.NewConflictErr("tournament has already started", err)
+		}
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to add tournament entry", err)
+	}
+
+	return s.tournamentDetails(ctx, tournamentID)
+}
+
+// RemoveTournamentEntry снимает участника до старта турнира
+func (s *Service) RemoveTournamentEntry(ctx context.Context, tournamentID, entryID uint64) error {
+	t, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return err
+	}
+	if t.Status != models.TournamentStatusDraft {
+		return myerrors.NewConflictErr("tournament has already started", errors.New("tournament is not a draft"))
+	}
+
+	if err = s.repository.RemoveTournamentEntry(ctx, tournamentID, entryID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerrors.NewNotFoundErr("tournament entry not found", err)
+		}
+		return myerrors.NewRepositoryErr("failed to remove tournament entry", err)
+	}
+
+	return nil
+}
+
+// StartTournament сеет участников по рейтингу и создаёт встречи первых кругов. Участник без рейтинга
+// в виде спорта турнира сеется с начальным рейтингом, пара — по среднему рейтингу игроков.
+func (s *Service) StartTournament(ctx context.Context, tournamentID uint64) (models.TournamentDetails, error) {
+	t, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentDetails{}, err
+	}
+	if t.Status != models.TournamentStatusDraft {
+		return models.TournamentDetails{}, myerrors.NewConflictErr("tournament has already started", errors.New("tournament is not a draft"))
+	}
+
+	entries, err := s.repository.ListTournamentEntries(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to list tournament entries", err)
+	}
+	if len(entries) < tournamentMinEntries {
+		return models.TournamentDetails{}, myerrors.NewConflictErr(
+			fmt.Sprintf("tournament needs at least %d entries", tournamentMinEntries), errors.New("not enough entries"))
+	}
+	if t.Format == models.TournamentSwiss && t.SwissRounds != nil && *t.SwissRounds >= len(entries) {
+		return models.TournamentDetails{}, myerrors.NewConflictErr(
+			"swiss_rounds must be less than the number of entries", errors.New("too many swiss rounds"))
+	}
+
+	playerIDs := make([]uint64, 0, len(entries)*t.TeamSize)
+	for _, e := range entries {
+		playerIDs = append(playerIDs, e.PlayerIDs...)
+	}
+	ratings, err := s.repository.GetSportRatings(ctx, t.SportID, playerIDs)
+	if err != nil {
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to fetch player ratings", err)
+	}
+
+	seeds := s.seedEntries(entries, ratings)
+	err = s.repository.StartTournament(ctx, tournamentID, models.TournamentPlan{
+		Seeds:    seeds,
+		Fixtures: tournamentPlan(t.Format, seeds),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TournamentDetails{}, myerrors.NewConflictErr("tournament has already started or its entries changed", err)
+		}
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to start tournament", err)
+	}
+
+	return s.tournamentDetails(ctx, tournamentID)
+}
+
+// CompleteLadder закрывает лестницу по окончании сезона; остальные форматы завершаются сами
+func (s *Service) CompleteLadder(ctx context.Context, tournamentID uint64) (models.TournamentDetails, error) {
+	t, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentDetails{}, err
+	}
+	if t.Format != models.TournamentLadder {
+		return models.TournamentDetails{}, myerrors.NewValidationError("only a ladder can be completed manually", errors.New("not a ladder"))
+	}
+
+	if err = s.repository.CompleteLadder(ctx, tournamentID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TournamentDetails{}, myerrors.NewConflictErr("ladder is not in progress", err)
+		}
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to complete ladder", err)
+	}
+
+	return s.tournamentDetails(ctx, tournamentID)
+}
+
+func (s *Service) ListTournamentFixtures(ctx context.Context, tournamentID uint64) (responses.TournamentFixturesResponse, error) {
+	if _, err := s.getTournament(ctx, tournamentID); err != nil {
+		return responses.TournamentFixturesResponse{}, err
+	}
+
+	fixtures, err := s.repository.ListTournamentFixtures(ctx, tournamentID)
+	if err != nil {
+		return responses.TournamentFixturesResponse{}, myerrors.NewRepositoryErr("failed to list tournament fixtures", err)
+	}
+
+	return responses.TournamentFixturesResponse{Fixtures: fixtures}, nil
+}
+
+// GetTournamentStandings — турнирная таблица: победы, затем коэффициент Бухгольца, затем посев.
+// В сетке на выбывание оставшиеся в борьбе идут выше выбывших, на лестнице порядок — места лестницы.
+func (s *Service) GetTournamentStandings(ctx context.Context, tournamentID uint64) (responses.TournamentStandingsResponse, error) {
+	t, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return responses.TournamentStandingsResponse{}, err
+	}
+
+	entries, err := s.repository.ListTournamentEntries(ctx, tournamentID)
+	if err != nil {
+		return responses.TournamentStandingsResponse{}, myerrors.NewRepositoryErr("failed to list tournament entries", err)
+	}
+	fixtures, err := s.repository.ListTournamentFixtures(ctx, tournamentID)
+	if err != nil {
+		return responses.TournamentStandingsResponse{}, myerrors.NewRepositoryErr("failed to list tournament fixtures", err)
+	}
+
+	return responses.TournamentStandingsResponse{
+		TournamentID: t.ID,
+		Format:       t.Format,
+		Status:       t.Status,
+		Standings:    tournamentStandings(t.Format, entries, fixtures),
+	}, nil
+}
+
+// ChallengeLadder — участник лестницы вызывает соперника не выше challenge_range позиций над собой.
+// Вызов — обычный матч на выбранное вызывающим время; победа вызывающего отдаёт ему место соперника.
+func (s *Service) ChallengeLadder(ctx context.Context, userID, tournamentID uint64, req requests.LadderChallengeRequest) (models.TournamentFixture, error) {
+	t, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentFixture{}, err
+	}
+	if t.Format != models.TournamentLadder {
+		return models.TournamentFixture{}, myerrors.NewValidationError("challenges are available in ladders only", errors.New("not a ladder"))
+	}
+	if t.Status != models.TournamentStatusInProgress {
+		return models.TournamentFixture{}, myerrors.NewConflictErr("ladder is not in progress", errors.New("ladder is not in progress"))
+	}
+
+	entries, err := s.repository.ListTournamentEntries(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentFixture{}, myerrors.NewRepositoryErr("failed to list tournament entries", err)
+	}
+
+	var challenger, defender *models.TournamentEntry
+	for i := range entries {
+		if containsID(entries[i].PlayerIDs, userID) {
+			challenger = &entries[i]
+		}
+		if entries[i].ID == req.DefenderEntryID {
+			defender = &entries[i]
+		}
+	}
+	if challenger == nil {
+		return models.TournamentFixture{}, myerrors.NewForbiddenErr("you are not entered in this ladder", errors.New("not an entry"))
+	}
+	if defender == nil {
+		return models.TournamentFixture{}, myerrors.NewNotFoundErr("defender entry not found", errors.New("unknown defender"))
+	}
+
+	challengeRange := ladderDefaultChallengeRange
+	if t.ChallengeRange != nil {
+		challengeRange = *t.ChallengeRange
+	}
+	if challenger.Position == nil || defender.Position == nil ||
+		*defender.Position >= *challenger.Position || *challenger.Position-*defender.Position > challengeRange {
+		return models.TournamentFixture{}, myerrors.NewValidationError(
+			fmt.Sprintf("you can challenge only entries up to %d positions above you", challengeRange), errors.New("defender out of range"))
+	}
+
+	if !req.StartsAt.After(time.Now()) {
+		return models.TournamentFixture{}, myerrors.NewValidationError("starts_at must be in the future", errors.New("invalid start time"))
+	}
+	durationMinutes := req.DurationMinutes
+	if durationMinutes == 0 {
+		durationMinutes = t.MatchDurationMinutes
+	}
+	startsAt := req.StartsAt.UTC()
+	endsAt, err := matchEndsAt(startsAt, req.EndsAt, durationMinutes)
+	if err != nil {
+		return models.TournamentFixture{}, err
+	}
+
+	fixtureID, err := s.repository.CreateLadderChallenge(ctx, tournamentID, challenger.ID, defender.ID, startsAt, endsAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, myerrors.ErrOpenChallenge):
+			return models.TournamentFixture{}, myerrors.NewConflictErr("you or your opponent already have an open challenge", err)
+		case errors.Is(err, pgx.ErrNoRows):
+			return models.TournamentFixture{}, myerrors.NewConflictErr("ladder is not in progress", err)
+		}
+		return models.TournamentFixture{}, myerrors.NewRepositoryErr("failed to create challenge", err)
+	}
+
+	fixture, err := s.repository.GetTournamentFixture(ctx, fixtureID)
+	if err != nil {
+		return models.TournamentFixture{}, myerrors.NewRepositoryErr("failed to fetch challenge", err)
+	}
+
+	return fixture, nil
+}
+
+// tournamentSchedule проверяет расписание кругов: круги, кроме лестницы, начинаются в будущем
+// и идут не чаще, чем длится матч
+func tournamentSchedule(t *models.Tournament, req requests.CreateTournamentRequest) error {
+	if t.Format != models.TournamentLadder {
+		if req.StartsAt == nil || !req.StartsAt.After(time.Now()) {
+			return myerrors.NewValidationError("starts_at is required and must be in the future", errors.New("invalid start time"))
+		}
+		startsAt := req.StartsAt.UTC()
+		t.StartsAt = &startsAt
+	}
+
+	endsAt, err := matchEndsAt(time.Time{}, nil, req.MatchDurationMinutes)
+	if err != nil {
+		return err
+	}
+	duration := endsAt.Sub(time.Time{})
+
+	interval := tournamentDefaultRoundInterval
+	if req.RoundIntervalMinutes != 0 {
+		interval = time.Duration(req.RoundIntervalMinutes) * time.Minute
+	}
+	if interval < duration {
+		return myerrors.NewValidationError("round_interval_minutes must not be shorter than a match", errors.New("invalid round interval"))
+	}
+
+	t.MatchDurationMinutes = int(duration.Minutes())
+	t.RoundIntervalMinutes = int(interval.Minutes())
+	return nil
+}
+
+// pairNextSwissRound составляет следующий круг швейцарки, когда подтверждён последний результат круга.
+// Результат к этому моменту уже подтверждён, поэтому ошибка только логируется.
+func (s *Service) pairNextSwissRound(ctx context.Context, matchID uint64) {
+	if err := s.nextSwissRound(ctx, matchID); err != nil {
+		s.logger.Warn("failed to pair the next swiss round", "match_id", matchID, "err", err)
+	}
+}
+
+func (s *Service) nextSwissRound(ctx context.Context, matchID uint64) error {
+	fixture, err := s.repository.GetMatchFixture(ctx, matchID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	t, err := s.repository.GetTournament(ctx, fixture.TournamentID)
+	if err != nil {
+		return err
+	}
+	if t.Format != models.TournamentSwiss || t.Status != models.TournamentStatusInProgress ||
+		t.SwissRounds == nil || fixture.Round >= *t.SwissRounds {
+		return nil
+	}
+
+	entries, err := s.repository.ListTournamentEntries(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	fixtures, err := s.repository.ListTournamentFixtures(ctx, t.ID)
+	if err != nil {
+		return err
+	}
+	for _, f := range fixtures {
+		if f.Round > fixture.Round || (f.Round == fixture.Round && f.Status != models.FixtureStatusCompleted) {
+			return nil
+		}
+	}
+
+	seeds, results := tournamentResults(entries, fixtures)
+	pairs := tournament.SwissPairings(tournament.Standings(seeds, results), results)
+	next := make([]models.TournamentFixture, 0, len(pairs))
+	for i, p := range pairs {
+		next = append(next, fixtureFromPair(fixture.Round+1, i+1, p))
+	}
+
+	// круг мог составить параллельный запрос
+	if err = s.repository.ScheduleSwissRound(ctx, t.ID, fixture.Round+1, next); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) getTournament(ctx context.Context, tournamentID uint64) (models.Tournament, error) {
+	t, err := s.repository.GetTournament(ctx, tournamentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Tournament{}, myerrors.NewNotFoundErr("tournament not found", err)
+		}
+		return models.Tournament{}, myerrors.NewRepositoryErr("failed to fetch tournament", err)
+	}
+
+	return t, nil
+}
+
+func (s *Service) tournamentDetails(ctx context.Context, tournamentID uint64) (models.TournamentDetails, error) {
+	t, err := s.getTournament(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentDetails{}, err
+	}
+
+	entries, err := s.repository.ListTournamentEntries(ctx, tournamentID)
+	if err != nil {
+		return models.TournamentDetails{}, myerrors.NewRepositoryErr("failed to list tournament entries", err)
+	}
+
+	return models.TournamentDetails{Tournament: t, Entries: entries}, nil
+}
+
+// seedEntries сортирует участников по рейтингу (сильнейший — первый посев); при равенстве раньше
+// сеется тот, кто раньше заявился
+func (s *Service) seedEntries(entries []models.TournamentEntry, ratings map[uint64]float64) []models.TournamentSeed {
+	seeds := make([]models.TournamentSeed, 0, len(entries))
+	for _, e := range entries {
+		values := make([]float64, 0, len(e.PlayerIDs))
+		for _, id := range e.PlayerIDs {
+			value, ok := ratings[id]
+			if !ok {
+				value = s.cfg.RatingConfig.InitialRating
+			}
+			values = append(values, value)
+		}
+		seeds = append(seeds, models.TournamentSeed{EntryID: e.ID, Rating: averageRating(values)})
+	}
+
+	sort.SliceStable(seeds, func(i, j int) bool {
+		if seeds[i].Rating != seeds[j].Rating {
+			return seeds[i].Rating > seeds[j].Rating
+		}
+		return seeds[i].EntryID < seeds[j].EntryID
+	})
+	for i := range seeds {
+		seeds[i].Seed = i + 1
+	}
+
+	return seeds
+}
+
+// tournamentPlan — встречи, с которыми турнир стартует. Сетка на выбывание создаётся на все круги:
+// следующие круги пустые и заполняются победителями. Лестница стартует без встреч.
+func tournamentPlan(format models.TournamentFormat, seeds []models.TournamentSeed) []models.TournamentFixture {
+	seeded := make([]uint64, len(seeds))
+	for i, seed := range seeds {
+		seeded[i] = seed.EntryID
+	}
+
+	fixtures := make([]models.TournamentFixture, 0)
+	switch format {
+	case models.TournamentSingleElimination:
+		firstRound := tournament.SingleElimination(seeded)
+		for i, p := range firstRound {
+			fixtures = append(fixtures, fixtureFromPair(1, i+1, p))
+		}
+		for round, size := 2, len(firstRound)/2; size > 0; round, size = round+1, size/2 {
+			for position := 1; position <= size; position++ {
+				fixtures = append(fixtures, models.TournamentFixture{Round: round, Position: position})
+			}
+		}
+	case models.TournamentRoundRobin:
+		for i, pairs := range tournament.RoundRobin(seeded) {
+			for j, p := range pairs {
+				fixtures = append(fixtures, fixtureFromPair(i+1, j+1, p))
+			}
+		}
+	case models.TournamentSwiss:
+		for i, p := range tournament.SwissFirstRound(seeded) {
+			fixtures = append(fixtures, fixtureFromPair(1, i+1, p))
+		}
+	}
+
+	return fixtures
+}
+
+// tournamentResults — посев участников и завершённые встречи в виде, нужном pkg/tournament
+func tournamentResults(entries []models.TournamentEntry, fixtures []models.TournamentFixture) (map[uint64]int, []tournament.Result) {
+	seeds := make(map[uint64]int, len(entries))
+	for _, e := range entries {
+		if e.Seed != nil {
+			seeds[e.ID] = *e.Seed
+		} else {
+			seeds[e.ID] = len(entries) + 1
+		}
+	}
+
+	results := make([]tournament.Result, 0, len(fixtures))
+	for _, f := range fixtures {
+		if f.Status != models.FixtureStatusCompleted || f.WinnerEntryID == nil || f.Entry1ID == nil {
+			continue
+		}
+		r := tournament.Result{Entry1: *f.Entry1ID, Winner: *f.WinnerEntryID}
+		if f.Entry2ID != nil {
+			r.Entry2 = *f.Entry2ID
+		}
+		results = append(results, r)
+	}
+
+	return seeds, results
+}
+
+// tournamentStandings считает таблицу по завершённым встречам
+func tournamentStandings(format models.TournamentFormat, entries []models.TournamentEntry, fixtures []models.TournamentFixture) []models.TournamentStanding {
+	byID := make(map[uint64]models.TournamentEntry, len(entries))
+	for _, e := range entries {
+		byID[e.ID] = e
+	}
+
+	seeds, results := tournamentResults(entries, fixtures)
+	rows := tournament.Standings(seeds, results)
+	standings := make([]models.TournamentStanding, 0, len(rows))
+	for _, row := range rows {
+		e := byID[row.EntryID]
+		standings = append(standings, models.TournamentStanding{
+			EntryID:    row.EntryID,
+			PlayerIDs:  e.PlayerIDs,
+			Seed:       e.Seed,
+			Position:   e.Position,
+			Played:     row.Played,
+			Wins:       row.Wins,
+			Losses:     row.Losses,
+			Byes:       row.Byes,
+			Buchholz:   row.Buchholz,
+			Eliminated: e.Eliminated,
+		})
+	}
+
+	switch format {
+	case models.TournamentSingleElimination:
+		sort.SliceStable(standings, func(i, j int) bool {
+			return !standings[i].Eliminated && standings[j].Eliminated
+		})
+	case models.TournamentLadder:
+		sort.SliceStable(standings, func(i, j int) bool {
+			a, b := standings[i].Position, standings[j].Position
+			return a != nil && (b == nil || *a < *b)
+		})
+	}
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+
+	return standings
+}
+
+// fixtureFromPair переводит пару pkg/tournament во встречу; 0 — пустое место
+func fixtureFromPair(round, position int, p tournament.Pair) models.TournamentFixture {
+	f := models.TournamentFixture{Round: round, Position: position}
+	if p.Entry1 != 0 {
+		f.Entry1ID = &p.Entry1
+	}
+	if p.Entry2 != 0 {
+		f.Entry2ID = &p.Entry2
+	}
+	return f
+}
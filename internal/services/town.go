@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	townSearchDefaultLimit = 10
+	townSearchMaxLimit     = 50
+)
+
+func (s *Service) SearchTowns(ctx context.Context, query string, limit int) ([]models.Town, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	if utf8.RuneCountInString(query) == 0 {
+		return nil, myerrors.NewValidationError("query is required", errors.New("empty query"))
+	}
+
+	if limit <= 0 {
+		limit = townSearchDefaultLimit
+	}
+	if limit > townSearchMaxLimit {
+		limit = townSearchMaxLimit
+	}
+
+	towns, err := s.repository.SearchTowns(ctx, query, limit)
+	if err != nil {
+		return nil, myerrors.NewRepositoryErr("failed to search towns", err)
+	}
+
+	return towns, nil
+}
@@ -1,7 +1,78 @@
 package services
 
-import "context"
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+
+	"github.com/jackc/pgx/v5"
+)
 
 func (s *Service) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
 	return s.repository.UserExistsByEmail(ctx, email)
 }
+
+// GetMe возвращает анкету владельца
+func (s *Service) GetMe(ctx context.Context, userID uint64) (dto.SelfUserDto, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return dto.SelfUserDto{}, err
+	}
+
+	photoURL, err := s.photoURL(ctx, user.Photo)
+	if err != nil {
+		return dto.SelfUserDto{}, err
+	}
+
+	return dto.ToSelfUser(user, photoURL), nil
+}
+
+// GetUser возвращает пользователя в проекции, которая положена вызывающему по его правам
+func (s *Service) GetUser(ctx context.Context, viewerID, userID uint64, permissions []string) (any, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := dto.ResolveProjection(viewerID, userID, permissions)
+	if projection == dto.ProjectionPublic {
+		// персонал видит и тех, кто заблокировал его как пользователя
+		if err = s.checkNotBlocked(ctx, viewerID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Чужим в публичной проекции отдаём миниатюру, полное фото — владельцу и персоналу
+	photo := user.Photo
+	if projection == dto.ProjectionPublic {
+		photo = user.PhotoThumbnail
+	}
+
+	photoURL, err := s.photoURL(ctx, photo)
+	if err != nil {
+		return nil, err
+	}
+
+	if projection == dto.ProjectionPublic {
+		visibility, err := s.repository.GetProfileVisibility(ctx, userID)
+		if err != nil {
+			return nil, myerrors.NewRepositoryErr("failed to fetch profile visibility", err)
+		}
+		return dto.ToPublicUser(user, photoURL).WithVisibility(visibility), nil
+	}
+
+	return dto.ProjectUser(user, projection, photoURL), nil
+}
+
+func (s *Service) getUser(ctx context.Context, userID uint64) (dto.UserDto, error) {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.UserDto{}, myerrors.NewNotFoundErr("user not found", err)
+		}
+		return dto.UserDto{}, myerrors.NewRepositoryErr("failed to fetch user", err)
+	}
+
+	return user, nil
+}
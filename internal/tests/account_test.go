@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestRequestAccountDeletion_ReturnsGracePeriodEnd(t *testing.T) {
+	requestedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	service := newService(mockRepository{
+		requestDeletionFn: func(_ context.Context, _ uint64) (time.Time, error) {
+			return requestedAt, nil
+		},
+	})
+
+	resp, err := service.RequestAccountDeletion(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if want := requestedAt.Add(30 * 24 * time.Hour); !resp.AnonymizeAfter.Equal(want) {
+		t.Fatalf("expected anonymize after %v, got %v", want, resp.AnonymizeAfter)
+	}
+}
+
+func TestCancelAccountDeletion_NotRequested(t *testing.T) {
+	service := newService(mockRepository{
+		cancelDeletionFn: func(_ context.Context, _ uint64) error {
+			return pgx.ErrNoRows
+		},
+	})
+
+	err := service.CancelAccountDeletion(context.Background(), 1)
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestPurgeDeletedAccounts_SkipsCancelledDeletion(t *testing.T) {
+	anonymized := make([]uint64, 0)
+	service := newService(mockRepository{
+		pendingAnonymizeFn: func(_ context.Context, requestedBefore time.Time, _ int) ([]uint64, error) {
+			if time.Since(requestedBefore) < 30*24*time.Hour-time.Minute {
+				t.Fatalf("expected grace period to be applied, got cutoff %v", requestedBefore)
+			}
+			return []uint64{1, 2}, nil
+		},
+		getUserPhotoFn: func(_ context.Context, _ uint64) (models.UserPhoto, error) {
+			return models.UserPhoto{}, nil
+		},
+		anonymizeUserFn: func(_ context.Context, userID uint64) error {
+			if userID == 2 {
+				return pgx.ErrNoRows
+			}
+			anonymized = append(anonymized, userID)
+			return nil
+		},
+	})
+
+	purged, err := service.PurgeDeletedAccounts(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if purged != 1 || len(anonymized) != 1 || anonymized[0] != 1 {
+		t.Fatalf("expected only user 1 to be anonymized, got purged=%d users=%v", purged, anonymized)
+	}
+}
+
+func TestExportUserData_ContainsAllSections(t *testing.T) {
+	service := newService(mockRepository{
+		getUserByIDFn: func(_ context.Context, userID uint64) (dto.UserDto, error) {
+			return dto.UserDto{ID: userID, Name: "Иван"}, nil
+		},
+		getVisibilityFn: func(_ context.Context, _ uint64) (models.ProfileVisibility, error) {
+			return models.DefaultProfileVisibility(), nil
+		},
+		getUserMatchesFn: func(_ context.Context, _ uint64) ([]models.Match, error) {
+			return []models.Match{{ID: 7}}, nil
+		},
+		getUserMessagesFn: func(_ context.Context, _ uint64) ([]models.ChatMessage, error) {
+			return []models.ChatMessage{{ID: 1, ChatID: 3, Body: "привет"}}, nil
+		},
+	})
+
+	data, err := service.ExportUserData(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("expected valid zip, got %v", err)
+	}
+
+	names := make(map[string]bool)
+	for _, file := range archive.File {
+		names[file.Name] = true
+	}
+	for _, name := range []string{"profile.json", "profile_visibility.json", "matches.json", "chats.json"} {
+		if !names[name] {
+			t.Fatalf("expected %s in export, got %v", name, names)
+		}
+	}
+}
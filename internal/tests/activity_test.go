@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+)
+
+// activityRepo — каталог с тренировкой по теннису (1) и арендой корта для любого спорта (2);
+// корт 7 относится к объекту 3
+func activityRepo(stored *models.ActivityRequest) mockRepository {
+	tennis := 1
+	return mockRepository{
+		getActivityFn: func(_ context.Context, activityID int) (models.Activity, error) {
+			if activityID == 1 {
+				return models.Activity{ID: 1, SportID: &tennis, Name: "Персональная тренировка"}, nil
+			}
+			return models.Activity{ID: activityID, Name: "Аренда корта"}, nil
+		},
+		courtObjectFn: func(_ context.Context, courtID int) (int, error) {
+			return 3, nil
+		},
+		createActivityReqFn: func(_ context.Context, request models.ActivityRequest) (uint64, error) {
+			request.ID = 5
+			request.Status = models.ActivityRequestPending
+			*stored = request
+			return 5, nil
+		},
+		getActivityReqFn: func(_ context.Context, requestID uint64) (models.ActivityRequest, error) {
+			return *stored, nil
+		},
+	}
+}
+
+func TestCreateActivityRequest_PendingWithVenueFromCourt(t *testing.T) {
+	var stored models.ActivityRequest
+	service := newService(activityRepo(&stored))
+	startsAt := time.Now().Add(48 * time.Hour)
+	courtID := 7
+
+	request, err := service.CreateActivityRequest(context.Background(), 1, requests.CreateActivityRequestRequest{
+		ActivityID: 1,
+		SportID:    1,
+		CourtID:    &courtID,
+		StartsAt:   startsAt,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if request.Status != models.ActivityRequestPending || request.SportObjectID != 3 || request.UserID != 1 {
+		t.Fatalf("expected a pending request at object 3, got %+v", request)
+	}
+	if !request.EndsAt.Equal(startsAt.UTC().Add(time.Hour)) {
+		t.Fatalf("expected default one hour duration, got %v", request.EndsAt)
+	}
+
+	// тренировка по теннису недоступна для другого вида спорта
+	_, err = service.CreateActivityRequest(context.Background(), 1, requests.CreateActivityRequestRequest{
+		ActivityID: 1, SportID: 2, CourtID: &courtID, StartsAt: startsAt,
+	})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	_, err = service.CreateActivityRequest(context.Background(), 1, requests.CreateActivityRequestRequest{
+		ActivityID: 2, SportID: 2, StartsAt: startsAt,
+	})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestCreateActivityRequest_ScheduleConflict(t *testing.T) {
+	var stored models.ActivityRequest
+	repo := activityRepo(&stored)
+	repo.scheduleConflictsFn = func(_ context.Context, _ uint64, startsAt, endsAt time.Time, _ uint64) ([]models.ScheduleConflict, error) {
+		return []models.ScheduleConflict{{Kind: models.ScheduleEventMatch, ID: 10, StartsAt: startsAt, EndsAt: endsAt}}, nil
+	}
+	repo.createActivityReqFn = func(_ context.Context, _ models.ActivityRequest) (uint64, error) {
+		t.Fatal("overlapping request must not be created")
+		return 0, nil
+	}
+	sportObjectID := 3
+
+	_, err := newService(repo).CreateActivityRequest(context.Background(), 1, requests.CreateActivityRequestRequest{
+		ActivityID:    2,
+		SportID:       2,
+		SportObjectID: &sportObjectID,
+		StartsAt:      time.Now().Add(time.Hour),
+	})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestConfirmActivityRequest_OnlyPendingAndFreeTime(t *testing.T) {
+	stored := models.ActivityRequest{
+		ID:       5,
+		UserID:   1,
+		Status:   models.ActivityRequestPending,
+		StartsAt: time.Now().Add(24 * time.Hour),
+		EndsAt:   time.Now().Add(25 * time.Hour),
+	}
+	var gotComment *string
+	repo := activityRepo(&stored)
+	repo.confirmActivityReqFn = func(_ context.Context, requestID, reviewerID uint64, comment *string) error {
+		if reviewerID != 9 {
+			t.Fatalf("expected reviewer 9, got %d", reviewerID)
+		}
+		gotComment = comment
+		stored.Status = models.ActivityRequestConfirmed
+		return nil
+	}
+	service := newService(repo)
+
+	comment := "  корт 2  "
+	request, err := service.ConfirmActivityRequest(context.Background(), 9, 5, requests.ReviewActivityRequestRequest{Comment: &comment})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if request.Status != models.ActivityRequestConfirmed || gotComment == nil || *gotComment != "корт 2" {
+		t.Fatalf("expected confirmed request with trimmed comment, got %+v %v", request, gotComment)
+	}
+
+	_, err = service.ConfirmActivityRequest(context.Background(), 9, 5, requests.ReviewActivityRequestRequest{})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	// пока заявка ждала, пользователь вступил в матч на это время
+	stored.Status = models.ActivityRequestPending
+	repo.scheduleConflictsFn = func(_ context.Context, _ uint64, startsAt, endsAt time.Time, _ uint64) ([]models.ScheduleConflict, error) {
+		return []models.ScheduleConflict{{Kind: models.ScheduleEventMatch, ID: 10, StartsAt: startsAt, EndsAt: endsAt}}, nil
+	}
+	repo.confirmActivityReqFn = func(_ context.Context, _, _ uint64, _ *string) error {
+		t.Fatal("overlapping request must not be confirmed")
+		return nil
+	}
+	_, err = newService(repo).ConfirmActivityRequest(context.Background(), 9, 5, requests.ReviewActivityRequestRequest{})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestCancelActivityRequest_ForeignLooksMissing(t *testing.T) {
+	stored := models.ActivityRequest{ID: 5, UserID: 2, Status: models.ActivityRequestConfirmed, StartsAt: time.Now().Add(time.Hour)}
+	repo := activityRepo(&stored)
+	repo.cancelActivityReqFn = func(_ context.Context, _ uint64) error {
+		stored.Status = models.ActivityRequestCancelled
+		return nil
+	}
+	service := newService(repo)
+
+	_, err := service.CancelActivityRequest(context.Background(), 1, 5)
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+
+	request, err := service.CancelActivityRequest(context.Background(), 2, 5)
+	if err != nil || request.Status != models.ActivityRequestCancelled {
+		t.Fatalf("expected owner to cancel confirmed activity, got %+v %v", request, err)
+	}
+}
+
+func TestGetSchedule_DateRange(t *testing.T) {
+	var gotFrom, gotTo time.Time
+	service := newService(mockRepository{
+		scheduleEventsFn: func(_ context.Context, _ uint64, from, to time.Time) ([]models.ScheduleEvent, error) {
+			gotFrom, gotTo = from, to
+			return []models.ScheduleEvent{}, nil
+		},
+	})
+
+	resp, err := service.GetSchedule(context.Background(), 1, requests.ScheduleRequest{From: "2026-06-01", To: "2026-06-03"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !gotFrom.Equal(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)) || !gotTo.Equal(time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected inclusive range, got %v - %v", gotFrom, gotTo)
+	}
+	if resp.From != "2026-06-01" || resp.To != "2026-06-03" {
+		t.Fatalf("unexpected response range %s - %s", resp.From, resp.To)
+	}
+
+	if _, err = service.GetSchedule(context.Background(), 1, requests.ScheduleRequest{From: "2026-06-01"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotTo.Sub(gotFrom) != 7*24*time.Hour {
+		t.Fatalf("expected a week by default, got %v", gotTo.Sub(gotFrom))
+	}
+
+	_, err = service.GetSchedule(context.Background(), 1, requests.ScheduleRequest{From: "2026-06-01", To: "2026-12-01"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestListAdminUsers_BuildsFilterAndPagination(t *testing.T) {
+	var captured models.UserFilter
+	service := newService(mockRepository{
+		countUsersFn: func(_ context.Context, filter models.UserFilter) (int, error) {
+			return 45, nil
+		},
+		listUsersFn: func(_ context.Context, filter models.UserFilter) ([]dto.UserDto, error) {
+			captured = filter
+			return []dto.UserDto{{ID: 1}}, nil
+		},
+	})
+
+	resp, err := service.ListAdminUsers(context.Background(), requests.AdminUsersRequest{
+		RoleIDs:     []int{2},
+		CreatedFrom: "2026-01-01",
+		CreatedTo:   "2026-01-31",
+		Sort:        "-created_at",
+		Page:        3,
+		PageSize:    500,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if resp.Total != 45 || resp.Page != 3 || resp.PageSize != 100 {
+		t.Fatalf("unexpected pagination: %+v", resp)
+	}
+	if captured.Limit != 100 || captured.Offset != 200 {
+		t.Fatalf("expected limit 100 offset 200, got %d/%d", captured.Limit, captured.Offset)
+	}
+	if captured.SortField != models.UserSortCreatedAt || !captured.SortDesc {
+		t.Fatalf("expected created_at desc, got %s desc=%v", captured.SortField, captured.SortDesc)
+	}
+	if captured.CreatedTo == nil || !captured.CreatedTo.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected created_to to include the whole day, got %v", captured.CreatedTo)
+	}
+}
+
+func TestListAdminUsers_RejectsUnknownSort(t *testing.T) {
+	service := newService(mockRepository{})
+
+	_, err := service.ListAdminUsers(context.Background(), requests.AdminUsersRequest{Sort: "password"})
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestExportAdminUsersCSV_EscapesFormulas(t *testing.T) {
+	service := newService(mockRepository{
+		streamUsersFn: func(_ context.Context, filter models.UserFilter, fn func(user dto.UserDto) error) error {
+			if filter.Limit != 0 {
+				t.Fatalf("expected export without pagination, got limit %d", filter.Limit)
+			}
+			return fn(dto.UserDto{ID: 7, Name: "=HYPERLINK(\"x\")", Surname: "Петров", PhoneNumber: "+79991234567"})
+		},
+	})
+
+	var buf bytes.Buffer
+	if err := service.ExportAdminUsersCSV(context.Background(), requests.AdminUsersRequest{}, &buf); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "id,name,surname") {
+		t.Fatalf("unexpected csv: %q", buf.String())
+	}
+	if !strings.Contains(lines[1], `"'=HYPERLINK(""x"")"`) || !strings.Contains(lines[1], "'+79991234567") {
+		t.Fatalf("expected formula and phone to be escaped, got %q", lines[1])
+	}
+}
+
+func TestAdminBlockUser_SetsBlockedState(t *testing.T) {
+	var blocked uint64
+	blockedAt := time.Now()
+	service := newService(mockRepository{
+		blockAccountFn: func(_ context.Context, userID uint64) error {
+			blocked = userID
+			return nil
+		},
+		getUserByIDFn: func(_ context.Context, userID uint64) (dto.UserDto, error) {
+			return dto.UserDto{ID: userID, BlockedAt: &blockedAt}, nil
+		},
+	})
+
+	user, err := service.AdminBlockUser(context.Background(), 1, 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if blocked != 7 || user.BlockedAt == nil {
+		t.Fatalf("expected user 7 to be blocked, got %d %+v", blocked, user)
+	}
+}
+
+func TestAdminBlockUser_RejectsSelf(t *testing.T) {
+	service := newService(mockRepository{})
+
+	_, err := service.AdminBlockUser(context.Background(), 1, 1)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestAdminUnblockUser_NotFound(t *testing.T) {
+	service := newService(mockRepository{
+		unblockAccountFn: func(_ context.Context, _ uint64) error {
+			return pgx.ErrNoRows
+		},
+	})
+
+	_, err := service.AdminUnblockUser(context.Background(), 7)
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+}
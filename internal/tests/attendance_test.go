@@ -0,0 +1,241 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// startedMatchRepo — матч 10 организатора 1, начавшийся час назад
+func startedMatchRepo(participants ...uint64) mockRepository {
+	repo := scheduledMatchRepo(1, participants...)
+	startsAt := time.Now().Add(-time.Hour)
+	endsAt := startsAt.Add(90 * time.Minute)
+	repo.getMatchFn = func(_ context.Context, matchID uint64) (models.Match, error) {
+		organizerID := uint64(1)
+		return models.Match{
+			ID:          matchID,
+			OrganizerID: &organizerID,
+			Status:      models.MatchStatusActive,
+			StartsAt:    &startsAt,
+			EndsAt:      &endsAt,
+		}, nil
+	}
+	return repo
+}
+
+func TestGetUserReliability_Score(t *testing.T) {
+	service := newService(mockRepository{
+		getUserByIDFn: func(_ context.Context, userID uint64) (dto.UserDto, error) {
+			return dto.UserDto{ID: userID}, nil
+		},
+		reliabilityFn: func(_ context.Context, userIDs []uint64) (map[uint64]models.Reliability, error) {
+			return map[uint64]models.Reliability{
+				7: {UserID: 7, Commitments: 10, NoShows: 2, LateCancellations: 1},
+			}, nil
+		},
+	})
+
+	rel, err := service.GetUserReliability(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// (10 + 5 − 2 − 0.5) / (10 + 5)
+	if rel.Score != 83 {
+		t.Fatalf("expected score 83, got %d", rel.Score)
+	}
+
+	rel, err = service.GetUserReliability(context.Background(), 8)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rel.UserID != 8 || rel.Score != 100 {
+		t.Fatalf("expected a new player to be fully reliable, got %+v", rel)
+	}
+}
+
+func TestMarkAttendance_Rules(t *testing.T) {
+	noShow := requests.MarkAttendanceRequest{UserID: 3, Status: string(models.AttendanceNoShow)}
+
+	_, err := newService(scheduledMatchRepo(1, 1, 3)).MarkAttendance(context.Background(), 1, 10, noShow, nil)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	_, err = newService(startedMatchRepo(1, 3)).MarkAttendance(context.Background(), 9, 10, noShow, nil)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	_, err = newService(startedMatchRepo(1, 3)).MarkAttendance(context.Background(), 3, 10, noShow, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	outsider := requests.MarkAttendanceRequest{UserID: 4, Status: string(models.AttendanceNoShow)}
+	_, err = newService(startedMatchRepo(1, 3)).MarkAttendance(context.Background(), 1, 10, outsider, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	// участник, который не организует матч, отмечать не может — только пожаловаться
+	_, err = newService(startedMatchRepo(1, 3, 5)).MarkAttendance(context.Background(), 5, 10, noShow, nil)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	// поздняя отмена записывается только при выходе из матча
+	late := requests.MarkAttendanceRequest{UserID: 3, Status: string(models.AttendanceLateCancellation)}
+	_, err = newService(startedMatchRepo(1, 3)).MarkAttendance(context.Background(), 1, 10, late, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestMarkAttendance_KeepsMarkOfAnotherReporter(t *testing.T) {
+	repo := startedMatchRepo(1, 3)
+	repo.markAttendanceFn = func(_ context.Context, _, _ uint64, _ models.AttendanceStatus, _ *uint64) (models.AttendanceMark, error) {
+		return models.AttendanceMark{}, pgx.ErrNoRows
+	}
+
+	_, err := newService(repo).MarkAttendance(context.Background(), 8, 10,
+		requests.MarkAttendanceRequest{UserID: 3, Status: string(models.AttendanceNoShow)}, []string{commons.PermissionMatchManageAny})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestMarkAttendance_OrganizerReportsNoShow(t *testing.T) {
+	repo := startedMatchRepo(1, 3)
+	var gotReporter *uint64
+	repo.markAttendanceFn = func(_ context.Context, matchID, userID uint64, status models.AttendanceStatus, reportedBy *uint64) (models.AttendanceMark, error) {
+		gotReporter = reportedBy
+		return models.AttendanceMark{MatchID: matchID, UserID: userID, Status: status, ReportedBy: reportedBy}, nil
+	}
+
+	mark, err := newService(repo).MarkAttendance(context.Background(), 1, 10,
+		requests.MarkAttendanceRequest{UserID: 3, Status: string(models.AttendanceNoShow)}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if mark.UserID != 3 || gotReporter == nil || *gotReporter != 1 {
+		t.Fatalf("expected no-show of 3 reported by 1, got %+v", mark)
+	}
+}
+
+func TestReportNoShow_Rules(t *testing.T) {
+	report := requests.ReportNoShowRequest{UserID: 3}
+
+	_, err := newService(scheduledMatchRepo(1, 1, 3, 5)).ReportNoShow(context.Background(), 5, 10, report)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	_, err = newService(startedMatchRepo(1, 3, 5)).ReportNoShow(context.Background(), 9, 10, report)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	_, err = newService(startedMatchRepo(1, 3, 5)).ReportNoShow(context.Background(), 3, 10, report)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	_, err = newService(startedMatchRepo(1, 3, 5)).ReportNoShow(context.Background(), 5, 10, requests.ReportNoShowRequest{UserID: 4})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestReportNoShow_RequiresMajorityOfOtherParticipants(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		participants []uint64
+		required     int
+	}{
+		{name: "singles", participants: []uint64{1, 3}, required: 1},
+		{name: "doubles", participants: []uint64{1, 3, 5, 7}, required: 2},
+		{name: "six players", participants: []uint64{1, 3, 5, 7, 9, 11}, required: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := startedMatchRepo(tc.participants...)
+			repo.reportNoShowFn = func(_ context.Context, matchID, userID, reporterID uint64, required int) (models.NoShowReport, error) {
+				if userID != 3 || reporterID != 1 {
+					t.Fatalf("expected report on 3 by 1, got %d by %d", userID, reporterID)
+				}
+				return models.NoShowReport{MatchID: matchID, UserID: userID, Reports: 1, Required: required}, nil
+			}
+
+			report, err := newService(repo).ReportNoShow(context.Background(), 1, 10, requests.ReportNoShowRequest{UserID: 3})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if report.Required != tc.required {
+				t.Fatalf("expected %d reports required, got %d", tc.required, report.Required)
+			}
+		})
+	}
+}
+
+func TestLeaveMatch_LateCancellationRecorded(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		startsIn time.Duration
+		recorded bool
+	}{
+		{name: "inside window", startsIn: 2 * time.Hour, recorded: true},
+		{name: "outside window", startsIn: 48 * time.Hour, recorded: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := scheduledMatchRepo(1, 1, 3)
+			startsAt := time.Now().Add(tc.startsIn)
+			repo.getMatchFn = func(_ context.Context, matchID uint64) (models.Match, error) {
+				organizerID := uint64(1)
+				return models.Match{ID: matchID, OrganizerID: &organizerID, Status: models.MatchStatusScheduled, StartsAt: &startsAt}, nil
+			}
+			repo.removeFromMatchFn = func(_ context.Context, _, _ uint64) error { return nil }
+
+			recorded := false
+			repo.markAttendanceFn = func(_ context.Context, _, userID uint64, status models.AttendanceStatus, reportedBy *uint64) (models.AttendanceMark, error) {
+				recorded = userID == 3 && status == models.AttendanceLateCancellation && reportedBy == nil
+				return models.AttendanceMark{}, nil
+			}
+
+			if err := newService(repo).LeaveMatch(context.Background(), 3, 10); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if recorded != tc.recorded {
+				t.Fatalf("expected late cancellation recorded=%v", tc.recorded)
+			}
+		})
+	}
+}
+
+func TestJoinMatch_RankedRequiresReliability(t *testing.T) {
+	repo := publicMatchRepo(4, 1)
+	getMatch := repo.getMatchFn
+	repo.getMatchFn = func(ctx context.Context, matchID uint64) (models.Match, error) {
+		match, err := getMatch(ctx, matchID)
+		match.MatchType = models.MatchTypeRanked
+		return match, err
+	}
+	repo.reliabilityFn = func(_ context.Context, _ []uint64) (map[uint64]models.Reliability, error) {
+		// (5 + 5 − 3) / 10 = 70% у игрока 2, 60% у игрока 3
+		return map[uint64]models.Reliability{
+			2: {Commitments: 5, NoShows: 3},
+			3: {Commitments: 5, NoShows: 4},
+		}, nil
+	}
+	service := newService(repo)
+
+	_, err := service.JoinMatch(context.Background(), 3, 10)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	if _, err = service.JoinMatch(context.Background(), 2, 10); err != nil {
+		t.Fatalf("expected player at the threshold to join, got %v", err)
+	}
+}
+
+func TestSuggestPlayers_MinReliability(t *testing.T) {
+	service := newService(mockRepository{
+		suggestionsFn: func(_ context.Context, _ models.SuggestionQuery) ([]models.SuggestionCandidate, error) {
+			return []models.SuggestionCandidate{{UserID: 10}, {UserID: 11}}, nil
+		},
+		reliabilityFn: func(_ context.Context, _ []uint64) (map[uint64]models.Reliability, error) {
+			return map[uint64]models.Reliability{11: {Commitments: 5, NoShows: 5}}, nil
+		},
+	})
+
+	suggestions, err := service.SuggestPlayers(context.Background(), 1, requests.PlayerSuggestionsRequest{MinReliability: intPtr(80)})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].UserID != 10 || suggestions[0].Reliability != 100 {
+		t.Fatalf("expected only the reliable player, got %+v", suggestions)
+	}
+}
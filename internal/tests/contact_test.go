@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+)
+
+func currentUserRepo(contactExists bool) mockRepository {
+	return mockRepository{
+		getUserByIDFn: func(_ context.Context, userID uint64) (dto.UserDto, error) {
+			return dto.UserDto{ID: userID, PhoneNumber: "+79990000000", Email: "old@example.com"}, nil
+		},
+		contactExistsFn: func(_ context.Context, _ models.ContactType, _ string, _ uint64) (bool, error) {
+			return contactExists, nil
+		},
+	}
+}
+
+func TestRequestContactChange_InvalidPhone(t *testing.T) {
+	service := newService(currentUserRepo(false))
+
+	_, err := service.RequestContactChange(context.Background(), 1, models.ContactTypePhone, "call me maybe")
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestRequestContactChange_SameEmail(t *testing.T) {
+	service := newService(currentUserRepo(false))
+
+	_, err := service.RequestContactChange(context.Background(), 1, models.ContactTypeEmail, " OLD@example.com ")
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestRequestContactChange_ContactTaken(t *testing.T) {
+	service := newService(currentUserRepo(true))
+
+	_, err := service.RequestContactChange(context.Background(), 1, models.ContactTypePhone, "+7 (999) 111-22-33")
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeConflict {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
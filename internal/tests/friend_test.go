@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// friendRepo — пользователи 1 и 2 не друзья и заявок между ними нет
+func friendRepo(guest, played bool) *mockRepository {
+	return &mockRepository{
+		getPlayerCardFn: func(ctx context.Context, userID uint64) (models.PlayerCard, error) {
+			return models.PlayerCard{ID: userID}, nil
+		},
+		areFriendsFn: func(ctx context.Context, userID, otherID uint64) (bool, error) {
+			return false, nil
+		},
+		isGuestFn: func(ctx context.Context, userID uint64) (bool, error) {
+			return guest, nil
+		},
+		playedTogetherFn: func(ctx context.Context, userID, otherID uint64) (bool, error) {
+			return played, nil
+		},
+		pendingFriendReqFn: func(ctx context.Context, userID, otherID uint64) (models.FriendRequest, error) {
+			return models.FriendRequest{}, pgx.ErrNoRows
+		},
+		createFriendReqFn: func(ctx context.Context, senderID, receiverID uint64) (uint64, error) {
+			return 10, nil
+		},
+		getFriendReqFn: func(ctx context.Context, requestID uint64) (models.FriendRequest, error) {
+			return models.FriendRequest{ID: requestID, SenderID: 1, ReceiverID: 2, Status: models.FriendRequestPending}, nil
+		},
+	}
+}
+
+func TestSendFriendRequest_GuestNeedsSharedMatch(t *testing.T) {
+	_, err := newService(friendRepo(true, false)).SendFriendRequest(context.Background(), 1, requests.SendFriendRequestRequest{UserID: 2})
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	request, err := newService(friendRepo(true, true)).SendFriendRequest(context.Background(), 1, requests.SendFriendRequestRequest{UserID: 2})
+	if err != nil {
+		t.Fatalf("guest must be able to add a match partner: %v", err)
+	}
+	if request.ID != 10 || request.Status != models.FriendRequestPending {
+		t.Fatalf("expected a pending request, got %+v", request)
+	}
+}
+
+func TestSendFriendRequest_ClientNeedsNoSharedMatch(t *testing.T) {
+	repo := friendRepo(false, false)
+	repo.playedTogetherFn = func(ctx context.Context, userID, otherID uint64) (bool, error) {
+		t.Fatal("match history must not be checked for clients")
+		return false, nil
+	}
+
+	if _, err := newService(repo).SendFriendRequest(context.Background(), 1, requests.SendFriendRequestRequest{UserID: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := newService(repo).SendFriendRequest(context.Background(), 1, requests.SendFriendRequestRequest{UserID: 1})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestSendFriendRequest_AcceptsCounterRequest(t *testing.T) {
+	accepted := uint64(0)
+	repo := friendRepo(false, false)
+	repo.pendingFriendReqFn = func(ctx context.Context, userID, otherID uint64) (models.FriendRequest, error) {
+		return models.FriendRequest{ID: 7, SenderID: 2, ReceiverID: 1, Status: models.FriendRequestPending}, nil
+	}
+	repo.acceptFriendReqFn = func(ctx context.Context, requestID uint64) error {
+		accepted = requestID
+		return nil
+	}
+	repo.createFriendReqFn = func(ctx context.Context, senderID, receiverID uint64) (uint64, error) {
+		t.Fatal("a counter request must not create a new one")
+		return 0, nil
+	}
+
+	if _, err := newService(repo).SendFriendRequest(context.Background(), 1, requests.SendFriendRequestRequest{UserID: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accepted != 7 {
+		t.Fatalf("expected the counter request to be accepted, got %d", accepted)
+	}
+
+	repo.pendingFriendReqFn = func(ctx context.Context, userID, otherID uint64) (models.FriendRequest, error) {
+		return models.FriendRequest{ID: 8, SenderID: 1, ReceiverID: 2, Status: models.FriendRequestPending}, nil
+	}
+	_, err := newService(repo).SendFriendRequest(context.Background(), 1, requests.SendFriendRequestRequest{UserID: 2})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestAcceptFriendRequest_OnlyReceiverAndGuestRule(t *testing.T) {
+	repo := friendRepo(true, false)
+	repo.acceptFriendReqFn = func(ctx context.Context, requestID uint64) error {
+		t.Fatal("request must not be accepted")
+		return nil
+	}
+
+	// отправитель не может принять свою заявку — для него её как будто нет
+	_, err := newService(repo).AcceptFriendRequest(context.Background(), 1, 10)
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+
+	// получатель-гость не играл с отправителем
+	_, err = newService(repo).AcceptFriendRequest(context.Background(), 2, 10)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	repo.getFriendReqFn = func(ctx context.Context, requestID uint64) (models.FriendRequest, error) {
+		return models.FriendRequest{ID: requestID, SenderID: 1, ReceiverID: 2, Status: models.FriendRequestCancelled}, nil
+	}
+	_, err = newService(repo).AcceptFriendRequest(context.Background(), 2, 10)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func pendingInvitationRepo(invitation models.MatchInvitation) mockRepository {
+	repo := scheduledMatchRepo(1, 1)
+	repo.getInvitationFn = func(_ context.Context, _ uint64) (models.MatchInvitation, error) {
+		return invitation, nil
+	}
+	return repo
+}
+
+func TestGetMatch_VisibleToInvitee(t *testing.T) {
+	repo := scheduledMatchRepo(1, 1)
+	repo.hasInvitationFn = func(_ context.Context, _, userID uint64) (bool, error) {
+		return userID == 4, nil
+	}
+	service := newService(repo)
+
+	if _, err := service.GetMatch(context.Background(), 4, 10, nil); err != nil {
+		t.Fatalf("expected invitee to see the match, got %v", err)
+	}
+}
+
+func TestInviteToMatch_OnlyOrganizer(t *testing.T) {
+	repo := scheduledMatchRepo(1, 1, 2)
+	repo.createInvitationsFn = func(_ context.Context, _, _ uint64, _ []uint64, _ time.Time) ([]models.MatchInvitation, error) {
+		t.Fatal("participant must not invite players")
+		return nil, nil
+	}
+	service := newService(repo)
+
+	_, err := service.InviteToMatch(context.Background(), 2, 10, requests.InviteToMatchRequest{UserIDs: []uint64{5}}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestInviteToMatch_RejectsClosedMatch(t *testing.T) {
+	repo := scheduledMatchRepo(1, 1)
+	organizerID := uint64(1)
+	repo.getMatchFn = func(_ context.Context, matchID uint64) (models.Match, error) {
+		return models.Match{ID: matchID, OrganizerID: &organizerID, Status: models.MatchStatusCancelled}, nil
+	}
+	service := newService(repo)
+
+	_, err := service.InviteToMatch(context.Background(), 1, 10, requests.InviteToMatchRequest{UserIDs: []uint64{5}}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestAcceptInvitation_ForeignInvitationLooksMissing(t *testing.T) {
+	service := newService(pendingInvitationRepo(models.MatchInvitation{
+		ID: 7, MatchID: 10, UserID: 3, Status: models.InvitationStatusPending,
+	}))
+
+	_, err := service.AcceptInvitation(context.Background(), 4, 7)
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+}
+
+func TestAcceptInvitation_ExpiredIsConflict(t *testing.T) {
+	service := newService(pendingInvitationRepo(models.MatchInvitation{
+		ID: 7, MatchID: 10, UserID: 3, Status: models.InvitationStatusExpired,
+	}))
+
+	_, err := service.AcceptInvitation(context.Background(), 3, 7)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestAcceptInvitation_RaceIsConflict(t *testing.T) {
+	repo := pendingInvitationRepo(models.MatchInvitation{
+		ID: 7, MatchID: 10, UserID: 3, Status: models.InvitationStatusPending,
+	})
+	repo.acceptInvitationFn = func(_ context.Context, _ uint64) error {
+		return pgx.ErrNoRows
+	}
+	service := newService(repo)
+
+	_, err := service.AcceptInvitation(context.Background(), 3, 7)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestDeclineAndRevokeInvitation(t *testing.T) {
+	var closed []models.InvitationStatus
+	repo := pendingInvitationRepo(models.MatchInvitation{
+		ID: 7, MatchID: 10, UserID: 3, Status: models.InvitationStatusPending,
+	})
+	repo.closeInvitationFn = func(_ context.Context, _ uint64, status models.InvitationStatus) error {
+		closed = append(closed, status)
+		return nil
+	}
+	service := newService(repo)
+
+	if err := service.DeclineInvitation(context.Background(), 3, 7); err != nil {
+		t.Fatalf("expected invitee to decline, got %v", err)
+	}
+	expectAppCode(t, service.RevokeInvitation(context.Background(), 3, 7, nil), myerrors.ErrCodeForbidden)
+	if err := service.RevokeInvitation(context.Background(), 1, 7, nil); err != nil {
+		t.Fatalf("expected organizer to revoke, got %v", err)
+	}
+
+	if len(closed) != 2 || closed[0] != models.InvitationStatusDeclined || closed[1] != models.InvitationStatusRevoked {
+		t.Fatalf("unexpected closed statuses %v", closed)
+	}
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// inviteLinkRepo — матч 10 организатора 1 и ссылка, которую он выпустил
+func inviteLinkRepo(t *testing.T) (mockRepository, *models.MatchInviteLink) {
+	t.Helper()
+
+	stored := &models.MatchInviteLink{}
+	repo := scheduledMatchRepo(1, 1)
+	repo.createInviteLinkFn = func(_ context.Context, link models.MatchInviteLink) (models.MatchInviteLink, error) {
+		link.ID = 5
+		*stored = link
+		return link, nil
+	}
+	repo.getInviteLinkFn = func(_ context.Context, _ uint64) (models.MatchInviteLink, error) {
+		return *stored, nil
+	}
+	repo.isUserInMatchFn = func(_ context.Context, _, userID uint64) (bool, error) {
+		return userID == 1, nil
+	}
+	repo.redeemInviteLinkFn = func(_ context.Context, linkID, userID uint64, expiresAt time.Time) (models.MatchInvitation, error) {
+		return models.MatchInvitation{ID: 70, MatchID: stored.MatchID, UserID: userID, LinkID: &linkID, ExpiresAt: expiresAt,
+			Status: models.InvitationStatusPending}, nil
+	}
+
+	return repo, stored
+}
+
+func TestInviteLink_CreateAndRedeem(t *testing.T) {
+	repo, _ := inviteLinkRepo(t)
+	service := newService(repo)
+
+	link, err := service.CreateInviteLink(context.Background(), 1, 10, requests.CreateInviteLinkRequest{}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if link.Token == "" || !strings.HasPrefix(link.URL, "https://app.example/invite/") {
+		t.Fatalf("expected signed token and url, got %+v", link)
+	}
+
+	invitation, err := service.RedeemInviteLink(context.Background(), 4, link.Token)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if invitation.UserID != 4 || invitation.LinkID == nil || *invitation.LinkID != 5 {
+		t.Fatalf("unexpected invitation %+v", invitation)
+	}
+}
+
+func TestInviteLink_OnlyOrganizerCreates(t *testing.T) {
+	repo, _ := inviteLinkRepo(t)
+	service := newService(repo)
+
+	_, err := service.CreateInviteLink(context.Background(), 2, 10, requests.CreateInviteLinkRequest{}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestInviteLink_TamperedTokenRejected(t *testing.T) {
+	repo, _ := inviteLinkRepo(t)
+	service := newService(repo)
+
+	link, err := service.CreateInviteLink(context.Background(), 1, 10, requests.CreateInviteLinkRequest{}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tampered := "6" + link.Token[1:]
+	_, err = service.RedeemInviteLink(context.Background(), 4, tampered)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestInviteLink_RevokedOrExhausted(t *testing.T) {
+	repo, stored := inviteLinkRepo(t)
+	service := newService(repo)
+
+	maxUses := 1
+	link, err := service.CreateInviteLink(context.Background(), 1, 10, requests.CreateInviteLinkRequest{MaxUses: &maxUses}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stored.UsesCount = 1
+	_, err = service.RedeemInviteLink(context.Background(), 4, link.Token)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	stored.UsesCount = 0
+	revokedAt := time.Now()
+	stored.RevokedAt = &revokedAt
+	_, err = service.RedeemInviteLink(context.Background(), 4, link.Token)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestInviteLink_ParticipantCannotRedeem(t *testing.T) {
+	repo, _ := inviteLinkRepo(t)
+	service := newService(repo)
+
+	link, err := service.CreateInviteLink(context.Background(), 1, 10, requests.CreateInviteLinkRequest{}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = service.RedeemInviteLink(context.Background(), 1, link.Token)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestInviteLink_ExpiresWithMatchStart(t *testing.T) {
+	repo, _ := inviteLinkRepo(t)
+	service := newService(repo)
+
+	link, err := service.CreateInviteLink(context.Background(), 1, 10, requests.CreateInviteLinkRequest{ExpiresInHours: 24 * 30}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// матч в scheduledMatchRepo начинается через сутки
+	if link.ExpiresAt.After(time.Now().Add(24 * time.Hour)) {
+		t.Fatalf("expected link to expire by match start, got %v", link.ExpiresAt)
+	}
+}
@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+)
+
+// lineupRepo — запланированный матч организатора 1 с сохраняемым составом
+func lineupRepo(players ...models.LineupPlayer) mockRepository {
+	ids := make([]uint64, 0, len(players))
+	for _, p := range players {
+		ids = append(ids, p.UserID)
+	}
+
+	repo := scheduledMatchRepo(1, ids...)
+	repo.getLineupFn = func(_ context.Context, _ uint64) ([]models.LineupPlayer, error) {
+		return players, nil
+	}
+	repo.setLineupFn = func(_ context.Context, _ uint64, assignments []models.LineupAssignment) error {
+		for i := range players {
+			players[i].Team, players[i].Side = nil, nil
+			for _, a := range assignments {
+				if a.UserID == players[i].UserID {
+					team := a.Team
+					players[i].Team, players[i].Side = &team, a.Side
+				}
+			}
+		}
+		return nil
+	}
+	return repo
+}
+
+func lineupPlayer(userID uint64, rating float64, position *models.CourtPosition) models.LineupPlayer {
+	return models.LineupPlayer{UserID: userID, Rating: &rating, CourtPosition: position}
+}
+
+func playerOf(resp []models.LineupPlayer, userID uint64) models.LineupPlayer {
+	for _, p := range resp {
+		if p.UserID == userID {
+			return p
+		}
+	}
+	return models.LineupPlayer{}
+}
+
+func TestAutoBalanceLineup_EqualTeamAveragesAndSides(t *testing.T) {
+	left := models.CourtPositionLeft
+	service := newService(lineupRepo(
+		lineupPlayer(1, 1800, nil),
+		lineupPlayer(2, 1600, nil),
+		lineupPlayer(3, 1500, nil),
+		lineupPlayer(4, 1300, &left),
+	))
+
+	resp, err := service.AutoBalanceLineup(context.Background(), 1, 10, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// 1800+1300 против 1600+1500 — разница средних нулевая
+	if *playerOf(resp.Players, 1).Team != *playerOf(resp.Players, 4).Team {
+		t.Fatalf("expected the strongest and the weakest together, got %+v", resp.Players)
+	}
+	if *resp.Team1Rating != 1550 || *resp.Team2Rating != 1550 {
+		t.Fatalf("expected equal team ratings, got %v and %v", *resp.Team1Rating, *resp.Team2Rating)
+	}
+	// игрок 4 предпочитает левую сторону; во второй паре слева сильнейший
+	if side := playerOf(resp.Players, 4).Side; side == nil || *side != models.MatchSideLeft {
+		t.Fatalf("expected player 4 on the left, got %v", side)
+	}
+	if side := playerOf(resp.Players, 2).Side; side == nil || *side != models.MatchSideLeft {
+		t.Fatalf("expected player 2 on the left, got %v", side)
+	}
+}
+
+func TestSetMatchLineup_Validation(t *testing.T) {
+	left := models.MatchSideLeft
+	cases := map[string][]models.LineupAssignment{
+		"not a participant": {{UserID: 9, Team: 1}},
+		"unknown team":      {{UserID: 1, Team: 3}},
+		"team too large":    {{UserID: 1, Team: 1}, {UserID: 2, Team: 1}, {UserID: 3, Team: 1}},
+		"same side twice":   {{UserID: 1, Team: 1, Side: &left}, {UserID: 2, Team: 1, Side: &left}},
+	}
+
+	for name, players := range cases {
+		t.Run(name, func(t *testing.T) {
+			service := newService(lineupRepo(
+				lineupPlayer(1, 1500, nil),
+				lineupPlayer(2, 1500, nil),
+				lineupPlayer(3, 1500, nil),
+				lineupPlayer(4, 1500, nil),
+			))
+
+			_, err := service.SetMatchLineup(context.Background(), 1, 10, requests.SetMatchLineupRequest{Players: players}, nil)
+			expectAppCode(t, err, myerrors.ErrCodeValidation)
+		})
+	}
+}
+
+func TestSetMatchLineup_OnlyOrganizer(t *testing.T) {
+	service := newService(lineupRepo(lineupPlayer(1, 1500, nil), lineupPlayer(2, 1500, nil)))
+
+	_, err := service.SetMatchLineup(context.Background(), 2, 10, requests.SetMatchLineupRequest{}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestSubmitMatchResult_UsesMatchLineup(t *testing.T) {
+	one, two := 1, 2
+	repo, stored := playedMatchRepo(models.SportPadel)
+	repo.getLineupFn = func(_ context.Context, _ uint64) ([]models.LineupPlayer, error) {
+		return []models.LineupPlayer{
+			{UserID: 1, Team: &one},
+			{UserID: 3, Team: &one},
+			{UserID: 2, Team: &two},
+			{UserID: 4, Team: &two},
+		}, nil
+	}
+	service := newService(repo)
+
+	// составы в запросе расходятся с распределёнными командами
+	_, err := service.SubmitMatchResult(context.Background(), 1, 10, doublesResult(
+		models.SetScore{Team1: 6, Team2: 2},
+		models.SetScore{Team1: 6, Team2: 2},
+	))
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	req := doublesResult(models.SetScore{Team1: 6, Team2: 2}, models.SetScore{Team1: 6, Team2: 2})
+	req.Team1, req.Team2 = nil, nil
+	if _, err = service.SubmitMatchResult(context.Background(), 1, 10, req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stored.TeamOf(3) != 1 || stored.TeamOf(2) != 2 {
+		t.Fatalf("expected teams from the lineup, got %v and %v", stored.Team1, stored.Team2)
+	}
+}
+
+func TestMatchLineup_OddParticipantsCannotFormTeams(t *testing.T) {
+	players := []models.LineupPlayer{
+		lineupPlayer(1, 1600, nil),
+		lineupPlayer(2, 1500, nil),
+		lineupPlayer(3, 1400, nil),
+	}
+	lineup := lineupRepo(players...)
+	repo, _ := playedMatchRepo(models.SportTennis)
+	repo.getParticipantsFn = lineup.getParticipantsFn
+	repo.getLineupFn, repo.setLineupFn = lineup.getLineupFn, lineup.setLineupFn
+	service := newService(repo)
+
+	_, err := service.AutoBalanceLineup(context.Background(), 1, 10, nil)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	_, err = service.SetMatchLineup(context.Background(), 1, 10, requests.SetMatchLineupRequest{Players: []models.LineupAssignment{
+		{UserID: 1, Team: 1},
+		{UserID: 2, Team: 2},
+		{UserID: 3, Team: 2},
+	}}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	// состав так и не распределён, а команды одиночек оставляют третьего участника без команды
+	req := requests.SubmitMatchResultRequest{
+		WinnerTeam: 1,
+		Team1:      []uint64{1},
+		Team2:      []uint64{2},
+		Sets:       []models.SetScore{{Team1: 6, Team2: 2}, {Team1: 6, Team2: 2}},
+	}
+	_, err = service.SubmitMatchResult(context.Background(), 1, 10, req)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	// с четвёртым участником состав собирается и результат берёт команды из него
+	players = append(players, lineupPlayer(4, 1300, nil))
+	lineup = lineupRepo(players...)
+	repo.getParticipantsFn = lineup.getParticipantsFn
+	repo.getLineupFn, repo.setLineupFn = lineup.getLineupFn, lineup.setLineupFn
+	service = newService(repo)
+
+	if _, err = service.AutoBalanceLineup(context.Background(), 1, 10, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	req.Team1, req.Team2 = nil, nil
+	if _, err = service.SubmitMatchResult(context.Background(), 1, 10, req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// publicMatchRepo — публичный матч 10 организатора 1 на capacity мест.
+// JoinPublicMatch ведёт себя как репозиторий: под блокировкой проверяет места.
+func publicMatchRepo(capacity int, participants ...uint64) mockRepository {
+	startsAt := time.Now().Add(24 * time.Hour)
+	endsAt := startsAt.Add(90 * time.Minute)
+	organizerID := uint64(1)
+
+	var mu sync.Mutex
+	joined := append([]uint64{}, participants...)
+
+	return mockRepository{
+		getMatchFn: func(_ context.Context, matchID uint64) (models.Match, error) {
+			return models.Match{
+				ID:          matchID,
+				OrganizerID: &organizerID,
+				Status:      models.MatchStatusScheduled,
+				Visibility:  models.MatchVisibilityPublic,
+				Capacity:    &capacity,
+				StartsAt:    &startsAt,
+				EndsAt:      &endsAt,
+			}, nil
+		},
+		getParticipantsFn: func(_ context.Context, _ uint64) ([]uint64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]uint64{}, joined...), nil
+		},
+		isUserInMatchFn: func(_ context.Context, _, userID uint64) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range joined {
+				if id == userID {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		joinPublicMatchFn: func(_ context.Context, _, userID uint64) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(joined) >= capacity {
+				return myerrors.ErrMatchFull
+			}
+			joined = append(joined, userID)
+			return nil
+		},
+	}
+}
+
+func TestCreateMatch_PublicRequiresSportAndTown(t *testing.T) {
+	service := newService(mockRepository{})
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID: 1,
+		StartsAt:    time.Now().Add(time.Hour),
+		Visibility:  string(models.MatchVisibilityPublic),
+	}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestCreateMatch_CapacityBelowRequiredPlayers(t *testing.T) {
+	service := newService(mockRepository{})
+	capacity := 3
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID:     1,
+		StartsAt:        time.Now().Add(time.Hour),
+		RequiredPlayers: 4,
+		Capacity:        &capacity,
+	}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestDiscoverPublicMatches_BuildsFilterAndCursor(t *testing.T) {
+	var got []models.PublicMatchFilter
+	first := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	repo := mockRepository{
+		listPublicMatchesFn: func(_ context.Context, filter models.PublicMatchFilter) ([]models.Match, error) {
+			got = append(got, filter)
+			if filter.AfterStartsAt != nil {
+				return []models.Match{}, nil
+			}
+			return []models.Match{
+				{ID: 3, StartsAt: &first},
+				{ID: 7, StartsAt: &second},
+			}, nil
+		},
+	}
+	service := newService(repo)
+	townID, levelID := 5, 2
+
+	resp, err := service.DiscoverPublicMatches(context.Background(), 1, requests.PublicMatchesRequest{
+		TownID:  &townID,
+		LevelID: &levelID,
+		From:    "2026-05-01",
+		To:      "2026-05-03",
+		Limit:   1,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(resp.Matches) != 1 || resp.Matches[0].ID != 3 || resp.NextCursor == "" {
+		t.Fatalf("expected first page with cursor, got %+v", resp)
+	}
+
+	filter := got[0]
+	if filter.Limit != 2 || filter.MinFreeSpots != 1 || *filter.TownID != 5 || *filter.LevelID != 2 || filter.ViewerID != 1 {
+		t.Fatalf("unexpected filter %+v", filter)
+	}
+	if !filter.To.Equal(time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected inclusive to date, got %v", filter.To)
+	}
+
+	resp, err = service.DiscoverPublicMatches(context.Background(), 1, requests.PublicMatchesRequest{Cursor: resp.NextCursor, Limit: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resp.NextCursor != "" {
+		t.Fatalf("expected last page, got cursor %q", resp.NextCursor)
+	}
+	if got[1].AfterStartsAt == nil || !got[1].AfterStartsAt.Equal(first) || got[1].AfterID != 3 {
+		t.Fatalf("expected cursor after match 3, got %+v", got[1])
+	}
+}
+
+func TestDiscoverPublicMatches_InvalidCursor(t *testing.T) {
+	service := newService(mockRepository{})
+
+	_, err := service.DiscoverPublicMatches(context.Background(), 1, requests.PublicMatchesRequest{Cursor: "not-a-cursor"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestJoinMatch_PrivateMatchForbidden(t *testing.T) {
+	service := newService(scheduledMatchRepo(1, 1))
+
+	_, err := service.JoinMatch(context.Background(), 2, 10)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestJoinMatch_AlreadyParticipant(t *testing.T) {
+	service := newService(publicMatchRepo(4, 1, 2))
+
+	_, err := service.JoinMatch(context.Background(), 2, 10)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestJoinMatch_ConcurrentJoinsDoNotOverbook(t *testing.T) {
+	repo := publicMatchRepo(4, 1)
+	service := newService(repo)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		joined int
+		full   int
+	)
+	for userID := uint64(2); userID < 12; userID++ {
+		wg.Add(1)
+		go func(userID uint64) {
+			defer wg.Done()
+			_, err := service.JoinMatch(context.Background(), userID, 10)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				joined++
+				return
+			}
+			var appErr myerrors.AppError
+			if errors.As(err, &appErr) && appErr.Code == myerrors.ErrCodeConflict {
+				full++
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	if joined != 3 || full != 7 {
+		t.Fatalf("expected 3 joined and 7 rejected, got %d and %d", joined, full)
+	}
+}
+
+func TestGetMatch_PublicVisibleToAnyone(t *testing.T) {
+	service := newService(publicMatchRepo(4, 1))
+
+	if _, err := service.GetMatch(context.Background(), 9, 10, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/rating"
+	"testing"
+	"time"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// playedMatchRepo — начавшийся матч 10 с участниками 1–4; результат хранится в памяти
+func playedMatchRepo(sport string) (mockRepository, *models.MatchResult) {
+	startsAt := time.Now().Add(-2 * time.Hour)
+	organizerID := uint64(1)
+	sportID := 1
+	stored := &models.MatchResult{}
+
+	repo := mockRepository{
+		getMatchFn: func(_ context.Context, matchID uint64) (models.Match, error) {
+			return models.Match{ID: matchID, OrganizerID: &organizerID, Status: models.MatchStatusActive, SportID: &sportID, StartsAt: &startsAt}, nil
+		},
+		getParticipantsFn: func(_ context.Context, _ uint64) ([]uint64, error) {
+			return []uint64{1, 2, 3, 4}, nil
+		},
+		getSportNameFn: func(_ context.Context, _ int) (string, error) {
+			return sport, nil
+		},
+		createResultFn: func(_ context.Context, result models.MatchResult, _ int) (uint64, error) {
+			result.ID = 50
+			result.Status = models.ResultStatusPending
+			*stored = result
+			return 50, nil
+		},
+		getResultFn: func(_ context.Context, _ uint64) (models.MatchResult, error) {
+			return *stored, nil
+		},
+		confirmResultFn: func(_ context.Context, _, userID uint64, _ rating.Elo) error {
+			stored.Status = models.ResultStatusConfirmed
+			stored.ConfirmedBy = &userID
+			return nil
+		},
+	}
+
+	return repo, stored
+}
+
+func doublesResult(sets ...models.SetScore) requests.SubmitMatchResultRequest {
+	return requests.SubmitMatchResultRequest{
+		WinnerTeam: 1,
+		Team1:      []uint64{1, 2},
+		Team2:      []uint64{3, 4},
+		Sets:       sets,
+	}
+}
+
+func TestSubmitMatchResult_PadelWithTiebreakAndConfirm(t *testing.T) {
+	repo, _ := playedMatchRepo(models.SportPadel)
+	service := newService(repo)
+
+	result, err := service.SubmitMatchResult(context.Background(), 1, 10, doublesResult(
+		models.SetScore{Team1: 6, Team2: 4},
+		models.SetScore{Team1: 6, Team2: 7, Team1Tiebreak: intPtr(5), Team2Tiebreak: intPtr(7)},
+		models.SetScore{Team1: 10, Team2: 8, MatchTiebreak: true},
+	))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Status != models.ResultStatusPending || result.WinnerTeam != 1 {
+		t.Fatalf("expected pending result won by team 1, got %+v", result)
+	}
+
+	// партнёр по команде не может подтвердить свой же результат
+	_, err = service.ConfirmMatchResult(context.Background(), 2, result.ID)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	result, err = service.ConfirmMatchResult(context.Background(), 3, result.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Status != models.ResultStatusConfirmed {
+		t.Fatalf("expected confirmed result, got %s", result.Status)
+	}
+}
+
+func TestSubmitMatchResult_InvalidScores(t *testing.T) {
+	cases := map[string][]models.SetScore{
+		"6:5 is not a finished set":      {{Team1: 6, Team2: 5}, {Team1: 6, Team2: 0}},
+		"7:6 without tiebreak":           {{Team1: 7, Team2: 6}, {Team1: 6, Team2: 0}},
+		"tiebreak won by the set loser":  {{Team1: 7, Team2: 6, Team1Tiebreak: intPtr(3), Team2Tiebreak: intPtr(7)}, {Team1: 6, Team2: 0}},
+		"tiebreak without two point gap": {{Team1: 7, Team2: 6, Team1Tiebreak: intPtr(8), Team2Tiebreak: intPtr(7)}, {Team1: 6, Team2: 0}},
+		"match not finished":             {{Team1: 6, Team2: 3}},
+		"extra set after the win":        {{Team1: 6, Team2: 3}, {Team1: 6, Team2: 3}, {Team1: 6, Team2: 3}},
+		"match tiebreak in first set":    {{Team1: 10, Team2: 5, MatchTiebreak: true}, {Team1: 6, Team2: 3}},
+		"match tiebreak to 9":            {{Team1: 6, Team2: 3}, {Team1: 3, Team2: 6}, {Team1: 9, Team2: 7, MatchTiebreak: true}},
+	}
+
+	for name, sets := range cases {
+		t.Run(name, func(t *testing.T) {
+			repo, _ := playedMatchRepo(models.SportTennis)
+			service := newService(repo)
+
+			_, err := service.SubmitMatchResult(context.Background(), 1, 10, doublesResult(sets...))
+			expectAppCode(t, err, myerrors.ErrCodeValidation)
+		})
+	}
+}
+
+func TestSubmitMatchResult_WinnerMustMatchScore(t *testing.T) {
+	repo, _ := playedMatchRepo(models.SportTennis)
+	service := newService(repo)
+	req := doublesResult(models.SetScore{Team1: 3, Team2: 6}, models.SetScore{Team1: 4, Team2: 6})
+
+	_, err := service.SubmitMatchResult(context.Background(), 1, 10, req)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestSubmitMatchResult_PadelIsDoublesOnly(t *testing.T) {
+	repo, _ := playedMatchRepo(models.SportPadel)
+	repo.getParticipantsFn = func(_ context.Context, _ uint64) ([]uint64, error) {
+		return []uint64{1, 3}, nil
+	}
+	service := newService(repo)
+
+	_, err := service.SubmitMatchResult(context.Background(), 1, 10, requests.SubmitMatchResultRequest{
+		WinnerTeam: 1,
+		Team1:      []uint64{1},
+		Team2:      []uint64{3},
+		Sets:       []models.SetScore{{Team1: 6, Team2: 1}, {Team1: 6, Team2: 2}},
+	})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestSubmitMatchResult_EveryParticipantInTeam(t *testing.T) {
+	repo, _ := playedMatchRepo(models.SportTennis)
+	service := newService(repo)
+
+	_, err := service.SubmitMatchResult(context.Background(), 1, 10, requests.SubmitMatchResultRequest{
+		WinnerTeam: 1,
+		Team1:      []uint64{1},
+		Team2:      []uint64{3},
+		Sets:       []models.SetScore{{Team1: 6, Team2: 1}, {Team1: 6, Team2: 2}},
+	})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestSubmitMatchResult_BeforeStartIsConflict(t *testing.T) {
+	service := newService(scheduledMatchRepo(1, 1, 2))
+
+	_, err := service.SubmitMatchResult(context.Background(), 1, 10, doublesResult())
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestDisputeMatchResult_GoesToAssistant(t *testing.T) {
+	repo, stored := playedMatchRepo(models.SportTennis)
+	var resolvedConfirm *bool
+	repo.disputeResultFn = func(_ context.Context, _, userID uint64, reason string) error {
+		if reason != "счёт перепутан" {
+			t.Fatalf("unexpected reason %q", reason)
+		}
+		stored.Status = models.ResultStatusDisputed
+		stored.DisputedBy = &userID
+		return nil
+	}
+	repo.resolveResultFn = func(_ context.Context, _, _ uint64, confirm bool, _ *string, _ rating.Elo) error {
+		resolvedConfirm = &confirm
+		stored.Status = models.ResultStatusRejected
+		return nil
+	}
+	service := newService(repo)
+
+	result, err := service.SubmitMatchResult(context.Background(), 3, 10, doublesResult(
+		models.SetScore{Team1: 6, Team2: 4},
+		models.SetScore{Team1: 7, Team2: 5},
+	))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// оспорить может и сторона, которая вводила результат
+	if _, err = service.DisputeMatchResult(context.Background(), 4, result.ID, requests.DisputeMatchResultRequest{Reason: " счёт перепутан "}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = service.ConfirmMatchResult(context.Background(), 1, result.ID)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	result, err = service.ResolveResultDispute(context.Background(), 9, result.ID, requests.ResolveMatchResultRequest{Decision: "reject"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resolvedConfirm == nil || *resolvedConfirm || result.Status != models.ResultStatusRejected {
+		t.Fatalf("expected dispute rejected, got %+v", result)
+	}
+}
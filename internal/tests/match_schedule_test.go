@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+)
+
+func TestCreateMatch_DurationAndCourt(t *testing.T) {
+	var got models.Match
+	repo := scheduledMatchRepo(1, 1)
+	repo.courtObjectFn = func(_ context.Context, courtID int) (int, error) {
+		return 7, nil
+	}
+	repo.createMatchFn = func(_ context.Context, match models.Match, _ []uint64, _ time.Time) (uint64, error) {
+		got = match
+		return 10, nil
+	}
+	service := newService(repo)
+	startsAt := time.Now().Add(3 * time.Hour)
+	courtID := 3
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID:     1,
+		StartsAt:        startsAt,
+		DurationMinutes: 60,
+		CourtID:         &courtID,
+	}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got.EndsAt == nil || !got.EndsAt.Equal(startsAt.Add(time.Hour)) {
+		t.Fatalf("expected match to end an hour after start, got %v", got.EndsAt)
+	}
+	if got.SportObjectID == nil || *got.SportObjectID != 7 {
+		t.Fatalf("expected sport object taken from court, got %v", got.SportObjectID)
+	}
+}
+
+func TestCreateMatch_CourtFromAnotherObject(t *testing.T) {
+	service := newService(mockRepository{
+		courtObjectFn: func(_ context.Context, _ int) (int, error) {
+			return 7, nil
+		},
+	})
+	courtID, objectID := 3, 8
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID:   1,
+		StartsAt:      time.Now().Add(time.Hour),
+		SportObjectID: &objectID,
+		CourtID:       &courtID,
+	}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestCreateMatch_EndsBeforeStart(t *testing.T) {
+	service := newService(mockRepository{})
+	startsAt := time.Now().Add(time.Hour)
+	endsAt := startsAt.Add(-time.Minute)
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID: 1,
+		StartsAt:    startsAt,
+		EndsAt:      &endsAt,
+	}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestCreateMatch_ScheduleConflictListsEvents(t *testing.T) {
+	startsAt := time.Now().Add(2 * time.Hour)
+	clash := models.ScheduleConflict{
+		Kind:     models.ScheduleEventActivity,
+		ID:       15,
+		Title:    "Тренировка",
+		StartsAt: startsAt.Add(30 * time.Minute),
+		EndsAt:   startsAt.Add(2 * time.Hour),
+	}
+	service := newService(mockRepository{
+		scheduleConflictsFn: func(_ context.Context, userID uint64, from, to time.Time, excludeMatchID uint64) ([]models.ScheduleConflict, error) {
+			if userID != 1 || excludeMatchID != 0 || !to.Equal(from.Add(90*time.Minute)) {
+				t.Fatalf("unexpected conflict lookup: user %d, %v–%v, exclude %d", userID, from, to, excludeMatchID)
+			}
+			return []models.ScheduleConflict{clash}, nil
+		},
+	})
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID: 1,
+		StartsAt:    startsAt,
+	}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	var appErr myerrors.AppError
+	errors.As(err, &appErr)
+	details, ok := appErr.Details.(services.ScheduleConflictDetails)
+	if !ok || len(details.Conflicts) != 1 || details.Conflicts[0] != clash {
+		t.Fatalf("expected clashing activity in details, got %+v", appErr.Details)
+	}
+	if !errors.Is(err, myerrors.ErrScheduleConflict) {
+		t.Fatalf("expected schedule conflict sentinel, got %v", err)
+	}
+}
+
+func TestAcceptInvitation_ScheduleConflict(t *testing.T) {
+	repo := pendingInvitationRepo(models.MatchInvitation{ID: 40, MatchID: 10, UserID: 4, Status: models.InvitationStatusPending})
+	repo.scheduleConflictsFn = func(_ context.Context, _ uint64, _, _ time.Time, excludeMatchID uint64) ([]models.ScheduleConflict, error) {
+		if excludeMatchID != 10 {
+			t.Fatalf("expected match 10 excluded, got %d", excludeMatchID)
+		}
+		return []models.ScheduleConflict{{Kind: models.ScheduleEventMatch, ID: 11}}, nil
+	}
+	repo.acceptInvitationFn = func(_ context.Context, _ uint64) error {
+		t.Fatal("invitation must not be accepted on conflict")
+		return nil
+	}
+	service := newService(repo)
+
+	_, err := service.AcceptInvitation(context.Background(), 4, 40)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestJoinMatch_ScheduleConflict(t *testing.T) {
+	repo := publicMatchRepo(4, 1)
+	repo.scheduleConflictsFn = func(_ context.Context, _ uint64, _, _ time.Time, _ uint64) ([]models.ScheduleConflict, error) {
+		return []models.ScheduleConflict{{Kind: models.ScheduleEventMatch, ID: 11}}, nil
+	}
+	service := newService(repo)
+
+	_, err := service.JoinMatch(context.Background(), 2, 10)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
@@ -0,0 +1,217 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func scheduledMatchRepo(organizerID uint64, participants ...uint64) mockRepository {
+	startsAt := time.Now().Add(24 * time.Hour)
+	endsAt := startsAt.Add(90 * time.Minute)
+
+	return mockRepository{
+		getMatchFn: func(_ context.Context, matchID uint64) (models.Match, error) {
+			return models.Match{
+				ID:          matchID,
+				OrganizerID: &organizerID,
+				Status:      models.MatchStatusScheduled,
+				StartsAt:    &startsAt,
+				EndsAt:      &endsAt,
+			}, nil
+		},
+		getParticipantsFn: func(_ context.Context, _ uint64) ([]uint64, error) {
+			return participants, nil
+		},
+	}
+}
+
+func expectAppCode(t *testing.T, err error, code myerrors.ErrorCode) {
+	t.Helper()
+
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != code {
+		t.Fatalf("expected %s error, got %v", code, err)
+	}
+}
+
+func TestCreateMatch_InvitesPlayersInsteadOfAddingThem(t *testing.T) {
+	var (
+		gotInvitees  []uint64
+		gotExpiresAt time.Time
+		gotRequired  int
+	)
+	startsAt := time.Now().Add(2 * time.Hour)
+	repo := scheduledMatchRepo(1, 1)
+	repo.createMatchFn = func(_ context.Context, match models.Match, inviteeIDs []uint64, expiresAt time.Time) (uint64, error) {
+		if match.OrganizerID == nil || *match.OrganizerID != 1 {
+			t.Fatalf("expected organizer 1, got %v", match.OrganizerID)
+		}
+		gotInvitees, gotExpiresAt, gotRequired = inviteeIDs, expiresAt, match.RequiredPlayers
+		return 10, nil
+	}
+	service := newService(repo)
+
+	match, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID:   1,
+		StartsAt:      startsAt,
+		InviteUserIDs: []uint64{2, 3, 2, 1},
+	}, []string{commons.PermissionMatchInviteUsers})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(gotInvitees) != 2 || gotInvitees[0] != 2 || gotInvitees[1] != 3 {
+		t.Fatalf("expected invitees [2 3], got %v", gotInvitees)
+	}
+	if gotRequired != 2 {
+		t.Fatalf("expected default of 2 required players, got %d", gotRequired)
+	}
+	// TTL в конфиге — 72 часа, но приглашение не должно пережить начало матча
+	if !gotExpiresAt.Equal(startsAt.UTC()) {
+		t.Fatalf("expected invitation to expire at match start %v, got %v", startsAt, gotExpiresAt)
+	}
+	if match.ID != 10 || len(match.ParticipantIDs) != 1 {
+		t.Fatalf("expected match 10 with organizer only, got %+v", match)
+	}
+}
+
+func TestCreateMatch_InviteesRequireInvitePermission(t *testing.T) {
+	service := newService(mockRepository{
+		createMatchFn: func(_ context.Context, _ models.Match, _ []uint64, _ time.Time) (uint64, error) {
+			t.Fatal("match must not be created without match.invite.users")
+			return 0, nil
+		},
+	})
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID:   1,
+		StartsAt:      time.Now().Add(time.Hour),
+		InviteUserIDs: []uint64{2},
+	}, []string{"match.create"})
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestCreateMatch_RejectsPastStart(t *testing.T) {
+	service := newService(mockRepository{})
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID: 1,
+		StartsAt:    time.Now().Add(-time.Hour),
+	}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestCreateMatch_UnknownTypeIsValidationError(t *testing.T) {
+	service := newService(mockRepository{
+		createMatchFn: func(_ context.Context, _ models.Match, _ []uint64, _ time.Time) (uint64, error) {
+			return 0, myerrors.ErrInvalidReference
+		},
+	})
+
+	_, err := service.CreateMatch(context.Background(), 1, requests.CreateMatchRequest{
+		MatchTypeID: 99,
+		StartsAt:    time.Now().Add(time.Hour),
+	}, nil)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestGetMatch_ForbiddenForOutsider(t *testing.T) {
+	repo := scheduledMatchRepo(1, 1, 2)
+	repo.hasInvitationFn = func(_ context.Context, _, _ uint64) (bool, error) {
+		return false, nil
+	}
+	service := newService(repo)
+
+	_, err := service.GetMatch(context.Background(), 5, 10, nil)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	if _, err = service.GetMatch(context.Background(), 5, 10, []string{commons.PermissionMatchManageAny}); err != nil {
+		t.Fatalf("expected manager to see the match, got %v", err)
+	}
+	if _, err = service.GetMatch(context.Background(), 2, 10, nil); err != nil {
+		t.Fatalf("expected participant to see the match, got %v", err)
+	}
+}
+
+func TestListMyMatches_ScopeAndPaging(t *testing.T) {
+	var got models.MatchListFilter
+	service := newService(mockRepository{
+		listUserMatchesFn: func(_ context.Context, _ uint64, filter models.MatchListFilter) ([]models.Match, error) {
+			got = filter
+			return nil, nil
+		},
+	})
+
+	if _, err := service.ListMyMatches(context.Background(), 1, requests.MyMatchesRequest{Scope: "past", Page: 3, PageSize: 500}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Upcoming || got.Limit != 100 || got.Offset != 200 {
+		t.Fatalf("unexpected filter %+v", got)
+	}
+
+	_, err := service.ListMyMatches(context.Background(), 1, requests.MyMatchesRequest{Scope: "soon"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestLeaveMatch_OrganizerCannotLeave(t *testing.T) {
+	service := newService(scheduledMatchRepo(1, 1, 2))
+
+	err := service.LeaveMatch(context.Background(), 1, 10)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestLeaveMatch_NotParticipant(t *testing.T) {
+	repo := scheduledMatchRepo(1, 1)
+	repo.removeFromMatchFn = func(_ context.Context, _, _ uint64) error {
+		return pgx.ErrNoRows
+	}
+	service := newService(repo)
+
+	err := service.LeaveMatch(context.Background(), 7, 10)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestLeaveMatch_AfterStart(t *testing.T) {
+	repo := scheduledMatchRepo(1, 1, 2)
+	startsAt := time.Now().Add(-10 * time.Minute)
+	repo.getMatchFn = func(_ context.Context, matchID uint64) (models.Match, error) {
+		organizerID := uint64(1)
+		return models.Match{ID: matchID, OrganizerID: &organizerID, Status: models.MatchStatusScheduled, StartsAt: &startsAt}, nil
+	}
+	repo.removeFromMatchFn = func(_ context.Context, _, _ uint64) error {
+		t.Fatal("participant must not leave a match that has already started")
+		return nil
+	}
+	service := newService(repo)
+
+	err := service.LeaveMatch(context.Background(), 2, 10)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestCancelMatch_OnlyOrganizer(t *testing.T) {
+	cancelled := false
+	repo := scheduledMatchRepo(1, 1, 2)
+	repo.cancelMatchFn = func(_ context.Context, _ uint64) error {
+		cancelled = true
+		return nil
+	}
+	service := newService(repo)
+
+	_, err := service.CancelMatch(context.Background(), 2, 10, nil)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+	if cancelled {
+		t.Fatal("participant must not cancel the match")
+	}
+
+	if _, err = service.CancelMatch(context.Background(), 1, 10, nil); err != nil || !cancelled {
+		t.Fatalf("expected organizer to cancel, err=%v", err)
+	}
+}
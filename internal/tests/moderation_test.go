@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func blockedBetween(context.Context, uint64, uint64) (bool, error) {
+	return true, nil
+}
+
+func TestBlockedUser_ProfileAndFriendRequestLookMissing(t *testing.T) {
+	repo := friendRepo(false, false)
+	repo.blockedBetweenFn = blockedBetween
+	repo.createFriendReqFn = func(ctx context.Context, senderID, receiverID uint64) (uint64, error) {
+		t.Fatal("blocked user must not receive a friend request")
+		return 0, nil
+	}
+	service := newService(*repo)
+
+	_, err := service.GetPublicProfile(context.Background(), 1, 2)
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+
+	_, err = service.SendFriendRequest(context.Background(), 1, requests.SendFriendRequestRequest{UserID: 2})
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+
+	err = service.BlockUser(context.Background(), 1, requests.BlockUserRequest{UserID: 1})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestInviteToMatch_SkipsBlockedUsers(t *testing.T) {
+	var invited []uint64
+	repo := scheduledMatchRepo(1, 1)
+	repo.blockedAmongFn = func(_ context.Context, userID uint64, userIDs []uint64) ([]uint64, error) {
+		if userID != 1 {
+			t.Fatalf("expected blocks of the inviter, got %d", userID)
+		}
+		return []uint64{3}, nil
+	}
+	repo.createInvitationsFn = func(_ context.Context, matchID, _ uint64, userIDs []uint64, expiresAt time.Time) ([]models.MatchInvitation, error) {
+		invited = userIDs
+		invitations := make([]models.MatchInvitation, 0, len(userIDs))
+		for _, id := range userIDs {
+			invitations = append(invitations, models.MatchInvitation{MatchID: matchID, UserID: id, Status: models.InvitationStatusPending})
+		}
+		return invitations, nil
+	}
+	service := newService(repo)
+
+	invitations, err := service.InviteToMatch(context.Background(), 1, 10, requests.InviteToMatchRequest{UserIDs: []uint64{2, 3}}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(invited) != 1 || invited[0] != 2 || len(invitations) != 1 {
+		t.Fatalf("expected only user 2 to be invited, got %v", invited)
+	}
+
+	// все приглашённые в блокировке — ответ пустой, без ошибки, раскрывающей блокировку
+	invited = nil
+	invitations, err = service.InviteToMatch(context.Background(), 1, 10, requests.InviteToMatchRequest{UserIDs: []uint64{3}}, nil)
+	if err != nil || len(invitations) != 0 || invited != nil {
+		t.Fatalf("expected no invitations, got %v %v", invitations, err)
+	}
+}
+
+func TestJoinMatch_BlockedParticipantForbidden(t *testing.T) {
+	repo := publicMatchRepo(4, 1)
+	repo.blockedParticipantFn = func(_ context.Context, matchID, userID uint64) (bool, error) {
+		return userID == 5, nil
+	}
+	repo.joinPublicMatchFn = func(_ context.Context, _, _ uint64) error {
+		t.Fatal("blocked user must not join")
+		return nil
+	}
+	service := newService(repo)
+
+	_, err := service.JoinMatch(context.Background(), 5, 10)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestCreateReport_ResolvesReportedUser(t *testing.T) {
+	var created models.Report
+	senderID := uint64(4)
+	repo := mockRepository{
+		reportableMessageFn: func(_ context.Context, messageID, userID uint64) (*uint64, error) {
+			if messageID == 99 {
+				return nil, pgx.ErrNoRows
+			}
+			return &senderID, nil
+		},
+		createReportFn: func(_ context.Context, report models.Report) (uint64, error) {
+			created = report
+			return 3, nil
+		},
+		getReportFn: func(_ context.Context, reportID uint64) (models.Report, error) {
+			created.ID = reportID
+			created.Status = models.ReportOpen
+			return created, nil
+		},
+	}
+	service := newService(repo)
+
+	comment := "  оскорбления в чате "
+	report, err := service.CreateReport(context.Background(), 1, requests.CreateReportRequest{
+		TargetType: "message",
+		TargetID:   7,
+		Reason:     "harassment",
+		Comment:    &comment,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.ID != 3 || report.ReportedUserID == nil || *report.ReportedUserID != 4 ||
+		report.Comment == nil || *report.Comment != "оскорбления в чате" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	// сообщение из чужого чата выглядит несуществующим
+	_, err = service.CreateReport(context.Background(), 1, requests.CreateReportRequest{TargetType: "message", TargetID: 99, Reason: "spam"})
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+
+	_, err = service.CreateReport(context.Background(), 1, requests.CreateReportRequest{TargetType: "user", TargetID: 2, Reason: "other"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	_, err = service.CreateReport(context.Background(), 1, requests.CreateReportRequest{TargetType: "court", TargetID: 2, Reason: "spam"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestResolveReport_AlreadyReviewedIsConflict(t *testing.T) {
+	service := newService(mockRepository{
+		resolveReportFn: func(_ context.Context, _, _ uint64, _ models.ReportStatus, _ *string) error {
+			return pgx.ErrNoRows
+		},
+		getReportFn: func(_ context.Context, reportID uint64) (models.Report, error) {
+			if reportID == 404 {
+				return models.Report{}, pgx.ErrNoRows
+			}
+			return models.Report{ID: reportID, Status: models.ReportDismissed}, nil
+		},
+	})
+
+	_, err := service.ResolveReport(context.Background(), 9, 1, requests.ResolveReportRequest{Decision: "resolve"})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+
+	_, err = service.ResolveReport(context.Background(), 9, 404, requests.ResolveReportRequest{Decision: "dismiss"})
+	expectAppCode(t, err, myerrors.ErrCodeNotFound)
+
+	_, err = service.ResolveReport(context.Background(), 9, 1, requests.ResolveReportRequest{Decision: "ban"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"testing"
+	"time"
+)
+
+// pairResult — матч с игроком other; day — сколько дней назад
+func pairResult(other uint64, sameTeam, won bool, day int) models.PairResult {
+	return models.PairResult{
+		MatchID:     uint64(100 + day),
+		ResultID:    uint64(200 + day),
+		OtherUserID: other,
+		SameTeam:    sameTeam,
+		Won:         won,
+		PlayedAt:    time.Date(2026, 6, 30, 18, 0, 0, 0, time.UTC).AddDate(0, 0, -day),
+	}
+}
+
+func pairStatsRepo(results ...models.PairResult) mockRepository {
+	return mockRepository{
+		pairResultsFn: func(_ context.Context, _ uint64, otherID *uint64) ([]models.PairResult, error) {
+			if otherID == nil {
+				return results, nil
+			}
+			filtered := make([]models.PairResult, 0)
+			for _, r := range results {
+				if r.OtherUserID == *otherID {
+					filtered = append(filtered, r)
+				}
+			}
+			return filtered, nil
+		},
+	}
+}
+
+func TestListPartners_CountsSameSideWithStreak(t *testing.T) {
+	// от новых к старым: с игроком 2 — две победы, затем поражение; с игроком 3 — одно поражение
+	service := newService(pairStatsRepo(
+		pairResult(2, true, true, 1),
+		pairResult(5, false, true, 1),
+		pairResult(2, true, true, 3),
+		pairResult(3, true, false, 4),
+		pairResult(2, true, false, 7),
+	))
+
+	partners, err := service.ListPartners(context.Background(), 1, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(partners) != 2 || partners[0].UserID != 2 {
+		t.Fatalf("expected two partners, the most frequent first, got %+v", partners)
+	}
+	p := partners[0]
+	if p.Matches != 3 || p.Wins != 2 || p.Losses != 1 || p.Streak != 2 {
+		t.Fatalf("unexpected partner stats: %+v", p)
+	}
+	if !p.LastPlayedAt.Equal(pairResult(2, true, true, 1).PlayedAt) {
+		t.Fatalf("expected last played date of the newest match, got %v", p.LastPlayedAt)
+	}
+	if partners[1].Streak != -1 {
+		t.Fatalf("expected a losing streak of one, got %+v", partners[1])
+	}
+}
+
+func TestListPartners_HiddenForOthers(t *testing.T) {
+	repo := pairStatsRepo(pairResult(2, true, true, 1))
+	repo.getVisibilityFn = func(_ context.Context, _ uint64) (models.ProfileVisibility, error) {
+		visibility := models.DefaultProfileVisibility()
+		visibility.ShowPartners = false
+		return visibility, nil
+	}
+	service := newService(repo)
+
+	_, err := service.ListPartners(context.Background(), 9, 1)
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+
+	if _, err = service.ListPartners(context.Background(), 1, 1); err != nil {
+		t.Fatalf("expected the owner to see own partners, got %v", err)
+	}
+}
+
+func TestGetHeadToHead_SplitsAgainstAndTogether(t *testing.T) {
+	repo := pairStatsRepo(
+		pairResult(2, false, false, 1),
+		pairResult(2, false, false, 2),
+		pairResult(2, true, true, 3),
+		pairResult(2, false, true, 5),
+		pairResult(3, false, true, 6),
+	)
+	repo.getVisibilityFn = func(_ context.Context, _ uint64) (models.ProfileVisibility, error) {
+		return models.DefaultProfileVisibility(), nil
+	}
+	service := newService(repo)
+
+	h2h, err := service.GetHeadToHead(context.Background(), 9, 1, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if h2h.Against == nil || h2h.Against.Wins != 1 || h2h.Against.Losses != 2 || h2h.Against.Streak != -2 {
+		t.Fatalf("unexpected record against: %+v", h2h.Against)
+	}
+	if h2h.Together == nil || h2h.Together.Matches != 1 || h2h.Together.Streak != 1 {
+		t.Fatalf("unexpected record together: %+v", h2h.Together)
+	}
+	if len(h2h.Matches) != 4 {
+		t.Fatalf("expected only matches with the opponent, got %d", len(h2h.Matches))
+	}
+
+	_, err = service.GetHeadToHead(context.Background(), 1, 1, 1)
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services"
+	"sport-assistance/pkg/imaging"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/storage"
+	"strings"
+	"testing"
+)
+
+func newPhotoService(t *testing.T, repo mockRepository) (*services.Service, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	blobStorage, err := storage.NewLocalStorage(dir, "http://localhost:8080/api/v1/files", "test-secret")
+	if err != nil {
+		t.Fatalf("failed to create local storage: %v", err)
+	}
+
+	return services.NewService(repo, testLogger(), testConfig(), unavailableRedis(), blobStorage, nil), dir
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadUserPhoto_StoresPhotoAndThumbnail(t *testing.T) {
+	var saved models.UserPhoto
+	service, dir := newPhotoService(t, mockRepository{
+		getUserPhotoFn: func(_ context.Context, _ uint64) (models.UserPhoto, error) {
+			return models.UserPhoto{}, nil
+		},
+		updateUserPhotoFn: func(_ context.Context, userID uint64, photo models.UserPhoto) error {
+			if userID != 7 {
+				t.Fatalf("unexpected user id: %d", userID)
+			}
+			saved = photo
+			return nil
+		},
+	})
+
+	response, err := service.UploadUserPhoto(context.Background(), 7, bytes.NewReader(encodePNG(t, 600, 300)))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if saved.Photo == nil || saved.PhotoThumbnail == nil {
+		t.Fatalf("expected photo and thumbnail keys to be saved, got %+v", saved)
+	}
+	if response.PhotoURL == nil || !strings.Contains(*response.PhotoURL, "signature=") {
+		t.Fatalf("expected signed photo url, got %v", response.PhotoURL)
+	}
+
+	thumb, err := os.ReadFile(filepath.Join(dir, *saved.PhotoThumbnail))
+	if err != nil {
+		t.Fatalf("thumbnail was not written: %v", err)
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatalf("failed to decode thumbnail: %v", err)
+	}
+	if format != "jpeg" || cfg.Width != 256 || cfg.Height != 256 {
+		t.Fatalf("unexpected thumbnail %s %dx%d", format, cfg.Width, cfg.Height)
+	}
+}
+
+func TestUploadUserPhoto_RejectsNonImage(t *testing.T) {
+	service, _ := newPhotoService(t, mockRepository{})
+
+	_, err := service.UploadUserPhoto(context.Background(), 1, strings.NewReader("definitely not an image"))
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestUploadUserPhoto_RejectsTooLarge(t *testing.T) {
+	service, _ := newPhotoService(t, mockRepository{})
+
+	_, err := service.UploadUserPhoto(context.Background(), 1, bytes.NewReader(make([]byte, 2<<20)))
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestApplyOrientation_RotatesClockwise(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.RGBA{R: 255, A: 255})
+	src.Set(1, 0, color.RGBA{B: 255, A: 255})
+
+	rotated := imaging.ApplyOrientation(src, 6)
+	if rotated.Bounds().Dx() != 1 || rotated.Bounds().Dy() != 2 {
+		t.Fatalf("expected 1x2 image, got %v", rotated.Bounds())
+	}
+	if r, _, _, _ := rotated.At(0, 0).RGBA(); r == 0 {
+		t.Fatalf("expected red pixel on top after rotation")
+	}
+}
+
+func TestOpenFile_RejectsTamperedSignature(t *testing.T) {
+	service, _ := newPhotoService(t, mockRepository{})
+
+	_, _, err := service.OpenFile(context.Background(), "users/1/photo/a.jpg", 1<<40, "bad")
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeForbidden {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"testing"
+)
+
+func playerProfileRepo(visibility models.ProfileVisibility) mockRepository {
+	town := "Москва"
+	townID := 1
+	position := models.CourtPositionLeft
+
+	return mockRepository{
+		getPlayerCardFn: func(_ context.Context, userID uint64) (models.PlayerCard, error) {
+			return models.PlayerCard{
+				ID:                userID,
+				Name:              "Иван",
+				Surname:           "Петров",
+				TownID:            &townID,
+				TownName:          &town,
+				CourtPosition:     &position,
+				TrainingDistricts: []string{"Хамовники"},
+			}, nil
+		},
+		getPlayerStatsFn: func(_ context.Context, _ uint64) (models.PlayerStats, error) {
+			return models.PlayerStats{MatchesPlayed: 12, FriendsCount: 3, PartnersCount: 5}, nil
+		},
+		getVisibilityFn: func(_ context.Context, _ uint64) (models.ProfileVisibility, error) {
+			return visibility, nil
+		},
+	}
+}
+
+func TestGetPublicProfile_HidesFieldsForOthers(t *testing.T) {
+	visibility := models.DefaultProfileVisibility()
+	visibility.ShowTrainingDistrict = false
+	visibility.ShowFriends = false
+	service := newService(playerProfileRepo(visibility))
+
+	profile, err := service.GetPublicProfile(context.Background(), 2, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if profile.TrainingDistricts != nil || profile.FriendsCount != nil {
+		t.Fatalf("expected hidden fields to be omitted, got %+v", profile)
+	}
+	if profile.MatchesPlayed == nil || *profile.MatchesPlayed != 12 {
+		t.Fatalf("expected matches played to be visible, got %v", profile.MatchesPlayed)
+	}
+	if profile.Town == nil || profile.Town.Name != "Москва" {
+		t.Fatalf("expected town to be visible, got %v", profile.Town)
+	}
+}
+
+func TestGetPublicProfile_OwnerSeesEverything(t *testing.T) {
+	visibility := models.ProfileVisibility{}
+	service := newService(playerProfileRepo(visibility))
+
+	profile, err := service.GetPublicProfile(context.Background(), 1, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if profile.TrainingDistricts == nil || profile.FriendsCount == nil || profile.CourtPosition == nil {
+		t.Fatalf("expected owner to see all fields, got %+v", profile)
+	}
+}
+
+func TestUpdateProfileVisibility_RefreshesLeaderboardOnRatingOrTownChange(t *testing.T) {
+	hidden, shown := false, true
+	cases := []struct {
+		name string
+		req  requests.UpdateProfileVisibilityRequest
+		want int
+	}{
+		{"town hidden", requests.UpdateProfileVisibilityRequest{ShowTown: &hidden}, 1},
+		{"rating hidden", requests.UpdateProfileVisibilityRequest{ShowRating: &hidden}, 1},
+		{"unchanged rating", requests.UpdateProfileVisibilityRequest{ShowRating: &shown}, 0},
+		{"partners hidden", requests.UpdateProfileVisibilityRequest{ShowPartners: &hidden}, 0},
+	}
+
+	for _, tc := range cases {
+		refreshed := 0
+		repo := playerProfileRepo(models.DefaultProfileVisibility())
+		repo.upsertVisibilityFn = func(_ context.Context, _ uint64, _ models.ProfileVisibility) error {
+			return nil
+		}
+		repo.refreshLeaderboardFn = func(_ context.Context) error {
+			refreshed++
+			return nil
+		}
+		service := newService(repo)
+
+		if _, err := service.UpdateProfileVisibility(context.Background(), 1, tc.req); err != nil {
+			t.Fatalf("%s: expected no error, got %v", tc.name, err)
+		}
+		if refreshed != tc.want {
+			t.Fatalf("%s: expected %d leaderboard refreshes, got %d", tc.name, tc.want, refreshed)
+		}
+	}
+}
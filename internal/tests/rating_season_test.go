@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/rating"
+	"testing"
+	"time"
+)
+
+func TestMatchup_IgnoresTeamOrder(t *testing.T) {
+	a := rating.NewMatchup([]uint64{3, 1}, []uint64{2, 4}, 1)
+	b := rating.NewMatchup([]uint64{4, 2}, []uint64{1, 3}, 2)
+
+	if a != b {
+		t.Fatalf("expected the same matchup regardless of team numbers, got %+v and %+v", a, b)
+	}
+}
+
+func TestElo_RepeatedMatchupsWeighLess(t *testing.T) {
+	elo := testElo()
+
+	if w := elo.RepeatWeight(0); w != 1 {
+		t.Fatalf("first meeting must count fully, got %v", w)
+	}
+	if w := elo.RepeatWeight(2); w != 0.25 {
+		t.Fatalf("expected 0.5^2 for the third meeting, got %v", w)
+	}
+
+	changes := elo.RateWeighted(
+		[]rating.Player{{UserID: 1, Rating: 1500, Matches: 20}},
+		[]rating.Player{{UserID: 2, Rating: 1500, Matches: 20}},
+		1, elo.RepeatWeight(1),
+	)
+	if changes[0].After != 1506 || changes[1].After != 1494 {
+		t.Fatalf("expected half of ±12 for a repeated meeting, got %+v", changes)
+	}
+}
+
+func TestElo_SuspiciousNeedsSameWinnerEveryTime(t *testing.T) {
+	elo := testElo()
+	current := rating.NewMatchup([]uint64{1}, []uint64{2}, 1)
+	sameWinner := []rating.Matchup{current, current, current}
+
+	if !elo.Suspicious(sameWinner, current) {
+		t.Fatal("expected the fourth win in a row of the same side to be suspicious")
+	}
+	if elo.Suspicious(sameWinner[:2], current) {
+		t.Fatal("three meetings are below RATING_FLAG_REPEATS")
+	}
+	mixed := append(sameWinner[:2:2], rating.NewMatchup([]uint64{1}, []uint64{2}, 2))
+	if elo.Suspicious(mixed, current) {
+		t.Fatal("a lost meeting must clear the suspicion")
+	}
+}
+
+func TestElo_SoftResetAndDecayKeepInitialRating(t *testing.T) {
+	elo := testElo()
+
+	if r := elo.SoftReset(1700, 0.5); r != 1600 {
+		t.Fatalf("expected half-way reset to 1600, got %v", r)
+	}
+	if r := elo.SoftReset(1300, 0.5); r != 1400 {
+		t.Fatalf("expected low ratings to move up to 1400, got %v", r)
+	}
+	if r := elo.Decayed(1510, 15); r != 1500 {
+		t.Fatalf("decay must stop at the initial rating, got %v", r)
+	}
+	if r := elo.Decayed(1400, 15); r != 1400 {
+		t.Fatalf("ratings below the initial one must not decay, got %v", r)
+	}
+}
+
+func TestStartRatingSeason_ValidatesAndDefaultsResetFactor(t *testing.T) {
+	var started models.RatingSeason
+	svc := newService(&mockRepository{
+		startSeasonFn: func(ctx context.Context, season models.RatingSeason, engine rating.Elo) (uint64, error) {
+			started = season
+			return 7, nil
+		},
+		getSeasonFn: func(ctx context.Context, seasonID uint64) (models.RatingSeason, error) {
+			return models.RatingSeason{ID: seasonID, SportID: started.SportID, Name: started.Name}, nil
+		},
+	})
+
+	invalid := []requests.StartRatingSeasonRequest{
+		{Name: "Spring"},
+		{SportID: 1, Name: "   "},
+		{SportID: 1, Name: "Spring", ResetFactor: floatPtr(1.5)},
+	}
+	for _, req := range invalid {
+		_, err := svc.StartRatingSeason(context.Background(), 1, req)
+		expectAppCode(t, err, myerrors.ErrCodeValidation)
+	}
+
+	season, err := svc.StartRatingSeason(context.Background(), 1, requests.StartRatingSeasonRequest{SportID: 1, Name: " Spring "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if season.ID != 7 || started.Name != "Spring" || started.ResetFactor != 0.5 || started.StartedBy == nil || *started.StartedBy != 1 {
+		t.Fatalf("expected trimmed name and default reset factor, got %+v", started)
+	}
+}
+
+func TestResolveRatingFlag_VoidRecalculatesRatings(t *testing.T) {
+	recalculated := 0
+	svc := newService(&mockRepository{
+		resolveRatingFlagFn: func(ctx context.Context, flagID, reviewerID uint64, status models.RatingFlagStatus, comment *string) error {
+			if status != models.RatingFlagVoided {
+				t.Fatalf("expected voided status, got %s", status)
+			}
+			return nil
+		},
+		recalculateFn: func(ctx context.Context, engine rating.Elo) (int, error) {
+			recalculated++
+			return 10, nil
+		},
+		getRatingFlagFn: func(ctx context.Context, flagID uint64) (models.RatingFlag, error) {
+			return models.RatingFlag{ID: flagID, Status: models.RatingFlagVoided}, nil
+		},
+	})
+
+	flag, err := svc.ResolveRatingFlag(context.Background(), 1, 3, requests.ResolveRatingFlagRequest{Decision: "void"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recalculated != 1 || flag.Status != models.RatingFlagVoided {
+		t.Fatalf("expected one recalculation and a voided flag, got %d and %+v", recalculated, flag)
+	}
+
+	_, err = svc.ResolveRatingFlag(context.Background(), 1, 3, requests.ResolveRatingFlagRequest{Decision: "ban"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestDecayRatings_PassesConfiguredPolicy(t *testing.T) {
+	var got rating.Decay
+	svc := newService(&mockRepository{
+		decayRatingsFn: func(ctx context.Context, engine rating.Elo, decay rating.Decay, now time.Time) (int, error) {
+			got = decay
+			return 3, nil
+		},
+	})
+
+	decayed, err := svc.DecayRatings(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := rating.Decay{After: 60 * 24 * time.Hour, Every: 7 * 24 * time.Hour, Points: 15}
+	if decayed != 3 || got != want {
+		t.Fatalf("expected %+v, got %+v (decayed %d)", want, got, decayed)
+	}
+}
@@ -0,0 +1,211 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/rating"
+	"testing"
+)
+
+func testElo() rating.Elo {
+	return rating.NewElo(testConfig().RatingConfig)
+}
+
+func TestElo_EqualPlayersSinglesIsZeroSum(t *testing.T) {
+	changes := testElo().Rate(
+		[]rating.Player{{UserID: 1, Rating: 1500, Matches: 20}},
+		[]rating.Player{{UserID: 2, Rating: 1500, Matches: 20}},
+		1,
+	)
+
+	if len(changes) != 2 || changes[0].After != 1512 || changes[1].After != 1488 {
+		t.Fatalf("expected ±12 with K=24, got %+v", changes)
+	}
+}
+
+func TestElo_ProvisionalPlayerMovesFaster(t *testing.T) {
+	changes := testElo().Rate(
+		[]rating.Player{{UserID: 1, Rating: 1500, Matches: 0}},
+		[]rating.Player{{UserID: 2, Rating: 1500, Matches: 20}},
+		1,
+	)
+
+	if changes[0].After != 1520 || changes[1].After != 1488 {
+		t.Fatalf("expected +20 for a newcomer and -12 for a regular, got %+v", changes)
+	}
+}
+
+func TestElo_DoublesUsesTeamAverage(t *testing.T) {
+	// средний рейтинг обеих пар — 1500, значит изменения как у равных соперников
+	changes := testElo().Rate(
+		[]rating.Player{{UserID: 1, Rating: 1700, Matches: 20}, {UserID: 2, Rating: 1300, Matches: 20}},
+		[]rating.Player{{UserID: 3, Rating: 1500, Matches: 20}, {UserID: 4, Rating: 1500, Matches: 20}},
+		2,
+	)
+
+	want := map[uint64]float64{1: 1688, 2: 1288, 3: 1512, 4: 1512}
+	for _, c := range changes {
+		if c.After != want[c.UserID] {
+			t.Fatalf("player %d: expected %v, got %v", c.UserID, want[c.UserID], c.After)
+		}
+	}
+}
+
+func TestElo_UpsetGainsMore(t *testing.T) {
+	changes := testElo().Rate(
+		[]rating.Player{{UserID: 1, Rating: 1300, Matches: 20}},
+		[]rating.Player{{UserID: 2, Rating: 1700, Matches: 20}},
+		1,
+	)
+
+	if gain := changes[0].After - changes[0].Before; gain <= 12 {
+		t.Fatalf("expected the underdog to gain more than half of K, got %v", gain)
+	}
+}
+
+func TestConfirmMatchResult_PassesRatingFormula(t *testing.T) {
+	repo, _ := playedMatchRepo(models.SportTennis)
+	var got rating.Elo
+	confirm := repo.confirmResultFn
+	repo.confirmResultFn = func(ctx context.Context, resultID, userID uint64, engine rating.Elo) error {
+		got = engine
+		return confirm(ctx, resultID, userID, engine)
+	}
+	service := newService(repo)
+
+	result, err := service.SubmitMatchResult(context.Background(), 1, 10, doublesResult(
+		models.SetScore{Team1: 6, Team2: 2},
+		models.SetScore{Team1: 6, Team2: 2},
+	))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err = service.ConfirmMatchResult(context.Background(), 4, result.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got != testElo() {
+		t.Fatalf("expected formula from RATING_* config, got %+v", got)
+	}
+}
+
+func TestConfirmMatchResult_RefreshesLeaderboard(t *testing.T) {
+	repo, _ := playedMatchRepo(models.SportTennis)
+	refreshed := 0
+	repo.refreshLeaderboardFn = func(_ context.Context) error {
+		refreshed++
+		return errors.New("refresh failed")
+	}
+	service := newService(repo)
+
+	result, err := service.SubmitMatchResult(context.Background(), 1, 10, doublesResult(
+		models.SetScore{Team1: 6, Team2: 2},
+		models.SetScore{Team1: 6, Team2: 2},
+	))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// сбой пересчёта мест не отменяет подтверждённый результат
+	if _, err = service.ConfirmMatchResult(context.Background(), 4, result.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if refreshed != 1 {
+		t.Fatalf("expected leaderboard to be refreshed once, got %d", refreshed)
+	}
+}
+
+func TestGetMyRatings_ClampsHistoryLimit(t *testing.T) {
+	var gotLimit int
+	service := newService(mockRepository{
+		getRatingsFn: func(_ context.Context, _ uint64) ([]models.PlayerRating, error) {
+			return []models.PlayerRating{{UserID: 1, SportID: 1, Rating: 1512}}, nil
+		},
+		ratingHistoryFn: func(_ context.Context, _ uint64, _ *int, limit int) ([]models.RatingChange, error) {
+			gotLimit = limit
+			return []models.RatingChange{}, nil
+		},
+	})
+
+	resp, err := service.GetMyRatings(context.Background(), 1, requests.RatingHistoryRequest{Limit: 10000})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotLimit != 200 || len(resp.Ratings) != 1 {
+		t.Fatalf("expected limit 200 and one rating, got %d and %+v", gotLimit, resp)
+	}
+}
+
+func TestGetLeaderboard_RequiresSportAndKnownScope(t *testing.T) {
+	service := newService(mockRepository{})
+
+	_, err := service.GetLeaderboard(context.Background(), 1, requests.LeaderboardRequest{})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+
+	_, err = service.GetLeaderboard(context.Background(), 1, requests.LeaderboardRequest{SportID: 1, Scope: "town"})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestGetLeaderboard_PagesAndKeepsOwnRank(t *testing.T) {
+	var got models.LeaderboardFilter
+	me := models.LeaderboardEntry{Rank: 120, UserID: 7, Rating: 1490}
+	service := newService(mockRepository{
+		getLeaderboardFn: func(_ context.Context, filter models.LeaderboardFilter) (models.Leaderboard, error) {
+			got = filter
+			return models.Leaderboard{
+				Entries: []models.LeaderboardEntry{
+					{Rank: 21, UserID: 3, Rating: 1600},
+					{Rank: 21, UserID: 4, Rating: 1600},
+				},
+				Me:    &me,
+				Total: 300,
+			}, nil
+		},
+	})
+
+	resp, err := service.GetLeaderboard(context.Background(), 7, requests.LeaderboardRequest{
+		SportID:  1,
+		TownID:   intPtr(5),
+		Scope:    "friends",
+		Page:     2,
+		PageSize: 1000,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got.Limit != 100 || got.Offset != 100 || got.Scope != models.LeaderboardScopeFriends || got.ViewerID != 7 || *got.TownID != 5 {
+		t.Fatalf("unexpected filter: %+v", got)
+	}
+	if got.Around != nil {
+		t.Fatal("expected a page, not a window around the player")
+	}
+	if resp.Me == nil || resp.Me.Rank != 120 || resp.Total != 300 || resp.PageSize != 100 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestGetNearbyPlayers_CentersOnInitialRatingWithClampedRadius(t *testing.T) {
+	var got models.LeaderboardFilter
+	service := newService(mockRepository{
+		getLeaderboardFn: func(_ context.Context, filter models.LeaderboardFilter) (models.Leaderboard, error) {
+			got = filter
+			return models.Leaderboard{Entries: []models.LeaderboardEntry{}}, nil
+		},
+	})
+
+	resp, err := service.GetNearbyPlayers(context.Background(), 7, requests.NearbyPlayersRequest{SportID: 1, Radius: 500})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got.Around == nil || *got.Around != 1500 || got.Radius != 25 || got.Scope != models.LeaderboardScopeAll {
+		t.Fatalf("unexpected filter: %+v", got)
+	}
+	if resp.Me != nil {
+		t.Fatal("expected no own entry for an unrated player")
+	}
+}
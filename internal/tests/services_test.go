@@ -3,32 +3,142 @@ package tests
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"sport-assistance/internal/handlers/requests"
 	"sport-assistance/internal/models"
 	"sport-assistance/internal/services"
+	"sport-assistance/internal/services/dto"
 	"sport-assistance/pkg/commons"
 	"sport-assistance/pkg/configs"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/rating"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var errNotImplemented = errors.New("not implemented")
 
+// mockRepository встраивает IRepository: методы без заданной функции паникуют,
+// поэтому тесту достаточно описать только те вызовы, которые он ожидает.
 type mockRepository struct {
+	services.IRepository
+
 	createUserFn         func(ctx context.Context, user models.User) (uint64, error)
-	getUserByIDFn        func(ctx context.Context, userID uint64) (models.User, error)
-	getUserByEmailFn     func(ctx context.Context, email string) (models.User, error)
+	getUserByIDFn        func(ctx context.Context, userID uint64) (dto.UserDto, error)
+	getUserByPhoneFn     func(ctx context.Context, phone string) (dto.UserDto, error)
+	getCredentialsFn     func(ctx context.Context, email string) (models.UserCredentials, error)
 	userExistsByEmailFn  func(ctx context.Context, email string) (bool, error)
 	rotateRefreshTokenFn func(ctx context.Context, userID uint64, oldRefreshToken, newRefreshToken string, newExpiresAt time.Time) error
 	createRefreshTokenFn func(ctx context.Context, userID uint64, refreshToken string, expiresAt time.Time) error
 	getRefreshTokenFn    func(ctx context.Context, refreshToken string) (models.RefreshTokenResponse, error)
 	revokeRefreshTokenFn func(ctx context.Context, refreshToken string) error
+	searchTownsFn        func(ctx context.Context, query string, limit int) ([]models.Town, error)
+	getUserPhotoFn       func(ctx context.Context, userID uint64) (models.UserPhoto, error)
+	updateUserPhotoFn    func(ctx context.Context, userID uint64, photo models.UserPhoto) error
+	contactExistsFn      func(ctx context.Context, contactType models.ContactType, value string, excludeUserID uint64) (bool, error)
+	getPlayerCardFn      func(ctx context.Context, userID uint64) (models.PlayerCard, error)
+	getPlayerStatsFn     func(ctx context.Context, userID uint64) (models.PlayerStats, error)
+	getVisibilityFn      func(ctx context.Context, userID uint64) (models.ProfileVisibility, error)
+	requestDeletionFn    func(ctx context.Context, userID uint64) (time.Time, error)
+	cancelDeletionFn     func(ctx context.Context, userID uint64) error
+	pendingAnonymizeFn   func(ctx context.Context, requestedBefore time.Time, limit int) ([]uint64, error)
+	anonymizeUserFn      func(ctx context.Context, userID uint64) error
+	getUserMessagesFn    func(ctx context.Context, userID uint64) ([]models.ChatMessage, error)
+	getUserMatchesFn     func(ctx context.Context, userID uint64) ([]models.Match, error)
+	listUsersFn          func(ctx context.Context, filter models.UserFilter) ([]dto.UserDto, error)
+	countUsersFn         func(ctx context.Context, filter models.UserFilter) (int, error)
+	streamUsersFn        func(ctx context.Context, filter models.UserFilter, fn func(user dto.UserDto) error) error
+	getSubscriptionFn    func(ctx context.Context, subscriptionID int) (models.Subscription, error)
+	activeSubscriptionFn func(ctx context.Context, userID uint64) (models.UserSubscription, error)
+	activateSubFn        func(ctx context.Context, userID uint64, subscriptionID int, endsAt time.Time) (models.UserSubscription, error)
+	expireSubsFn         func(ctx context.Context, now time.Time, limit int) ([]uint64, error)
+	updateProfileFn      func(ctx context.Context, userID uint64, profile models.ExtendedProfile) error
+	createMatchFn        func(ctx context.Context, match models.Match, inviteeIDs []uint64, invitationExpiresAt time.Time) (uint64, error)
+	getMatchFn           func(ctx context.Context, matchID uint64) (models.Match, error)
+	listUserMatchesFn    func(ctx context.Context, userID uint64, filter models.MatchListFilter) ([]models.Match, error)
+	getParticipantsFn    func(ctx context.Context, matchID uint64) ([]uint64, error)
+	removeFromMatchFn    func(ctx context.Context, matchID, userID uint64) error
+	cancelMatchFn        func(ctx context.Context, matchID uint64) error
+	isUserInMatchFn      func(ctx context.Context, matchID, userID uint64) (bool, error)
+	createInvitationsFn  func(ctx context.Context, matchID, inviterID uint64, userIDs []uint64, expiresAt time.Time) ([]models.MatchInvitation, error)
+	getInvitationFn      func(ctx context.Context, invitationID uint64) (models.MatchInvitation, error)
+	hasInvitationFn      func(ctx context.Context, matchID, userID uint64) (bool, error)
+	acceptInvitationFn   func(ctx context.Context, invitationID uint64) error
+	closeInvitationFn    func(ctx context.Context, invitationID uint64, status models.InvitationStatus) error
+	createInviteLinkFn   func(ctx context.Context, link models.MatchInviteLink) (models.MatchInviteLink, error)
+	getInviteLinkFn      func(ctx context.Context, linkID uint64) (models.MatchInviteLink, error)
+	redeemInviteLinkFn   func(ctx context.Context, linkID, userID uint64, invitationExpiresAt time.Time) (models.MatchInvitation, error)
+	listPublicMatchesFn  func(ctx context.Context, filter models.PublicMatchFilter) ([]models.Match, error)
+	joinPublicMatchFn    func(ctx context.Context, matchID, userID uint64) error
+	courtObjectFn        func(ctx context.Context, courtID int) (int, error)
+	scheduleConflictsFn  func(ctx context.Context, userID uint64, startsAt, endsAt time.Time, excludeMatchID uint64) ([]models.ScheduleConflict, error)
+	createResultFn       func(ctx context.Context, result models.MatchResult, sportID int) (uint64, error)
+	getResultFn          func(ctx context.Context, resultID uint64) (models.MatchResult, error)
+	confirmResultFn      func(ctx context.Context, resultID, userID uint64, engine rating.Elo) error
+	disputeResultFn      func(ctx context.Context, resultID, userID uint64, reason string) error
+	resolveResultFn      func(ctx context.Context, resultID, resolverID uint64, confirm bool, comment *string, engine rating.Elo) error
+	getSportNameFn       func(ctx context.Context, sportID int) (string, error)
+	getRatingsFn         func(ctx context.Context, userID uint64) ([]models.PlayerRating, error)
+	ratingHistoryFn      func(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingChange, error)
+	getLeaderboardFn     func(ctx context.Context, filter models.LeaderboardFilter) (models.Leaderboard, error)
+	suggestionsFn        func(ctx context.Context, query models.SuggestionQuery) ([]models.SuggestionCandidate, error)
+	pairResultsFn        func(ctx context.Context, userID uint64, otherID *uint64) ([]models.PairResult, error)
+	getLineupFn          func(ctx context.Context, matchID uint64) ([]models.LineupPlayer, error)
+	setLineupFn          func(ctx context.Context, matchID uint64, assignments []models.LineupAssignment) error
+	createTournamentFn   func(ctx context.Context, t models.Tournament) (uint64, error)
+	getTournamentFn      func(ctx context.Context, tournamentID uint64) (models.Tournament, error)
+	addEntryFn           func(ctx context.Context, tournamentID uint64, playerIDs []uint64) (uint64, error)
+	listEntriesFn        func(ctx context.Context, tournamentID uint64) ([]models.TournamentEntry, error)
+	startTournamentFn    func(ctx context.Context, tournamentID uint64, plan models.TournamentPlan) error
+	getFixtureFn         func(ctx context.Context, fixtureID uint64) (models.TournamentFixture, error)
+	matchFixtureFn       func(ctx context.Context, matchID uint64) (models.TournamentFixture, error)
+	scheduleSwissFn      func(ctx context.Context, tournamentID uint64, round int, fixtures []models.TournamentFixture) error
+	listFixturesFn       func(ctx context.Context, tournamentID uint64) ([]models.TournamentFixture, error)
+	createChallengeFn    func(ctx context.Context, tournamentID, challengerID, defenderID uint64, startsAt, endsAt time.Time) (uint64, error)
+	sportRatingsFn       func(ctx context.Context, sportID int, userIDs []uint64) (map[uint64]float64, error)
+	markAttendanceFn     func(ctx context.Context, matchID, userID uint64, status models.AttendanceStatus, reportedBy *uint64) (models.AttendanceMark, error)
+	reportNoShowFn       func(ctx context.Context, matchID, userID, reporterID uint64, required int) (models.NoShowReport, error)
+	reliabilityFn        func(ctx context.Context, userIDs []uint64) (map[uint64]models.Reliability, error)
+	adjustmentsFn        func(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingAdjustment, error)
+	recalculateFn        func(ctx context.Context, engine rating.Elo) (int, error)
+	decayRatingsFn       func(ctx context.Context, engine rating.Elo, decay rating.Decay, now time.Time) (int, error)
+	startSeasonFn        func(ctx context.Context, season models.RatingSeason, engine rating.Elo) (uint64, error)
+	getSeasonFn          func(ctx context.Context, seasonID uint64) (models.RatingSeason, error)
+	getRatingFlagFn      func(ctx context.Context, flagID uint64) (models.RatingFlag, error)
+	resolveRatingFlagFn  func(ctx context.Context, flagID, reviewerID uint64, status models.RatingFlagStatus, comment *string) error
+	isGuestFn            func(ctx context.Context, userID uint64) (bool, error)
+	playedTogetherFn     func(ctx context.Context, userID, otherID uint64) (bool, error)
+	areFriendsFn         func(ctx context.Context, userID, otherID uint64) (bool, error)
+	mutualFriendsFn      func(ctx context.Context, userID, otherID uint64) (int, error)
+	createFriendReqFn    func(ctx context.Context, senderID, receiverID uint64) (uint64, error)
+	getFriendReqFn       func(ctx context.Context, requestID uint64) (models.FriendRequest, error)
+	pendingFriendReqFn   func(ctx context.Context, userID, otherID uint64) (models.FriendRequest, error)
+	acceptFriendReqFn    func(ctx context.Context, requestID uint64) error
+	blockedBetweenFn     func(ctx context.Context, userID, otherID uint64) (bool, error)
+	blockedAmongFn       func(ctx context.Context, userID uint64, userIDs []uint64) ([]uint64, error)
+	blockedParticipantFn func(ctx context.Context, matchID, userID uint64) (bool, error)
+	blockUserFn          func(ctx context.Context, blockerID, blockedID uint64) error
+	createReportFn       func(ctx context.Context, report models.Report) (uint64, error)
+	getReportFn          func(ctx context.Context, reportID uint64) (models.Report, error)
+	resolveReportFn      func(ctx context.Context, reportID, reviewerID uint64, status models.ReportStatus, resolution *string) error
+	reportableMessageFn  func(ctx context.Context, messageID, userID uint64) (*uint64, error)
+	getActivityFn        func(ctx context.Context, activityID int) (models.Activity, error)
+	createActivityReqFn  func(ctx context.Context, request models.ActivityRequest) (uint64, error)
+	getActivityReqFn     func(ctx context.Context, requestID uint64) (models.ActivityRequest, error)
+	confirmActivityReqFn func(ctx context.Context, requestID, reviewerID uint64, comment *string) error
+	cancelActivityReqFn  func(ctx context.Context, requestID uint64) error
+	scheduleEventsFn     func(ctx context.Context, userID uint64, from, to time.Time) ([]models.ScheduleEvent, error)
+	blockAccountFn       func(ctx context.Context, userID uint64) error
+	unblockAccountFn     func(ctx context.Context, userID uint64) error
+	refreshLeaderboardFn func(ctx context.Context) error
+	upsertVisibilityFn   func(ctx context.Context, userID uint64, visibility models.ProfileVisibility) error
 }
 
 func (m mockRepository) CreateUser(ctx context.Context, user models.User) (uint64, error) {
@@ -38,18 +148,25 @@ func (m mockRepository) CreateUser(ctx context.Context, user models.User) (uint6
 	return m.createUserFn(ctx, user)
 }
 
-func (m mockRepository) GetUserByID(ctx context.Context, userID uint64) (models.User, error) {
+func (m mockRepository) GetUserByID(ctx context.Context, userID uint64) (dto.UserDto, error) {
 	if m.getUserByIDFn == nil {
-		return models.User{}, errNotImplemented
+		return dto.UserDto{}, errNotImplemented
 	}
 	return m.getUserByIDFn(ctx, userID)
 }
 
-func (m mockRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
-	if m.getUserByEmailFn == nil {
-		return models.User{}, errNotImplemented
+func (m mockRepository) GetUserByPhone(ctx context.Context, phone string) (dto.UserDto, error) {
+	if m.getUserByPhoneFn == nil {
+		return dto.UserDto{}, errNotImplemented
+	}
+	return m.getUserByPhoneFn(ctx, phone)
+}
+
+func (m mockRepository) GetUserCredentialsByEmail(ctx context.Context, email string) (models.UserCredentials, error) {
+	if m.getCredentialsFn == nil {
+		return models.UserCredentials{}, errNotImplemented
 	}
-	return m.getUserByEmailFn(ctx, email)
+	return m.getCredentialsFn(ctx, email)
 }
 
 func (m mockRepository) UserExistsByEmail(ctx context.Context, email string) (bool, error) {
@@ -87,6 +204,716 @@ func (m mockRepository) RevokeRefreshToken(ctx context.Context, refreshToken str
 	return m.revokeRefreshTokenFn(ctx, refreshToken)
 }
 
+func (m mockRepository) SearchTowns(ctx context.Context, query string, limit int) ([]models.Town, error) {
+	if m.searchTownsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.searchTownsFn(ctx, query, limit)
+}
+
+func (m mockRepository) GetUserPhoto(ctx context.Context, userID uint64) (models.UserPhoto, error) {
+	if m.getUserPhotoFn == nil {
+		return models.UserPhoto{}, errNotImplemented
+	}
+	return m.getUserPhotoFn(ctx, userID)
+}
+
+func (m mockRepository) UpdateUserPhoto(ctx context.Context, userID uint64, photo models.UserPhoto) error {
+	if m.updateUserPhotoFn == nil {
+		return errNotImplemented
+	}
+	return m.updateUserPhotoFn(ctx, userID, photo)
+}
+
+func (m mockRepository) ContactExists(ctx context.Context, contactType models.ContactType, value string, excludeUserID uint64) (bool, error) {
+	if m.contactExistsFn == nil {
+		return false, errNotImplemented
+	}
+	return m.contactExistsFn(ctx, contactType, value, excludeUserID)
+}
+
+func (m mockRepository) GetPlayerCard(ctx context.Context, userID uint64) (models.PlayerCard, error) {
+	if m.getPlayerCardFn == nil {
+		return models.PlayerCard{}, errNotImplemented
+	}
+	return m.getPlayerCardFn(ctx, userID)
+}
+
+func (m mockRepository) GetPlayerStats(ctx context.Context, userID uint64) (models.PlayerStats, error) {
+	if m.getPlayerStatsFn == nil {
+		return models.PlayerStats{}, errNotImplemented
+	}
+	return m.getPlayerStatsFn(ctx, userID)
+}
+
+func (m mockRepository) GetProfileVisibility(ctx context.Context, userID uint64) (models.ProfileVisibility, error) {
+	if m.getVisibilityFn == nil {
+		return models.ProfileVisibility{}, errNotImplemented
+	}
+	return m.getVisibilityFn(ctx, userID)
+}
+
+func (m mockRepository) UpsertProfileVisibility(ctx context.Context, userID uint64, visibility models.ProfileVisibility) error {
+	if m.upsertVisibilityFn == nil {
+		return errNotImplemented
+	}
+	return m.upsertVisibilityFn(ctx, userID, visibility)
+}
+
+func (m mockRepository) RequestUserDeletion(ctx context.Context, userID uint64) (time.Time, error) {
+	if m.requestDeletionFn == nil {
+		return time.Time{}, errNotImplemented
+	}
+	return m.requestDeletionFn(ctx, userID)
+}
+
+func (m mockRepository) CancelUserDeletion(ctx context.Context, userID uint64) error {
+	if m.cancelDeletionFn == nil {
+		return errNotImplemented
+	}
+	return m.cancelDeletionFn(ctx, userID)
+}
+
+func (m mockRepository) GetUsersPendingAnonymization(ctx context.Context, requestedBefore time.Time, limit int) ([]uint64, error) {
+	if m.pendingAnonymizeFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.pendingAnonymizeFn(ctx, requestedBefore, limit)
+}
+
+func (m mockRepository) AnonymizeUser(ctx context.Context, userID uint64) error {
+	if m.anonymizeUserFn == nil {
+		return errNotImplemented
+	}
+	return m.anonymizeUserFn(ctx, userID)
+}
+
+func (m mockRepository) GetUserMessages(ctx context.Context, userID uint64) ([]models.ChatMessage, error) {
+	if m.getUserMessagesFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.getUserMessagesFn(ctx, userID)
+}
+
+func (m mockRepository) GetMatchesByUserID(ctx context.Context, userID uint64) ([]models.Match, error) {
+	if m.getUserMatchesFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.getUserMatchesFn(ctx, userID)
+}
+
+func (m mockRepository) ListUsers(ctx context.Context, filter models.UserFilter) ([]dto.UserDto, error) {
+	if m.listUsersFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.listUsersFn(ctx, filter)
+}
+
+func (m mockRepository) CountUsers(ctx context.Context, filter models.UserFilter) (int, error) {
+	if m.countUsersFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.countUsersFn(ctx, filter)
+}
+
+func (m mockRepository) StreamUsers(ctx context.Context, filter models.UserFilter, fn func(user dto.UserDto) error) error {
+	if m.streamUsersFn == nil {
+		return errNotImplemented
+	}
+	return m.streamUsersFn(ctx, filter, fn)
+}
+
+func (m mockRepository) GetSubscriptionByID(ctx context.Context, subscriptionID int) (models.Subscription, error) {
+	if m.getSubscriptionFn == nil {
+		return models.Subscription{}, errNotImplemented
+	}
+	return m.getSubscriptionFn(ctx, subscriptionID)
+}
+
+func (m mockRepository) GetActiveUserSubscription(ctx context.Context, userID uint64) (models.UserSubscription, error) {
+	if m.activeSubscriptionFn == nil {
+		return models.UserSubscription{}, errNotImplemented
+	}
+	return m.activeSubscriptionFn(ctx, userID)
+}
+
+func (m mockRepository) ActivateUserSubscription(ctx context.Context, userID uint64, subscriptionID int, endsAt time.Time) (models.UserSubscription, error) {
+	if m.activateSubFn == nil {
+		return models.UserSubscription{}, errNotImplemented
+	}
+	return m.activateSubFn(ctx, userID, subscriptionID, endsAt)
+}
+
+func (m mockRepository) UpdateExtendedProfile(ctx context.Context, userID uint64, profile models.ExtendedProfile) error {
+	if m.updateProfileFn == nil {
+		return errNotImplemented
+	}
+	return m.updateProfileFn(ctx, userID, profile)
+}
+
+func (m mockRepository) ExpireUserSubscriptions(ctx context.Context, now time.Time, limit int) ([]uint64, error) {
+	if m.expireSubsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.expireSubsFn(ctx, now, limit)
+}
+
+func (m mockRepository) CreateMatch(ctx context.Context, match models.Match, inviteeIDs []uint64, invitationExpiresAt time.Time) (uint64, error) {
+	if m.createMatchFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.createMatchFn(ctx, match, inviteeIDs, invitationExpiresAt)
+}
+
+func (m mockRepository) GetMatchByID(ctx context.Context, matchID uint64) (models.Match, error) {
+	if m.getMatchFn == nil {
+		return models.Match{}, errNotImplemented
+	}
+	return m.getMatchFn(ctx, matchID)
+}
+
+func (m mockRepository) ListUserMatches(ctx context.Context, userID uint64, filter models.MatchListFilter) ([]models.Match, error) {
+	if m.listUserMatchesFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.listUserMatchesFn(ctx, userID, filter)
+}
+
+func (m mockRepository) GetMatchParticipants(ctx context.Context, matchID uint64) ([]uint64, error) {
+	if m.getParticipantsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.getParticipantsFn(ctx, matchID)
+}
+
+func (m mockRepository) RemoveUserFromMatch(ctx context.Context, matchID, userID uint64) error {
+	if m.removeFromMatchFn == nil {
+		return errNotImplemented
+	}
+	return m.removeFromMatchFn(ctx, matchID, userID)
+}
+
+func (m mockRepository) CancelMatch(ctx context.Context, matchID uint64) error {
+	if m.cancelMatchFn == nil {
+		return errNotImplemented
+	}
+	return m.cancelMatchFn(ctx, matchID)
+}
+
+func (m mockRepository) CreateMatchInvitations(ctx context.Context, matchID, inviterID uint64, userIDs []uint64, expiresAt time.Time) ([]models.MatchInvitation, error) {
+	if m.createInvitationsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.createInvitationsFn(ctx, matchID, inviterID, userIDs, expiresAt)
+}
+
+func (m mockRepository) GetMatchInvitation(ctx context.Context, invitationID uint64) (models.MatchInvitation, error) {
+	if m.getInvitationFn == nil {
+		return models.MatchInvitation{}, errNotImplemented
+	}
+	return m.getInvitationFn(ctx, invitationID)
+}
+
+func (m mockRepository) HasPendingMatchInvitation(ctx context.Context, matchID, userID uint64) (bool, error) {
+	if m.hasInvitationFn == nil {
+		return false, errNotImplemented
+	}
+	return m.hasInvitationFn(ctx, matchID, userID)
+}
+
+func (m mockRepository) AcceptMatchInvitation(ctx context.Context, invitationID uint64) error {
+	if m.acceptInvitationFn == nil {
+		return errNotImplemented
+	}
+	return m.acceptInvitationFn(ctx, invitationID)
+}
+
+func (m mockRepository) CloseMatchInvitation(ctx context.Context, invitationID uint64, status models.InvitationStatus) error {
+	if m.closeInvitationFn == nil {
+		return errNotImplemented
+	}
+	return m.closeInvitationFn(ctx, invitationID, status)
+}
+
+func (m mockRepository) IsUserInMatch(ctx context.Context, matchID, userID uint64) (bool, error) {
+	if m.isUserInMatchFn == nil {
+		return false, errNotImplemented
+	}
+	return m.isUserInMatchFn(ctx, matchID, userID)
+}
+
+func (m mockRepository) CreateMatchInviteLink(ctx context.Context, link models.MatchInviteLink) (models.MatchInviteLink, error) {
+	if m.createInviteLinkFn == nil {
+		return models.MatchInviteLink{}, errNotImplemented
+	}
+	return m.createInviteLinkFn(ctx, link)
+}
+
+func (m mockRepository) GetMatchInviteLink(ctx context.Context, linkID uint64) (models.MatchInviteLink, error) {
+	if m.getInviteLinkFn == nil {
+		return models.MatchInviteLink{}, errNotImplemented
+	}
+	return m.getInviteLinkFn(ctx, linkID)
+}
+
+func (m mockRepository) RedeemMatchInviteLink(ctx context.Context, linkID, userID uint64, invitationExpiresAt time.Time) (models.MatchInvitation, error) {
+	if m.redeemInviteLinkFn == nil {
+		return models.MatchInvitation{}, errNotImplemented
+	}
+	return m.redeemInviteLinkFn(ctx, linkID, userID, invitationExpiresAt)
+}
+
+func (m mockRepository) ListPublicMatches(ctx context.Context, filter models.PublicMatchFilter) ([]models.Match, error) {
+	if m.listPublicMatchesFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.listPublicMatchesFn(ctx, filter)
+}
+
+func (m mockRepository) JoinPublicMatch(ctx context.Context, matchID, userID uint64) error {
+	if m.joinPublicMatchFn == nil {
+		return errNotImplemented
+	}
+	return m.joinPublicMatchFn(ctx, matchID, userID)
+}
+
+func (m mockRepository) GetCourtSportObjectID(ctx context.Context, courtID int) (int, error) {
+	if m.courtObjectFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.courtObjectFn(ctx, courtID)
+}
+
+// FindScheduleConflicts по умолчанию отвечает, что расписание свободно
+func (m mockRepository) FindScheduleConflicts(ctx context.Context, userID uint64, startsAt, endsAt time.Time, excludeMatchID uint64) ([]models.ScheduleConflict, error) {
+	if m.scheduleConflictsFn == nil {
+		return nil, nil
+	}
+	return m.scheduleConflictsFn(ctx, userID, startsAt, endsAt, excludeMatchID)
+}
+
+func (m mockRepository) CreateMatchResult(ctx context.Context, result models.MatchResult, sportID int) (uint64, error) {
+	if m.createResultFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.createResultFn(ctx, result, sportID)
+}
+
+func (m mockRepository) GetMatchResult(ctx context.Context, resultID uint64) (models.MatchResult, error) {
+	if m.getResultFn == nil {
+		return models.MatchResult{}, errNotImplemented
+	}
+	return m.getResultFn(ctx, resultID)
+}
+
+func (m mockRepository) ConfirmMatchResult(ctx context.Context, resultID, userID uint64, engine rating.Elo) error {
+	if m.confirmResultFn == nil {
+		return errNotImplemented
+	}
+	return m.confirmResultFn(ctx, resultID, userID, engine)
+}
+
+func (m mockRepository) DisputeMatchResult(ctx context.Context, resultID, userID uint64, reason string) error {
+	if m.disputeResultFn == nil {
+		return errNotImplemented
+	}
+	return m.disputeResultFn(ctx, resultID, userID, reason)
+}
+
+func (m mockRepository) ResolveMatchResult(ctx context.Context, resultID, resolverID uint64, confirm bool, comment *string, engine rating.Elo) error {
+	if m.resolveResultFn == nil {
+		return errNotImplemented
+	}
+	return m.resolveResultFn(ctx, resultID, resolverID, confirm, comment, engine)
+}
+
+func (m mockRepository) GetSportName(ctx context.Context, sportID int) (string, error) {
+	if m.getSportNameFn == nil {
+		return "", errNotImplemented
+	}
+	return m.getSportNameFn(ctx, sportID)
+}
+
+func (m mockRepository) GetPlayerRatings(ctx context.Context, userID uint64) ([]models.PlayerRating, error) {
+	if m.getRatingsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.getRatingsFn(ctx, userID)
+}
+
+func (m mockRepository) ListRatingHistory(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingChange, error) {
+	if m.ratingHistoryFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.ratingHistoryFn(ctx, userID, sportID, limit)
+}
+
+func (m mockRepository) GetLeaderboard(ctx context.Context, filter models.LeaderboardFilter) (models.Leaderboard, error) {
+	if m.getLeaderboardFn == nil {
+		return models.Leaderboard{}, errNotImplemented
+	}
+	return m.getLeaderboardFn(ctx, filter)
+}
+
+func (m mockRepository) ListSuggestionCandidates(ctx context.Context, query models.SuggestionQuery) ([]models.SuggestionCandidate, error) {
+	if m.suggestionsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.suggestionsFn(ctx, query)
+}
+
+func (m mockRepository) ListPairResults(ctx context.Context, userID uint64, otherID *uint64) ([]models.PairResult, error) {
+	if m.pairResultsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.pairResultsFn(ctx, userID, otherID)
+}
+
+// GetMatchLineup по умолчанию — состав не распределён
+func (m mockRepository) GetMatchLineup(ctx context.Context, matchID uint64) ([]models.LineupPlayer, error) {
+	if m.getLineupFn == nil {
+		return nil, nil
+	}
+	return m.getLineupFn(ctx, matchID)
+}
+
+func (m mockRepository) SetMatchLineup(ctx context.Context, matchID uint64, assignments []models.LineupAssignment) error {
+	if m.setLineupFn == nil {
+		return errNotImplemented
+	}
+	return m.setLineupFn(ctx, matchID, assignments)
+}
+
+func (m mockRepository) CreateTournament(ctx context.Context, t models.Tournament) (uint64, error) {
+	if m.createTournamentFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.createTournamentFn(ctx, t)
+}
+
+func (m mockRepository) GetTournament(ctx context.Context, tournamentID uint64) (models.Tournament, error) {
+	if m.getTournamentFn == nil {
+		return models.Tournament{}, errNotImplemented
+	}
+	return m.getTournamentFn(ctx, tournamentID)
+}
+
+func (m mockRepository) AddTournamentEntry(ctx context.Context, tournamentID uint64, playerIDs []uint64) (uint64, error) {
+	if m.addEntryFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.addEntryFn(ctx, tournamentID, playerIDs)
+}
+
+func (m mockRepository) ListTournamentEntries(ctx context.Context, tournamentID uint64) ([]models.TournamentEntry, error) {
+	if m.listEntriesFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.listEntriesFn(ctx, tournamentID)
+}
+
+func (m mockRepository) StartTournament(ctx context.Context, tournamentID uint64, plan models.TournamentPlan) error {
+	if m.startTournamentFn == nil {
+		return errNotImplemented
+	}
+	return m.startTournamentFn(ctx, tournamentID, plan)
+}
+
+func (m mockRepository) GetTournamentFixture(ctx context.Context, fixtureID uint64) (models.TournamentFixture, error) {
+	if m.getFixtureFn == nil {
+		return models.TournamentFixture{}, errNotImplemented
+	}
+	return m.getFixtureFn(ctx, fixtureID)
+}
+
+// GetMatchFixture по умолчанию — матч вне турнира
+func (m mockRepository) GetMatchFixture(ctx context.Context, matchID uint64) (models.TournamentFixture, error) {
+	if m.matchFixtureFn == nil {
+		return models.TournamentFixture{}, pgx.ErrNoRows
+	}
+	return m.matchFixtureFn(ctx, matchID)
+}
+
+func (m mockRepository) ScheduleSwissRound(ctx context.Context, tournamentID uint64, round int, fixtures []models.TournamentFixture) error {
+	if m.scheduleSwissFn == nil {
+		return errNotImplemented
+	}
+	return m.scheduleSwissFn(ctx, tournamentID, round, fixtures)
+}
+
+func (m mockRepository) ListTournamentFixtures(ctx context.Context, tournamentID uint64) ([]models.TournamentFixture, error) {
+	if m.listFixturesFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.listFixturesFn(ctx, tournamentID)
+}
+
+func (m mockRepository) CreateLadderChallenge(ctx context.Context, tournamentID, challengerID, defenderID uint64, startsAt, endsAt time.Time) (uint64, error) {
+	if m.createChallengeFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.createChallengeFn(ctx, tournamentID, challengerID, defenderID, startsAt, endsAt)
+}
+
+func (m mockRepository) GetSportRatings(ctx context.Context, sportID int, userIDs []uint64) (map[uint64]float64, error) {
+	if m.sportRatingsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.sportRatingsFn(ctx, sportID, userIDs)
+}
+
+func (m mockRepository) MarkAttendance(ctx context.Context, matchID, userID uint64, status models.AttendanceStatus, reportedBy *uint64) (models.AttendanceMark, error) {
+	if m.markAttendanceFn == nil {
+		return models.AttendanceMark{}, errNotImplemented
+	}
+	return m.markAttendanceFn(ctx, matchID, userID, status, reportedBy)
+}
+
+func (m mockRepository) ReportNoShow(ctx context.Context, matchID, userID, reporterID uint64, required int) (models.NoShowReport, error) {
+	if m.reportNoShowFn == nil {
+		return models.NoShowReport{}, errNotImplemented
+	}
+	return m.reportNoShowFn(ctx, matchID, userID, reporterID, required)
+}
+
+// GetReliabilityStats по умолчанию — отметок ни у кого нет
+func (m mockRepository) GetReliabilityStats(ctx context.Context, userIDs []uint64) (map[uint64]models.Reliability, error) {
+	if m.reliabilityFn == nil {
+		return nil, nil
+	}
+	return m.reliabilityFn(ctx, userIDs)
+}
+
+// ListRatingAdjustments по умолчанию — сбросов и снижений не было
+func (m mockRepository) ListRatingAdjustments(ctx context.Context, userID uint64, sportID *int, limit int) ([]models.RatingAdjustment, error) {
+	if m.adjustmentsFn == nil {
+		return nil, nil
+	}
+	return m.adjustmentsFn(ctx, userID, sportID, limit)
+}
+
+func (m mockRepository) RecalculateRatings(ctx context.Context, engine rating.Elo) (int, error) {
+	if m.recalculateFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.recalculateFn(ctx, engine)
+}
+
+func (m mockRepository) DecayRatings(ctx context.Context, engine rating.Elo, decay rating.Decay, now time.Time) (int, error) {
+	if m.decayRatingsFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.decayRatingsFn(ctx, engine, decay, now)
+}
+
+func (m mockRepository) StartRatingSeason(ctx context.Context, season models.RatingSeason, engine rating.Elo) (uint64, error) {
+	if m.startSeasonFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.startSeasonFn(ctx, season, engine)
+}
+
+func (m mockRepository) GetRatingSeason(ctx context.Context, seasonID uint64) (models.RatingSeason, error) {
+	if m.getSeasonFn == nil {
+		return models.RatingSeason{}, errNotImplemented
+	}
+	return m.getSeasonFn(ctx, seasonID)
+}
+
+func (m mockRepository) GetRatingFlag(ctx context.Context, flagID uint64) (models.RatingFlag, error) {
+	if m.getRatingFlagFn == nil {
+		return models.RatingFlag{}, errNotImplemented
+	}
+	return m.getRatingFlagFn(ctx, flagID)
+}
+
+func (m mockRepository) ResolveRatingFlag(ctx context.Context, flagID, reviewerID uint64, status models.RatingFlagStatus, comment *string) error {
+	if m.resolveRatingFlagFn == nil {
+		return errNotImplemented
+	}
+	return m.resolveRatingFlagFn(ctx, flagID, reviewerID, status, comment)
+}
+
+func (m mockRepository) IsGuest(ctx context.Context, userID uint64) (bool, error) {
+	if m.isGuestFn == nil {
+		return false, errNotImplemented
+	}
+	return m.isGuestFn(ctx, userID)
+}
+
+func (m mockRepository) HavePlayedTogether(ctx context.Context, userID, otherID uint64) (bool, error) {
+	if m.playedTogetherFn == nil {
+		return false, errNotImplemented
+	}
+	return m.playedTogetherFn(ctx, userID, otherID)
+}
+
+func (m mockRepository) AreFriends(ctx context.Context, userID, otherID uint64) (bool, error) {
+	if m.areFriendsFn == nil {
+		return false, errNotImplemented
+	}
+	return m.areFriendsFn(ctx, userID, otherID)
+}
+
+// CountMutualFriends по умолчанию — общих друзей нет
+func (m mockRepository) CountMutualFriends(ctx context.Context, userID, otherID uint64) (int, error) {
+	if m.mutualFriendsFn == nil {
+		return 0, nil
+	}
+	return m.mutualFriendsFn(ctx, userID, otherID)
+}
+
+func (m mockRepository) CreateFriendRequest(ctx context.Context, senderID, receiverID uint64) (uint64, error) {
+	if m.createFriendReqFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.createFriendReqFn(ctx, senderID, receiverID)
+}
+
+func (m mockRepository) GetFriendRequest(ctx context.Context, requestID uint64) (models.FriendRequest, error) {
+	if m.getFriendReqFn == nil {
+		return models.FriendRequest{}, errNotImplemented
+	}
+	return m.getFriendReqFn(ctx, requestID)
+}
+
+func (m mockRepository) GetPendingFriendRequest(ctx context.Context, userID, otherID uint64) (models.FriendRequest, error) {
+	if m.pendingFriendReqFn == nil {
+		return models.FriendRequest{}, errNotImplemented
+	}
+	return m.pendingFriendReqFn(ctx, userID, otherID)
+}
+
+func (m mockRepository) AcceptFriendRequest(ctx context.Context, requestID uint64) error {
+	if m.acceptFriendReqFn == nil {
+		return errNotImplemented
+	}
+	return m.acceptFriendReqFn(ctx, requestID)
+}
+
+// IsBlockedBetween по умолчанию — блокировок нет
+func (m mockRepository) IsBlockedBetween(ctx context.Context, userID, otherID uint64) (bool, error) {
+	if m.blockedBetweenFn == nil {
+		return false, nil
+	}
+	return m.blockedBetweenFn(ctx, userID, otherID)
+}
+
+// BlockedAmong по умолчанию — блокировок нет
+func (m mockRepository) BlockedAmong(ctx context.Context, userID uint64, userIDs []uint64) ([]uint64, error) {
+	if m.blockedAmongFn == nil {
+		return nil, nil
+	}
+	return m.blockedAmongFn(ctx, userID, userIDs)
+}
+
+// HasBlockedParticipant по умолчанию — блокировок нет
+func (m mockRepository) HasBlockedParticipant(ctx context.Context, matchID, userID uint64) (bool, error) {
+	if m.blockedParticipantFn == nil {
+		return false, nil
+	}
+	return m.blockedParticipantFn(ctx, matchID, userID)
+}
+
+func (m mockRepository) BlockUser(ctx context.Context, blockerID, blockedID uint64) error {
+	if m.blockUserFn == nil {
+		return errNotImplemented
+	}
+	return m.blockUserFn(ctx, blockerID, blockedID)
+}
+
+func (m mockRepository) CreateReport(ctx context.Context, report models.Report) (uint64, error) {
+	if m.createReportFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.createReportFn(ctx, report)
+}
+
+func (m mockRepository) GetReport(ctx context.Context, reportID uint64) (models.Report, error) {
+	if m.getReportFn == nil {
+		return models.Report{}, errNotImplemented
+	}
+	return m.getReportFn(ctx, reportID)
+}
+
+func (m mockRepository) ResolveReport(ctx context.Context, reportID, reviewerID uint64, status models.ReportStatus, resolution *string) error {
+	if m.resolveReportFn == nil {
+		return errNotImplemented
+	}
+	return m.resolveReportFn(ctx, reportID, reviewerID, status, resolution)
+}
+
+func (m mockRepository) GetReportableMessage(ctx context.Context, messageID, userID uint64) (*uint64, error) {
+	if m.reportableMessageFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.reportableMessageFn(ctx, messageID, userID)
+}
+
+func (m mockRepository) GetActivity(ctx context.Context, activityID int) (models.Activity, error) {
+	if m.getActivityFn == nil {
+		return models.Activity{}, errNotImplemented
+	}
+	return m.getActivityFn(ctx, activityID)
+}
+
+func (m mockRepository) CreateActivityRequest(ctx context.Context, request models.ActivityRequest) (uint64, error) {
+	if m.createActivityReqFn == nil {
+		return 0, errNotImplemented
+	}
+	return m.createActivityReqFn(ctx, request)
+}
+
+func (m mockRepository) GetActivityRequest(ctx context.Context, requestID uint64) (models.ActivityRequest, error) {
+	if m.getActivityReqFn == nil {
+		return models.ActivityRequest{}, errNotImplemented
+	}
+	return m.getActivityReqFn(ctx, requestID)
+}
+
+func (m mockRepository) ConfirmActivityRequest(ctx context.Context, requestID, reviewerID uint64, comment *string) error {
+	if m.confirmActivityReqFn == nil {
+		return errNotImplemented
+	}
+	return m.confirmActivityReqFn(ctx, requestID, reviewerID, comment)
+}
+
+func (m mockRepository) CancelActivityRequest(ctx context.Context, requestID uint64) error {
+	if m.cancelActivityReqFn == nil {
+		return errNotImplemented
+	}
+	return m.cancelActivityReqFn(ctx, requestID)
+}
+
+func (m mockRepository) ListScheduleEvents(ctx context.Context, userID uint64, from, to time.Time) ([]models.ScheduleEvent, error) {
+	if m.scheduleEventsFn == nil {
+		return nil, errNotImplemented
+	}
+	return m.scheduleEventsFn(ctx, userID, from, to)
+}
+
+func (m mockRepository) BlockUserAccount(ctx context.Context, userID uint64) error {
+	if m.blockAccountFn == nil {
+		return errNotImplemented
+	}
+	return m.blockAccountFn(ctx, userID)
+}
+
+// RefreshLeaderboard по умолчанию ничего не делает: пересчёт мест — побочный эффект
+func (m mockRepository) RefreshLeaderboard(ctx context.Context) error {
+	if m.refreshLeaderboardFn == nil {
+		return nil
+	}
+	return m.refreshLeaderboardFn(ctx)
+}
+
+func (m mockRepository) UnblockUserAccount(ctx context.Context, userID uint64) error {
+	if m.unblockAccountFn == nil {
+		return errNotImplemented
+	}
+	return m.unblockAccountFn(ctx, userID)
+}
+
 func testConfig() *configs.Config {
 	return &configs.Config{
 		DatabaseConfig: configs.DatabaseConfig{DBDateFormat: "02-01-2006"},
@@ -97,6 +924,41 @@ func testConfig() *configs.Config {
 			RefreshTokenSecret:     "refresh-secret",
 			AccessTokenRedisPrefix: "auth:access_token:%d",
 		},
+		StorageConfig: configs.StorageConfig{
+			SignedURLTTL: time.Hour,
+			MaxPhotoSize: 1 << 20,
+		},
+		AccountConfig: configs.AccountConfig{
+			DeletionGracePeriod: 30 * 24 * time.Hour,
+			PurgeBatchSize:      100,
+		},
+		SubscriptionConfig: configs.SubscriptionConfig{
+			Period:          30 * 24 * time.Hour,
+			ExpiryBatchSize: 100,
+		},
+		MatchConfig: configs.MatchConfig{
+			InvitationTTL:         72 * time.Hour,
+			InviteLinkSecret:      "invite-link-secret",
+			InviteLinkTTL:         7 * 24 * time.Hour,
+			InviteLinkBaseURL:     "https://app.example/invite/",
+			InviteLinkRedisPrefix: "match:invite_link:%s",
+
+			LateCancellationWindow: 24 * time.Hour,
+			RankedMinReliability:   70,
+		},
+		RatingConfig: configs.RatingConfig{
+			InitialRating:      1500,
+			KFactor:            24,
+			ProvisionalKFactor: 40,
+			ProvisionalMatches: 10,
+			SeasonResetFactor:  0.5,
+			DecayAfter:         60 * 24 * time.Hour,
+			DecayInterval:      7 * 24 * time.Hour,
+			DecayPoints:        15,
+			RepeatWindow:       30 * 24 * time.Hour,
+			RepeatFactor:       0.5,
+			FlagRepeats:        4,
+		},
 	}
 }
 
@@ -115,8 +977,49 @@ func unavailableRedis() *redis.Client {
 	})
 }
 
+// memoryRedisHook отвечает на GET и DEL из памяти, не обращаясь к серверу
+type memoryRedisHook map[string]string
+
+func (h memoryRedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h memoryRedisHook) ProcessHook(_ redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		key := fmt.Sprint(cmd.Args()[1])
+		switch c := cmd.(type) {
+		case *redis.StringCmd:
+			value, ok := h[key]
+			if !ok {
+				c.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			c.SetVal(value)
+		case *redis.IntCmd:
+			delete(h, key)
+			c.SetVal(1)
+		default:
+			return errNotImplemented
+		}
+		return nil
+	}
+}
+
+func (h memoryRedisHook) ProcessPipelineHook(_ redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(_ context.Context, _ []redis.Cmder) error {
+		return errNotImplemented
+	}
+}
+
+// memoryRedis — клиент с заданными значениями ключей для сценариев, где сервис читает Redis
+func memoryRedis(values map[string]string) *redis.Client {
+	client := unavailableRedis()
+	client.AddHook(memoryRedisHook(values))
+	return client
+}
+
 func newService(repo services.IRepository) *services.Service {
-	return services.NewService(repo, testLogger(), testConfig(), unavailableRedis())
+	return services.NewService(repo, testLogger(), testConfig(), unavailableRedis(), nil, nil)
 }
 
 func signRefreshToken(t *testing.T, cfg *configs.Config, userID uint64) string {
@@ -201,13 +1104,14 @@ func TestRegister_CreateUserError(t *testing.T) {
 
 func TestLogin_UserNotFound(t *testing.T) {
 	service := newService(mockRepository{
-		getUserByEmailFn: func(_ context.Context, _ string) (models.User, error) {
-			return models.User{}, errors.New("no rows")
+		getCredentialsFn: func(_ context.Context, _ string) (models.UserCredentials, error) {
+			return models.UserCredentials{}, errors.New("no rows")
 		},
 	})
 
 	_, err := service.Login(context.Background(), requests.LoginRequest{Email: "user@example.com", Password: "pass"})
-	if err == nil || err.Error() != "this user does not exist" {
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Message != myerrors.UserDoesNotExistErrorMessage {
 		t.Fatalf("expected not exists error, got %v", err)
 	}
 }
@@ -219,8 +1123,8 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	}
 
 	service := newService(mockRepository{
-		getUserByEmailFn: func(_ context.Context, _ string) (models.User, error) {
-			return models.User{ID: 10, Email: "user@example.com", Password: string(hash)}, nil
+		getCredentialsFn: func(_ context.Context, _ string) (models.UserCredentials, error) {
+			return models.UserCredentials{ID: 10, Email: "user@example.com", PasswordHash: string(hash)}, nil
 		},
 	})
 
@@ -230,6 +1134,42 @@ func TestLogin_InvalidPassword(t *testing.T) {
 	}
 }
 
+func TestLogin_BlockedAccount(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct-password"), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("failed to generate hash: %v", err)
+	}
+
+	blockedAt := time.Now()
+	service := newService(mockRepository{
+		getCredentialsFn: func(_ context.Context, _ string) (models.UserCredentials, error) {
+			return models.UserCredentials{ID: 10, Email: "user@example.com", PasswordHash: string(hash), BlockedAt: &blockedAt}, nil
+		},
+	})
+
+	_, err = service.Login(context.Background(), requests.LoginRequest{Email: "user@example.com", Password: "correct-password"})
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+}
+
+func TestConfirmOTP_BlockedAccount(t *testing.T) {
+	cfg := testConfig()
+	cfg.SecurityConfig.OtpRedisPrefix = "auth:otp:code:%s"
+	repo := mockRepository{
+		getUserByPhoneFn: func(_ context.Context, _ string) (dto.UserDto, error) {
+			blockedAt := time.Now()
+			return dto.UserDto{ID: 10, Email: "user@example.com", BlockedAt: &blockedAt}, nil
+		},
+	}
+	redisClient := memoryRedis(map[string]string{"auth:otp:code:+79991234567": "1234"})
+	service := services.NewService(repo, testLogger(), cfg, redisClient, nil, nil)
+
+	resp, err := service.ConfirmOTP(context.Background(), "+79991234567", "1234", "")
+	expectAppCode(t, err, myerrors.ErrCodeForbidden)
+	if resp.AccessToken != "" || resp.RefreshToken != "" {
+		t.Fatalf("expected no tokens for a blocked account, got %+v", resp)
+	}
+}
+
 func TestLogout_EmptyRefreshToken(t *testing.T) {
 	service := newService(mockRepository{})
 
@@ -241,7 +1181,7 @@ func TestLogout_EmptyRefreshToken(t *testing.T) {
 
 func TestLogout_TokenBelongsToAnotherUser(t *testing.T) {
 	cfg := testConfig()
-	service := services.NewService(mockRepository{}, testLogger(), cfg, unavailableRedis())
+	service := services.NewService(mockRepository{}, testLogger(), cfg, unavailableRedis(), nil, nil)
 	refreshToken := signRefreshToken(t, cfg, 1)
 
 	_, err := service.Logout(context.Background(), requests.LogoutRequest{UserID: 2, RefreshToken: refreshToken})
@@ -281,7 +1221,7 @@ func TestRefreshTokens_RevokedToken(t *testing.T) {
 				RevokedAt: &revokedAt,
 			}, nil
 		},
-	}, testLogger(), cfg, unavailableRedis())
+	}, testLogger(), cfg, unavailableRedis(), nil, nil)
 
 	_, err := service.RefreshTokens(context.Background(), requests.RefreshTokensRequest{RefreshToken: token})
 	if err == nil || err.Error() != "refresh token is revoked" {
@@ -299,3 +1239,35 @@ func TestIsTokenExpired(t *testing.T) {
 		t.Fatalf("expected future token to be active")
 	}
 }
+
+func TestSearchTowns_EmptyQuery(t *testing.T) {
+	service := newService(mockRepository{})
+
+	_, err := service.SearchTowns(context.Background(), "   ", 10)
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestSearchTowns_NormalizesQueryAndLimit(t *testing.T) {
+	service := newService(mockRepository{
+		searchTownsFn: func(_ context.Context, query string, limit int) ([]models.Town, error) {
+			if query != "Нижний Новгород" {
+				t.Fatalf("unexpected query: %q", query)
+			}
+			if limit != 50 {
+				t.Fatalf("expected limit to be clamped to 50, got %d", limit)
+			}
+			return []models.Town{{ID: 1, Name: "Нижний Новгород"}}, nil
+		},
+	})
+
+	towns, err := service.SearchTowns(context.Background(), "  Нижний   Новгород ", 500)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(towns) != 1 {
+		t.Fatalf("expected 1 town, got %d", len(towns))
+	}
+}
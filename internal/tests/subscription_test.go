@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func guestRepo() mockRepository {
+	height := 180
+
+	return mockRepository{
+		getUserByIDFn: func(_ context.Context, userID uint64) (dto.UserDto, error) {
+			return dto.UserDto{ID: userID, Email: "guest@example.com", HeightCm: &height}, nil
+		},
+		activeSubscriptionFn: func(_ context.Context, _ uint64) (models.UserSubscription, error) {
+			return models.UserSubscription{}, pgx.ErrNoRows
+		},
+	}
+}
+
+func TestGetUpgradeStatus_ListsMissingFields(t *testing.T) {
+	service := newService(guestRepo())
+
+	status, err := service.GetUpgradeStatus(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if status.ProfileComplete || status.Subscription != nil {
+		t.Fatalf("expected incomplete profile without subscription, got %+v", status)
+	}
+	want := "weight_kg,sport_activity_level_id,sport_target_id,town_id"
+	if got := strings.Join(status.MissingFields, ","); got != want {
+		t.Fatalf("expected missing %s, got %s", want, got)
+	}
+}
+
+func TestPurchaseSubscription_RequiresExtendedProfile(t *testing.T) {
+	repo := guestRepo()
+	repo.activateSubFn = func(_ context.Context, _ uint64, _ int, _ time.Time) (models.UserSubscription, error) {
+		t.Fatal("subscription must not be activated for incomplete profile")
+		return models.UserSubscription{}, nil
+	}
+	service := newService(repo)
+
+	_, err := service.PurchaseSubscription(context.Background(), 1, requests.PurchaseSubscriptionRequest{SubscriptionID: 1, RefreshToken: "token"})
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestPurchaseSubscription_RejectsForeignOrRevokedRefreshToken(t *testing.T) {
+	value := 1
+	revokedAt := time.Now()
+	tokens := map[string]models.RefreshTokenResponse{
+		"foreign": {UserID: 2, ExpiresAt: time.Now().Add(time.Hour)},
+		"revoked": {UserID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+	}
+
+	repo := mockRepository{
+		getUserByIDFn: func(_ context.Context, userID uint64) (dto.UserDto, error) {
+			return dto.UserDto{
+				ID:                   userID,
+				HeightCm:             &value,
+				WeightKg:             &value,
+				SportActivityLevelID: &value,
+				SportTargetID:        &value,
+				TownID:               &value,
+			}, nil
+		},
+		getRefreshTokenFn: func(_ context.Context, token string) (models.RefreshTokenResponse, error) {
+			return tokens[token], nil
+		},
+		activateSubFn: func(_ context.Context, _ uint64, _ int, _ time.Time) (models.UserSubscription, error) {
+			t.Fatal("subscription must not be activated with an invalid refresh token")
+			return models.UserSubscription{}, nil
+		},
+	}
+	service := newService(repo)
+
+	for token := range tokens {
+		_, err := service.PurchaseSubscription(context.Background(), 1, requests.PurchaseSubscriptionRequest{SubscriptionID: 1, RefreshToken: token})
+		expectAppCode(t, err, myerrors.ErrCodeUnauthorized)
+	}
+}
+
+func TestAdminActivateSubscription_UnknownTier(t *testing.T) {
+	service := newService(mockRepository{
+		getSubscriptionFn: func(_ context.Context, _ int) (models.Subscription, error) {
+			return models.Subscription{}, pgx.ErrNoRows
+		},
+	})
+
+	_, err := service.AdminActivateSubscription(context.Background(), 1, requests.AdminActivateSubscriptionRequest{SubscriptionID: 99})
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestAdminActivateSubscription_UsesRequestedDays(t *testing.T) {
+	var endsAt time.Time
+	service := newService(mockRepository{
+		getSubscriptionFn: func(_ context.Context, id int) (models.Subscription, error) {
+			return models.Subscription{ID: id, Name: "Sport Pro"}, nil
+		},
+		activateSubFn: func(_ context.Context, userID uint64, subscriptionID int, end time.Time) (models.UserSubscription, error) {
+			endsAt = end
+			return models.UserSubscription{UserID: userID, SubscriptionID: subscriptionID, EndsAt: end}, nil
+		},
+	})
+
+	_, err := service.AdminActivateSubscription(context.Background(), 1, requests.AdminActivateSubscriptionRequest{SubscriptionID: 2, Days: 7})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if left := time.Until(endsAt); left < 7*24*time.Hour-time.Minute || left > 7*24*time.Hour {
+		t.Fatalf("expected subscription for 7 days, ends at %v", endsAt)
+	}
+}
+
+func TestExpireSubscriptions_ReturnsDowngradedCount(t *testing.T) {
+	service := newService(mockRepository{
+		expireSubsFn: func(_ context.Context, _ time.Time, limit int) ([]uint64, error) {
+			if limit != 100 {
+				t.Fatalf("expected batch size 100, got %d", limit)
+			}
+			return []uint64{3, 4}, nil
+		},
+	})
+
+	expired, err := service.ExpireSubscriptions(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expired != 2 {
+		t.Fatalf("expected 2 expired subscriptions, got %d", expired)
+	}
+}
+
+func TestUpdateExtendedProfile_RejectsImplausibleHeight(t *testing.T) {
+	service := newService(mockRepository{})
+	height := 20
+
+	_, err := service.UpdateExtendedProfile(context.Background(), 1, requests.ExtendedProfileRequest{HeightCm: &height})
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestUpdateExtendedProfile_PartialUpdateKeepsOtherFields(t *testing.T) {
+	height, weight, townID := 180, 75, 3
+	description := "knee"
+	stored := dto.UserDto{ID: 1, HeightCm: &height, TownID: &townID, IsHaveInjury: true, InjuryDescription: &description}
+
+	repo := mockRepository{
+		getUserByIDFn: func(_ context.Context, _ uint64) (dto.UserDto, error) {
+			return stored, nil
+		},
+		updateProfileFn: func(_ context.Context, _ uint64, profile models.ExtendedProfile) error {
+			if profile.HeightCm != nil || profile.TownID != nil || profile.IsHaveInjury != nil || profile.InjuryDescription != nil {
+				t.Fatalf("omitted fields must stay nil to keep stored values, got %+v", profile)
+			}
+			stored.WeightKg = profile.WeightKg
+			return nil
+		},
+		refreshLeaderboardFn: func(_ context.Context) error {
+			t.Fatal("leaderboard must not be refreshed when town is not changed")
+			return nil
+		},
+	}
+	service := newService(repo)
+
+	user, err := service.UpdateExtendedProfile(context.Background(), 1, requests.ExtendedProfileRequest{WeightKg: &weight})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.WeightKg == nil || *user.WeightKg != weight || user.HeightCm == nil || *user.HeightCm != height ||
+		user.TownID == nil || *user.TownID != townID || !user.IsHaveInjury {
+		t.Fatalf("expected only weight to change, got %+v", user)
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"testing"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func reasonKinds(s models.PlayerSuggestion) []string {
+	kinds := make([]string, 0, len(s.Reasons))
+	for _, r := range s.Reasons {
+		kinds = append(kinds, r.Kind)
+	}
+	return kinds
+}
+
+func TestSuggestPlayers_RanksByScoreWithReasons(t *testing.T) {
+	var gotQuery models.SuggestionQuery
+	service := newService(mockRepository{
+		getRatingsFn: func(_ context.Context, _ uint64) ([]models.PlayerRating, error) {
+			return []models.PlayerRating{{UserID: 1, SportID: 2, Rating: 1600}}, nil
+		},
+		suggestionsFn: func(_ context.Context, query models.SuggestionQuery) ([]models.SuggestionCandidate, error) {
+			gotQuery = query
+			return []models.SuggestionCandidate{
+				// только общий город
+				{UserID: 10, SameTown: true},
+				// близкий рейтинг, общий спорт и время, бывший напарник
+				{
+					UserID:          11,
+					Rating:          floatPtr(1620),
+					SharedSports:    []string{"падел"},
+					SharedTimeSlots: []string{"вечер"},
+					PartnerMatches:  2,
+				},
+				// рейтинг далеко: очков за рейтинг нет
+				{UserID: 12, Rating: floatPtr(2100), Friend: true},
+			}, nil
+		},
+	})
+
+	suggestions, err := service.SuggestPlayers(context.Background(), 1, requests.PlayerSuggestionsRequest{SportID: intPtr(2)})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotQuery.SportID == nil || *gotQuery.SportID != 2 {
+		t.Fatalf("expected sport filter to reach repository, got %v", gotQuery.SportID)
+	}
+	// база отбирает кандидатов по той же оценке, включая близость рейтинга
+	if gotQuery.Rating == nil || *gotQuery.Rating != 1600 || gotQuery.Weights.Rating <= 0 || gotQuery.Weights.RatingSpread <= 0 {
+		t.Fatalf("expected own rating and weights to reach repository, got %+v", gotQuery)
+	}
+	if len(suggestions) != 3 || suggestions[0].UserID != 11 {
+		t.Fatalf("expected the closest player first, got %+v", suggestions)
+	}
+
+	best := suggestions[0]
+	// 38 за рейтинг (разница 20), 10 за спорт, 8 за время, 10 за два совместных матча
+	if best.Score != 66 {
+		t.Fatalf("expected score 66, got %v (%+v)", best.Score, best.Reasons)
+	}
+	want := []string{
+		models.SuggestionReasonRating,
+		models.SuggestionReasonSports,
+		models.SuggestionReasonTime,
+		models.SuggestionReasonPartner,
+	}
+	if kinds := reasonKinds(best); len(kinds) != len(want) {
+		t.Fatalf("expected reasons %v, got %v", want, kinds)
+	}
+
+	for _, s := range suggestions {
+		if s.UserID == 12 {
+			if kinds := reasonKinds(s); len(kinds) != 1 || kinds[0] != models.SuggestionReasonFriend {
+				t.Fatalf("expected only the friend reason for a distant rating, got %v", kinds)
+			}
+		}
+	}
+}
+
+func TestSuggestPlayers_UnratedViewerComparedFromInitialRating(t *testing.T) {
+	service := newService(mockRepository{
+		getRatingsFn: func(_ context.Context, _ uint64) ([]models.PlayerRating, error) {
+			return []models.PlayerRating{}, nil
+		},
+		suggestionsFn: func(_ context.Context, _ models.SuggestionQuery) ([]models.SuggestionCandidate, error) {
+			return []models.SuggestionCandidate{{UserID: 10, Rating: floatPtr(1500)}}, nil
+		},
+	})
+
+	suggestions, err := service.SuggestPlayers(context.Background(), 1, requests.PlayerSuggestionsRequest{SportID: intPtr(2)})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(suggestions) != 1 || suggestions[0].Score != 40 {
+		t.Fatalf("expected full rating points against the initial rating, got %+v", suggestions)
+	}
+}
+
+func TestSuggestPlayers_WithoutSportSkipsRatingAndLimits(t *testing.T) {
+	candidates := make([]models.SuggestionCandidate, 0, 60)
+	for i := range 60 {
+		candidates = append(candidates, models.SuggestionCandidate{
+			UserID:   uint64(100 + i),
+			Rating:   floatPtr(1500),
+			SameTown: true,
+		})
+	}
+	service := newService(mockRepository{
+		suggestionsFn: func(_ context.Context, _ models.SuggestionQuery) ([]models.SuggestionCandidate, error) {
+			return candidates, nil
+		},
+	})
+
+	suggestions, err := service.SuggestPlayers(context.Background(), 1, requests.PlayerSuggestionsRequest{Limit: 1000})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(suggestions) != 50 {
+		t.Fatalf("expected at most 50 suggestions, got %d", len(suggestions))
+	}
+	if kinds := reasonKinds(suggestions[0]); len(kinds) != 1 || kinds[0] != models.SuggestionReasonTown {
+		t.Fatalf("expected only the town reason without a sport, got %v", kinds)
+	}
+}
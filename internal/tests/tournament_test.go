@@ -0,0 +1,443 @@
+package tests
+
+import (
+	"context"
+	"sport-assistance/internal/handlers/requests"
+	"sport-assistance/internal/models"
+	"sport-assistance/pkg/myerrors"
+	"sport-assistance/pkg/tournament"
+	"testing"
+	"time"
+)
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+// tournamentRepo — турнир 7 в теннисе с заданными участниками
+func tournamentRepo(t models.Tournament, entries ...models.TournamentEntry) mockRepository {
+	t.ID = 7
+	t.SportID = 1
+	t.EntriesCount = len(entries)
+	if t.TeamSize == 0 {
+		t.TeamSize = 1
+	}
+	if t.Status == "" {
+		t.Status = models.TournamentStatusDraft
+	}
+
+	return mockRepository{
+		getTournamentFn: func(_ context.Context, _ uint64) (models.Tournament, error) {
+			return t, nil
+		},
+		listEntriesFn: func(_ context.Context, _ uint64) ([]models.TournamentEntry, error) {
+			return entries, nil
+		},
+	}
+}
+
+func TestSingleElimination_ByesGoToTopSeeds(t *testing.T) {
+	pairs := tournament.SingleElimination([]uint64{1, 2, 3, 4, 5, 6})
+
+	expected := []tournament.Pair{{Entry1: 1}, {Entry1: 4, Entry2: 5}, {Entry1: 2}, {Entry1: 3, Entry2: 6}}
+	if len(pairs) != len(expected) {
+		t.Fatalf("expected %d pairs, got %+v", len(expected), pairs)
+	}
+	for i := range expected {
+		if pairs[i] != expected[i] {
+			t.Fatalf("expected %+v, got %+v", expected, pairs)
+		}
+	}
+	if rounds := tournament.Rounds(6); rounds != 3 {
+		t.Fatalf("expected 3 rounds, got %d", rounds)
+	}
+}
+
+func TestRoundRobin_EveryoneMeetsOnce(t *testing.T) {
+	rounds := tournament.RoundRobin([]uint64{1, 2, 3, 4, 5})
+	if len(rounds) != 5 {
+		t.Fatalf("expected 5 rounds, got %d", len(rounds))
+	}
+
+	met := map[[2]uint64]int{}
+	for _, pairs := range rounds {
+		if len(pairs) != 2 {
+			t.Fatalf("expected 2 games per round with one entry resting, got %+v", pairs)
+		}
+		for _, p := range pairs {
+			a, b := min(p.Entry1, p.Entry2), max(p.Entry1, p.Entry2)
+			met[[2]uint64{a, b}]++
+		}
+	}
+	if len(met) != 10 {
+		t.Fatalf("expected all 10 pairings, got %d", len(met))
+	}
+	for pair, n := range met {
+		if n != 1 {
+			t.Fatalf("expected %v to meet once, met %d times", pair, n)
+		}
+	}
+}
+
+func TestSwissPairings_AvoidsRematchesAndRepeatedByes(t *testing.T) {
+	results := []tournament.Result{
+		{Entry1: 1, Entry2: 2, Winner: 1},
+		{Entry1: 3, Entry2: 4, Winner: 3},
+		{Entry1: 5, Winner: 5},
+	}
+	standings := tournament.Standings(map[uint64]int{1: 1, 2: 2, 3: 3, 4: 4, 5: 5}, results)
+
+	pairs := tournament.SwissPairings(standings, results)
+
+	// 5 уже получал проход — теперь он достаётся нижнему без прохода
+	if pairs[0].Entry2 != 0 || pairs[0].Entry1 == 5 {
+		t.Fatalf("expected a bye for an entry other than 5, got %+v", pairs)
+	}
+	for _, p := range pairs[1:] {
+		if (p.Entry1 == 1 && p.Entry2 == 2) || (p.Entry1 == 3 && p.Entry2 == 4) {
+			t.Fatalf("expected no rematches, got %+v", pairs)
+		}
+	}
+}
+
+func TestStandings_BuchholzBreaksTies(t *testing.T) {
+	standings := tournament.Standings(map[uint64]int{1: 1, 2: 2, 3: 3, 4: 4}, []tournament.Result{
+		{Entry1: 1, Entry2: 2, Winner: 2},
+		{Entry1: 3, Entry2: 4, Winner: 3},
+		{Entry1: 2, Entry2: 3, Winner: 2},
+		{Entry1: 1, Entry2: 4, Winner: 1},
+	})
+
+	// у 1 и 3 по победе и одинаковый Бухгольц (их соперники — 2 и 4), поэтому решает посев
+	order := []uint64{2, 1, 3, 4}
+	for i, id := range order {
+		if standings[i].EntryID != id {
+			t.Fatalf("expected order %v, got %+v", order, standings)
+		}
+	}
+	if standings[0].Wins != 2 || standings[0].Buchholz != 2 {
+		t.Fatalf("expected leader with 2 wins and buchholz 2, got %+v", standings[0])
+	}
+}
+
+func TestCreateTournament_Validation(t *testing.T) {
+	repo := mockRepository{
+		getSportNameFn: func(_ context.Context, _ int) (string, error) {
+			return models.SportTennis, nil
+		},
+	}
+	service := newService(repo)
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(24*time.Hour)
+
+	cases := map[string]requests.CreateTournamentRequest{
+		"missing name":         {Format: "swiss", SportID: 1, MatchTypeID: 1, SwissRounds: intPtr(3)},
+		"unknown format":       {Name: "Cup", Format: "double_elimination", SportID: 1, MatchTypeID: 1},
+		"team of three":        {Name: "Cup", Format: "round_robin", SportID: 1, MatchTypeID: 1, TeamSize: 3},
+		"swiss without rounds": {Name: "Cup", Format: "swiss", SportID: 1, MatchTypeID: 1},
+		"negative range":       {Name: "Cup", Format: "ladder", SportID: 1, MatchTypeID: 1, ChallengeRange: intPtr(-1)},
+		"no start time":        {Name: "Cup", Format: "round_robin", SportID: 1, MatchTypeID: 1},
+		"past start time":      {Name: "Cup", Format: "round_robin", SportID: 1, MatchTypeID: 1, StartsAt: &past},
+		"rounds overlap":       {Name: "Cup", Format: "round_robin", SportID: 1, MatchTypeID: 1, StartsAt: &future, RoundIntervalMinutes: 60},
+	}
+
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.CreateTournament(context.Background(), 1, req)
+			expectAppCode(t, err, myerrors.ErrCodeValidation)
+		})
+	}
+}
+
+func TestCreateTournament_LadderDefaultsChallengeRange(t *testing.T) {
+	var created models.Tournament
+	repo := tournamentRepo(models.Tournament{Format: models.TournamentLadder})
+	repo.getSportNameFn = func(_ context.Context, _ int) (string, error) {
+		return models.SportPadel, nil
+	}
+	repo.createTournamentFn = func(_ context.Context, t models.Tournament) (uint64, error) {
+		created = t
+		return 7, nil
+	}
+
+	_, err := newService(repo).CreateTournament(context.Background(), 3, requests.CreateTournamentRequest{
+		Name:        " Лестница сезона ",
+		Format:      "ladder",
+		SportID:     1,
+		MatchTypeID: 2,
+		TeamSize:    2,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if created.Name != "Лестница сезона" || created.ChallengeRange == nil || *created.ChallengeRange != 3 {
+		t.Fatalf("expected trimmed name and default challenge range, got %+v", created)
+	}
+	if created.OrganizerID == nil || *created.OrganizerID != 3 || created.TeamSize != 2 {
+		t.Fatalf("expected organizer 3 and pairs, got %+v", created)
+	}
+	if created.StartsAt != nil || created.MatchDurationMinutes != 90 || created.RoundIntervalMinutes != 24*60 {
+		t.Fatalf("expected a ladder without a round schedule and default durations, got %+v", created)
+	}
+}
+
+func TestTournamentRoundSchedule(t *testing.T) {
+	now := time.Now().UTC()
+	startsAt := now.Add(time.Hour)
+	tr := models.Tournament{StartsAt: &startsAt, RoundIntervalMinutes: 24 * 60, MatchDurationMinutes: 90}
+
+	start, end := tr.RoundSchedule(3, now)
+	if !start.Equal(startsAt.Add(48*time.Hour)) || !end.Equal(start.Add(90*time.Minute)) {
+		t.Fatalf("expected round 3 two days after the start, got %v-%v", start, end)
+	}
+
+	// круг, составленный позже своего времени, начинается сразу
+	later := startsAt.Add(30 * time.Hour)
+	if start, _ = tr.RoundSchedule(2, later); !start.Equal(later) {
+		t.Fatalf("expected a late round to start at %v, got %v", later, start)
+	}
+}
+
+func TestAddTournamentEntry_RequiresTeamSizePlayers(t *testing.T) {
+	repo := tournamentRepo(models.Tournament{Format: models.TournamentRoundRobin, TeamSize: 2})
+	repo.addEntryFn = func(_ context.Context, _ uint64, _ []uint64) (uint64, error) {
+		t.Fatal("entry must not be saved")
+		return 0, nil
+	}
+
+	_, err := newService(repo).AddTournamentEntry(context.Background(), 7, requests.AddTournamentEntryRequest{PlayerIDs: []uint64{4, 4}})
+	expectAppCode(t, err, myerrors.ErrCodeValidation)
+}
+
+func TestAddTournamentEntry_PlayerAlreadyEntered(t *testing.T) {
+	repo := tournamentRepo(models.Tournament{Format: models.TournamentRoundRobin})
+	repo.addEntryFn = func(_ context.Context, _ uint64, _ []uint64) (uint64, error) {
+		return 0, myerrors.ErrAlreadyEntered
+	}
+
+	_, err := newService(repo).AddTournamentEntry(context.Background(), 7, requests.AddTournamentEntryRequest{PlayerIDs: []uint64{4}})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestStartTournament_SeedsByRatingAndBuildsBracket(t *testing.T) {
+	repo := tournamentRepo(models.Tournament{Format: models.TournamentSingleElimination},
+		models.TournamentEntry{ID: 11, PlayerIDs: []uint64{1}},
+		models.TournamentEntry{ID: 12, PlayerIDs: []uint64{2}},
+		models.TournamentEntry{ID: 13, PlayerIDs: []uint64{3}},
+		models.TournamentEntry{ID: 14, PlayerIDs: []uint64{4}},
+		models.TournamentEntry{ID: 15, PlayerIDs: []uint64{5}},
+	)
+	repo.sportRatingsFn = func(_ context.Context, _ int, _ []uint64) (map[uint64]float64, error) {
+		// у игрока 3 рейтинга нет — он сеется с начальным 1500
+		return map[uint64]float64{1: 1400, 2: 1700, 4: 1600, 5: 1450}, nil
+	}
+	var plan models.TournamentPlan
+	repo.startTournamentFn = func(_ context.Context, _ uint64, p models.TournamentPlan) error {
+		plan = p
+		return nil
+	}
+
+	if _, err := newService(repo).StartTournament(context.Background(), 7); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	order := []uint64{12, 14, 13, 15, 11}
+	for i, id := range order {
+		if plan.Seeds[i].EntryID != id || plan.Seeds[i].Seed != i+1 {
+			t.Fatalf("expected seeding %v, got %+v", order, plan.Seeds)
+		}
+	}
+
+	// сетка на 8: четыре встречи первого круга, две полуфинальные и финал
+	if len(plan.Fixtures) != 7 {
+		t.Fatalf("expected 7 fixtures, got %d", len(plan.Fixtures))
+	}
+	byes := 0
+	for _, f := range plan.Fixtures[:4] {
+		if f.Entry2ID == nil {
+			byes++
+		}
+	}
+	if byes != 3 {
+		t.Fatalf("expected 3 byes in the first round, got %d", byes)
+	}
+	final := plan.Fixtures[6]
+	if final.Round != 3 || final.Entry1ID != nil || final.Entry2ID != nil {
+		t.Fatalf("expected an empty final in round 3, got %+v", final)
+	}
+}
+
+func TestStartTournament_NeedsEnoughEntries(t *testing.T) {
+	repo := tournamentRepo(models.Tournament{Format: models.TournamentSwiss, SwissRounds: intPtr(3)},
+		models.TournamentEntry{ID: 11, PlayerIDs: []uint64{1}},
+		models.TournamentEntry{ID: 12, PlayerIDs: []uint64{2}},
+		models.TournamentEntry{ID: 13, PlayerIDs: []uint64{3}},
+	)
+
+	// три участника не сыграют три круга без повторов
+	_, err := newService(repo).StartTournament(context.Background(), 7)
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func ladderRepo() mockRepository {
+	return tournamentRepo(
+		models.Tournament{Format: models.TournamentLadder, Status: models.TournamentStatusInProgress, ChallengeRange: intPtr(2), MatchDurationMinutes: 60},
+		models.TournamentEntry{ID: 11, PlayerIDs: []uint64{1}, Position: intPtr(1)},
+		models.TournamentEntry{ID: 12, PlayerIDs: []uint64{2}, Position: intPtr(2)},
+		models.TournamentEntry{ID: 13, PlayerIDs: []uint64{3}, Position: intPtr(3)},
+		models.TournamentEntry{ID: 14, PlayerIDs: []uint64{4}, Position: intPtr(4)},
+	)
+}
+
+func TestChallengeLadder_WithinRange(t *testing.T) {
+	startsAt := time.Now().Add(48 * time.Hour).UTC()
+	repo := ladderRepo()
+	repo.createChallengeFn = func(_ context.Context, _, challengerID, defenderID uint64, start, end time.Time) (uint64, error) {
+		if challengerID != 14 || defenderID != 12 {
+			t.Fatalf("expected 14 to challenge 12, got %d and %d", challengerID, defenderID)
+		}
+		// без ends_at матч длится столько, сколько матчи турнира
+		if !start.Equal(startsAt) || !end.Equal(startsAt.Add(60*time.Minute)) {
+			t.Fatalf("expected the challenge at %v for an hour, got %v-%v", startsAt, start, end)
+		}
+		return 30, nil
+	}
+	repo.getFixtureFn = func(_ context.Context, fixtureID uint64) (models.TournamentFixture, error) {
+		return models.TournamentFixture{ID: fixtureID, IsChallenge: true, MatchID: uint64Ptr(90)}, nil
+	}
+
+	fixture, err := newService(repo).ChallengeLadder(context.Background(), 4, 7, requests.LadderChallengeRequest{DefenderEntryID: 12, StartsAt: startsAt})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fixture.ID != 30 || fixture.MatchID == nil {
+		t.Fatalf("expected challenge fixture with a match, got %+v", fixture)
+	}
+}
+
+func TestChallengeLadder_Rules(t *testing.T) {
+	cases := map[string]struct {
+		userID   uint64
+		defender uint64
+		code     myerrors.ErrorCode
+	}{
+		"too far above":  {userID: 4, defender: 11, code: myerrors.ErrCodeValidation},
+		"below":          {userID: 2, defender: 13, code: myerrors.ErrCodeValidation},
+		"not an entry":   {userID: 9, defender: 11, code: myerrors.ErrCodeForbidden},
+		"unknown target": {userID: 4, defender: 99, code: myerrors.ErrCodeNotFound},
+		"no start time":  {userID: 4, defender: 12, code: myerrors.ErrCodeValidation},
+	}
+
+	service := newService(ladderRepo())
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.ChallengeLadder(context.Background(), tc.userID, 7, requests.LadderChallengeRequest{DefenderEntryID: tc.defender})
+			expectAppCode(t, err, tc.code)
+		})
+	}
+}
+
+func TestChallengeLadder_OpenChallenge(t *testing.T) {
+	repo := ladderRepo()
+	repo.createChallengeFn = func(_ context.Context, _, _, _ uint64, _, _ time.Time) (uint64, error) {
+		return 0, myerrors.ErrOpenChallenge
+	}
+
+	_, err := newService(repo).ChallengeLadder(context.Background(), 3, 7, requests.LadderChallengeRequest{
+		DefenderEntryID: 12,
+		StartsAt:        time.Now().Add(time.Hour),
+	})
+	expectAppCode(t, err, myerrors.ErrCodeConflict)
+}
+
+func TestGetTournamentStandings_EliminationKeepsAliveEntriesFirst(t *testing.T) {
+	repo := tournamentRepo(models.Tournament{Format: models.TournamentSingleElimination, Status: models.TournamentStatusInProgress},
+		models.TournamentEntry{ID: 11, PlayerIDs: []uint64{1}, Seed: intPtr(1)},
+		models.TournamentEntry{ID: 12, PlayerIDs: []uint64{2}, Seed: intPtr(2), Eliminated: true},
+		models.TournamentEntry{ID: 13, PlayerIDs: []uint64{3}, Seed: intPtr(3)},
+	)
+	repo.listFixturesFn = func(_ context.Context, _ uint64) ([]models.TournamentFixture, error) {
+		return []models.TournamentFixture{
+			{Round: 1, Position: 1, Entry1ID: uint64Ptr(11), WinnerEntryID: uint64Ptr(11), Status: models.FixtureStatusCompleted},
+			{Round: 1, Position: 2, Entry1ID: uint64Ptr(12), Entry2ID: uint64Ptr(13), WinnerEntryID: uint64Ptr(13), Status: models.FixtureStatusCompleted},
+			{Round: 2, Position: 1, Entry1ID: uint64Ptr(11), Entry2ID: uint64Ptr(13), Status: models.FixtureStatusScheduled},
+		}, nil
+	}
+
+	resp, err := newService(repo).GetTournamentStandings(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// 11 и 13 по победе (проход засчитывается победой), выше — по посеву; выбывший 12 последний
+	order := []uint64{11, 13, 12}
+	for i, id := range order {
+		if resp.Standings[i].EntryID != id || resp.Standings[i].Rank != i+1 {
+			t.Fatalf("expected order %v, got %+v", order, resp.Standings)
+		}
+	}
+	if resp.Standings[0].Byes != 1 || resp.Standings[2].Losses != 1 {
+		t.Fatalf("expected bye for 11 and loss for 12, got %+v", resp.Standings)
+	}
+}
+
+func TestConfirmMatchResult_PairsNextSwissRoundWhenRoundIsComplete(t *testing.T) {
+	repo, _ := playedMatchRepo(models.SportTennis)
+	swiss := tournamentRepo(models.Tournament{Format: models.TournamentSwiss, Status: models.TournamentStatusInProgress, SwissRounds: intPtr(2)},
+		models.TournamentEntry{ID: 11, Seed: intPtr(1)},
+		models.TournamentEntry{ID: 12, Seed: intPtr(2)},
+		models.TournamentEntry{ID: 13, Seed: intPtr(3)},
+		models.TournamentEntry{ID: 14, Seed: intPtr(4)},
+	)
+	repo.getTournamentFn, repo.listEntriesFn = swiss.getTournamentFn, swiss.listEntriesFn
+
+	fixtures := []models.TournamentFixture{
+		{TournamentID: 7, Round: 1, Position: 1, Entry1ID: uint64Ptr(11), Entry2ID: uint64Ptr(13), WinnerEntryID: uint64Ptr(11), Status: models.FixtureStatusCompleted},
+		{TournamentID: 7, Round: 1, Position: 2, Entry1ID: uint64Ptr(12), Entry2ID: uint64Ptr(14), MatchID: uint64Ptr(10), Status: models.FixtureStatusScheduled},
+	}
+	repo.matchFixtureFn = func(_ context.Context, _ uint64) (models.TournamentFixture, error) {
+		return fixtures[1], nil
+	}
+	repo.listFixturesFn = func(_ context.Context, _ uint64) ([]models.TournamentFixture, error) {
+		return fixtures, nil
+	}
+	var (
+		scheduled int
+		next      []models.TournamentFixture
+	)
+	repo.scheduleSwissFn = func(_ context.Context, _ uint64, round int, f []models.TournamentFixture) error {
+		scheduled, next = round, f
+		return nil
+	}
+	service := newService(repo)
+
+	confirm := func() {
+		t.Helper()
+		result, err := service.SubmitMatchResult(context.Background(), 1, 10, doublesResult(
+			models.SetScore{Team1: 6, Team2: 2},
+			models.SetScore{Team1: 6, Team2: 2},
+		))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err = service.ConfirmMatchResult(context.Background(), 4, result.ID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// вторая встреча круга ещё не завершена — следующий круг не составляется
+	confirm()
+	if scheduled != 0 {
+		t.Fatalf("expected no round to be paired, got round %d", scheduled)
+	}
+
+	fixtures[1].WinnerEntryID, fixtures[1].Status = uint64Ptr(12), models.FixtureStatusCompleted
+	confirm()
+	if scheduled != 2 || len(next) != 2 {
+		t.Fatalf("expected two fixtures of round 2, got round %d with %+v", scheduled, next)
+	}
+	// победители первого круга встречаются между собой
+	if top := next[0]; *top.Entry1ID != 11 || *top.Entry2ID != 12 || top.Round != 2 || top.Position != 1 {
+		t.Fatalf("expected 11 against 12 first, got %+v", top)
+	}
+}
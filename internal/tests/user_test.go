@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sport-assistance/internal/models"
+	"sport-assistance/internal/services/dto"
+	"sport-assistance/pkg/commons"
+	"sport-assistance/pkg/myerrors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func userRepo() mockRepository {
+	roleID := 2
+	injury := "колено"
+	townID := 5
+	position := models.CourtPosition("left")
+
+	return mockRepository{
+		getUserByIDFn: func(_ context.Context, userID uint64) (dto.UserDto, error) {
+			return dto.UserDto{
+				ID:                userID,
+				Name:              "Иван",
+				Surname:           "Петров",
+				PhoneNumber:       "+79991234567",
+				Email:             "ivan@example.com",
+				RoleID:            &roleID,
+				IsHaveInjury:      true,
+				InjuryDescription: &injury,
+				TownID:            &townID,
+				CourtPosition:     &position,
+			}, nil
+		},
+		getVisibilityFn: func(_ context.Context, _ uint64) (models.ProfileVisibility, error) {
+			return models.DefaultProfileVisibility(), nil
+		},
+	}
+}
+
+func userJSON(t *testing.T, v any) map[string]any {
+	t.Helper()
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal user: %v", err)
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("failed to unmarshal user: %v", err)
+	}
+	return fields
+}
+
+func TestResolveProjection(t *testing.T) {
+	cases := []struct {
+		name        string
+		viewerID    uint64
+		permissions []string
+		want        dto.UserProjection
+	}{
+		{"stranger", 2, []string{commons.PermissionProfileViewOwn}, dto.ProjectionPublic},
+		{"owner", 1, []string{commons.PermissionProfileViewOwn}, dto.ProjectionSelf},
+		{"assistant", 2, []string{commons.PermissionProfileViewAny}, dto.ProjectionAssistant},
+		{"admin", 2, []string{commons.PermissionProfileViewAny, commons.PermissionAdminUsersManage}, dto.ProjectionAdmin},
+		{"admin owner", 1, []string{commons.PermissionAdminUsersManage}, dto.ProjectionAdmin},
+	}
+
+	for _, tc := range cases {
+		if got := dto.ResolveProjection(tc.viewerID, 1, tc.permissions); got != tc.want {
+			t.Fatalf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestGetUser_PublicProjectionHidesPrivateFields(t *testing.T) {
+	service := newService(userRepo())
+
+	user, err := service.GetUser(context.Background(), 2, 1, []string{commons.PermissionProfileViewOwn})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := userJSON(t, user)
+	for _, key := range []string{"phone_number", "email", "birth_date", "injury_description", "role_id", "password"} {
+		if _, ok := fields[key]; ok {
+			t.Fatalf("expected %q to be hidden in public projection, got %v", key, fields)
+		}
+	}
+	if fields["name"] != "Иван" {
+		t.Fatalf("expected name to be visible, got %v", fields["name"])
+	}
+	if fields["town_id"] == nil || fields["court_position"] == nil {
+		t.Fatalf("expected town and court position to be visible by default, got %v", fields)
+	}
+}
+
+func TestGetUser_PublicProjectionRespectsVisibility(t *testing.T) {
+	repo := userRepo()
+	repo.getVisibilityFn = func(_ context.Context, _ uint64) (models.ProfileVisibility, error) {
+		visibility := models.DefaultProfileVisibility()
+		visibility.ShowTown = false
+		visibility.ShowCourtPosition = false
+		return visibility, nil
+	}
+	service := newService(repo)
+
+	user, err := service.GetUser(context.Background(), 2, 1, []string{commons.PermissionProfileViewOwn})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := userJSON(t, user)
+	for _, key := range []string{"town_id", "court_position"} {
+		if _, ok := fields[key]; ok {
+			t.Fatalf("expected %q to be hidden by visibility settings, got %v", key, fields)
+		}
+	}
+
+	// владелец видит свои поля независимо от настроек
+	own, err := service.GetUser(context.Background(), 1, 1, []string{commons.PermissionProfileViewOwn})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fields = userJSON(t, own); fields["town_id"] == nil || fields["court_position"] == nil {
+		t.Fatalf("expected owner to see town and court position, got %v", fields)
+	}
+}
+
+func TestGetUser_AssistantSeesContactsAndRole(t *testing.T) {
+	service := newService(userRepo())
+
+	user, err := service.GetUser(context.Background(), 2, 1, []string{commons.PermissionProfileViewAny})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fields := userJSON(t, user)
+	if fields["phone_number"] != "+79991234567" || fields["role_id"] == nil {
+		t.Fatalf("expected contacts and role in assistant projection, got %v", fields)
+	}
+	if _, ok := fields["deleted_at"]; ok {
+		t.Fatalf("expected admin-only fields to be hidden, got %v", fields)
+	}
+}
+
+func TestGetUser_NoProjectionExposesPassword(t *testing.T) {
+	user, _ := userRepo().getUserByIDFn(context.Background(), 1)
+
+	projections := []dto.UserProjection{dto.ProjectionPublic, dto.ProjectionSelf, dto.ProjectionAssistant, dto.ProjectionAdmin}
+	for _, projection := range projections {
+		raw, err := json.Marshal(dto.ProjectUser(user, projection, nil))
+		if err != nil {
+			t.Fatalf("failed to marshal %s projection: %v", projection, err)
+		}
+		if strings.Contains(string(raw), "password") {
+			t.Fatalf("%s projection exposes password: %s", projection, raw)
+		}
+	}
+}
+
+func TestGetMe_NotFound(t *testing.T) {
+	service := newService(mockRepository{
+		getUserByIDFn: func(_ context.Context, _ uint64) (dto.UserDto, error) {
+			return dto.UserDto{}, pgx.ErrNoRows
+		},
+	})
+
+	_, err := service.GetMe(context.Background(), 1)
+	var appErr myerrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != myerrors.ErrCodeNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE towns
+    ADD COLUMN region TEXT,
+    ADD COLUMN timezone TEXT,
+    ADD COLUMN latitude DOUBLE PRECISION,
+    ADD COLUMN longitude DOUBLE PRECISION;
+
+CREATE INDEX idx_towns_name_prefix ON towns (lower(name) text_pattern_ops);
+CREATE INDEX idx_towns_name_trgm ON towns USING GIN (lower(name) gin_trgm_ops);
+
+UPDATE towns AS t
+SET region = v.region,
+    timezone = v.timezone,
+    latitude = v.latitude,
+    longitude = v.longitude
+FROM (
+    VALUES
+        ('Москва', 'Москва', 'Europe/Moscow', 55.7558, 37.6173),
+        ('Санкт-Петербург', 'Санкт-Петербург', 'Europe/Moscow', 59.9386, 30.3141),
+        ('Новосибирск', 'Новосибирская область', 'Asia/Novosibirsk', 55.0415, 82.9346),
+        ('Екатеринбург', 'Свердловская область', 'Asia/Yekaterinburg', 56.8389, 60.6057),
+        ('Казань', 'Республика Татарстан', 'Europe/Moscow', 55.7963, 49.1088),
+        ('Нижний Новгород', 'Нижегородская область', 'Europe/Moscow', 56.3269, 44.0059),
+        ('Челябинск', 'Челябинская область', 'Asia/Yekaterinburg', 55.1644, 61.4368),
+        ('Самара', 'Самарская область', 'Europe/Samara', 53.1959, 50.1002),
+        ('Омск', 'Омская область', 'Asia/Omsk', 54.9885, 73.3242),
+        ('Ростов-на-Дону', 'Ростовская область', 'Europe/Moscow', 47.2357, 39.7015),
+        ('Уфа', 'Республика Башкортостан', 'Asia/Yekaterinburg', 54.7388, 55.9721),
+        ('Красноярск', 'Красноярский край', 'Asia/Krasnoyarsk', 56.0153, 92.8932),
+        ('Воронеж', 'Воронежская область', 'Europe/Moscow', 51.6720, 39.1843),
+        ('Пермь', 'Пермский край', 'Asia/Yekaterinburg', 58.0105, 56.2502),
+        ('Волгоград', 'Волгоградская область', 'Europe/Volgograd', 48.7080, 44.5133),
+        ('Краснодар', 'Краснодарский край', 'Europe/Moscow', 45.0355, 38.9753),
+        ('Сочи', 'Краснодарский край', 'Europe/Moscow', 43.6028, 39.7342),
+        ('Тюмень', 'Тюменская область', 'Asia/Yekaterinburg', 57.1530, 65.5343),
+        ('Калининград', 'Калининградская область', 'Europe/Kaliningrad', 54.7104, 20.4522),
+        ('Владивосток', 'Приморский край', 'Asia/Vladivostok', 43.1155, 131.8855)
+) AS v (name, region, timezone, latitude, longitude)
+WHERE t.name = v.name;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_towns_name_trgm;
+DROP INDEX IF EXISTS idx_towns_name_prefix;
+
+ALTER TABLE towns
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS region;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN photo_thumbnail TEXT;
+
+-- +goose Down
+ALTER TABLE users
+    DROP COLUMN IF EXISTS photo_thumbnail;
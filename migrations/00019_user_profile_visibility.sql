@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN court_position VARCHAR(10)
+        CHECK (court_position IN ('left', 'right', 'both'));
+
+-- Отсутствие строки означает настройки по умолчанию: всё видно всем
+CREATE TABLE user_profile_visibility (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    show_rating BOOLEAN NOT NULL DEFAULT true,
+    show_matches_played BOOLEAN NOT NULL DEFAULT true,
+    show_friends BOOLEAN NOT NULL DEFAULT true,
+    show_partners BOOLEAN NOT NULL DEFAULT true,
+    show_town BOOLEAN NOT NULL DEFAULT true,
+    show_training_district BOOLEAN NOT NULL DEFAULT true,
+    show_court_position BOOLEAN NOT NULL DEFAULT true,
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_profile_visibility;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS court_position;
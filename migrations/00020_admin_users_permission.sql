@@ -0,0 +1,18 @@
+-- +goose Up
+INSERT INTO permissions (name)
+VALUES ('admin.users.manage')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'admin.users.manage'
+WHERE r.name = 'admin'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- +goose Down
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'admin.users.manage');
+
+DELETE FROM permissions
+WHERE name = 'admin.users.manage';
@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE users
+    ADD COLUMN deletion_requested_at TIMESTAMP,
+    ADD COLUMN anonymized_at TIMESTAMP;
+
+-- Телефон и email уникальны только среди живых аккаунтов:
+-- после удаления их можно использовать для новой регистрации
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_phone_number_key;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+
+CREATE UNIQUE INDEX uq_users_phone_number_active ON users (phone_number) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX uq_users_email_active ON users (email) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_users_deletion_requested ON users (deletion_requested_at)
+    WHERE deletion_requested_at IS NOT NULL AND anonymized_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_users_deletion_requested;
+DROP INDEX IF EXISTS uq_users_email_active;
+DROP INDEX IF EXISTS uq_users_phone_number_active;
+
+ALTER TABLE users ADD CONSTRAINT users_phone_number_key UNIQUE (phone_number);
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS anonymized_at,
+    DROP COLUMN IF EXISTS deletion_requested_at;
@@ -0,0 +1,26 @@
+-- +goose Up
+INSERT INTO subscriptions (name)
+VALUES
+    ('Sport Basic'),
+    ('Sport Pro'),
+    ('Sport Elite')
+ON CONFLICT (name) DO NOTHING;
+
+-- subscription_id — текущий тариф пользователя (NULL у гостей)
+ALTER TABLE users
+    ADD COLUMN subscription_id INT REFERENCES subscriptions(id) ON DELETE RESTRICT,
+    ADD COLUMN blocked_at TIMESTAMP;
+
+CREATE INDEX idx_users_subscription ON users(subscription_id);
+CREATE INDEX idx_users_created_at ON users(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_users_created_at;
+DROP INDEX IF EXISTS idx_users_subscription;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS blocked_at,
+    DROP COLUMN IF EXISTS subscription_id;
+
+DELETE FROM subscriptions
+WHERE name IN ('Sport Basic', 'Sport Pro', 'Sport Elite');
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE user_subscriptions (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subscription_id INT NOT NULL REFERENCES subscriptions(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'expired', 'replaced')),
+    starts_at TIMESTAMP NOT NULL DEFAULT now(),
+    ends_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- У пользователя не больше одной активной подписки
+CREATE UNIQUE INDEX uq_user_subscriptions_active ON user_subscriptions (user_id) WHERE status = 'active';
+CREATE INDEX idx_user_subscriptions_ends_at ON user_subscriptions (ends_at) WHERE status = 'active';
+
+-- +goose Down
+DROP TABLE IF EXISTS user_subscriptions;
@@ -0,0 +1,33 @@
+-- +goose Up
+INSERT INTO match_types (name)
+VALUES
+    ('friendly'),
+    ('ranked')
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE matches
+    ADD COLUMN organizer_id INT REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'cancelled', 'completed')),
+    ADD COLUMN starts_at TIMESTAMP,
+    ADD COLUMN cancelled_at TIMESTAMP,
+    ADD COLUMN updated_at TIMESTAMP DEFAULT now();
+
+CREATE INDEX idx_matches_organizer ON matches(organizer_id);
+CREATE INDEX idx_matches_starts_at ON matches(starts_at);
+CREATE INDEX idx_user_matches_match ON user_matches(match_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_matches_match;
+DROP INDEX IF EXISTS idx_matches_starts_at;
+DROP INDEX IF EXISTS idx_matches_organizer;
+
+ALTER TABLE matches
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS starts_at,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS organizer_id;
+
+DELETE FROM match_types
+WHERE name IN ('friendly', 'ranked');
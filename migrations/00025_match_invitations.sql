@@ -0,0 +1,37 @@
+-- +goose Up
+ALTER TABLE matches
+    ADD COLUMN required_players INT NOT NULL DEFAULT 2 CHECK (required_players BETWEEN 2 AND 32);
+
+ALTER TABLE matches DROP CONSTRAINT matches_status_check;
+ALTER TABLE matches
+    ADD CONSTRAINT matches_status_check
+        CHECK (status IN ('scheduled', 'active', 'cancelled', 'completed'));
+
+CREATE TABLE match_invitations (
+    id           SERIAL PRIMARY KEY,
+    match_id     INT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    inviter_id   INT REFERENCES users(id) ON DELETE SET NULL,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined', 'revoked', 'expired')),
+    expires_at   TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- одно действующее приглашение на пользователя в матче; история ответов сохраняется
+CREATE UNIQUE INDEX uniq_match_invitations_pending
+    ON match_invitations(match_id, user_id)
+    WHERE status = 'pending';
+CREATE INDEX idx_match_invitations_user ON match_invitations(user_id, status);
+
+-- +goose Down
+DROP TABLE IF EXISTS match_invitations;
+
+UPDATE matches SET status = 'scheduled' WHERE status = 'active';
+ALTER TABLE matches DROP CONSTRAINT matches_status_check;
+ALTER TABLE matches
+    ADD CONSTRAINT matches_status_check
+        CHECK (status IN ('scheduled', 'cancelled', 'completed'));
+
+ALTER TABLE matches DROP COLUMN IF EXISTS required_players;
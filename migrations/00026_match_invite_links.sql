@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE match_invite_links (
+    id          SERIAL PRIMARY KEY,
+    match_id    INT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    inviter_id  INT REFERENCES users(id) ON DELETE SET NULL,
+    max_uses    INT CHECK (max_uses > 0),
+    uses_count  INT NOT NULL DEFAULT 0,
+    expires_at  TIMESTAMP NOT NULL,
+    revoked_at  TIMESTAMP,
+    created_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_match_invite_links_match ON match_invite_links(match_id);
+
+-- приглашение, полученное по ссылке, помнит её: так считаются использования
+ALTER TABLE match_invitations
+    ADD COLUMN link_id INT REFERENCES match_invite_links(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE match_invitations DROP COLUMN IF EXISTS link_id;
+
+DROP TABLE IF EXISTS match_invite_links;
@@ -0,0 +1,47 @@
+-- +goose Up
+ALTER TABLE matches
+    ADD COLUMN visibility VARCHAR(10) NOT NULL DEFAULT 'private'
+        CHECK (visibility IN ('private', 'public')),
+    ADD COLUMN sport_id INT REFERENCES sports(id) ON DELETE RESTRICT,
+    ADD COLUMN town_id INT REFERENCES towns(id) ON DELETE RESTRICT,
+    ADD COLUMN venue TEXT,
+    -- уровни сравниваются по id: справочник заполняется от низкого к высокому
+    ADD COLUMN min_level_id INT REFERENCES sport_activity_levels(id) ON DELETE RESTRICT,
+    ADD COLUMN max_level_id INT REFERENCES sport_activity_levels(id) ON DELETE RESTRICT,
+    ADD COLUMN capacity INT CHECK (capacity BETWEEN 2 AND 32),
+    ADD CONSTRAINT matches_capacity_required CHECK (capacity IS NULL OR capacity >= required_players),
+    ADD CONSTRAINT matches_level_band CHECK (min_level_id IS NULL OR max_level_id IS NULL OR min_level_id <= max_level_id);
+
+CREATE INDEX idx_matches_public_upcoming
+    ON matches(starts_at, id)
+    WHERE visibility = 'public' AND status IN ('scheduled', 'active');
+CREATE INDEX idx_matches_sport ON matches(sport_id);
+CREATE INDEX idx_matches_town ON matches(town_id);
+
+-- публичные матчи организуют ассистенты
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'match.create'
+WHERE r.name = 'assistant'
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- +goose Down
+DELETE FROM role_permissions
+WHERE role_id IN (SELECT id FROM roles WHERE name = 'assistant')
+  AND permission_id IN (SELECT id FROM permissions WHERE name = 'match.create');
+
+DROP INDEX IF EXISTS idx_matches_town;
+DROP INDEX IF EXISTS idx_matches_sport;
+DROP INDEX IF EXISTS idx_matches_public_upcoming;
+
+ALTER TABLE matches
+    DROP CONSTRAINT IF EXISTS matches_level_band,
+    DROP CONSTRAINT IF EXISTS matches_capacity_required,
+    DROP COLUMN IF EXISTS capacity,
+    DROP COLUMN IF EXISTS max_level_id,
+    DROP COLUMN IF EXISTS min_level_id,
+    DROP COLUMN IF EXISTS venue,
+    DROP COLUMN IF EXISTS town_id,
+    DROP COLUMN IF EXISTS sport_id,
+    DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,44 @@
+-- +goose Up
+CREATE TABLE sport_objects (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    town_id INT REFERENCES towns(id) ON DELETE RESTRICT,
+    address TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE TABLE courts (
+    id SERIAL PRIMARY KEY,
+    sport_object_id INT NOT NULL REFERENCES sport_objects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    sport_id INT REFERENCES sports(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_sport_objects_town ON sport_objects(town_id);
+CREATE INDEX idx_courts_sport_object ON courts(sport_object_id);
+
+ALTER TABLE matches
+    ADD COLUMN ends_at TIMESTAMP,
+    ADD COLUMN sport_object_id INT REFERENCES sport_objects(id) ON DELETE RESTRICT,
+    ADD COLUMN court_id INT REFERENCES courts(id) ON DELETE RESTRICT;
+
+-- у старых матчей длительности не было: считаем стандартные полтора часа
+UPDATE matches
+SET ends_at = starts_at + INTERVAL '90 minutes'
+WHERE starts_at IS NOT NULL;
+
+ALTER TABLE matches
+    ADD CONSTRAINT matches_schedule CHECK (ends_at IS NULL OR (starts_at IS NOT NULL AND ends_at > starts_at));
+
+CREATE INDEX idx_activity_calendars_time ON activity_calendars(start_time, end_time);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_activity_calendars_time;
+
+ALTER TABLE matches
+    DROP CONSTRAINT IF EXISTS matches_schedule,
+    DROP COLUMN IF EXISTS court_id,
+    DROP COLUMN IF EXISTS sport_object_id,
+    DROP COLUMN IF EXISTS ends_at;
+
+DROP TABLE IF EXISTS courts, sport_objects;
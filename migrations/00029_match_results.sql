@@ -0,0 +1,50 @@
+-- +goose Up
+CREATE TABLE match_results (
+    id                 SERIAL PRIMARY KEY,
+    match_id           INT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    submitted_by       INT REFERENCES users(id) ON DELETE SET NULL,
+    winner_team        SMALLINT NOT NULL CHECK (winner_team IN (1, 2)),
+    status             VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'disputed', 'rejected')),
+    confirmed_by       INT REFERENCES users(id) ON DELETE SET NULL,
+    confirmed_at       TIMESTAMP,
+    disputed_by        INT REFERENCES users(id) ON DELETE SET NULL,
+    dispute_reason     TEXT,
+    disputed_at        TIMESTAMP,
+    resolved_by        INT REFERENCES users(id) ON DELETE SET NULL,
+    resolution_comment TEXT,
+    resolved_at        TIMESTAMP,
+    created_at         TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- у матча один действующий результат; отклонённые остаются в истории
+CREATE UNIQUE INDEX uniq_match_results_active
+    ON match_results(match_id)
+    WHERE status <> 'rejected';
+CREATE INDEX idx_match_results_disputed
+    ON match_results(disputed_at)
+    WHERE status = 'disputed';
+
+CREATE TABLE match_result_players (
+    result_id INT NOT NULL REFERENCES match_results(id) ON DELETE CASCADE,
+    user_id   INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team      SMALLINT NOT NULL CHECK (team IN (1, 2)),
+    PRIMARY KEY (result_id, user_id)
+);
+
+CREATE INDEX idx_match_result_players_user ON match_result_players(user_id);
+
+CREATE TABLE match_result_sets (
+    result_id      INT NOT NULL REFERENCES match_results(id) ON DELETE CASCADE,
+    set_number     SMALLINT NOT NULL CHECK (set_number BETWEEN 1 AND 5),
+    team1_games    SMALLINT NOT NULL CHECK (team1_games >= 0),
+    team2_games    SMALLINT NOT NULL CHECK (team2_games >= 0),
+    team1_tiebreak SMALLINT CHECK (team1_tiebreak >= 0),
+    team2_tiebreak SMALLINT CHECK (team2_tiebreak >= 0),
+    -- решающий тай-брейк до 10 вместо сета: в team*_games — очки
+    match_tiebreak BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (result_id, set_number)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS match_result_sets, match_result_players, match_results;
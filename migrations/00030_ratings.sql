@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE player_ratings (
+    user_id        INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sport_id       INT NOT NULL REFERENCES sports(id) ON DELETE RESTRICT,
+    rating         DOUBLE PRECISION NOT NULL,
+    matches_played INT NOT NULL DEFAULT 0,
+    updated_at     TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, sport_id)
+);
+
+CREATE INDEX idx_player_ratings_sport_rating ON player_ratings(sport_id, rating DESC);
+
+-- история изменений по каждому рейтинговому матчу; пересчёт строит её заново
+CREATE TABLE rating_history (
+    id            SERIAL PRIMARY KEY,
+    user_id       INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sport_id      INT NOT NULL REFERENCES sports(id) ON DELETE RESTRICT,
+    match_id      INT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    result_id     INT NOT NULL REFERENCES match_results(id) ON DELETE CASCADE,
+    rating_before DOUBLE PRECISION NOT NULL,
+    rating_after  DOUBLE PRECISION NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (result_id, user_id)
+);
+
+CREATE INDEX idx_rating_history_user ON rating_history(user_id, sport_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS rating_history, player_ratings;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- таблица друзей хранит пару один раз (user_id < friend_id); обратный поиск нужен для рейтинга среди друзей
+CREATE INDEX idx_friends_friend ON friends(friend_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_friends_friend;
@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE user_profile_visibility
+    ADD COLUMN show_in_suggestions BOOLEAN NOT NULL DEFAULT true;
+
+-- подбор партнёров ищет по общим видам спорта и времени тренировок
+CREATE INDEX idx_user_sports_sport ON user_sports(sport_id);
+CREATE INDEX idx_user_training_time_slots_slot ON user_training_time_slots(training_time_slot_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_training_time_slots_slot;
+DROP INDEX IF EXISTS idx_user_sports_sport;
+
+ALTER TABLE user_profile_visibility
+    DROP COLUMN IF EXISTS show_in_suggestions;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- состав команд матча: команда 1 или 2 и сторона корта в паре; NULL — ещё не распределён
+ALTER TABLE user_matches
+    ADD COLUMN team SMALLINT CHECK (team IN (1, 2)),
+    ADD COLUMN side VARCHAR(10) CHECK (side IN ('left', 'right')),
+    ADD CONSTRAINT user_matches_side_needs_team CHECK (side IS NULL OR team IS NOT NULL);
+
+CREATE UNIQUE INDEX uniq_user_matches_team_side ON user_matches(match_id, team, side)
+    WHERE side IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS uniq_user_matches_team_side;
+
+ALTER TABLE user_matches
+    DROP CONSTRAINT IF EXISTS user_matches_side_needs_team,
+    DROP COLUMN IF EXISTS side,
+    DROP COLUMN IF EXISTS team;
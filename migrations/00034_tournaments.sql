@@ -0,0 +1,75 @@
+-- +goose Up
+CREATE TABLE tournaments (
+    id              SERIAL PRIMARY KEY,
+    name            VARCHAR(200) NOT NULL,
+    format          VARCHAR(30) NOT NULL
+        CHECK (format IN ('single_elimination', 'round_robin', 'swiss', 'ladder')),
+    status          VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'in_progress', 'completed')),
+    sport_id        INT NOT NULL REFERENCES sports(id) ON DELETE RESTRICT,
+    town_id         INT REFERENCES towns(id) ON DELETE SET NULL,
+    match_type_id   INT NOT NULL REFERENCES match_types(id) ON DELETE RESTRICT,
+    -- 1 — одиночный разряд, 2 — пары
+    team_size       SMALLINT NOT NULL DEFAULT 1 CHECK (team_size IN (1, 2)),
+    swiss_rounds    SMALLINT CHECK (swiss_rounds > 0),
+    -- лестница: на сколько позиций выше можно вызвать соперника
+    challenge_range SMALLINT CHECK (challenge_range > 0),
+    current_round   INT NOT NULL DEFAULT 0,
+    organizer_id    INT REFERENCES users(id) ON DELETE SET NULL,
+    started_at      TIMESTAMP,
+    completed_at    TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_tournaments_status ON tournaments(status, created_at DESC);
+
+-- участник турнира — игрок или пара
+CREATE TABLE tournament_entries (
+    id            SERIAL PRIMARY KEY,
+    tournament_id INT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    seed          INT,
+    rating        DOUBLE PRECISION,
+    -- место на лестнице, 1 — верх
+    position      INT,
+    eliminated    BOOLEAN NOT NULL DEFAULT false,
+    created_at    TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_tournament_entries_tournament ON tournament_entries(tournament_id);
+
+CREATE TABLE tournament_entry_players (
+    entry_id      INT NOT NULL REFERENCES tournament_entries(id) ON DELETE CASCADE,
+    tournament_id INT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    user_id       INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (entry_id, user_id),
+    -- игрок заявлен в турнир один раз
+    UNIQUE (tournament_id, user_id)
+);
+
+-- встреча сетки; каждая сыгранная встреча — обычный матч
+CREATE TABLE tournament_fixtures (
+    id              SERIAL PRIMARY KEY,
+    tournament_id   INT NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    round           INT NOT NULL,
+    position        INT NOT NULL,
+    entry1_id       INT REFERENCES tournament_entries(id) ON DELETE CASCADE,
+    -- NULL при известном entry1_id и завершённой встрече — свободный проход
+    entry2_id       INT REFERENCES tournament_entries(id) ON DELETE CASCADE,
+    winner_entry_id INT REFERENCES tournament_entries(id) ON DELETE CASCADE,
+    match_id        INT UNIQUE REFERENCES matches(id) ON DELETE SET NULL,
+    -- сетка на выбывание: куда проходит победитель
+    next_fixture_id INT REFERENCES tournament_fixtures(id) ON DELETE SET NULL,
+    next_slot       SMALLINT CHECK (next_slot IN (1, 2)),
+    is_challenge    BOOLEAN NOT NULL DEFAULT false,
+    status          VARCHAR(20) NOT NULL DEFAULT 'waiting'
+        CHECK (status IN ('waiting', 'scheduled', 'completed')),
+    completed_at    TIMESTAMP,
+    created_at      TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX uniq_tournament_fixtures_slot ON tournament_fixtures(tournament_id, round, position)
+    WHERE NOT is_challenge;
+CREATE INDEX idx_tournament_fixtures_open ON tournament_fixtures(tournament_id, status);
+
+-- +goose Down
+DROP TABLE IF EXISTS tournament_fixtures, tournament_entry_players, tournament_entries, tournaments;
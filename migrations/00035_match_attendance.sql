@@ -0,0 +1,23 @@
+-- +goose Up
+-- минимальная надёжность для вступления в публичный матч; NULL — без порога
+ALTER TABLE matches
+    ADD COLUMN min_reliability SMALLINT CHECK (min_reliability BETWEEN 0 AND 100);
+
+-- неявки и поздние отмены; у игрока в матче не больше одной отметки
+CREATE TABLE match_attendance (
+    match_id    INT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status      VARCHAR(20) NOT NULL CHECK (status IN ('no_show', 'late_cancellation')),
+    -- NULL — записано автоматически при позднем выходе из матча
+    reported_by INT REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (match_id, user_id)
+);
+
+CREATE INDEX idx_match_attendance_user ON match_attendance(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS match_attendance;
+
+ALTER TABLE matches
+    DROP COLUMN IF EXISTS min_reliability;
@@ -0,0 +1,82 @@
+-- +goose Up
+-- сезон рейтинга по виду спорта; открытый сезон у вида спорта один
+CREATE TABLE rating_seasons (
+    id           SERIAL PRIMARY KEY,
+    sport_id     INT NOT NULL REFERENCES sports(id) ON DELETE RESTRICT,
+    name         VARCHAR(100) NOT NULL,
+    reset_factor DOUBLE PRECISION NOT NULL CHECK (reset_factor BETWEEN 0 AND 1),
+    started_by   INT REFERENCES users(id) ON DELETE SET NULL,
+    started_at   TIMESTAMP NOT NULL DEFAULT now(),
+    ended_at     TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uniq_rating_seasons_open ON rating_seasons(sport_id) WHERE ended_at IS NULL;
+
+-- итоговая таблица закрытого сезона: игроки, сыгравшие в сезоне хотя бы один рейтинговый матч
+CREATE TABLE rating_season_standings (
+    season_id      INT NOT NULL REFERENCES rating_seasons(id) ON DELETE CASCADE,
+    user_id        INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rank           INT NOT NULL,
+    rating         DOUBLE PRECISION NOT NULL,
+    matches_played INT NOT NULL,
+    PRIMARY KEY (season_id, user_id)
+);
+
+-- изменения рейтинга не по матчам: мягкий сброс в начале сезона и снижение за неактивность
+CREATE TABLE rating_adjustments (
+    id            SERIAL PRIMARY KEY,
+    user_id       INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sport_id      INT NOT NULL REFERENCES sports(id) ON DELETE RESTRICT,
+    kind          VARCHAR(20) NOT NULL CHECK (kind IN ('season_reset', 'decay')),
+    season_id     INT REFERENCES rating_seasons(id) ON DELETE CASCADE,
+    rating_before DOUBLE PRECISION NOT NULL,
+    rating_after  DOUBLE PRECISION NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_rating_adjustments_user ON rating_adjustments(user_id, sport_id, created_at DESC);
+
+-- когда рейтинг последний раз снижался за неактивность
+ALTER TABLE player_ratings
+    ADD COLUMN decayed_at TIMESTAMP;
+
+-- доля изменения после поправки на повторные встречи тех же составов
+ALTER TABLE rating_history
+    ADD COLUMN weight DOUBLE PRECISION NOT NULL DEFAULT 1;
+
+-- результат, исключённый ассистентом из рейтинга: пересчёт его пропускает
+ALTER TABLE match_results
+    ADD COLUMN rating_voided BOOLEAN NOT NULL DEFAULT false;
+
+-- подозрительные результаты в очереди ассистента
+CREATE TABLE rating_flags (
+    id             SERIAL PRIMARY KEY,
+    result_id      INT NOT NULL REFERENCES match_results(id) ON DELETE CASCADE,
+    match_id       INT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    sport_id       INT NOT NULL REFERENCES sports(id) ON DELETE RESTRICT,
+    reason         VARCHAR(30) NOT NULL CHECK (reason IN ('repeated_matchup')),
+    details        TEXT NOT NULL,
+    status         VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'dismissed', 'voided')),
+    reviewed_by    INT REFERENCES users(id) ON DELETE SET NULL,
+    review_comment TEXT,
+    reviewed_at    TIMESTAMP,
+    created_at     TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (result_id, reason)
+);
+
+CREATE INDEX idx_rating_flags_open ON rating_flags(created_at) WHERE status = 'open';
+
+-- +goose Down
+DROP TABLE IF EXISTS rating_flags;
+
+ALTER TABLE match_results
+    DROP COLUMN IF EXISTS rating_voided;
+
+ALTER TABLE rating_history
+    DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE player_ratings
+    DROP COLUMN IF EXISTS decayed_at;
+
+DROP TABLE IF EXISTS rating_adjustments, rating_season_standings, rating_seasons;
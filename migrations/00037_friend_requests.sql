@@ -0,0 +1,25 @@
+-- +goose Up
+-- заявки в друзья; принятая заявка добавляет пару в friends
+CREATE TABLE friend_requests (
+    id           SERIAL PRIMARY KEY,
+    sender_id    INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    receiver_id  INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status       VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    created_at   TIMESTAMP NOT NULL DEFAULT now(),
+    responded_at TIMESTAMP,
+    CHECK (sender_id <> receiver_id)
+);
+
+-- между двумя пользователями не больше одной ожидающей заявки в любую сторону
+CREATE UNIQUE INDEX idx_friend_requests_pending_pair
+    ON friend_requests (LEAST(sender_id, receiver_id), GREATEST(sender_id, receiver_id))
+    WHERE status = 'pending';
+
+CREATE INDEX idx_friend_requests_receiver ON friend_requests(receiver_id) WHERE status = 'pending';
+CREATE INDEX idx_friend_requests_sender ON friend_requests(sender_id) WHERE status = 'pending';
+CREATE INDEX idx_friends_friend ON friends(friend_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_friends_friend;
+DROP TABLE IF EXISTS friend_requests;
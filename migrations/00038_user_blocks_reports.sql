@@ -0,0 +1,57 @@
+-- +goose Up
+-- личный чёрный список: заблокированный не видит профиль, не приглашает и не попадает в подбор
+CREATE TABLE user_blocks (
+    blocker_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked ON user_blocks(blocked_id);
+
+-- жалобы на пользователя, сообщение или матч; разбирают ассистенты и администраторы
+CREATE TABLE reports (
+    id               SERIAL PRIMARY KEY,
+    reporter_id      INT REFERENCES users(id) ON DELETE SET NULL,
+    target_type      VARCHAR(20) NOT NULL CHECK (target_type IN ('user', 'message', 'match')),
+    target_id        INT NOT NULL,
+    -- на кого жалоба: сам пользователь, автор сообщения или организатор матча
+    reported_user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    reason           VARCHAR(30) NOT NULL
+        CHECK (reason IN ('spam', 'harassment', 'inappropriate_content', 'cheating', 'fake_profile', 'other')),
+    comment          TEXT,
+    status           VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    reviewed_by      INT REFERENCES users(id) ON DELETE SET NULL,
+    resolution       TEXT,
+    reviewed_at      TIMESTAMP,
+    created_at       TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- одна открытая жалоба пользователя на один объект
+CREATE UNIQUE INDEX idx_reports_open_target
+    ON reports (reporter_id, target_type, target_id)
+    WHERE status = 'open';
+
+CREATE INDEX idx_reports_status ON reports(status, created_at);
+
+INSERT INTO permissions (name)
+VALUES ('moderation.manage')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.name = 'moderation.manage'
+WHERE r.name IN ('assistant', 'admin')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
+
+-- +goose Down
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'moderation.manage');
+
+DELETE FROM permissions
+WHERE name = 'moderation.manage';
+
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS user_blocks;
@@ -0,0 +1,61 @@
+-- +goose Up
+-- вид активности может относиться к одному виду спорта; NULL — подходит для любого
+ALTER TABLE activities
+    ADD COLUMN sport_id INT REFERENCES sports(id) ON DELETE RESTRICT;
+
+ALTER TABLE activity_calendars
+    ADD COLUMN sport_id INT REFERENCES sports(id) ON DELETE RESTRICT,
+    ADD COLUMN sport_object_id INT REFERENCES sport_objects(id) ON DELETE RESTRICT,
+    ADD COLUMN court_id INT REFERENCES courts(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_user_activity_calendars_calendar ON user_activity_calendars(calendar_id);
+
+-- заявка пользователя на активность; в календарь она попадает только после подтверждения ассистентом
+CREATE TABLE activity_requests (
+    id              SERIAL PRIMARY KEY,
+    user_id         INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    activity_id     INT NOT NULL REFERENCES activities(id) ON DELETE RESTRICT,
+    sport_id        INT NOT NULL REFERENCES sports(id) ON DELETE RESTRICT,
+    sport_object_id INT NOT NULL REFERENCES sport_objects(id) ON DELETE RESTRICT,
+    court_id        INT REFERENCES courts(id) ON DELETE RESTRICT,
+    starts_at       TIMESTAMP NOT NULL,
+    ends_at         TIMESTAMP NOT NULL,
+    comment         TEXT,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'rejected', 'cancelled')),
+    reviewed_by     INT REFERENCES users(id) ON DELETE SET NULL,
+    review_comment  TEXT,
+    reviewed_at     TIMESTAMP,
+    calendar_id     INT REFERENCES activity_calendars(id) ON DELETE SET NULL,
+    created_at      TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_activity_requests_user ON activity_requests(user_id, created_at);
+CREATE INDEX idx_activity_requests_status ON activity_requests(status, starts_at);
+
+INSERT INTO activities (name)
+SELECT name
+FROM (VALUES
+    ('Персональная тренировка'),
+    ('Сплит-тренировка'),
+    ('Групповая тренировка'),
+    ('Аренда корта')
+) AS v(name)
+WHERE NOT EXISTS (SELECT 1 FROM activities a WHERE a.name = v.name);
+
+-- +goose Down
+DROP TABLE IF EXISTS activity_requests;
+
+DELETE FROM activities
+WHERE name IN ('Персональная тренировка', 'Сплит-тренировка', 'Групповая тренировка', 'Аренда корта');
+
+DROP INDEX IF EXISTS idx_user_activity_calendars_calendar;
+
+ALTER TABLE activity_calendars
+    DROP COLUMN IF EXISTS court_id,
+    DROP COLUMN IF EXISTS sport_object_id,
+    DROP COLUMN IF EXISTS sport_id;
+
+ALTER TABLE activities
+    DROP COLUMN IF EXISTS sport_id;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- места в таблицах рейтинга считаются заранее, а не в каждом запросе. Обновляется сервисом
+-- после подтверждения результатов, пересчётов, сезонов, затухания и смены видимости профиля.
+-- В таблицу попадают только игроки с открытым рейтингом; город — только у тех, кто его не скрыл,
+-- поэтому скрывшие город не участвуют в таблицах по городу.
+CREATE MATERIALIZED VIEW leaderboard_ranks AS
+SELECT board.*,
+       RANK() OVER (PARTITION BY board.sport_id, board.town_id ORDER BY board.rating DESC) AS town_rank,
+       ROW_NUMBER() OVER (PARTITION BY board.sport_id, board.town_id ORDER BY board.rating DESC, board.user_id) AS town_position
+FROM (
+    SELECT pr.sport_id, pr.user_id, pr.rating, pr.matches_played,
+           CASE WHEN COALESCE(v.show_town, true) THEN u.town_id END AS town_id,
+           RANK() OVER (PARTITION BY pr.sport_id ORDER BY pr.rating DESC) AS rank,
+           ROW_NUMBER() OVER (PARTITION BY pr.sport_id ORDER BY pr.rating DESC, pr.user_id) AS position
+    FROM player_ratings pr
+    JOIN users u ON u.id = pr.user_id
+    LEFT JOIN user_profile_visibility v ON v.user_id = pr.user_id
+    WHERE u.deleted_at IS NULL
+      AND u.blocked_at IS NULL
+      AND COALESCE(v.show_rating, true)
+) board;
+
+-- уникальный индекс нужен для REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX idx_leaderboard_ranks_user ON leaderboard_ranks(sport_id, user_id);
+CREATE INDEX idx_leaderboard_ranks_position ON leaderboard_ranks(sport_id, position);
+CREATE INDEX idx_leaderboard_ranks_town_position ON leaderboard_ranks(sport_id, town_id, town_position)
+    WHERE town_id IS NOT NULL;
+CREATE INDEX idx_leaderboard_ranks_rating ON leaderboard_ranks(sport_id, rating DESC);
+
+-- +goose Down
+DROP MATERIALIZED VIEW IF EXISTS leaderboard_ranks;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- матчи встреч получают время по расписанию турнира: круг N начинается через
+-- (N - 1) * round_interval_minutes после starts_at
+ALTER TABLE tournaments
+    ADD COLUMN starts_at              TIMESTAMP,
+    ADD COLUMN round_interval_minutes INT NOT NULL DEFAULT 1440 CHECK (round_interval_minutes > 0),
+    ADD COLUMN match_duration_minutes INT NOT NULL DEFAULT 90 CHECK (match_duration_minutes > 0);
+
+-- +goose Down
+ALTER TABLE tournaments
+    DROP COLUMN IF EXISTS match_duration_minutes,
+    DROP COLUMN IF EXISTS round_interval_minutes,
+    DROP COLUMN IF EXISTS starts_at;
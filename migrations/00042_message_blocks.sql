@@ -0,0 +1,30 @@
+-- +goose Up
+-- блокировка в любую сторону запрещает сообщения в личном чате пары (чат из двух участников);
+-- проверка в базе действует для любого кода, который пишет в messages
+-- +goose StatementBegin
+CREATE FUNCTION reject_blocked_message() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT count(*) FROM chat_members WHERE chat_id = NEW.chat_id) = 2 AND EXISTS (
+        SELECT 1
+        FROM chat_members cm
+        JOIN user_blocks b
+          ON (b.blocker_id = NEW.sender_id AND b.blocked_id = cm.user_id)
+          OR (b.blocker_id = cm.user_id AND b.blocked_id = NEW.sender_id)
+        WHERE cm.chat_id = NEW.chat_id
+    ) THEN
+        RAISE EXCEPTION 'messages between blocked users are not allowed'
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_messages_reject_blocked
+    BEFORE INSERT ON messages
+    FOR EACH ROW EXECUTE FUNCTION reject_blocked_message();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_messages_reject_blocked ON messages;
+DROP FUNCTION IF EXISTS reject_blocked_message();
@@ -0,0 +1,13 @@
+-- +goose Up
+-- жалобы участников на неявку другого участника; неявка засчитывается,
+-- когда жалобу подало большинство остальных участников матча
+CREATE TABLE match_no_show_reports (
+    match_id    INT NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reported_by INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at  TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (match_id, user_id, reported_by)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS match_no_show_reports;
@@ -0,0 +1,22 @@
+package commons
+
+// Права, от которых зависит бизнес-логика сервисов (а не только маршрутизация)
+const (
+	PermissionProfileViewOwn   = "profile.view.own"
+	PermissionProfileEditOwn   = "profile.edit.own"
+	PermissionProfileViewAny   = "profile.view.any"
+	PermissionAdminUsersManage = "admin.users.manage"
+	PermissionMatchManageAny   = "match.manage.any"
+	PermissionMatchInviteUsers = "match.invite.users"
+)
+
+// HasPermission проверяет, есть ли право в списке из access-токена
+func HasPermission(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
@@ -30,12 +30,13 @@ type ServerConfig struct {
 }
 
 type SecurityConfig struct {
-	AccessTokenTTL         time.Duration
-	AccessTokenSecret      string
-	RefreshTokenTTL        time.Duration
-	RefreshTokenSecret     string
-	AccessTokenRedisPrefix string
-	OtpRedisPrefix         string
+	AccessTokenTTL           time.Duration
+	AccessTokenSecret        string
+	RefreshTokenTTL          time.Duration
+	RefreshTokenSecret       string
+	AccessTokenRedisPrefix   string
+	OtpRedisPrefix           string
+	ContactChangeRedisPrefix string
 }
 
 type LoggerConfig struct {
@@ -51,13 +52,73 @@ type RedisConfig struct {
 type SwaggerConfig struct {
 	SwaggerEnabled bool
 }
+
+type StorageConfig struct {
+	Driver        string // local | s3
+	LocalDir      string
+	PublicBaseURL string // базовый URL для ссылок на файлы из локального хранилища
+	URLSecret     string // секрет подписи ссылок локального хранилища
+	SignedURLTTL  time.Duration
+	MaxPhotoSize  int64
+
+	S3Endpoint  string
+	S3AccessKey string
+	S3SecretKey string
+	S3Bucket    string
+	S3Region    string
+	S3UseSSL    bool
+}
+
+type AccountConfig struct {
+	DeletionGracePeriod time.Duration // сколько аккаунт ждёт после запроса на удаление
+	PurgeInterval       time.Duration // как часто запускается обезличивание
+	PurgeBatchSize      int
+}
+
+type SubscriptionConfig struct {
+	Period          time.Duration // срок действия оформленной подписки
+	ExpiryInterval  time.Duration // как часто проверяются истёкшие подписки
+	ExpiryBatchSize int
+}
+
+type MatchConfig struct {
+	InvitationTTL         time.Duration // сколько действует приглашение в матч (но не дольше начала матча)
+	InviteLinkSecret      string        // ключ подписи ссылок-приглашений
+	InviteLinkTTL         time.Duration // срок ссылки по умолчанию (но не дольше начала матча)
+	InviteLinkBaseURL     string        // куда ведёт ссылка: токен дописывается в конец
+	InviteLinkRedisPrefix string        // ссылка, ждущая регистрации, по номеру телефона
+
+	LateCancellationWindow time.Duration // выход из матча ближе к началу считается поздней отменой
+	RankedMinReliability   int           // порог надёжности для рейтинговых публичных матчей без своего порога
+}
+
+type RatingConfig struct {
+	InitialRating      float64 // рейтинг игрока до первого рейтингового матча
+	KFactor            float64
+	ProvisionalKFactor float64 // K-фактор для первых ProvisionalMatches матчей: рейтинг новичка быстрее находит уровень
+	ProvisionalMatches int
+
+	SeasonResetFactor float64       // доля, на которую рейтинг притягивается к начальному в начале сезона
+	DecayAfter        time.Duration // сколько без рейтинговых матчей до начала снижения
+	DecayInterval     time.Duration // как часто снижается рейтинг неактивного игрока
+	DecayPoints       float64       // на сколько за раз
+	RepeatWindow      time.Duration // окно, в котором повторные встречи тех же составов весят меньше
+	RepeatFactor      float64       // множитель изменения за каждую предыдущую встречу в окне
+	FlagRepeats       int           // столько встреч в окне с одним победителем попадают к ассистенту
+}
+
 type Config struct {
-	ServerConfig   ServerConfig
-	DatabaseConfig DatabaseConfig
-	SecurityConfig SecurityConfig
-	Logger         LoggerConfig
-	RedisConfig    RedisConfig
-	SwaggerConfig  SwaggerConfig
+	ServerConfig       ServerConfig
+	DatabaseConfig     DatabaseConfig
+	SecurityConfig     SecurityConfig
+	Logger             LoggerConfig
+	RedisConfig        RedisConfig
+	SwaggerConfig      SwaggerConfig
+	StorageConfig      StorageConfig
+	AccountConfig      AccountConfig
+	SubscriptionConfig SubscriptionConfig
+	MatchConfig        MatchConfig
+	RatingConfig       RatingConfig
 }
 
 func GetConfigs() (*Config, error) {
@@ -88,6 +149,71 @@ func GetConfigs() (*Config, error) {
 
 	isSwaggerEnabled, err := strconv.ParseBool(getEnv("SWAGGER_ENABLED", "false"))
 
+	maxPhotoSize, err := strconv.ParseInt(getEnv("STORAGE_MAX_PHOTO_SIZE", "10485760"), 10, 64)
+	if err != nil {
+		maxPhotoSize = 10 << 20
+	}
+
+	s3UseSSL, err := strconv.ParseBool(getEnv("STORAGE_S3_USE_SSL", "false"))
+	if err != nil {
+		s3UseSSL = false
+	}
+
+	purgeBatchSize, err := strconv.Atoi(getEnv("ACCOUNT_PURGE_BATCH_SIZE", "100"))
+	if err != nil {
+		purgeBatchSize = 100
+	}
+
+	expiryBatchSize, err := strconv.Atoi(getEnv("SUBSCRIPTION_EXPIRY_BATCH_SIZE", "100"))
+	if err != nil {
+		expiryBatchSize = 100
+	}
+
+	rankedMinReliability, err := strconv.Atoi(getEnv("MATCH_RANKED_MIN_RELIABILITY", "70"))
+	if err != nil {
+		rankedMinReliability = 70
+	}
+
+	initialRating, err := strconv.ParseFloat(getEnv("RATING_INITIAL", "1500"), 64)
+	if err != nil {
+		initialRating = 1500
+	}
+
+	ratingK, err := strconv.ParseFloat(getEnv("RATING_K_FACTOR", "24"), 64)
+	if err != nil {
+		ratingK = 24
+	}
+
+	provisionalK, err := strconv.ParseFloat(getEnv("RATING_PROVISIONAL_K_FACTOR", "40"), 64)
+	if err != nil {
+		provisionalK = 40
+	}
+
+	provisionalMatches, err := strconv.Atoi(getEnv("RATING_PROVISIONAL_MATCHES", "10"))
+	if err != nil {
+		provisionalMatches = 10
+	}
+
+	seasonResetFactor, err := strconv.ParseFloat(getEnv("RATING_SEASON_RESET_FACTOR", "0.5"), 64)
+	if err != nil {
+		seasonResetFactor = 0.5
+	}
+
+	decayPoints, err := strconv.ParseFloat(getEnv("RATING_DECAY_POINTS", "15"), 64)
+	if err != nil {
+		decayPoints = 15
+	}
+
+	repeatFactor, err := strconv.ParseFloat(getEnv("RATING_REPEAT_FACTOR", "0.5"), 64)
+	if err != nil {
+		repeatFactor = 0.5
+	}
+
+	flagRepeats, err := strconv.Atoi(getEnv("RATING_FLAG_REPEATS", "4"))
+	if err != nil {
+		flagRepeats = 4
+	}
+
 	return &Config{
 		ServerConfig: ServerConfig{
 			Port:         getEnv("PORT", "8080"),
@@ -112,12 +238,13 @@ func GetConfigs() (*Config, error) {
 			DBName:   getEnv("REDIS_DB", ""),
 		},
 		SecurityConfig: SecurityConfig{
-			AccessTokenSecret:      getEnv("SECURITY_JWT_ACCESS_SECRET_KEY", ""),
-			AccessTokenTTL:         utils.ToDuration(getEnv("SECURITY_JWT_ACCESS_TOKEN_TTL", "10m")),
-			AccessTokenRedisPrefix: getEnv("SECURITY_JWT_ACCESS_TOKEN_REDIS_PREFIX", "auth:access_token:%d"),
-			RefreshTokenSecret:     getEnv("SECURITY_JWT_REFRESH_SECRET_KEY", ""),
-			RefreshTokenTTL:        utils.ToDuration(getEnv("SECURITY_JWT_REFRESH_TOKEN_TTL", "720h")),
-			OtpRedisPrefix:         getEnv("OTP_REDIS_PREFIX", "auth:otp:code:%s"),
+			AccessTokenSecret:        getEnv("SECURITY_JWT_ACCESS_SECRET_KEY", ""),
+			AccessTokenTTL:           utils.ToDuration(getEnv("SECURITY_JWT_ACCESS_TOKEN_TTL", "10m")),
+			AccessTokenRedisPrefix:   getEnv("SECURITY_JWT_ACCESS_TOKEN_REDIS_PREFIX", "auth:access_token:%d"),
+			RefreshTokenSecret:       getEnv("SECURITY_JWT_REFRESH_SECRET_KEY", ""),
+			RefreshTokenTTL:          utils.ToDuration(getEnv("SECURITY_JWT_REFRESH_TOKEN_TTL", "720h")),
+			OtpRedisPrefix:           getEnv("OTP_REDIS_PREFIX", "auth:otp:code:%s"),
+			ContactChangeRedisPrefix: getEnv("CONTACT_CHANGE_REDIS_PREFIX", "auth:contact_change:%d:%s"),
 		},
 		Logger: LoggerConfig{
 			Level: getEnv("LOG_LEVEL", "info"),
@@ -125,6 +252,53 @@ func GetConfigs() (*Config, error) {
 		SwaggerConfig: SwaggerConfig{
 			SwaggerEnabled: isSwaggerEnabled,
 		},
+		StorageConfig: StorageConfig{
+			Driver:        getEnv("STORAGE_DRIVER", "local"),
+			LocalDir:      getEnv("STORAGE_LOCAL_DIR", "./data/uploads"),
+			PublicBaseURL: getEnv("STORAGE_PUBLIC_BASE_URL", "http://localhost:8080/api/v1/files"),
+			URLSecret:     getEnv("STORAGE_URL_SECRET", ""),
+			SignedURLTTL:  utils.ToDuration(getEnv("STORAGE_SIGNED_URL_TTL", "1h")),
+			MaxPhotoSize:  maxPhotoSize,
+			S3Endpoint:    getEnv("STORAGE_S3_ENDPOINT", "localhost:9000"),
+			S3AccessKey:   getEnv("STORAGE_S3_ACCESS_KEY", ""),
+			S3SecretKey:   getEnv("STORAGE_S3_SECRET_KEY", ""),
+			S3Bucket:      getEnv("STORAGE_S3_BUCKET", "sport-assistance"),
+			S3Region:      getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3UseSSL:      s3UseSSL,
+		},
+		AccountConfig: AccountConfig{
+			DeletionGracePeriod: utils.ToDuration(getEnv("ACCOUNT_DELETION_GRACE_PERIOD", "720h")),
+			PurgeInterval:       utils.ToDuration(getEnv("ACCOUNT_PURGE_INTERVAL", "1h")),
+			PurgeBatchSize:      purgeBatchSize,
+		},
+		SubscriptionConfig: SubscriptionConfig{
+			Period:          utils.ToDuration(getEnv("SUBSCRIPTION_PERIOD", "720h")),
+			ExpiryInterval:  utils.ToDuration(getEnv("SUBSCRIPTION_EXPIRY_INTERVAL", "10m")),
+			ExpiryBatchSize: expiryBatchSize,
+		},
+		MatchConfig: MatchConfig{
+			InvitationTTL:         utils.ToDuration(getEnv("MATCH_INVITATION_TTL", "72h")),
+			InviteLinkSecret:      getEnv("MATCH_INVITE_LINK_SECRET", ""),
+			InviteLinkTTL:         utils.ToDuration(getEnv("MATCH_INVITE_LINK_TTL", "168h")),
+			InviteLinkBaseURL:     getEnv("MATCH_INVITE_LINK_BASE_URL", "http://localhost:8080/invite/"),
+			InviteLinkRedisPrefix: getEnv("MATCH_INVITE_LINK_REDIS_PREFIX", "match:invite_link:%s"),
+
+			LateCancellationWindow: utils.ToDuration(getEnv("MATCH_LATE_CANCELLATION_WINDOW", "24h")),
+			RankedMinReliability:   rankedMinReliability,
+		},
+		RatingConfig: RatingConfig{
+			InitialRating:      initialRating,
+			KFactor:            ratingK,
+			ProvisionalKFactor: provisionalK,
+			ProvisionalMatches: provisionalMatches,
+			SeasonResetFactor:  seasonResetFactor,
+			DecayAfter:         utils.ToDuration(getEnv("RATING_DECAY_AFTER", "1440h")),
+			DecayInterval:      utils.ToDuration(getEnv("RATING_DECAY_INTERVAL", "168h")),
+			DecayPoints:        decayPoints,
+			RepeatWindow:       utils.ToDuration(getEnv("RATING_REPEAT_WINDOW", "720h")),
+			RepeatFactor:       repeatFactor,
+			FlagRepeats:        flagRepeats,
+		},
 	}, nil
 }
 
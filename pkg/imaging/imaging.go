@@ -0,0 +1,53 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+
+	"golang.org/x/image/draw"
+)
+
+// Fit уменьшает изображение так, чтобы большая сторона не превышала maxSide.
+// Изображения меньше maxSide не увеличиваются.
+func Fit(src image.Image, maxSide int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxSide && height <= maxSide {
+		return src
+	}
+
+	if width >= height {
+		height = height * maxSide / width
+		width = maxSide
+	} else {
+		width = width * maxSide / height
+		height = maxSide
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+	return dst
+}
+
+// Thumbnail вырезает центральный квадрат и масштабирует его до side×side
+func Thumbnail(src image.Image, side int) image.Image {
+	bounds := src.Bounds()
+	square := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-square)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-square)/2
+	crop := image.Rect(x0, y0, x0+square, y0+square)
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+	return dst
+}
+
+// EncodeJPEG кодирует изображение заново. Метаданные исходника (EXIF, GPS, ICC) при этом не переносятся.
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
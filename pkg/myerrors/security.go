@@ -14,11 +14,23 @@ const (
 	ErrCodeDatabase        ErrorCode = "DATABASE"
 	ErrCodeValidation      ErrorCode = "VALIDATION_ERROR"
 	ErrParseData           ErrorCode = "PARSE_ERROR"
+	ErrCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrCodeForbidden       ErrorCode = "FORBIDDEN"
+	ErrCodeConflict        ErrorCode = "CONFLICT"
 )
 
 var (
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 	ErrRefreshTokenInvalid  = errors.New("refresh token invalid or expired")
+	ErrContactAlreadyUsed   = errors.New("contact is already used by another user")
+	ErrInvalidReference     = errors.New("referenced record does not exist")
+	ErrMatchFull            = errors.New("match has no free spots")
+	ErrScheduleConflict     = errors.New("schedule conflict")
+	ErrResultExists         = errors.New("match already has a result")
+	ErrAlreadyEntered       = errors.New("player is already entered in the tournament")
+	ErrOpenChallenge        = errors.New("ladder entry already has an open challenge")
+	ErrFriendRequestExists  = errors.New("friend request between these users is already pending")
+	ErrReportExists         = errors.New("report on this target is already open")
 )
 
 const (
@@ -40,12 +52,14 @@ const (
 type Response struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
 }
 
 type AppError struct {
 	Code    ErrorCode
 	Message string
 	Err     error
+	Details any // структурированные подробности для клиента, например пересекающиеся события
 }
 
 func (e AppError) Error() string {
@@ -63,6 +77,7 @@ func (e AppError) Unwrap() error {
 func (e AppError) ToResponse() Response {
 	r := Response{
 		Message: e.Message,
+		Details: e.Details,
 	}
 	if e.Err != nil {
 		r.Error = e.Err.Error()
@@ -101,3 +116,21 @@ func NewValidationError(message string, err error) AppError {
 func NewParseErr(message string, err error) AppError {
 	return NewAppError(ErrParseData, message, err)
 }
+
+func NewNotFoundErr(message string, err error) AppError {
+	return NewAppError(ErrCodeNotFound, message, err)
+}
+
+func NewForbiddenErr(message string, err error) AppError {
+	return NewAppError(ErrCodeForbidden, message, err)
+}
+
+func NewConflictErr(message string, err error) AppError {
+	return NewAppError(ErrCodeConflict, message, err)
+}
+
+// WithDetails возвращает копию ошибки с подробностями для ответа клиенту
+func (e AppError) WithDetails(details any) AppError {
+	e.Details = details
+	return e
+}
@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Notifier доставляет сообщения пользователю по SMS или email
+type Notifier interface {
+	SendSMS(ctx context.Context, phone, text string) error
+	SendEmail(ctx context.Context, email, subject, body string) error
+}
+
+// LogNotifier — заглушка до подключения SMS/email-провайдера: пишет сообщения в лог
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+func NewLogNotifier(log *slog.Logger) *LogNotifier {
+	return &LogNotifier{logger: log}
+}
+
+func (n *LogNotifier) SendSMS(_ context.Context, phone, text string) error {
+	n.logger.Info("sms notification", "phone", phone, "text", text)
+	return nil
+}
+
+func (n *LogNotifier) SendEmail(_ context.Context, email, subject, body string) error {
+	n.logger.Info("email notification", "email", email, "subject", subject, "body", body)
+	return nil
+}
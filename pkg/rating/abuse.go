@@ -0,0 +1,87 @@
+package rating
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Matchup — встреча двух составов без учёта номеров команд: по Key повторные встречи
+// находятся независимо от того, кто был командой 1. WinnerSide — 1 или 2 в порядке Key.
+type Matchup struct {
+	Key        string
+	WinnerSide int
+}
+
+// NewMatchup строит ключ встречи: составы сортируются, меньший идёт первым
+func NewMatchup(team1, team2 []uint64, winnerTeam int) Matchup {
+	side1, side2 := sideKey(team1), sideKey(team2)
+	if side1 > side2 {
+		side1, side2 = side2, side1
+		winnerTeam = 3 - winnerTeam
+	}
+
+	return Matchup{Key: side1 + "|" + side2, WinnerSide: winnerTeam}
+}
+
+func sideKey(team []uint64) string {
+	ids := slices.Clone(team)
+	slices.Sort(ids)
+
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatUint(id, 10)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Repeats — сколько раз среди previous встречались те же составы, что в current
+func Repeats(previous []Matchup, current Matchup) int {
+	n := 0
+	for _, m := range previous {
+		if m.Key == current.Key {
+			n++
+		}
+	}
+
+	return n
+}
+
+// RepeatWeight — доля изменения рейтинга, если эти составы уже встречались previous раз в окне:
+// первая встреча меняет рейтинг полностью, каждая следующая — в RepeatFactor раз слабее
+func (e Elo) RepeatWeight(previous int) float64 {
+	if e.RepeatWindow <= 0 || previous <= 0 || e.RepeatFactor >= 1 {
+		return 1
+	}
+
+	return math.Pow(max(e.RepeatFactor, 0), float64(previous))
+}
+
+// RepeatSince — начало окна повторных встреч для матча, подтверждённого в at
+func (e Elo) RepeatSince(at time.Time) time.Time {
+	return at.Add(-e.RepeatWindow)
+}
+
+// Suspicious — текущая встреча вместе с previous даёт не меньше FlagRepeats встреч тех же составов,
+// и все их выиграла одна сторона: похоже на накрутку рейтинга
+func (e Elo) Suspicious(previous []Matchup, current Matchup) bool {
+	if e.FlagRepeats <= 1 {
+		return false
+	}
+
+	same := 1
+	for _, m := range previous {
+		if m.Key != current.Key {
+			continue
+		}
+		if m.WinnerSide != current.WinnerSide {
+			return false
+		}
+		same++
+	}
+
+	return same >= e.FlagRepeats
+}
@@ -0,0 +1,129 @@
+package rating
+
+import (
+	"math"
+	"sport-assistance/pkg/configs"
+	"time"
+)
+
+// Player — рейтинг игрока перед матчем
+type Player struct {
+	UserID  uint64
+	Rating  float64
+	Matches int // сколько рейтинговых матчей уже сыграно в этом виде спорта
+}
+
+// Change — изменение рейтинга игрока за матч
+type Change struct {
+	UserID uint64
+	Before float64
+	After  float64
+}
+
+// Elo — рейтинг Эло с повышенным K-фактором для новичков.
+// В парах сила команды — средний рейтинг её игроков, изменение получает каждый игрок.
+type Elo struct {
+	Initial            float64 // рейтинг нового игрока
+	K                  float64
+	ProvisionalK       float64 // K-фактор, пока не сыграно ProvisionalMatches матчей
+	ProvisionalMatches int
+
+	RepeatWindow time.Duration // окно, в котором повторные встречи тех же составов весят меньше
+	RepeatFactor float64       // множитель изменения за каждую предыдущую встречу в окне
+	FlagRepeats  int           // столько встреч в окне с одним победителем попадают к ассистенту
+}
+
+// Rate пересчитывает рейтинги участников по итогу матча; winnerTeam — 1 или 2
+func (e Elo) Rate(team1, team2 []Player, winnerTeam int) []Change {
+	return e.RateWeighted(team1, team2, winnerTeam, 1)
+}
+
+// RateWeighted — Rate, где изменение умножено на weight (см. RepeatWeight)
+func (e Elo) RateWeighted(team1, team2 []Player, winnerTeam int, weight float64) []Change {
+	expected1 := 1 / (1 + math.Pow(10, (average(team2)-average(team1))/400))
+	score1 := 0.0
+	if winnerTeam == 1 {
+		score1 = 1
+	}
+
+	changes := make([]Change, 0, len(team1)+len(team2))
+	for _, p := range team1 {
+		changes = append(changes, e.change(p, (score1-expected1)*weight))
+	}
+	for _, p := range team2 {
+		changes = append(changes, e.change(p, (expected1-score1)*weight))
+	}
+
+	return changes
+}
+
+func (e Elo) change(p Player, surprise float64) Change {
+	k := e.K
+	if p.Matches < e.ProvisionalMatches {
+		k = e.ProvisionalK
+	}
+
+	return Change{UserID: p.UserID, Before: p.Rating, After: round(p.Rating + k*surprise)}
+}
+
+// SoftReset — рейтинг в начале нового сезона: отклонение от начального сокращается на долю factor
+func (e Elo) SoftReset(r, factor float64) float64 {
+	return round(e.Initial + (r-e.Initial)*(1-factor))
+}
+
+// Decayed — рейтинг после снижения на points за неактивность; ниже начального не опускается,
+// а рейтинг не выше начального не снижается вовсе
+func (e Elo) Decayed(r, points float64) float64 {
+	if r <= e.Initial {
+		return r
+	}
+	return round(math.Max(r-points, e.Initial))
+}
+
+// рейтинг хранится с точностью до сотых, иначе повторный пересчёт даёт другой результат
+func round(r float64) float64 {
+	return math.Round(r*100) / 100
+}
+
+func average(team []Player) float64 {
+	if len(team) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, p := range team {
+		sum += p.Rating
+	}
+
+	return sum / float64(len(team))
+}
+
+// NewElo собирает формулу из настроек RATING_*
+func NewElo(cfg configs.RatingConfig) Elo {
+	return Elo{
+		Initial:            cfg.InitialRating,
+		K:                  cfg.KFactor,
+		ProvisionalK:       cfg.ProvisionalKFactor,
+		ProvisionalMatches: cfg.ProvisionalMatches,
+		RepeatWindow:       cfg.RepeatWindow,
+		RepeatFactor:       cfg.RepeatFactor,
+		FlagRepeats:        cfg.FlagRepeats,
+	}
+}
+
+// Decay — снижение рейтинга за неактивность: раз в Every игрок без рейтинговых матчей дольше After
+// теряет Points (см. Elo.Decayed)
+type Decay struct {
+	After  time.Duration
+	Every  time.Duration
+	Points float64
+}
+
+// NewDecay собирает правило снижения из настроек RATING_DECAY_*
+func NewDecay(cfg configs.RatingConfig) Decay {
+	return Decay{
+		After:  cfg.DecayAfter,
+		Every:  cfg.DecayInterval,
+		Points: cfg.DecayPoints,
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sport-assistance/pkg/configs"
+	"time"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var (
+	ErrObjectNotFound   = errors.New("object not found")
+	ErrInvalidSignature = errors.New("invalid or expired signature")
+)
+
+// BlobStorage — хранилище бинарных объектов (фото профиля, миниатюры и т.д.)
+type BlobStorage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// SignedURL возвращает ссылку на скачивание, действующую ttl
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// SignedReader реализуют хранилища, которые раздают файлы через наш API
+// (S3 отдаёт файлы сам по presigned-ссылке и этот интерфейс не реализует)
+type SignedReader interface {
+	OpenSigned(ctx context.Context, key string, expires int64, signature string) (io.ReadCloser, string, error)
+}
+
+// New создаёт хранилище по драйверу из конфига
+func New(cfg configs.StorageConfig) (BlobStorage, error) {
+	switch cfg.Driver {
+	case DriverLocal, "":
+		return NewLocalStorage(cfg.LocalDir, cfg.PublicBaseURL, cfg.URLSecret)
+	case DriverS3:
+		return NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Driver)
+	}
+}
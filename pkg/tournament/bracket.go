@@ -0,0 +1,250 @@
+package tournament
+
+import "sort"
+
+// Pair — встреча двух участников; 0 вместо участника — свободный проход (bye)
+type Pair struct {
+	Entry1 uint64
+	Entry2 uint64
+}
+
+// SingleElimination раскладывает посев (сильнейший первым) по сетке на выбывание.
+// Размер сетки — ближайшая степень двойки; свободные проходы достаются верхним сеяным.
+// Возвращается первый круг; пара i следующего круга собирается из победителей пар 2i и 2i+1.
+func SingleElimination(seeded []uint64) []Pair {
+	size := 1
+	for size < len(seeded) {
+		size *= 2
+	}
+
+	entry := func(seed int) uint64 {
+		if seed > len(seeded) {
+			return 0
+		}
+		return seeded[seed-1]
+	}
+
+	order := seedOrder(size)
+	pairs := make([]Pair, 0, size/2)
+	for i := 0; i < len(order); i += 2 {
+		pairs = append(pairs, Pair{Entry1: entry(order[i]), Entry2: entry(order[i+1])})
+	}
+
+	return pairs
+}
+
+// Rounds — число кругов сетки на выбывание для n участников
+func Rounds(n int) int {
+	rounds := 0
+	for size := 1; size < n; size *= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// seedOrder — классическая расстановка посева: 1 и 2 встречаются только в финале
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		sum := len(order)*2 + 1
+		for _, seed := range order {
+			next = append(next, seed, sum-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// RoundRobin составляет круговой турнир методом «карусели»: каждый встречается с каждым один раз.
+// При нечётном числе участников в каждом круге один отдыхает (пара с 0 не возвращается).
+func RoundRobin(entries []uint64) [][]Pair {
+	players := append([]uint64(nil), entries...)
+	if len(players)%2 == 1 {
+		players = append(players, 0)
+	}
+	n := len(players)
+	if n < 2 {
+		return nil
+	}
+
+	rounds := make([][]Pair, 0, n-1)
+	for round := 0; round < n-1; round++ {
+		pairs := make([]Pair, 0, n/2)
+		for i := 0; i < n/2; i++ {
+			a, b := players[i], players[n-1-i]
+			if a == 0 || b == 0 {
+				continue
+			}
+			// чередуем, кто записан первым, чтобы у первого сеяного не было всегда одной стороны
+			if round%2 == 1 && i == 0 {
+				a, b = b, a
+			}
+			pairs = append(pairs, Pair{Entry1: a, Entry2: b})
+		}
+		rounds = append(rounds, pairs)
+
+		// первый стоит на месте, остальные сдвигаются по кругу
+		last := players[n-1]
+		copy(players[2:], players[1:n-1])
+		players[1] = last
+	}
+
+	return rounds
+}
+
+// Standing — место участника по итогам сыгранных встреч
+type Standing struct {
+	EntryID  uint64
+	Seed     int
+	Played   int
+	Wins     int
+	Losses   int
+	Byes     int
+	Buchholz int // сумма побед соперников — разбивает равенство побед
+}
+
+// Result — сыгранная встреча; Winner == 0 — встреча ещё не сыграна
+type Result struct {
+	Entry1 uint64
+	Entry2 uint64
+	Winner uint64
+}
+
+// Standings считает таблицу: победы, затем коэффициент Бухгольца, затем посев.
+// Свободный проход засчитывается победой.
+func Standings(seeds map[uint64]int, results []Result) []Standing {
+	byEntry := make(map[uint64]*Standing, len(seeds))
+	for id, seed := range seeds {
+		byEntry[id] = &Standing{EntryID: id, Seed: seed}
+	}
+
+	opponents := make(map[uint64][]uint64)
+	for _, r := range results {
+		if r.Winner == 0 {
+			continue
+		}
+		if r.Entry1 == 0 || r.Entry2 == 0 {
+			if s, ok := byEntry[r.Winner]; ok {
+				s.Wins++
+				s.Byes++
+			}
+			continue
+		}
+
+		for _, id := range []uint64{r.Entry1, r.Entry2} {
+			s, ok := byEntry[id]
+			if !ok {
+				continue
+			}
+			s.Played++
+			if id == r.Winner {
+				s.Wins++
+			} else {
+				s.Losses++
+			}
+		}
+		opponents[r.Entry1] = append(opponents[r.Entry1], r.Entry2)
+		opponents[r.Entry2] = append(opponents[r.Entry2], r.Entry1)
+	}
+
+	standings := make([]Standing, 0, len(byEntry))
+	for id, s := range byEntry {
+		for _, opp := range opponents[id] {
+			if o, ok := byEntry[opp]; ok {
+				s.Buchholz += o.Wins
+			}
+		}
+		standings = append(standings, *s)
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.Buchholz != b.Buchholz {
+			return a.Buchholz > b.Buchholz
+		}
+		return a.Seed < b.Seed
+	})
+
+	return standings
+}
+
+// SwissPairings составляет следующий круг швейцарской системы: участники идут по таблице,
+// каждый встречается с ближайшим ниже, с кем ещё не играл. При нечётном числе свободный проход
+// получает нижний в таблице, у кого его ещё не было.
+func SwissPairings(standings []Standing, results []Result) []Pair {
+	played := make(map[[2]uint64]bool, len(results))
+	for _, r := range results {
+		played[[2]uint64{r.Entry1, r.Entry2}] = true
+		played[[2]uint64{r.Entry2, r.Entry1}] = true
+	}
+
+	pool := append([]Standing(nil), standings...)
+	pairs := make([]Pair, 0, len(pool)/2+1)
+
+	if len(pool)%2 == 1 {
+		byeIdx := len(pool) - 1
+		for i := len(pool) - 1; i >= 0; i-- {
+			if pool[i].Byes == 0 {
+				byeIdx = i
+				break
+			}
+		}
+		pairs = append(pairs, Pair{Entry1: pool[byeIdx].EntryID})
+		pool = append(pool[:byeIdx], pool[byeIdx+1:]...)
+	}
+
+	if matched := pairSwiss(pool, played); matched != nil {
+		return append(pairs, matched...)
+	}
+
+	// все допустимые пары уже сыграны — повторная встреча лучше, чем пропуск круга
+	for i := 0; i+1 < len(pool); i += 2 {
+		pairs = append(pairs, Pair{Entry1: pool[i].EntryID, Entry2: pool[i+1].EntryID})
+	}
+	return pairs
+}
+
+// pairSwiss подбирает пары без повторов перебором с возвратом; nil — таких пар нет
+func pairSwiss(pool []Standing, played map[[2]uint64]bool) []Pair {
+	if len(pool) == 0 {
+		return []Pair{}
+	}
+
+	first := pool[0]
+	for i := 1; i < len(pool); i++ {
+		if played[[2]uint64{first.EntryID, pool[i].EntryID}] {
+			continue
+		}
+
+		rest := make([]Standing, 0, len(pool)-2)
+		rest = append(rest, pool[1:i]...)
+		rest = append(rest, pool[i+1:]...)
+		if tail := pairSwiss(rest, played); tail != nil {
+			return append([]Pair{{Entry1: first.EntryID, Entry2: pool[i].EntryID}}, tail...)
+		}
+	}
+
+	return nil
+}
+
+// SwissFirstRound — первый круг швейцарской системы: верхняя половина посева играет с нижней
+// (1 — с n/2+1, 2 — с n/2+2 …). При нечётном числе свободный проход получает последний сеяный.
+func SwissFirstRound(seeded []uint64) []Pair {
+	players := seeded
+	pairs := make([]Pair, 0, len(seeded)/2+1)
+	if len(players)%2 == 1 {
+		pairs = append(pairs, Pair{Entry1: players[len(players)-1]})
+		players = players[:len(players)-1]
+	}
+
+	half := len(players) / 2
+	for i := 0; i < half; i++ {
+		pairs = append(pairs, Pair{Entry1: players[i], Entry2: players[half+i]})
+	}
+
+	return pairs
+}